@@ -4,19 +4,24 @@ import (
 	"db_cp_6/pkg/logger"
 	"github.com/ilyakaznacheev/cleanenv"
 	"sync"
+	"time"
 )
 
 type Config struct {
 	HTTPServer `yaml:"http_server"`
 	Member     Postgres `yaml:"memberpostgres"`
 	Leader     Postgres `yaml:"leaderpostgres"`
+	Curator    Postgres `yaml:"curatorpostgres"`
 	Admin      Postgres `yaml:"adminpostgres"`
 	Test       Postgres `yaml:"testpostgres"`
+
+	Attachments Attachments `yaml:"attachments"`
 }
 
 type HTTPServer struct {
-	Host string `yaml:"host"`
-	Port string `yaml:"port" default:"8080"`
+	Host      string `yaml:"host"`
+	Port      string `yaml:"port" default:"8080"`
+	PublicURL string `yaml:"public_url" default:"http://localhost:8080"`
 }
 
 type Postgres struct {
@@ -27,6 +32,28 @@ type Postgres struct {
 	Database string `yaml:"dbname"`
 }
 
+type Attachments struct {
+	Store   string `yaml:"store" default:"local"`
+	Dir     string `yaml:"dir" default:"./data/attachments"`
+	MaxSize int64  `yaml:"max_size" default:"26214400"`
+	S3      S3     `yaml:"s3"`
+	Images  Images `yaml:"images"`
+}
+
+type S3 struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region" default:"us-east-1"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
+}
+
+type Images struct {
+	Workers       int           `yaml:"workers" default:"2"`
+	QueueSize     int           `yaml:"queue_size" default:"64"`
+	SweepInterval time.Duration `yaml:"sweep_interval" default:"1m"`
+}
+
 var instance *Config
 var once sync.Once
 
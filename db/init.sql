@@ -1,35 +1,84 @@
 -- ТАБЛИЦЫ
 
+create extension if not exists btree_gist;
+create extension if not exists pg_trgm;
+create extension if not exists pgcrypto;
+
+create table if not exists admins
+(
+    id       int generated always as identity primary key,
+    name     text not null,
+    login    text unique not null,
+    password text not null
+);
+
 create table if not exists leaders
 (
-    id           int generated always as identity primary key,
-    name         text not null,
-    phone_number text not null,
-    login        text unique not null,
-    password     text not null
+    id            int generated always as identity primary key,
+    name          text not null,
+    phone_number  text not null,
+    login         text unique not null,
+    password      text not null,
+    search_vector tsvector generated always as
+        (setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A')) stored
 );
 
 create table if not exists members
 (
-    id           int generated always as identity primary key,
-    name         text not null,
-    phone_number text not null,
-    login        text unique not null,
-    password     text not null
+    id            int generated always as identity primary key,
+    name          text not null,
+    phone_number  text not null,
+    login         text unique not null,
+    password      text not null,
+    search_vector tsvector generated always as
+        (setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A')) stored
+);
+
+create table if not exists institutions
+(
+    id      int generated always as identity primary key,
+    name    text unique not null,
+    city    text not null default '',
+    country text not null default '' check (country = '' or country ~ '^[A-Z]{2}$')
+);
+
+create table if not exists countries
+(
+    code    text primary key check (code ~ '^[A-Z]{2}$'),
+    alpha3  text unique not null check (alpha3 ~ '^[A-Z]{3}$'),
+    name_en text not null,
+    name_ru text not null,
+    aliases text[] not null default '{}'
 );
 
 create table if not exists curators
 (
-    id           int generated always as identity primary key,
-    name         text unique not null
+    id             int generated always as identity primary key,
+    name           text unique not null,
+    email          text not null default '',
+    phone          text not null default '',
+    institution_id int,
+    login          text not null default '',
+    password       text not null default '',
+    search_vector  tsvector generated always as
+        (setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A')) stored,
+
+    foreign key (institution_id) references institutions(id) on delete set null
 );
 
 create table if not exists locations
 (
-    id           int generated always as identity primary key,
-    name         text not null,
-    country      text not null,
-    nearest_town text not null
+    id            int generated always as identity primary key,
+    name          text not null,
+    country       text not null check (country ~ '^[A-Z]{2}$'),
+    nearest_town  text not null,
+    latitude      double precision check (latitude between -90 and 90),
+    longitude     double precision check (longitude between -180 and 180),
+    elevation     double precision,
+    datum         text not null default '',
+    search_vector tsvector,
+
+    constraint locations_coordinates_check check ((latitude is null) = (longitude is null))
 );
 
 create table if not exists expeditions
@@ -38,30 +87,468 @@ create table if not exists expeditions
     location_id int not null,
     start_date  date not null,
     end_date    date not null,
+    closed_on   date,
 
     foreign key (location_id) references locations(id) on delete cascade
 );
 
-create table if not exists artifacts
+create table if not exists trenches
 (
     id          int generated always as identity primary key,
     location_id int not null,
     name        text not null,
-    age         int not null,
+    description text not null default '',
 
     foreign key (location_id) references locations(id) on delete cascade
 );
 
-create table if not exists equipments
+create table if not exists contexts
+(
+    id          int generated always as identity primary key,
+    trench_id   int not null,
+    code        text not null,
+    description text not null default '',
+
+    foreign key (trench_id) references trenches(id) on delete cascade,
+    unique (trench_id, code)
+);
+
+create table if not exists context_relations
+(
+    id              int generated always as identity primary key,
+    from_context_id int not null,
+    to_context_id   int not null,
+    relation        text not null check (relation in ('above', 'cuts')),
+
+    foreign key (from_context_id) references contexts(id) on delete cascade,
+    foreign key (to_context_id) references contexts(id) on delete cascade,
+    unique (from_context_id, to_context_id),
+    check (from_context_id <> to_context_id)
+);
+
+create table if not exists periods
+(
+    id         int generated always as identity primary key,
+    parent_id  int,
+    name       text not null unique,
+    start_year int not null,
+    end_year   int not null,
+
+    foreign key (parent_id) references periods(id) on delete set null,
+    check (start_year <= end_year)
+);
+
+create table if not exists storage_nodes
+(
+    id        int generated always as identity primary key,
+    parent_id int,
+    kind      text not null check (kind in ('museum', 'room', 'cabinet', 'shelf', 'box')),
+    name      text not null,
+    capacity  int check (capacity >= 0),
+
+    foreign key (parent_id) references storage_nodes(id) on delete restrict,
+    unique (parent_id, name),
+    check ((parent_id is null) = (kind = 'museum'))
+);
+
+create unique index if not exists idx_storage_nodes_root_name on storage_nodes(name) where parent_id is null;
+
+create table if not exists artifacts
+(
+    id                     int generated always as identity primary key,
+    location_id            int not null,
+    context_id             int,
+    expedition_id          int,
+    found_by_member_id     int,
+    found_on               date,
+    find_context           text not null default '',
+    find_latitude          double precision check (find_latitude between -90 and 90),
+    find_longitude         double precision check (find_longitude between -180 and 180),
+    find_elevation         double precision,
+    find_datum             text not null default '',
+    name                   text not null,
+    earliest_year          int,
+    latest_year            int,
+    central_year           int,
+    error_years            int check (error_years >= 0),
+    dating_method          text not null default '' check (dating_method in ('', 'typological', 'radiocarbon', 'dendro', 'stratigraphic')),
+    dating_confidence      text not null default '' check (dating_confidence in ('', 'low', 'medium', 'high')),
+    period_id              int,
+    radiocarbon_age_bp     int check (radiocarbon_age_bp >= 0),
+    radiocarbon_sigma      int check (radiocarbon_sigma >= 0),
+    radiocarbon_sample_id  int,
+    prior_dating           jsonb,
+    storage_node_id        int,
+    responsible_curator_id int,
+    catalog_location_id    int not null,
+    catalog_year           int not null,
+    catalog_seq            int not null check (catalog_seq > 0),
+    catalog_number         text generated always as
+        (catalog_location_id::text || '-' || catalog_year::text || '-' || lpad(catalog_seq::text, greatest(4, length(catalog_seq::text)), '0')) stored,
+    search_vector          tsvector generated always as
+        (setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A') ||
+         setweight(to_tsvector('russian', find_context), 'B') || setweight(to_tsvector('english', find_context), 'B')) stored,
+
+    unique (catalog_location_id, catalog_year, catalog_seq),
+    foreign key (location_id) references locations(id) on delete cascade,
+    foreign key (context_id) references contexts(id) on delete set null,
+    foreign key (expedition_id) references expeditions(id) on delete set null,
+    foreign key (found_by_member_id) references members(id) on delete set null,
+    foreign key (period_id) references periods(id) on delete set null,
+    foreign key (storage_node_id) references storage_nodes(id) on delete restrict,
+    foreign key (responsible_curator_id) references curators(id) on delete set null,
+    constraint artifacts_find_coordinates_check check ((find_latitude is null) = (find_longitude is null)),
+    check (earliest_year <= latest_year)
+);
+
+create table if not exists catalog_sequences
+(
+    location_id int not null,
+    year        int not null,
+    last_seq    int not null check (last_seq > 0),
+
+    primary key (location_id, year),
+    foreign key (location_id) references locations(id) on delete cascade
+);
+
+create table if not exists samples
 (
     id            int generated always as identity primary key,
     expedition_id int not null,
-    name          text not null,
-    amount        int not null,
+    artifact_id   int,
+    context_id    int,
+    sample_type   text not null check (sample_type in ('soil', 'bone', 'charcoal', 'wood', 'shell', 'other')),
+    lab           text not null default '',
+    submitted_on  date,
+    status        text not null default 'collected' check (status in ('collected', 'submitted', 'in_analysis', 'reported', 'accepted', 'rejected')),
+    results       jsonb not null default '{}',
+
+    foreign key (expedition_id) references expeditions(id) on delete cascade,
+    foreign key (artifact_id) references artifacts(id) on delete set null,
+    foreign key (context_id) references contexts(id) on delete set null
+);
+
+create table if not exists artifact_custody
+(
+    id             int generated always as identity primary key,
+    artifact_id    int not null,
+    previous_id    int unique,
+    holder_type    text not null check (holder_type in ('member', 'curator', 'facility')),
+    holder_id      int,
+    holder_name    text not null default '',
+    reason         text not null,
+    transferred_at timestamptz not null default now(),
+    signed_by_id   int not null,
+    signed_by_role text not null,
+
+    foreign key (artifact_id) references artifacts(id) on delete cascade,
+    foreign key (previous_id) references artifact_custody(id),
+    check ((holder_type = 'facility') = (holder_id is null))
+);
+
+create unique index if not exists idx_artifact_custody_first on artifact_custody(artifact_id) where previous_id is null;
+
+create table if not exists condition_reports
+(
+    id               int generated always as identity primary key,
+    artifact_id      int not null,
+    grade            text not null check (grade in ('excellent', 'good', 'fair', 'poor', 'critical')),
+    damage_notes     text not null default '',
+    treatments       text not null default '',
+    materials        text not null default '',
+    conservator      text not null,
+    inspected_on     date not null default current_date,
+    recorded_by_id   int not null,
+    recorded_by_role text not null check (recorded_by_role in ('curator', 'admin')),
+
+    foreign key (artifact_id) references artifacts(id) on delete cascade
+);
+
+create table if not exists storage_moves
+(
+    id            int generated always as identity primary key,
+    artifact_id   int not null,
+    from_node_id  int,
+    to_node_id    int not null,
+    reason        text not null default '',
+    moved_at      timestamptz not null default now(),
+    moved_by_id   int not null,
+    moved_by_role text not null check (moved_by_role in ('curator', 'admin')),
+
+    foreign key (artifact_id) references artifacts(id) on delete cascade,
+    foreign key (from_node_id) references storage_nodes(id) on delete restrict,
+    foreign key (to_node_id) references storage_nodes(id) on delete restrict
+);
+
+create table if not exists loans
+(
+    id              int generated always as identity primary key,
+    borrower        text not null,
+    out_date        date not null,
+    due_date        date not null,
+    returned_on     date,
+    insurance_value numeric(14, 2) not null default 0 check (insurance_value >= 0),
+    status          text not null default 'requested'
+        constraint loans_status_check check (status in ('requested', 'approved', 'shipped', 'returned', 'cancelled')),
+    created_by_id   int not null,
+    created_by_role text not null check (created_by_role in ('curator', 'admin')),
+
+    check (out_date <= due_date),
+    check ((status = 'returned') = (returned_on is not null))
+);
+
+create table if not exists loan_artifacts
+(
+    id          int generated always as identity primary key,
+    loan_id     int not null,
+    artifact_id int not null,
+    out_date    date not null,
+    due_date    date not null,
+    active      boolean not null default true,
+
+    foreign key (loan_id) references loans(id) on delete cascade,
+    foreign key (artifact_id) references artifacts(id) on delete cascade,
+    unique (loan_id, artifact_id),
+    exclude using gist (artifact_id with =, daterange(out_date, due_date, '[]') with &&) where (active)
+);
+
+create table if not exists inventory_items
+(
+    id                    int generated always as identity primary key,
+    name                  text unique not null,
+    item_type             text not null,
+    total_quantity        int not null check (total_quantity >= 0),
+    service_interval_days int check (service_interval_days > 0),
+    service_field_days    int check (service_field_days > 0),
+    serviceable           boolean not null default true
+);
+
+create table if not exists maintenance_logs
+(
+    id           int generated always as identity primary key,
+    item_id      int not null,
+    performed_on date not null,
+    technician   text not null,
+    description  text not null,
+    serviceable  boolean not null,
+
+    foreign key (item_id) references inventory_items(id) on delete cascade
+);
+
+create table if not exists packing_templates
+(
+    id   int generated always as identity primary key,
+    name text unique not null
+);
+
+create table if not exists packing_template_items
+(
+    id             int generated always as identity primary key,
+    template_id    int not null,
+    item_id        int not null,
+    base_quantity  numeric not null default 0 check (base_quantity >= 0),
+    per_person     numeric not null default 0 check (per_person >= 0),
+    per_day        numeric not null default 0 check (per_day >= 0),
+    per_person_day numeric not null default 0 check (per_person_day >= 0),
+
+    foreign key (template_id) references packing_templates(id) on delete cascade,
+    foreign key (item_id) references inventory_items(id) on delete restrict,
+    unique (template_id, item_id)
+);
+
+create table if not exists equipments
+(
+    id                  int generated always as identity primary key,
+    expedition_id       int not null,
+    item_id             int not null,
+    amount              int not null check (amount > 0),
+    reserved_from       date not null,
+    reserved_to         date not null,
+    packing_template_id int,
+
+    foreign key (expedition_id) references expeditions(id) on delete cascade,
+    foreign key (item_id) references inventory_items(id) on delete restrict,
+    foreign key (packing_template_id) references packing_templates(id) on delete set null,
+    check (reserved_from <= reserved_to)
+);
+
+create table if not exists expedition_packing_lists
+(
+    expedition_id int not null,
+    template_id   int not null,
+    applied_at    timestamptz not null default now(),
+
+    primary key (expedition_id, template_id),
+    foreign key (expedition_id) references expeditions(id) on delete cascade,
+    foreign key (template_id) references packing_templates(id) on delete cascade
+);
+
+create table if not exists equipment_events
+(
+    id           int generated always as identity primary key,
+    equipment_id int not null,
+    kind         text not null check (kind in ('check_out', 'check_in')),
+    quantity     int not null default 0 check (quantity >= 0),
+    returned     int not null default 0 check (returned >= 0),
+    lost         int not null default 0 check (lost >= 0),
+    damaged      int not null default 0 check (damaged >= 0),
+    member_id    int not null,
+    recorded_at  timestamptz not null default now(),
+
+    foreign key (equipment_id) references equipments(id) on delete cascade,
+    foreign key (member_id) references members(id) on delete restrict,
+    check ((kind = 'check_out' and quantity > 0 and returned + lost + damaged = 0)
+        or (kind = 'check_in' and quantity = 0 and returned + lost + damaged > 0))
+);
+
+create table if not exists expedition_budgets
+(
+    expedition_id int primary key,
+    currency      char(3) not null check (currency ~ '^[A-Z]{3}$'),
 
     foreign key (expedition_id) references expeditions(id) on delete cascade
 );
 
+create table if not exists budget_lines
+(
+    id            int generated always as identity primary key,
+    expedition_id int not null,
+    category      text not null,
+    planned       numeric(14, 2) not null check (planned >= 0),
+
+    foreign key (expedition_id) references expedition_budgets(expedition_id) on delete cascade,
+    unique (expedition_id, category)
+);
+
+create table if not exists exchange_rates
+(
+    id            int generated always as identity primary key,
+    currency      char(3) not null check (currency ~ '^[A-Z]{3}$'),
+    base_currency char(3) not null check (base_currency ~ '^[A-Z]{3}$'),
+    rate          numeric(18, 8) not null check (rate > 0),
+    effective_on  date not null,
+
+    unique (currency, base_currency, effective_on),
+    check (currency <> base_currency)
+);
+
+create table if not exists expenses
+(
+    id               int generated always as identity primary key,
+    expedition_id    int not null,
+    category         text not null,
+    amount           numeric(14, 2) not null check (amount > 0),
+    currency         char(3) not null check (currency ~ '^[A-Z]{3}$'),
+    exchange_rate    numeric(18, 8) not null check (exchange_rate > 0),
+    base_amount      numeric(14, 2) generated always as (round(amount * exchange_rate, 2)) stored,
+    spent_on         date not null,
+    description      text not null default '',
+    vendor           text not null default '',
+    receipt_number   text not null default '',
+    recorded_by_id   int not null,
+    recorded_by_role text not null check (recorded_by_role in ('leader', 'admin')),
+    recorded_at      timestamptz not null default now(),
+
+    foreign key (expedition_id) references expeditions(id) on delete cascade,
+    foreign key (expedition_id, category) references budget_lines(expedition_id, category) on delete no action
+);
+
+create table if not exists journal_entries
+(
+    id            int generated always as identity primary key,
+    expedition_id int not null,
+    member_id     int not null,
+    entry_date    date not null,
+    weather       text not null default '',
+    summary       text not null check (summary <> ''),
+    hours_worked  numeric(4, 2) not null check (hours_worked between 0 and 24),
+    created_at    timestamptz not null default now(),
+    updated_at    timestamptz not null default now(),
+
+    foreign key (expedition_id) references expeditions(id) on delete cascade,
+    foreign key (member_id) references members(id) on delete cascade
+);
+
+create table if not exists journal_entry_artifacts
+(
+    entry_id    int not null,
+    artifact_id int not null,
+
+    primary key (entry_id, artifact_id),
+    foreign key (entry_id) references journal_entries(id) on delete cascade,
+    foreign key (artifact_id) references artifacts(id) on delete cascade
+);
+
+create table if not exists journal_day_locks
+(
+    expedition_id int not null,
+    day           date not null,
+    locked_by_id  int,
+    locked_at     timestamptz not null default now(),
+
+    primary key (expedition_id, day),
+    foreign key (expedition_id) references expeditions(id) on delete cascade,
+    foreign key (locked_by_id) references leaders(id) on delete set null
+);
+
+create table if not exists blobs
+(
+    sha256       char(64) primary key check (sha256 ~ '^[0-9a-f]{64}$'),
+    size         bigint not null check (size > 0),
+    content_type text not null,
+    created_at   timestamptz not null default now()
+);
+
+create table if not exists attachments
+(
+    id                int generated always as identity primary key,
+    blob_sha256       char(64) not null,
+    artifact_id       int,
+    location_id       int,
+    expedition_id     int,
+    journal_entry_id  int,
+    kind              text not null check (kind in ('photo', 'drawing', 'document')),
+    file_name         text not null check (file_name <> ''),
+    description       text not null default '',
+    uploaded_by_role  text not null,
+    uploaded_by_id    int,
+    uploaded_at       timestamptz not null default now(),
+    processing_status text not null default 'none' check (processing_status in ('none', 'pending', 'processing', 'done', 'failed')),
+    processing_error  text not null default '',
+    image_width       int check (image_width > 0),
+    image_height      int check (image_height > 0),
+    captured_at       timestamptz,
+    camera_make       text,
+    camera_model      text,
+    gps_latitude      double precision check (gps_latitude between -90 and 90),
+    gps_longitude     double precision check (gps_longitude between -180 and 180),
+    gps_altitude      double precision,
+
+    check (num_nonnulls(artifact_id, location_id, expedition_id, journal_entry_id) = 1),
+    unique (artifact_id, blob_sha256),
+    unique (location_id, blob_sha256),
+    unique (expedition_id, blob_sha256),
+    unique (journal_entry_id, blob_sha256),
+    foreign key (blob_sha256) references blobs(sha256) on delete restrict,
+    foreign key (artifact_id) references artifacts(id) on delete cascade,
+    foreign key (location_id) references locations(id) on delete cascade,
+    foreign key (expedition_id) references expeditions(id) on delete cascade,
+    foreign key (journal_entry_id) references journal_entries(id) on delete cascade
+);
+
+create table if not exists attachment_thumbnails
+(
+    attachment_id int not null,
+    size          text not null check (size in ('small', 'medium', 'large')),
+    width         int not null check (width > 0),
+    height        int not null check (height > 0),
+    blob_sha256   char(64) not null,
+
+    primary key (attachment_id, size),
+    foreign key (attachment_id) references attachments(id) on delete cascade,
+    foreign key (blob_sha256) references blobs(sha256) on delete restrict
+);
+
 create table if not exists expeditions_leaders
 (
     id            int generated always as identity primary key,
@@ -92,6 +579,519 @@ create table if not exists expeditions_curators
     foreign key (curator_id) references curators(id) on delete cascade
 );
 
+create table if not exists merge_history
+(
+    id             int generated always as identity primary key,
+    entity_type    text not null
+        constraint merge_history_entity_type_check check (entity_type in ('location', 'member', 'leader', 'curator')),
+    survivor_id    int not null,
+    merged_id      int not null,
+    merged_name    text not null,
+    reassigned     jsonb not null default '{}',
+    merged_by_id   int not null,
+    merged_by_role text not null,
+    merged_at      timestamptz not null default now(),
+
+    check (survivor_id <> merged_id)
+);
+
+-- МИГРАЦИЯ
+
+alter table curators
+    add column if not exists email text not null default '',
+    add column if not exists phone text not null default '',
+    add column if not exists institution_id int references institutions(id) on delete set null,
+    add column if not exists login text not null default '',
+    add column if not exists password text not null default '';
+
+create unique index if not exists idx_curators_login on curators(login) where login <> '';
+
+alter table locations
+    add column if not exists latitude double precision check (latitude between -90 and 90),
+    add column if not exists longitude double precision check (longitude between -180 and 180),
+    add column if not exists elevation double precision,
+    add column if not exists datum text not null default '';
+
+alter table expeditions
+    add column if not exists closed_on date;
+
+alter table artifacts
+    add column if not exists context_id int references contexts(id) on delete set null,
+    add column if not exists expedition_id int references expeditions(id) on delete set null,
+    add column if not exists found_by_member_id int references members(id) on delete set null,
+    add column if not exists found_on date,
+    add column if not exists find_context text not null default '',
+    add column if not exists find_latitude double precision check (find_latitude between -90 and 90),
+    add column if not exists find_longitude double precision check (find_longitude between -180 and 180),
+    add column if not exists find_elevation double precision,
+    add column if not exists find_datum text not null default '',
+    add column if not exists storage_node_id int references storage_nodes(id) on delete restrict,
+    add column if not exists responsible_curator_id int references curators(id) on delete set null,
+    add column if not exists radiocarbon_age_bp int check (radiocarbon_age_bp >= 0),
+    add column if not exists radiocarbon_sigma int check (radiocarbon_sigma >= 0),
+    add column if not exists radiocarbon_sample_id int,
+    add column if not exists prior_dating jsonb;
+
+alter table inventory_items
+    add column if not exists service_interval_days int check (service_interval_days > 0),
+    add column if not exists service_field_days int check (service_field_days > 0),
+    add column if not exists serviceable boolean not null default true;
+
+alter table equipments
+    add column if not exists packing_template_id int references packing_templates(id) on delete set null;
+
+alter table attachments
+    add column if not exists processing_status text not null default 'none'
+        check (processing_status in ('none', 'pending', 'processing', 'done', 'failed')),
+    add column if not exists processing_error text not null default '',
+    add column if not exists image_width int check (image_width > 0),
+    add column if not exists image_height int check (image_height > 0),
+    add column if not exists captured_at timestamptz,
+    add column if not exists camera_make text,
+    add column if not exists camera_model text,
+    add column if not exists gps_latitude double precision check (gps_latitude between -90 and 90),
+    add column if not exists gps_longitude double precision check (gps_longitude between -180 and 180),
+    add column if not exists gps_altitude double precision;
+
+do $$
+begin
+    if not exists (select 1 from pg_constraint where conname = 'locations_coordinates_check') then
+        alter table locations add constraint locations_coordinates_check
+            check ((latitude is null) = (longitude is null));
+    end if;
+    if not exists (select 1 from pg_constraint where conname = 'artifacts_find_coordinates_check') then
+        alter table artifacts add constraint artifacts_find_coordinates_check
+            check ((find_latitude is null) = (find_longitude is null));
+    end if;
+    if not exists (select 1 from pg_constraint where conname = 'artifacts_radiocarbon_sample_id_fkey') then
+        alter table artifacts add constraint artifacts_radiocarbon_sample_id_fkey
+            foreign key (radiocarbon_sample_id) references samples(id) on delete set null;
+    end if;
+end;
+$$;
+
+do $$
+begin
+    if exists (select 1 from information_schema.columns where table_name = 'artifacts' and column_name = 'age') then
+        alter table artifacts
+            add column if not exists earliest_year int,
+            add column if not exists latest_year int,
+            add column if not exists central_year int,
+            add column if not exists error_years int check (error_years >= 0),
+            add column if not exists dating_method text not null default '' check (dating_method in ('', 'typological', 'radiocarbon', 'dendro', 'stratigraphic')),
+            add column if not exists dating_confidence text not null default '' check (dating_confidence in ('', 'low', 'medium', 'high')),
+            add column if not exists period_id int references periods(id) on delete set null;
+
+        update artifacts
+        set central_year = extract(year from current_date)::int - age,
+            error_years = 0,
+            earliest_year = extract(year from current_date)::int - age,
+            latest_year = extract(year from current_date)::int - age,
+            dating_confidence = 'low'
+        where earliest_year is null and latest_year is null;
+
+        alter table artifacts drop column age;
+    end if;
+end;
+$$;
+
+do $$
+begin
+    if exists (select 1 from information_schema.columns where table_name = 'equipments' and column_name = 'name') then
+        insert into inventory_items (name, item_type, total_quantity)
+        select name, 'general', sum(amount)
+        from equipments
+        group by name
+        on conflict (name) do nothing;
+
+        alter table equipments
+            add column if not exists item_id int references inventory_items(id) on delete restrict,
+            add column if not exists reserved_from date,
+            add column if not exists reserved_to date;
+
+        update equipments e
+        set item_id = i.id,
+            reserved_from = ex.start_date,
+            reserved_to = ex.end_date
+        from inventory_items i, expeditions ex
+        where i.name = e.name and ex.id = e.expedition_id;
+
+        alter table equipments
+            alter column item_id set not null,
+            alter column reserved_from set not null,
+            alter column reserved_to set not null,
+            drop column name;
+    end if;
+end;
+$$;
+
+do $$
+begin
+    if not exists (select 1 from information_schema.columns where table_name = 'artifacts' and column_name = 'catalog_seq') then
+        alter table artifacts
+            add column catalog_location_id int,
+            add column catalog_year int,
+            add column catalog_seq int check (catalog_seq > 0);
+
+        update artifacts a
+        set catalog_location_id = a.location_id,
+            catalog_year = n.catalog_year,
+            catalog_seq = n.catalog_seq
+        from (
+            select id,
+                   extract(year from coalesce(found_on, current_date))::int as catalog_year,
+                   row_number() over (
+                       partition by location_id, extract(year from coalesce(found_on, current_date))
+                       order by found_on nulls last, id
+                   ) as catalog_seq
+            from artifacts
+        ) n
+        where n.id = a.id;
+
+        insert into catalog_sequences (location_id, year, last_seq)
+        select location_id, catalog_year, max(catalog_seq)
+        from artifacts
+        group by location_id, catalog_year
+        on conflict (location_id, year) do update
+        set last_seq = greatest(catalog_sequences.last_seq, excluded.last_seq);
+
+        alter table artifacts
+            alter column catalog_location_id set not null,
+            alter column catalog_year set not null,
+            alter column catalog_seq set not null,
+            add column catalog_number text generated always as
+                (catalog_location_id::text || '-' || catalog_year::text || '-' || lpad(catalog_seq::text, greatest(4, length(catalog_seq::text)), '0')) stored,
+            add unique (catalog_location_id, catalog_year, catalog_seq);
+    end if;
+end;
+$$;
+
+alter table leaders add column if not exists search_vector tsvector generated always as
+    (setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A')) stored;
+alter table members add column if not exists search_vector tsvector generated always as
+    (setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A')) stored;
+alter table curators add column if not exists search_vector tsvector generated always as
+    (setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A')) stored;
+alter table locations add column if not exists search_vector tsvector;
+alter table artifacts add column if not exists search_vector tsvector generated always as
+    (setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A') ||
+     setweight(to_tsvector('russian', find_context), 'B') || setweight(to_tsvector('english', find_context), 'B')) stored;
+
+insert into countries (code, alpha3, name_en, name_ru, aliases) values
+    ('AD', 'AND', 'Andorra', 'Андорра', '{}'),
+    ('AE', 'ARE', 'United Arab Emirates', 'Объединённые Арабские Эмираты', array['UAE', 'ОАЭ', 'Emirates', 'Эмираты']),
+    ('AF', 'AFG', 'Afghanistan', 'Афганистан', '{}'),
+    ('AG', 'ATG', 'Antigua and Barbuda', 'Антигуа и Барбуда', '{}'),
+    ('AI', 'AIA', 'Anguilla', 'Ангилья', '{}'),
+    ('AL', 'ALB', 'Albania', 'Албания', '{}'),
+    ('AM', 'ARM', 'Armenia', 'Армения', '{}'),
+    ('AO', 'AGO', 'Angola', 'Ангола', '{}'),
+    ('AQ', 'ATA', 'Antarctica', 'Антарктида', '{}'),
+    ('AR', 'ARG', 'Argentina', 'Аргентина', '{}'),
+    ('AS', 'ASM', 'American Samoa', 'Американское Самоа', '{}'),
+    ('AT', 'AUT', 'Austria', 'Австрия', '{}'),
+    ('AU', 'AUS', 'Australia', 'Австралия', '{}'),
+    ('AW', 'ABW', 'Aruba', 'Аруба', '{}'),
+    ('AX', 'ALA', 'Åland Islands', 'Аландские острова', array['Aland Islands']),
+    ('AZ', 'AZE', 'Azerbaijan', 'Азербайджан', '{}'),
+    ('BA', 'BIH', 'Bosnia and Herzegovina', 'Босния и Герцеговина', array['Bosnia']),
+    ('BB', 'BRB', 'Barbados', 'Барбадос', '{}'),
+    ('BD', 'BGD', 'Bangladesh', 'Бангладеш', '{}'),
+    ('BE', 'BEL', 'Belgium', 'Бельгия', '{}'),
+    ('BF', 'BFA', 'Burkina Faso', 'Буркина-Фасо', '{}'),
+    ('BG', 'BGR', 'Bulgaria', 'Болгария', '{}'),
+    ('BH', 'BHR', 'Bahrain', 'Бахрейн', '{}'),
+    ('BI', 'BDI', 'Burundi', 'Бурунди', '{}'),
+    ('BJ', 'BEN', 'Benin', 'Бенин', '{}'),
+    ('BL', 'BLM', 'Saint Barthélemy', 'Сен-Бартелеми', array['Saint Barthelemy']),
+    ('BM', 'BMU', 'Bermuda', 'Бермудские острова', array['Бермуды']),
+    ('BN', 'BRN', 'Brunei Darussalam', 'Бруней', array['Brunei']),
+    ('BO', 'BOL', 'Bolivia', 'Боливия', array['Plurinational State of Bolivia']),
+    ('BQ', 'BES', 'Bonaire, Sint Eustatius and Saba', 'Бонэйр, Синт-Эстатиус и Саба', array['Caribbean Netherlands']),
+    ('BR', 'BRA', 'Brazil', 'Бразилия', '{}'),
+    ('BS', 'BHS', 'Bahamas', 'Багамские Острова', array['Багамы']),
+    ('BT', 'BTN', 'Bhutan', 'Бутан', '{}'),
+    ('BV', 'BVT', 'Bouvet Island', 'Остров Буве', '{}'),
+    ('BW', 'BWA', 'Botswana', 'Ботсвана', '{}'),
+    ('BY', 'BLR', 'Belarus', 'Беларусь', array['Белоруссия', 'Republic of Belarus', 'Республика Беларусь']),
+    ('BZ', 'BLZ', 'Belize', 'Белиз', '{}'),
+    ('CA', 'CAN', 'Canada', 'Канада', '{}'),
+    ('CC', 'CCK', 'Cocos (Keeling) Islands', 'Кокосовые острова', array['Cocos Islands']),
+    ('CD', 'COD', 'Democratic Republic of the Congo', 'Демократическая Республика Конго', array['DR Congo', 'DRC', 'ДР Конго', 'Congo-Kinshasa']),
+    ('CF', 'CAF', 'Central African Republic', 'Центральноафриканская Республика', array['ЦАР']),
+    ('CG', 'COG', 'Congo', 'Республика Конго', array['Republic of the Congo', 'Congo-Brazzaville', 'Конго']),
+    ('CH', 'CHE', 'Switzerland', 'Швейцария', '{}'),
+    ('CI', 'CIV', 'Côte d''Ivoire', 'Кот-д''Ивуар', array['Cote d''Ivoire', 'Ivory Coast']),
+    ('CK', 'COK', 'Cook Islands', 'Острова Кука', '{}'),
+    ('CL', 'CHL', 'Chile', 'Чили', '{}'),
+    ('CM', 'CMR', 'Cameroon', 'Камерун', '{}'),
+    ('CN', 'CHN', 'China', 'Китай', array['People''s Republic of China', 'PRC', 'КНР']),
+    ('CO', 'COL', 'Colombia', 'Колумбия', '{}'),
+    ('CR', 'CRI', 'Costa Rica', 'Коста-Рика', '{}'),
+    ('CU', 'CUB', 'Cuba', 'Куба', '{}'),
+    ('CV', 'CPV', 'Cabo Verde', 'Кабо-Верде', array['Cape Verde']),
+    ('CW', 'CUW', 'Curaçao', 'Кюрасао', array['Curacao']),
+    ('CX', 'CXR', 'Christmas Island', 'Остров Рождества', '{}'),
+    ('CY', 'CYP', 'Cyprus', 'Кипр', '{}'),
+    ('CZ', 'CZE', 'Czechia', 'Чехия', array['Czech Republic', 'Чешская Республика']),
+    ('DE', 'DEU', 'Germany', 'Германия', array['Deutschland', 'ФРГ']),
+    ('DJ', 'DJI', 'Djibouti', 'Джибути', '{}'),
+    ('DK', 'DNK', 'Denmark', 'Дания', '{}'),
+    ('DM', 'DMA', 'Dominica', 'Доминика', '{}'),
+    ('DO', 'DOM', 'Dominican Republic', 'Доминиканская Республика', '{}'),
+    ('DZ', 'DZA', 'Algeria', 'Алжир', '{}'),
+    ('EC', 'ECU', 'Ecuador', 'Эквадор', '{}'),
+    ('EE', 'EST', 'Estonia', 'Эстония', '{}'),
+    ('EG', 'EGY', 'Egypt', 'Египет', '{}'),
+    ('EH', 'ESH', 'Western Sahara', 'Западная Сахара', '{}'),
+    ('ER', 'ERI', 'Eritrea', 'Эритрея', '{}'),
+    ('ES', 'ESP', 'Spain', 'Испания', '{}'),
+    ('ET', 'ETH', 'Ethiopia', 'Эфиопия', '{}'),
+    ('FI', 'FIN', 'Finland', 'Финляндия', '{}'),
+    ('FJ', 'FJI', 'Fiji', 'Фиджи', '{}'),
+    ('FK', 'FLK', 'Falkland Islands (Malvinas)', 'Фолклендские острова', array['Falkland Islands']),
+    ('FM', 'FSM', 'Micronesia', 'Микронезия', array['Federated States of Micronesia']),
+    ('FO', 'FRO', 'Faroe Islands', 'Фарерские острова', '{}'),
+    ('FR', 'FRA', 'France', 'Франция', '{}'),
+    ('GA', 'GAB', 'Gabon', 'Габон', '{}'),
+    ('GB', 'GBR', 'United Kingdom', 'Великобритания', array['UK', 'Great Britain', 'Britain', 'England', 'Scotland', 'Wales', 'Northern Ireland', 'Соединённое Королевство', 'Англия']),
+    ('GD', 'GRD', 'Grenada', 'Гренада', '{}'),
+    ('GE', 'GEO', 'Georgia', 'Грузия', array['Sakartvelo']),
+    ('GF', 'GUF', 'French Guiana', 'Французская Гвиана', '{}'),
+    ('GG', 'GGY', 'Guernsey', 'Гернси', '{}'),
+    ('GH', 'GHA', 'Ghana', 'Гана', '{}'),
+    ('GI', 'GIB', 'Gibraltar', 'Гибралтар', '{}'),
+    ('GL', 'GRL', 'Greenland', 'Гренландия', '{}'),
+    ('GM', 'GMB', 'Gambia', 'Гамбия', '{}'),
+    ('GN', 'GIN', 'Guinea', 'Гвинея', '{}'),
+    ('GP', 'GLP', 'Guadeloupe', 'Гваделупа', '{}'),
+    ('GQ', 'GNQ', 'Equatorial Guinea', 'Экваториальная Гвинея', '{}'),
+    ('GR', 'GRC', 'Greece', 'Греция', array['Hellas', 'Ellada']),
+    ('GS', 'SGS', 'South Georgia and the South Sandwich Islands', 'Южная Георгия и Южные Сандвичевы острова', '{}'),
+    ('GT', 'GTM', 'Guatemala', 'Гватемала', '{}'),
+    ('GU', 'GUM', 'Guam', 'Гуам', '{}'),
+    ('GW', 'GNB', 'Guinea-Bissau', 'Гвинея-Бисау', '{}'),
+    ('GY', 'GUY', 'Guyana', 'Гайана', '{}'),
+    ('HK', 'HKG', 'Hong Kong', 'Гонконг', array['Сянган']),
+    ('HM', 'HMD', 'Heard Island and McDonald Islands', 'Остров Херд и острова Макдональд', '{}'),
+    ('HN', 'HND', 'Honduras', 'Гондурас', '{}'),
+    ('HR', 'HRV', 'Croatia', 'Хорватия', array['Hrvatska']),
+    ('HT', 'HTI', 'Haiti', 'Гаити', '{}'),
+    ('HU', 'HUN', 'Hungary', 'Венгрия', '{}'),
+    ('ID', 'IDN', 'Indonesia', 'Индонезия', '{}'),
+    ('IE', 'IRL', 'Ireland', 'Ирландия', array['Eire']),
+    ('IL', 'ISR', 'Israel', 'Израиль', '{}'),
+    ('IM', 'IMN', 'Isle of Man', 'Остров Мэн', '{}'),
+    ('IN', 'IND', 'India', 'Индия', '{}'),
+    ('IO', 'IOT', 'British Indian Ocean Territory', 'Британская территория в Индийском океане', '{}'),
+    ('IQ', 'IRQ', 'Iraq', 'Ирак', '{}'),
+    ('IR', 'IRN', 'Iran', 'Иран', array['Islamic Republic of Iran', 'Persia', 'Персия']),
+    ('IS', 'ISL', 'Iceland', 'Исландия', '{}'),
+    ('IT', 'ITA', 'Italy', 'Италия', array['Italia']),
+    ('JE', 'JEY', 'Jersey', 'Джерси', '{}'),
+    ('JM', 'JAM', 'Jamaica', 'Ямайка', '{}'),
+    ('JO', 'JOR', 'Jordan', 'Иордания', '{}'),
+    ('JP', 'JPN', 'Japan', 'Япония', '{}'),
+    ('KE', 'KEN', 'Kenya', 'Кения', '{}'),
+    ('KG', 'KGZ', 'Kyrgyzstan', 'Киргизия', array['Kyrgyz Republic', 'Kirghizia', 'Кыргызстан', 'Киргизстан']),
+    ('KH', 'KHM', 'Cambodia', 'Камбоджа', '{}'),
+    ('KI', 'KIR', 'Kiribati', 'Кирибати', '{}'),
+    ('KM', 'COM', 'Comoros', 'Коморские Острова', array['Коморы']),
+    ('KN', 'KNA', 'Saint Kitts and Nevis', 'Сент-Китс и Невис', '{}'),
+    ('KP', 'PRK', 'North Korea', 'КНДР', array['Democratic People''s Republic of Korea', 'DPRK', 'Северная Корея']),
+    ('KR', 'KOR', 'South Korea', 'Республика Корея', array['Republic of Korea', 'Korea', 'Южная Корея', 'Корея']),
+    ('KW', 'KWT', 'Kuwait', 'Кувейт', '{}'),
+    ('KY', 'CYM', 'Cayman Islands', 'Каймановы острова', '{}'),
+    ('KZ', 'KAZ', 'Kazakhstan', 'Казахстан', array['Republic of Kazakhstan', 'Qazaqstan', 'Республика Казахстан']),
+    ('LA', 'LAO', 'Laos', 'Лаос', array['Lao People''s Democratic Republic']),
+    ('LB', 'LBN', 'Lebanon', 'Ливан', '{}'),
+    ('LC', 'LCA', 'Saint Lucia', 'Сент-Люсия', '{}'),
+    ('LI', 'LIE', 'Liechtenstein', 'Лихтенштейн', '{}'),
+    ('LK', 'LKA', 'Sri Lanka', 'Шри-Ланка', array['Ceylon', 'Цейлон']),
+    ('LR', 'LBR', 'Liberia', 'Либерия', '{}'),
+    ('LS', 'LSO', 'Lesotho', 'Лесото', '{}'),
+    ('LT', 'LTU', 'Lithuania', 'Литва', '{}'),
+    ('LU', 'LUX', 'Luxembourg', 'Люксембург', '{}'),
+    ('LV', 'LVA', 'Latvia', 'Латвия', '{}'),
+    ('LY', 'LBY', 'Libya', 'Ливия', '{}'),
+    ('MA', 'MAR', 'Morocco', 'Марокко', '{}'),
+    ('MC', 'MCO', 'Monaco', 'Монако', '{}'),
+    ('MD', 'MDA', 'Moldova', 'Молдова', array['Republic of Moldova', 'Молдавия']),
+    ('ME', 'MNE', 'Montenegro', 'Черногория', '{}'),
+    ('MF', 'MAF', 'Saint Martin (French part)', 'Сен-Мартен', array['Saint Martin']),
+    ('MG', 'MDG', 'Madagascar', 'Мадагаскар', '{}'),
+    ('MH', 'MHL', 'Marshall Islands', 'Маршалловы Острова', '{}'),
+    ('MK', 'MKD', 'North Macedonia', 'Северная Македония', array['Macedonia', 'Македония']),
+    ('ML', 'MLI', 'Mali', 'Мали', '{}'),
+    ('MM', 'MMR', 'Myanmar', 'Мьянма', array['Burma', 'Бирма']),
+    ('MN', 'MNG', 'Mongolia', 'Монголия', '{}'),
+    ('MO', 'MAC', 'Macao', 'Макао', array['Macau', 'Аомынь']),
+    ('MP', 'MNP', 'Northern Mariana Islands', 'Северные Марианские острова', '{}'),
+    ('MQ', 'MTQ', 'Martinique', 'Мартиника', '{}'),
+    ('MR', 'MRT', 'Mauritania', 'Мавритания', '{}'),
+    ('MS', 'MSR', 'Montserrat', 'Монтсеррат', '{}'),
+    ('MT', 'MLT', 'Malta', 'Мальта', '{}'),
+    ('MU', 'MUS', 'Mauritius', 'Маврикий', '{}'),
+    ('MV', 'MDV', 'Maldives', 'Мальдивы', '{}'),
+    ('MW', 'MWI', 'Malawi', 'Малави', '{}'),
+    ('MX', 'MEX', 'Mexico', 'Мексика', '{}'),
+    ('MY', 'MYS', 'Malaysia', 'Малайзия', '{}'),
+    ('MZ', 'MOZ', 'Mozambique', 'Мозамбик', '{}'),
+    ('NA', 'NAM', 'Namibia', 'Намибия', '{}'),
+    ('NC', 'NCL', 'New Caledonia', 'Новая Каледония', '{}'),
+    ('NE', 'NER', 'Niger', 'Нигер', '{}'),
+    ('NF', 'NFK', 'Norfolk Island', 'Остров Норфолк', '{}'),
+    ('NG', 'NGA', 'Nigeria', 'Нигерия', '{}'),
+    ('NI', 'NIC', 'Nicaragua', 'Никарагуа', '{}'),
+    ('NL', 'NLD', 'Netherlands', 'Нидерланды', array['Holland', 'Голландия']),
+    ('NO', 'NOR', 'Norway', 'Норвегия', '{}'),
+    ('NP', 'NPL', 'Nepal', 'Непал', '{}'),
+    ('NR', 'NRU', 'Nauru', 'Науру', '{}'),
+    ('NU', 'NIU', 'Niue', 'Ниуэ', '{}'),
+    ('NZ', 'NZL', 'New Zealand', 'Новая Зеландия', '{}'),
+    ('OM', 'OMN', 'Oman', 'Оман', '{}'),
+    ('PA', 'PAN', 'Panama', 'Панама', '{}'),
+    ('PE', 'PER', 'Peru', 'Перу', '{}'),
+    ('PF', 'PYF', 'French Polynesia', 'Французская Полинезия', '{}'),
+    ('PG', 'PNG', 'Papua New Guinea', 'Папуа — Новая Гвинея', array['Папуа-Новая Гвинея']),
+    ('PH', 'PHL', 'Philippines', 'Филиппины', '{}'),
+    ('PK', 'PAK', 'Pakistan', 'Пакистан', '{}'),
+    ('PL', 'POL', 'Poland', 'Польша', array['Polska']),
+    ('PM', 'SPM', 'Saint Pierre and Miquelon', 'Сен-Пьер и Микелон', '{}'),
+    ('PN', 'PCN', 'Pitcairn', 'Острова Питкэрн', array['Pitcairn Islands']),
+    ('PR', 'PRI', 'Puerto Rico', 'Пуэрто-Рико', '{}'),
+    ('PS', 'PSE', 'Palestine', 'Палестина', array['State of Palestine']),
+    ('PT', 'PRT', 'Portugal', 'Португалия', '{}'),
+    ('PW', 'PLW', 'Palau', 'Палау', '{}'),
+    ('PY', 'PRY', 'Paraguay', 'Парагвай', '{}'),
+    ('QA', 'QAT', 'Qatar', 'Катар', '{}'),
+    ('RE', 'REU', 'Réunion', 'Реюньон', array['Reunion']),
+    ('RO', 'ROU', 'Romania', 'Румыния', '{}'),
+    ('RS', 'SRB', 'Serbia', 'Сербия', '{}'),
+    ('RU', 'RUS', 'Russia', 'Россия', array['Russian Federation', 'Российская Федерация', 'РФ', 'Rossiya']),
+    ('RW', 'RWA', 'Rwanda', 'Руанда', '{}'),
+    ('SA', 'SAU', 'Saudi Arabia', 'Саудовская Аравия', '{}'),
+    ('SB', 'SLB', 'Solomon Islands', 'Соломоновы Острова', '{}'),
+    ('SC', 'SYC', 'Seychelles', 'Сейшельские Острова', array['Сейшелы']),
+    ('SD', 'SDN', 'Sudan', 'Судан', '{}'),
+    ('SE', 'SWE', 'Sweden', 'Швеция', '{}'),
+    ('SG', 'SGP', 'Singapore', 'Сингапур', '{}'),
+    ('SH', 'SHN', 'Saint Helena, Ascension and Tristan da Cunha', 'Остров Святой Елены', array['Saint Helena']),
+    ('SI', 'SVN', 'Slovenia', 'Словения', '{}'),
+    ('SJ', 'SJM', 'Svalbard and Jan Mayen', 'Шпицберген и Ян-Майен', array['Svalbard']),
+    ('SK', 'SVK', 'Slovakia', 'Словакия', '{}'),
+    ('SL', 'SLE', 'Sierra Leone', 'Сьерра-Леоне', '{}'),
+    ('SM', 'SMR', 'San Marino', 'Сан-Марино', '{}'),
+    ('SN', 'SEN', 'Senegal', 'Сенегал', '{}'),
+    ('SO', 'SOM', 'Somalia', 'Сомали', '{}'),
+    ('SR', 'SUR', 'Suriname', 'Суринам', '{}'),
+    ('SS', 'SSD', 'South Sudan', 'Южный Судан', '{}'),
+    ('ST', 'STP', 'Sao Tome and Principe', 'Сан-Томе и Принсипи', '{}'),
+    ('SV', 'SLV', 'El Salvador', 'Сальвадор', '{}'),
+    ('SX', 'SXM', 'Sint Maarten (Dutch part)', 'Синт-Мартен', array['Sint Maarten']),
+    ('SY', 'SYR', 'Syria', 'Сирия', array['Syrian Arab Republic']),
+    ('SZ', 'SWZ', 'Eswatini', 'Эсватини', array['Swaziland', 'Свазиленд']),
+    ('TC', 'TCA', 'Turks and Caicos Islands', 'Теркс и Кайкос', '{}'),
+    ('TD', 'TCD', 'Chad', 'Чад', '{}'),
+    ('TF', 'ATF', 'French Southern Territories', 'Французские Южные и Антарктические территории', '{}'),
+    ('TG', 'TGO', 'Togo', 'Того', '{}'),
+    ('TH', 'THA', 'Thailand', 'Таиланд', array['Тайланд']),
+    ('TJ', 'TJK', 'Tajikistan', 'Таджикистан', array['Republic of Tajikistan']),
+    ('TK', 'TKL', 'Tokelau', 'Токелау', '{}'),
+    ('TL', 'TLS', 'Timor-Leste', 'Восточный Тимор', array['East Timor']),
+    ('TM', 'TKM', 'Turkmenistan', 'Туркменистан', array['Туркмения']),
+    ('TN', 'TUN', 'Tunisia', 'Тунис', '{}'),
+    ('TO', 'TON', 'Tonga', 'Тонга', '{}'),
+    ('TR', 'TUR', 'Türkiye', 'Турция', array['Turkey', 'Turkiye']),
+    ('TT', 'TTO', 'Trinidad and Tobago', 'Тринидад и Тобаго', '{}'),
+    ('TV', 'TUV', 'Tuvalu', 'Тувалу', '{}'),
+    ('TW', 'TWN', 'Taiwan', 'Тайвань', '{}'),
+    ('TZ', 'TZA', 'Tanzania', 'Танзания', array['United Republic of Tanzania']),
+    ('UA', 'UKR', 'Ukraine', 'Украина', '{}'),
+    ('UG', 'UGA', 'Uganda', 'Уганда', '{}'),
+    ('UM', 'UMI', 'United States Minor Outlying Islands', 'Внешние малые острова США', '{}'),
+    ('US', 'USA', 'United States', 'США', array['United States of America', 'America', 'Соединённые Штаты Америки', 'Соединённые Штаты', 'Америка']),
+    ('UY', 'URY', 'Uruguay', 'Уругвай', '{}'),
+    ('UZ', 'UZB', 'Uzbekistan', 'Узбекистан', array['Republic of Uzbekistan', 'Ozbekiston']),
+    ('VA', 'VAT', 'Holy See', 'Ватикан', array['Vatican', 'Vatican City']),
+    ('VC', 'VCT', 'Saint Vincent and the Grenadines', 'Сент-Винсент и Гренадины', '{}'),
+    ('VE', 'VEN', 'Venezuela', 'Венесуэла', '{}'),
+    ('VG', 'VGB', 'British Virgin Islands', 'Британские Виргинские острова', '{}'),
+    ('VI', 'VIR', 'United States Virgin Islands', 'Виргинские острова (США)', array['US Virgin Islands']),
+    ('VN', 'VNM', 'Viet Nam', 'Вьетнам', array['Vietnam']),
+    ('VU', 'VUT', 'Vanuatu', 'Вануату', '{}'),
+    ('WF', 'WLF', 'Wallis and Futuna', 'Уоллис и Футуна', '{}'),
+    ('WS', 'WSM', 'Samoa', 'Самоа', '{}'),
+    ('YE', 'YEM', 'Yemen', 'Йемен', '{}'),
+    ('YT', 'MYT', 'Mayotte', 'Майотта', '{}'),
+    ('ZA', 'ZAF', 'South Africa', 'Южно-Африканская Республика', array['ЮАР', 'Republic of South Africa']),
+    ('ZM', 'ZMB', 'Zambia', 'Замбия', '{}'),
+    ('ZW', 'ZWE', 'Zimbabwe', 'Зимбабве', '{}')
+on conflict (code) do update
+    set alpha3 = excluded.alpha3, name_en = excluded.name_en, name_ru = excluded.name_ru, aliases = excluded.aliases;
+
+do $$
+declare
+    unmapped record;
+begin
+    create temporary table country_mapping on commit drop as
+    with names as (
+        select c.code, n.value, n.priority
+        from countries c
+        cross join lateral unnest(array[c.code, c.alpha3, c.name_en, c.name_ru] || c.aliases)
+            with ordinality n(value, priority)
+        union all
+        select null, v.country, null
+        from (select country from locations union select country from institutions) v
+        where v.country <> '' and v.country !~ '^[A-Z]{2}$'
+    ), keyed as (
+        select code, value, priority,
+               regexp_replace(trim(regexp_replace(
+                   translate(lower(value), 'ёåáàäéèçíóôöüúñ''’', 'еaaaaeeciooouun'),
+                   '[^[:alnum:]]+', ' ', 'g')), '^the ', '') as key
+        from names
+    )
+    select distinct on (v.value) v.value, c.code
+    from keyed v
+    join keyed c on c.code is not null and c.key = v.key
+    where v.code is null
+    order by v.value, c.priority;
+
+    update locations l set country = m.code from country_mapping m where l.country = m.value;
+    update institutions i set country = m.code from country_mapping m where i.country = m.value;
+
+    for unmapped in
+        select 'locations' as table_name, country, count(*) as total
+        from locations
+        where country !~ '^[A-Z]{2}$'
+        group by country
+        union all
+        select 'institutions', country, count(*)
+        from institutions
+        where country <> '' and country !~ '^[A-Z]{2}$'
+        group by country
+    loop
+        raise notice 'country "%" in % (% rows) does not match any ISO 3166 country',
+            unmapped.country, unmapped.table_name, unmapped.total;
+    end loop;
+
+    if not exists (select 1 from pg_constraint where conname = 'locations_country_check') then
+        alter table locations add constraint locations_country_check check (country ~ '^[A-Z]{2}$') not valid;
+    end if;
+    if not exists (select 1 from pg_constraint where conname = 'institutions_country_check') then
+        alter table institutions add constraint institutions_country_check
+            check (country = '' or country ~ '^[A-Z]{2}$') not valid;
+    end if;
+
+    if not exists (select 1 from locations where country !~ '^[A-Z]{2}$') then
+        alter table locations validate constraint locations_country_check;
+    end if;
+    if not exists (select 1 from institutions where country <> '' and country !~ '^[A-Z]{2}$') then
+        alter table institutions validate constraint institutions_country_check;
+    end if;
+end;
+$$;
+
 -- РОЛИ
 
 -- Участник
@@ -100,12 +1100,42 @@ grant select on public.expeditions to member;
 grant select on public.leaders to member;
 grant select on public.members to member;
 grant select on public.curators to member;
+grant select on public.institutions to member;
 grant select on public.locations to member;
+grant select on public.countries to member;
 grant select on public.artifacts to member;
+grant select on public.periods to member;
+grant select on public.samples to member;
+grant select on public.condition_reports to member;
+grant select on public.storage_nodes to member;
+grant select on public.storage_moves to member;
+grant select on public.loans to member;
+grant select on public.loan_artifacts to member;
+grant select on public.inventory_items to member;
 grant select on public.equipments to member;
+grant select on public.equipment_events to member;
+grant select on public.maintenance_logs to member;
+grant select on public.packing_templates to member;
+grant select on public.packing_template_items to member;
+grant select on public.expedition_packing_lists to member;
+grant select on public.expedition_budgets to member;
+grant select on public.budget_lines to member;
+grant select on public.exchange_rates to member;
+grant select on public.expenses to member;
+grant select, insert on public.journal_entries to member;
+grant update (entry_date, weather, summary, hours_worked, updated_at) on public.journal_entries to member;
+grant select, insert, delete on public.journal_entry_artifacts to member;
+grant select on public.journal_day_locks to member;
+grant select, insert, delete on public.blobs to member;
+grant select, insert, delete on public.attachments to member;
+grant select on public.attachment_thumbnails to member;
 grant select on public.expeditions_leaders to member;
 grant select on public.expeditions_members to member;
 grant select on public.expeditions_curators to member;
+grant select on public.trenches to member;
+grant select on public.contexts to member;
+grant select on public.context_relations to member;
+grant select, insert on public.artifact_custody to member;
 
 create user member1 with PASSWORD 'member1' in role member;
 
@@ -115,14 +1145,47 @@ grant member to leader;
 grant insert, delete on public.members to leader;
 grant insert, update, delete on public.expeditions to leader;
 grant insert, delete on public.curators to leader;
+grant insert, delete on public.institutions to leader;
 grant insert, delete on public.locations to leader;
 grant insert on public.artifacts to leader;
+grant select, insert, update on public.catalog_sequences to leader;
+grant select on public.merge_history to leader;
+grant update (earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id, radiocarbon_age_bp, radiocarbon_sigma, radiocarbon_sample_id, prior_dating, responsible_curator_id) on public.artifacts to leader;
+grant insert, update, delete on public.samples to leader;
+grant insert, delete on public.trenches to leader;
+grant insert, delete on public.contexts to leader;
+grant insert, delete on public.context_relations to leader;
+grant insert, delete on public.inventory_items to leader;
+grant update (total_quantity, service_interval_days, service_field_days, serviceable) on public.inventory_items to leader;
+grant insert on public.maintenance_logs to leader;
+grant insert, delete on public.packing_templates to leader;
+grant insert on public.packing_template_items to leader;
+grant insert on public.expedition_packing_lists to leader;
+grant insert, update on public.expedition_budgets to leader;
+grant insert, update, delete on public.budget_lines to leader;
+grant insert on public.exchange_rates to leader;
+grant insert on public.expenses to leader;
+grant insert on public.journal_day_locks to leader;
 grant insert, delete on public.equipments to leader;
+grant update (amount) on public.equipments to leader;
+grant insert on public.equipment_events to leader;
 grant insert, delete on public.expeditions_members to leader;
 grant insert, delete on public.expeditions_curators to leader;
 
 create user leader1 with PASSWORD 'leader1' in role leader;
 
+-- Куратор
+create role curator inherit;
+grant member to curator;
+grant insert on public.condition_reports to curator;
+grant insert, delete on public.storage_nodes to curator;
+grant insert on public.storage_moves to curator;
+grant update (storage_node_id) on public.artifacts to curator;
+grant insert, update on public.loans to curator;
+grant insert, update on public.loan_artifacts to curator;
+
+create user curator1 with PASSWORD 'curator1' in role curator;
+
 -- Администратор
 create role admin;
 grant create, usage on schema public to admin;
@@ -130,6 +1193,34 @@ grant all privileges on all tables in schema public to admin;
 
 create user admin1 with PASSWORD 'admin1' in role admin;
 
+insert into admins (name, login, password)
+values ('admin', 'admin1', crypt('admin1', gen_salt('bf', 14)))
+on conflict (login) do nothing;
+
+-- ФУНКЦИИ
+
+create or replace function name_key(value text)
+returns text as $$
+    select trim(regexp_replace(
+        translate(
+            replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(
+                lower(value),
+                'щ', 'shch'), 'ж', 'zh'), 'х', 'kh'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'),
+                'ю', 'yu'), 'я', 'ya'), 'ё', 'yo'), 'ї', 'yi'), 'є', 'ye'),
+            'абвгдезийклмнопрстуфыэіґўъь',
+            'abvgdeziyklmnoprstufyeigu'),
+        '[^a-z0-9]+', ' ', 'g'));
+$$ language sql immutable parallel safe;
+
+create or replace function location_search_vector(name text, nearest_town text, country text)
+returns tsvector as $$
+    select setweight(to_tsvector('russian', name), 'A') || setweight(to_tsvector('english', name), 'A') ||
+           setweight(to_tsvector('russian', concat_ws(' ', nearest_town, country,
+               (select name_ru from countries where code = country))), 'B') ||
+           setweight(to_tsvector('english', concat_ws(' ', nearest_town, country,
+               (select name_en from countries where code = country))), 'B');
+$$ language sql stable;
+
 -- ТРИГГЕР
 
 create or replace function check_expedition_dates()
@@ -164,7 +1255,307 @@ before insert on expeditions_members
 for each row
 execute function check_expedition_dates();
 
+create or replace function set_location_search_vector()
+returns trigger as $$
+begin
+    new.search_vector := location_search_vector(new.name, new.nearest_town, new.country);
+
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace trigger set_location_search_vector_trigger
+before insert or update of name, nearest_town, country on locations
+for each row
+execute function set_location_search_vector();
+
+update locations
+set search_vector = location_search_vector(name, nearest_town, country)
+where search_vector is null;
+
+create or replace function forbid_custody_changes()
+returns trigger as $$
+begin
+    if tg_op = 'DELETE' and not exists (select 1 from artifacts where id = old.artifact_id) then
+        return old;
+    end if;
+    if tg_op = 'UPDATE' and current_setting('app.merging', true) = 'on'
+        and to_jsonb(new) - 'signed_by_id' = to_jsonb(old) - 'signed_by_id' then
+        return new;
+    end if;
+
+    raise exception 'artifact custody records are append-only';
+end;
+$$ language plpgsql;
+
+create or replace trigger forbid_custody_changes_trigger
+before update or delete on artifact_custody
+for each row
+execute function forbid_custody_changes();
+
+create or replace function assign_catalog_number()
+returns trigger as $$
+begin
+    new.catalog_location_id := new.location_id;
+    new.catalog_year := extract(year from coalesce(new.found_on, current_date))::int;
+
+    insert into catalog_sequences (location_id, year, last_seq)
+    values (new.catalog_location_id, new.catalog_year, 1)
+    on conflict (location_id, year) do update
+    set last_seq = catalog_sequences.last_seq + 1
+    returning last_seq into new.catalog_seq;
+
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace trigger assign_catalog_number_trigger
+before insert on artifacts
+for each row
+execute function assign_catalog_number();
+
+create or replace function forbid_catalog_number_changes()
+returns trigger as $$
+begin
+    if new.catalog_location_id <> old.catalog_location_id or new.catalog_year <> old.catalog_year or new.catalog_seq <> old.catalog_seq then
+        raise exception 'artifact catalog numbers are immutable';
+    end if;
+
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace trigger forbid_catalog_number_changes_trigger
+before update on artifacts
+for each row
+execute function forbid_catalog_number_changes();
+
+create or replace function forbid_loaned_artifact_delete()
+returns trigger as $$
+begin
+    if exists (select 1 from loan_artifacts where artifact_id = old.id and active) then
+        raise exception 'artifact % is on loan and cannot be deleted', old.id;
+    end if;
+
+    return old;
+end;
+$$ language plpgsql;
+
+create or replace trigger forbid_loaned_artifact_delete_trigger
+before delete on artifacts
+for each row
+execute function forbid_loaned_artifact_delete();
+
+create or replace function check_equipment_stock()
+returns trigger as $$
+declare
+    total integer;
+    reserved integer;
+    is_serviceable boolean;
+begin
+    select total_quantity, serviceable
+    into total, is_serviceable
+    from inventory_items
+    where id = new.item_id
+    for update;
+
+    if not is_serviceable then
+        raise exception 'item % is unserviceable', new.item_id
+            using errcode = 'check_violation';
+    end if;
+
+    select coalesce(sum(amount), 0)
+    into reserved
+    from equipments
+    where item_id = new.item_id and id <> new.id
+        and daterange(reserved_from, reserved_to, '[]') && daterange(new.reserved_from, new.reserved_to, '[]');
+
+    if reserved + new.amount > total then
+        raise exception 'not enough stock of item % for the reservation period', new.item_id
+            using errcode = 'check_violation';
+    end if;
+
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace trigger check_equipment_stock_trigger
+before insert or update on equipments
+for each row
+execute function check_equipment_stock();
+
+create or replace function apply_equipment_event()
+returns trigger as $$
+declare
+    eq record;
+    closed date;
+    checked_out integer;
+    outstanding integer;
+begin
+    select e.id, e.expedition_id, e.item_id, e.amount
+    into eq
+    from equipments e
+    where e.id = new.equipment_id
+    for update;
+
+    select closed_on
+    into closed
+    from expeditions
+    where id = eq.expedition_id
+    for share;
+
+    if closed is not null then
+        raise exception 'expedition % is closed', eq.expedition_id
+            using errcode = 'check_violation';
+    end if;
+
+    if not exists (select 1 from expeditions_members where expedition_id = eq.expedition_id and member_id = new.member_id) then
+        raise exception 'member % is not on the roster of expedition %', new.member_id, eq.expedition_id
+            using errcode = 'foreign_key_violation';
+    end if;
+
+    select coalesce(sum(quantity), 0), coalesce(sum(quantity - returned - lost - damaged), 0)
+    into checked_out, outstanding
+    from equipment_events
+    where equipment_id = new.equipment_id;
+
+    if checked_out + new.quantity > eq.amount then
+        raise exception 'check-out of equipment % exceeds the reserved amount', new.equipment_id
+            using errcode = 'check_violation';
+    end if;
+
+    if new.returned + new.lost + new.damaged > outstanding then
+        raise exception 'check-in of equipment % exceeds the checked-out amount', new.equipment_id
+            using errcode = 'check_violation';
+    end if;
+
+    if new.lost > 0 then
+        update inventory_items
+        set total_quantity = greatest(total_quantity - new.lost, 0)
+        where id = eq.item_id;
+    end if;
+
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace trigger apply_equipment_event_trigger
+before insert on equipment_events
+for each row
+execute function apply_equipment_event();
+
+create or replace function check_expedition_reconciled()
+returns trigger as $$
+begin
+    if new.closed_on is not null and old.closed_on is null and exists (
+        select 1
+        from equipments e
+        join equipment_events ev on ev.equipment_id = e.id
+        where e.expedition_id = new.id
+        group by e.id
+        having sum(ev.quantity - ev.returned - ev.lost - ev.damaged) <> 0
+    ) then
+        raise exception 'equipment of expedition % is not reconciled', new.id
+            using errcode = 'check_violation';
+    end if;
+
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace trigger check_expedition_reconciled_trigger
+before update of closed_on on expeditions
+for each row
+execute function check_expedition_reconciled();
+
+create or replace function forbid_locked_journal_changes()
+returns trigger as $$
+begin
+    if tg_op = 'UPDATE' and current_setting('app.merging', true) = 'on'
+        and to_jsonb(new) - 'member_id' = to_jsonb(old) - 'member_id' then
+        return new;
+    end if;
+
+    if tg_op = 'UPDATE' and old.entry_date <> new.entry_date then
+        perform pg_advisory_xact_lock(old.expedition_id, least(old.entry_date, new.entry_date) - date '2000-01-01');
+        perform pg_advisory_xact_lock(old.expedition_id, greatest(old.entry_date, new.entry_date) - date '2000-01-01');
+    else
+        perform pg_advisory_xact_lock(new.expedition_id, new.entry_date - date '2000-01-01');
+    end if;
+
+    if exists (
+        select 1
+        from journal_day_locks
+        where expedition_id = new.expedition_id
+            and (day = new.entry_date or (tg_op = 'UPDATE' and day = old.entry_date))
+    ) then
+        raise exception 'journal day % of expedition % is locked', new.entry_date, new.expedition_id
+            using errcode = 'check_violation';
+    end if;
+
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace trigger forbid_locked_journal_changes_trigger
+before insert or update on journal_entries
+for each row
+execute function forbid_locked_journal_changes();
+
+create or replace function serialize_journal_day_lock()
+returns trigger as $$
+begin
+    perform pg_advisory_xact_lock(new.expedition_id, new.day - date '2000-01-01');
+
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace trigger serialize_journal_day_lock_trigger
+before insert on journal_day_locks
+for each row
+execute function serialize_journal_day_lock();
+
 -- ИНДЕКСЫ
 
 create index idx_expeditions_members_member_id on expeditions_members(member_id);
-create index idx_expeditions_members_expedition_id on expeditions_members(expedition_id);
\ No newline at end of file
+create index idx_expeditions_members_expedition_id on expeditions_members(expedition_id);
+create index if not exists idx_artifacts_expedition_id on artifacts(expedition_id);
+create index if not exists idx_artifact_custody_artifact_id on artifact_custody(artifact_id);
+create index if not exists idx_artifacts_context_id on artifacts(context_id);
+create index if not exists idx_contexts_trench_id on contexts(trench_id);
+create index if not exists idx_locations_coordinates on locations(latitude, longitude);
+create index if not exists idx_locations_country on locations(country);
+create index if not exists idx_artifacts_find_coordinates on artifacts(find_latitude, find_longitude);
+create index if not exists idx_artifacts_dating on artifacts(earliest_year, latest_year);
+create index if not exists idx_artifacts_period_id on artifacts(period_id);
+create index if not exists idx_samples_expedition_id on samples(expedition_id);
+create index if not exists idx_samples_status_lab on samples(status, lab);
+create index if not exists idx_condition_reports_artifact_id on condition_reports(artifact_id, inspected_on);
+create index if not exists idx_storage_nodes_parent_id on storage_nodes(parent_id);
+create index if not exists idx_artifacts_storage_node_id on artifacts(storage_node_id);
+create index if not exists idx_storage_moves_artifact_id on storage_moves(artifact_id, moved_at);
+create index if not exists idx_loan_artifacts_artifact_id on loan_artifacts(artifact_id);
+create index if not exists idx_loans_status_due_date on loans(status, due_date);
+create index if not exists idx_curators_institution_id on curators(institution_id);
+create index if not exists idx_artifacts_responsible_curator_id on artifacts(responsible_curator_id);
+create index if not exists idx_equipments_item_reserved on equipments(item_id, reserved_from, reserved_to);
+create index if not exists idx_equipment_events_equipment_id on equipment_events(equipment_id);
+create index if not exists idx_maintenance_logs_item_performed on maintenance_logs(item_id, performed_on);
+create index if not exists idx_packing_template_items_template_id on packing_template_items(template_id);
+create index if not exists idx_equipments_expedition_template on equipments(expedition_id, packing_template_id);
+create index if not exists idx_expenses_expedition_category on expenses(expedition_id, category);
+create index if not exists idx_journal_entries_expedition_date on journal_entries(expedition_id, entry_date);
+create index if not exists idx_journal_entry_artifacts_artifact_id on journal_entry_artifacts(artifact_id);
+create index if not exists idx_attachments_processing_status on attachments(processing_status) where processing_status in ('pending', 'processing');
+create index if not exists idx_artifacts_catalog_year_seq on artifacts(catalog_year, catalog_seq);
+create index if not exists idx_leaders_search_vector on leaders using gin(search_vector);
+create index if not exists idx_members_search_vector on members using gin(search_vector);
+create index if not exists idx_curators_search_vector on curators using gin(search_vector);
+create index if not exists idx_locations_search_vector on locations using gin(search_vector);
+create index if not exists idx_artifacts_search_vector on artifacts using gin(search_vector);
+create index if not exists idx_locations_name_key on locations using gin(name_key(name) gin_trgm_ops);
+create index if not exists idx_members_name_key on members using gin(name_key(name) gin_trgm_ops);
+create index if not exists idx_leaders_name_key on leaders using gin(name_key(name) gin_trgm_ops);
+create index if not exists idx_curators_name_key on curators using gin(name_key(name) gin_trgm_ops);
+create index if not exists idx_merge_history_entity on merge_history(entity_type, merged_at);
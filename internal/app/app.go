@@ -7,6 +7,7 @@ import (
 	"db_cp_6/internal/httpserver"
 	"db_cp_6/internal/repo"
 	"db_cp_6/internal/service"
+	"db_cp_6/pkg/blobstore"
 	"db_cp_6/pkg/logger"
 	"db_cp_6/pkg/postgres"
 	"fmt"
@@ -32,6 +33,12 @@ func Run(cfg *config.Config, log *logger.Logger) {
 	}
 	defer leader.Close()
 
+	curator, err := postgres.NewClient(context.Background(), 3, &cfg.Curator)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer curator.Close()
+
 	admin, err := postgres.NewClient(context.Background(), 3, &cfg.Admin)
 	if err != nil {
 		log.Fatal(err)
@@ -39,15 +46,35 @@ func Run(cfg *config.Config, log *logger.Logger) {
 	defer admin.Close()
 	log.Info("connected to db")
 
+	log.Info("initializing blob store")
+	blobs, err := newBlobStore(&cfg.Attachments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	log.Info("initializing repositories")
 	repos := repo.NewRepositories()
 
 	log.Info("initializing services")
-	services := service.NewServices(repos, admin, leader, member)
+	attachments := service.AttachmentConfig{
+		Store:   blobs,
+		MaxSize: cfg.Attachments.MaxSize,
+		Images: service.ImageConfig{
+			Workers:       cfg.Attachments.Images.Workers,
+			QueueSize:     cfg.Attachments.Images.QueueSize,
+			SweepInterval: cfg.Attachments.Images.SweepInterval,
+			Log:           log,
+		},
+	}
+	services := service.NewServices(repos, attachments, admin, leader, member, curator)
+
+	log.Info("starting image processing")
+	services.Images.Start(context.Background())
+	defer services.Images.Stop()
 
 	log.Info("initializing handlers and routes")
 	handler := gin.Default()
-	v1.NewRouter(handler, services, log)
+	v1.NewRouter(handler, services, cfg.HTTPServer.PublicURL, log)
 
 	log.Info("starting http server")
 	address := fmt.Sprintf("%s:%s", cfg.HTTPServer.Host, cfg.HTTPServer.Port)
@@ -69,3 +96,20 @@ func Run(cfg *config.Config, log *logger.Logger) {
 	}
 	log.Debug("Httpserver exited")
 }
+
+func newBlobStore(cfg *config.Attachments) (blobstore.Store, error) {
+	switch cfg.Store {
+	case "", "local":
+		return blobstore.NewLocal(cfg.Dir)
+	case "s3":
+		return blobstore.NewS3(blobstore.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+		}, nil)
+	}
+
+	return nil, fmt.Errorf("unknown attachment store %q", cfg.Store)
+}
@@ -29,6 +29,26 @@ func newArtifactRoutes(gr *gin.RouterGroup, artifactService service.Artifact, au
 	gr.POST("/", r.create)
 }
 
+func newExpeditionArtifactRoutes(gr *gin.RouterGroup, artifactService service.Artifact, authService service.Auth, log *logger.Logger) {
+	r := &artifactRoutes{
+		artifactService: artifactService,
+		authService:     authService,
+		log:             log,
+	}
+
+	gr.GET("/:id/artifacts", r.getByExpeditionId)
+}
+
+func newContextArtifactRoutes(gr *gin.RouterGroup, artifactService service.Artifact, authService service.Auth, log *logger.Logger) {
+	r := &artifactRoutes{
+		artifactService: artifactService,
+		authService:     authService,
+		log:             log,
+	}
+
+	gr.GET("/:id/artifacts", r.getByContextId)
+}
+
 func (r *artifactRoutes) getById(ctx *gin.Context) {
 	token := ctx.Query("token")
 	client, err := r.authService.GetClient(token)
@@ -85,6 +105,58 @@ func (r *artifactRoutes) getByLocationId(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, map[string]interface{}{"artifacts": artifacts})
 }
 
+func (r *artifactRoutes) getByExpeditionId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("artifactRoutes getByExpeditionId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("artifactRoutes getByExpeditionId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifacts, err := r.artifactService.GetExpeditionArtifacts(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("artifactRoutes getByExpeditionId: artifactService.GetExpeditionArtifacts %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"artifacts": artifacts})
+}
+
+func (r *artifactRoutes) getByContextId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("artifactRoutes getByContextId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	contextId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("artifactRoutes getByContextId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifacts, err := r.artifactService.GetContextArtifacts(ctx, client, contextId)
+	if err != nil {
+		r.log.Errorf("artifactRoutes getByContextId: artifactService.GetContextArtifacts %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"artifacts": artifacts})
+}
+
 func (r *artifactRoutes) getAll(ctx *gin.Context) {
 	token := ctx.Query("token")
 	client, err := r.authService.GetClient(token)
@@ -94,9 +166,57 @@ func (r *artifactRoutes) getAll(ctx *gin.Context) {
 		return
 	}
 
-	artifacts, err := r.artifactService.GetAllArtifacts(ctx, client)
+	bbox, err := parseBoundingBox(ctx)
+	if err != nil {
+		r.log.Errorf("artifactRoutes getAll: parseBoundingBox %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	periodId, err := parseOptionalIntQuery(ctx, "period")
+	if err != nil {
+		r.log.Errorf("artifactRoutes getAll: parseOptionalIntQuery %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	from, err := parseOptionalIntQuery(ctx, "from")
+	if err != nil {
+		r.log.Errorf("artifactRoutes getAll: parseOptionalIntQuery %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	to, err := parseOptionalIntQuery(ctx, "to")
+	if err != nil {
+		r.log.Errorf("artifactRoutes getAll: parseOptionalIntQuery %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	curatorId, err := parseOptionalIntQuery(ctx, "curator")
+	if err != nil {
+		r.log.Errorf("artifactRoutes getAll: parseOptionalIntQuery %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	filter := &entity.ArtifactFilter{
+		BoundingBox: bbox,
+		PeriodId:    periodId,
+		DatedFrom:   from,
+		DatedTo:     to,
+		CuratorId:   curatorId,
+	}
+	artifacts, err := r.artifactService.GetAllArtifacts(ctx, client, filter)
 	if err != nil {
 		r.log.Errorf("artifactRoutes getAll: artifactService.GetAllArtifacts %v", err)
+		if errors.Is(err, service.ErrPeriodNotFound) ||
+			errors.Is(err, service.ErrInvalidYearRange) ||
+			errors.Is(err, service.ErrInvalidBoundingBox) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
 		return
 	}
@@ -124,6 +244,15 @@ func (r *artifactRoutes) create(ctx *gin.Context) {
 	id, err := r.artifactService.CreateArtifact(ctx, client, &input)
 	if err != nil {
 		r.log.Errorf("artifactRoutes create: artifactService.CreateArtifact %v", err)
+		if errors.Is(err, service.ErrExpeditionNotFound) ||
+			errors.Is(err, service.ErrContextNotFound) ||
+			errors.Is(err, service.ErrArtifactLocationMismatch) ||
+			errors.Is(err, service.ErrArtifactFoundOutsideExpedition) ||
+			errors.Is(err, service.ErrArtifactFinderNotOnExpedition) ||
+			errors.Is(err, service.ErrPeriodNotFound) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
 		return
 	}
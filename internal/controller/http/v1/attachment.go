@@ -0,0 +1,333 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+const multipartOverhead = 1 << 20
+
+type attachmentRoutes struct {
+	attachmentService service.Attachment
+	authService       service.Auth
+	log               *logger.Logger
+}
+
+func newAttachmentRoutes(gr *gin.RouterGroup, attachmentService service.Attachment, authService service.Auth, log *logger.Logger) {
+	r := &attachmentRoutes{
+		attachmentService: attachmentService,
+		authService:       authService,
+		log:               log,
+	}
+
+	gr.GET("/", r.getByParent)
+	gr.POST("/", r.upload)
+	gr.GET("/:id", r.getById)
+	gr.GET("/:id/content", r.download)
+	gr.DELETE("/:id", r.delete)
+	gr.GET("/:id/thumbnails/:size", r.thumbnail)
+}
+
+func newArtifactAttachmentRoutes(gr *gin.RouterGroup, attachmentService service.Attachment, authService service.Auth, log *logger.Logger) {
+	r := &attachmentRoutes{
+		attachmentService: attachmentService,
+		authService:       authService,
+		log:               log,
+	}
+
+	gr.GET("/:id/find-spot-suggestions", r.getFindSpotSuggestions)
+}
+
+func (r *attachmentRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	attachment, err := r.attachmentService.GetAttachment(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getById: attachmentService.GetAttachment %v", err)
+		switch {
+		case errors.Is(err, service.ErrAttachmentNotFound) ||
+			errors.Is(err, service.ErrAttachmentParentNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"attachment": attachment})
+}
+
+func (r *attachmentRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes delete: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("attachmentRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.attachmentService.DeleteAttachment(ctx, client, user, id)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes delete: attachmentService.DeleteAttachment %v", err)
+		switch {
+		case errors.Is(err, service.ErrAttachmentForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrAttachmentNotFound) ||
+			errors.Is(err, service.ErrAttachmentParentNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *attachmentRoutes) getByParent(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getByParent: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var parent entity.AttachmentParent
+	err = ctx.ShouldBindQuery(&parent)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getByParent: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	attachments, err := r.attachmentService.GetParentAttachments(ctx, client, &parent)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getByParent: attachmentService.GetParentAttachments %v", err)
+		if errors.Is(err, service.ErrAttachmentParentNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"attachments": attachments})
+}
+
+func (r *attachmentRoutes) upload(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes upload: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes upload: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	maxSize := r.attachmentService.MaxUploadSize()
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+multipartOverhead)
+
+	var input entity.UploadAttachmentInput
+	err = ctx.ShouldBind(&input)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes upload: %v", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, map[string]interface{}{"error": service.ErrAttachmentTooLarge.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		r.log.Errorf("attachmentRoutes upload: FormFile %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+	if fileHeader.Size > maxSize {
+		r.log.Errorf("attachmentRoutes upload: file size %d", fileHeader.Size)
+		ctx.JSON(http.StatusRequestEntityTooLarge, map[string]interface{}{"error": service.ErrAttachmentTooLarge.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		r.log.Errorf("attachmentRoutes upload: Open %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	id, err := r.attachmentService.UploadAttachment(ctx, client, user, &input, &entity.AttachmentFile{Name: fileHeader.Filename, Content: file})
+	if err != nil {
+		r.log.Errorf("attachmentRoutes upload: attachmentService.UploadAttachment %v", err)
+		switch {
+		case errors.Is(err, service.ErrAttachmentForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrAttachmentParentNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrAttachmentTooLarge):
+			ctx.JSON(http.StatusRequestEntityTooLarge, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+			ctx.JSON(http.StatusUnsupportedMediaType, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrAttachmentEmpty) ||
+			errors.Is(err, service.ErrAttachmentKindMismatch):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrAttachmentAlreadyExists):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *attachmentRoutes) download(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes download: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("attachmentRoutes download: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	content, err := r.attachmentService.GetAttachmentContent(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes download: attachmentService.GetAttachmentContent %v", err)
+		switch {
+		case errors.Is(err, service.ErrAttachmentNotFound), errors.Is(err, service.ErrAttachmentParentNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+	defer content.Content.Close()
+
+	a := content.Attachment
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.DataFromReader(http.StatusOK, a.Size, a.ContentType, content.Content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": a.FileName}),
+		"ETag":                `"` + a.Sha256 + `"`,
+	})
+}
+
+func (r *attachmentRoutes) thumbnail(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes thumbnail: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("attachmentRoutes thumbnail: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	content, err := r.attachmentService.GetAttachmentThumbnail(ctx, client, id, ctx.Param("size"))
+	if err != nil {
+		r.log.Errorf("attachmentRoutes thumbnail: attachmentService.GetAttachmentThumbnail %v", err)
+		switch {
+		case errors.Is(err, service.ErrAttachmentNotFound),
+			errors.Is(err, service.ErrAttachmentParentNotFound),
+			errors.Is(err, service.ErrThumbnailNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+	defer content.Content.Close()
+
+	t := content.Thumbnail
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.DataFromReader(http.StatusOK, t.Bytes, "image/jpeg", content.Content, map[string]string{
+		"Content-Disposition": "inline",
+		"ETag":                `"` + t.Sha256 + `"`,
+	})
+}
+
+func (r *attachmentRoutes) getFindSpotSuggestions(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getFindSpotSuggestions: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getFindSpotSuggestions: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	suggestions, err := r.attachmentService.GetArtifactFindSpotSuggestions(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("attachmentRoutes getFindSpotSuggestions: attachmentService.GetArtifactFindSpotSuggestions %v", err)
+		if errors.Is(err, service.ErrArtifactNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"suggestions": suggestions})
+}
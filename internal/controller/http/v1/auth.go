@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type authRoutes struct {
+	authService service.Auth
+}
+
+func newAuthRoutes(gr *gin.RouterGroup, authService service.Auth) {
+	r := &authRoutes{
+		authService: authService,
+	}
+
+	gr.POST("/sign-in", r.signIn)
+}
+
+func (r *authRoutes) signIn(ctx *gin.Context) {
+	var input entity.SignInInput
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	token, err := r.authService.SignIn(ctx, &input)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			ctx.JSON(http.StatusUnauthorized, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"token": token})
+}
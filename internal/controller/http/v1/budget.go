@@ -0,0 +1,325 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type budgetRoutes struct {
+	budgetService service.Budget
+	authService   service.Auth
+	log           *logger.Logger
+}
+
+func newExpeditionBudgetRoutes(gr *gin.RouterGroup, budgetService service.Budget, authService service.Auth, log *logger.Logger) {
+	r := &budgetRoutes{
+		budgetService: budgetService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/:id/budget", r.getByExpeditionId)
+	gr.PUT("/:id/budget", r.set)
+	gr.GET("/:id/budget-report", r.getExpeditionReport)
+	gr.GET("/:id/expenses", r.getExpenses)
+	gr.POST("/:id/expenses", r.createExpense)
+}
+
+func newLocationBudgetRoutes(gr *gin.RouterGroup, budgetService service.Budget, authService service.Auth, log *logger.Logger) {
+	r := &budgetRoutes{
+		budgetService: budgetService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/:id/budget-report", r.getLocationReport)
+}
+
+func newExchangeRateRoutes(gr *gin.RouterGroup, budgetService service.Budget, authService service.Auth, log *logger.Logger) {
+	r := &budgetRoutes{
+		budgetService: budgetService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/", r.getExchangeRates)
+	gr.POST("/", r.createExchangeRate)
+}
+
+func (r *budgetRoutes) getByExpeditionId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getByExpeditionId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("budgetRoutes getByExpeditionId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	budget, err := r.budgetService.GetExpeditionBudget(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getByExpeditionId: budgetService.GetExpeditionBudget %v", err)
+		if errors.Is(err, service.ErrBudgetNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"budget": budget})
+}
+
+func (r *budgetRoutes) set(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes set: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes set: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("budgetRoutes set: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.SetBudgetInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("budgetRoutes set: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.budgetService.SetExpeditionBudget(ctx, client, user, expeditionId, &input)
+	if err != nil {
+		r.log.Errorf("budgetRoutes set: budgetService.SetExpeditionBudget %v", err)
+		switch {
+		case errors.Is(err, service.ErrBudgetForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionClosed) ||
+			errors.Is(err, service.ErrBudgetCurrencyLocked) ||
+			errors.Is(err, service.ErrBudgetCategoryInUse):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *budgetRoutes) getExpeditionReport(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getExpeditionReport: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("budgetRoutes getExpeditionReport: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	report, err := r.budgetService.GetExpeditionBudgetReport(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getExpeditionReport: budgetService.GetExpeditionBudgetReport %v", err)
+		if errors.Is(err, service.ErrExpeditionNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"budget_report": report})
+}
+
+func (r *budgetRoutes) getExpenses(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getExpenses: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("budgetRoutes getExpenses: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expenses, err := r.budgetService.GetExpeditionExpenses(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getExpenses: budgetService.GetExpeditionExpenses %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"expenses": expenses})
+}
+
+func (r *budgetRoutes) createExpense(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes createExpense: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes createExpense: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("budgetRoutes createExpense: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateExpenseInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("budgetRoutes createExpense: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.budgetService.CreateExpense(ctx, client, user, expeditionId, &input)
+	if err != nil {
+		r.log.Errorf("budgetRoutes createExpense: budgetService.CreateExpense %v", err)
+		switch {
+		case errors.Is(err, service.ErrBudgetForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionNotFound) ||
+			errors.Is(err, service.ErrBudgetNotFound) ||
+			errors.Is(err, service.ErrBudgetCategoryNotFound) ||
+			errors.Is(err, service.ErrExchangeRateNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpenseInFuture):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionClosed):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *budgetRoutes) getLocationReport(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getLocationReport: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	locationId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("budgetRoutes getLocationReport: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	report, err := r.budgetService.GetLocationBudgetReport(ctx, client, locationId)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getLocationReport: budgetService.GetLocationBudgetReport %v", err)
+		if errors.Is(err, service.ErrLocationNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"budget_report": report})
+}
+
+func (r *budgetRoutes) getExchangeRates(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getExchangeRates: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	rates, err := r.budgetService.GetAllExchangeRates(ctx, client)
+	if err != nil {
+		r.log.Errorf("budgetRoutes getExchangeRates: budgetService.GetAllExchangeRates %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"exchange_rates": rates})
+}
+
+func (r *budgetRoutes) createExchangeRate(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("budgetRoutes createExchangeRate: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateExchangeRateInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("budgetRoutes createExchangeRate: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.budgetService.CreateExchangeRate(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("budgetRoutes createExchangeRate: budgetService.CreateExchangeRate %v", err)
+		if errors.Is(err, service.ErrExchangeRateAlreadyExists) {
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
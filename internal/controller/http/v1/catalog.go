@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type catalogRoutes struct {
+	catalogService service.Catalog
+	authService    service.Auth
+	log            *logger.Logger
+}
+
+func newCatalogRoutes(gr *gin.RouterGroup, catalogService service.Catalog, authService service.Auth, log *logger.Logger) {
+	r := &catalogRoutes{
+		catalogService: catalogService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/lookup", r.lookup)
+}
+
+func (r *catalogRoutes) lookup(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("catalogRoutes lookup: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var query entity.CatalogLookupQuery
+	err = ctx.ShouldBindQuery(&query)
+	if err != nil {
+		r.log.Errorf("catalogRoutes lookup: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	result, candidates, err := r.catalogService.LookupCatalogCode(ctx, client, query.Code)
+	if err != nil {
+		r.log.Errorf("catalogRoutes lookup: catalogService.LookupCatalogCode %v", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidCatalogCode):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrCatalogNumberNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error(), "candidates": candidates})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"lookup": result})
+}
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type conditionReportRoutes struct {
+	conditionReportService service.ConditionReport
+	authService            service.Auth
+	log                    *logger.Logger
+}
+
+func newConditionReportRoutes(gr *gin.RouterGroup, conditionReportService service.ConditionReport, authService service.Auth, log *logger.Logger) {
+	r := &conditionReportRoutes{
+		conditionReportService: conditionReportService,
+		authService:            authService,
+		log:                    log,
+	}
+
+	gr.GET("/:id/condition-reports", r.getByArtifactId)
+	gr.POST("/:id/condition-reports", r.create)
+}
+
+func newOverdueInspectionRoutes(gr *gin.RouterGroup, conditionReportService service.ConditionReport, authService service.Auth, log *logger.Logger) {
+	r := &conditionReportRoutes{
+		conditionReportService: conditionReportService,
+		authService:            authService,
+		log:                    log,
+	}
+
+	gr.GET("/overdue", r.getOverdue)
+}
+
+func (r *conditionReportRoutes) getByArtifactId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes getByArtifactId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes getByArtifactId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	reports, err := r.conditionReportService.GetArtifactConditionReports(ctx, client, artifactId)
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes getByArtifactId: conditionReportService.GetArtifactConditionReports %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"condition_reports": reports})
+}
+
+func (r *conditionReportRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes create: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes create: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateConditionReportInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.conditionReportService.CreateConditionReport(ctx, client, user, artifactId, &input)
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes create: conditionReportService.CreateConditionReport %v", err)
+		switch {
+		case errors.Is(err, service.ErrConditionReportForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrArtifactNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrConditionReportInFuture):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *conditionReportRoutes) getOverdue(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes getOverdue: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	intervalDays, err := strconv.Atoi(ctx.DefaultQuery("interval_days", "0"))
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes getOverdue: Atoi interval_days %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	inspections, err := r.conditionReportService.GetOverdueInspections(ctx, client, intervalDays)
+	if err != nil {
+		r.log.Errorf("conditionReportRoutes getOverdue: conditionReportService.GetOverdueInspections %v", err)
+		if errors.Is(err, service.ErrInvalidInspectionInterval) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"overdue_inspections": inspections})
+}
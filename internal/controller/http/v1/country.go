@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type countryRoutes struct {
+	countryService service.Country
+	authService    service.Auth
+	log            *logger.Logger
+}
+
+func newCountryRoutes(gr *gin.RouterGroup, countryService service.Country, authService service.Auth, log *logger.Logger) {
+	r := &countryRoutes{
+		countryService: countryService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/", r.getAll)
+	gr.GET("/migration", r.previewMigration)
+	gr.POST("/migration", r.applyMigration)
+}
+
+func (r *countryRoutes) getAll(ctx *gin.Context) {
+	countries := r.countryService.GetCountries(requestLanguage(ctx))
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"countries": countries})
+}
+
+func (r *countryRoutes) previewMigration(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("countryRoutes previewMigration: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("countryRoutes previewMigration: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	report, err := r.countryService.MigrateCountries(ctx, client, user, false)
+	if err != nil {
+		r.log.Errorf("countryRoutes previewMigration: countryService.MigrateCountries %v", err)
+		if errors.Is(err, service.ErrCountryMigrationForbidden) {
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"migration": report})
+}
+
+func (r *countryRoutes) applyMigration(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("countryRoutes applyMigration: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("countryRoutes applyMigration: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	report, err := r.countryService.MigrateCountries(ctx, client, user, true)
+	if err != nil {
+		r.log.Errorf("countryRoutes applyMigration: countryService.MigrateCountries %v", err)
+		if errors.Is(err, service.ErrCountryMigrationForbidden) {
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"migration": report})
+}
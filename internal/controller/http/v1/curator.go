@@ -23,6 +23,7 @@ func newCuratorRoutes(gr *gin.RouterGroup, curatorService service.Curator, authS
 		log:            log,
 	}
 
+	gr.GET("/workload", r.getWorkload)
 	gr.GET("/:id", r.getById)
 	gr.GET("/:expedition_id", r.getByExpeditionId)
 	gr.GET("/", r.getAll)
@@ -30,6 +31,26 @@ func newCuratorRoutes(gr *gin.RouterGroup, curatorService service.Curator, authS
 	gr.DELETE("/:id", r.delete)
 }
 
+func newInstitutionCuratorRoutes(gr *gin.RouterGroup, curatorService service.Curator, authService service.Auth, log *logger.Logger) {
+	r := &curatorRoutes{
+		curatorService: curatorService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/:id/curators", r.getByInstitutionId)
+}
+
+func newArtifactCuratorRoutes(gr *gin.RouterGroup, curatorService service.Curator, authService service.Auth, log *logger.Logger) {
+	r := &curatorRoutes{
+		curatorService: curatorService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.PUT("/:id/curator", r.assignArtifact)
+}
+
 func (r *curatorRoutes) getById(ctx *gin.Context) {
 	token := ctx.Query("token")
 	client, err := r.authService.GetClient(token)
@@ -125,7 +146,8 @@ func (r *curatorRoutes) create(ctx *gin.Context) {
 	id, err := r.curatorService.CreateCurator(ctx, client, &input)
 	if err != nil {
 		r.log.Errorf("curatorRoutes create: curatorService.CreateCurator %v", err)
-		if errors.Is(err, service.ErrCuratorAlreadyExists) {
+		if errors.Is(err, service.ErrCuratorAlreadyExists) ||
+			errors.Is(err, service.ErrInstitutionNotFound) {
 			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
 			return
 		}
@@ -165,3 +187,99 @@ func (r *curatorRoutes) delete(ctx *gin.Context) {
 
 	ctx.Status(http.StatusOK)
 }
+
+func (r *curatorRoutes) getByInstitutionId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("curatorRoutes getByInstitutionId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	institutionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("curatorRoutes getByInstitutionId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	curators, err := r.curatorService.GetInstitutionCurators(ctx, client, institutionId)
+	if err != nil {
+		r.log.Errorf("curatorRoutes getByInstitutionId: curatorService.GetInstitutionCurators %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"curators": curators})
+}
+
+func (r *curatorRoutes) assignArtifact(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("curatorRoutes assignArtifact: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("curatorRoutes assignArtifact: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.AssignCuratorInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("curatorRoutes assignArtifact: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.curatorService.AssignArtifactCurator(ctx, client, artifactId, &input)
+	if err != nil {
+		r.log.Errorf("curatorRoutes assignArtifact: curatorService.AssignArtifactCurator %v", err)
+		switch {
+		case errors.Is(err, service.ErrArtifactNotFound) ||
+			errors.Is(err, service.ErrCuratorNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *curatorRoutes) getWorkload(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("curatorRoutes getWorkload: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	intervalDays, err := strconv.Atoi(ctx.DefaultQuery("interval_days", "0"))
+	if err != nil {
+		r.log.Errorf("curatorRoutes getWorkload: Atoi interval_days %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	workloads, err := r.curatorService.GetCuratorWorkloads(ctx, client, intervalDays)
+	if err != nil {
+		r.log.Errorf("curatorRoutes getWorkload: curatorService.GetCuratorWorkloads %v", err)
+		if errors.Is(err, service.ErrInvalidInspectionInterval) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"workloads": workloads})
+}
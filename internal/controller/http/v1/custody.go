@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type custodyRoutes struct {
+	custodyService service.Custody
+	authService    service.Auth
+	log            *logger.Logger
+}
+
+func newCustodyRoutes(gr *gin.RouterGroup, custodyService service.Custody, authService service.Auth, log *logger.Logger) {
+	r := &custodyRoutes{
+		custodyService: custodyService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/:id/custody", r.getByArtifactId)
+	gr.POST("/:id/custody", r.transfer)
+}
+
+func (r *custodyRoutes) getByArtifactId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("custodyRoutes getByArtifactId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("custodyRoutes getByArtifactId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	records, err := r.custodyService.GetArtifactCustody(ctx, client, artifactId)
+	if err != nil {
+		r.log.Errorf("custodyRoutes getByArtifactId: custodyService.GetArtifactCustody %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"custody": records})
+}
+
+func (r *custodyRoutes) transfer(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("custodyRoutes transfer: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("custodyRoutes transfer: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("custodyRoutes transfer: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.TransferArtifactInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("custodyRoutes transfer: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.custodyService.TransferArtifact(ctx, client, user, artifactId, &input)
+	if err != nil {
+		r.log.Errorf("custodyRoutes transfer: custodyService.TransferArtifact %v", err)
+		switch {
+		case errors.Is(err, service.ErrArtifactNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrCustodyHolderNotFound):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrCustodyNotHolder):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrCustodyConflict):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
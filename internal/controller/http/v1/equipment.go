@@ -28,6 +28,19 @@ func newEquipmentRoutes(gr *gin.RouterGroup, equipmentService service.Equipment,
 	gr.GET("/", r.getAll)
 	gr.POST("/", r.create)
 	gr.DELETE("/:id", r.delete)
+	gr.GET("/:id/events", r.getEvents)
+	gr.POST("/:id/check-out", r.checkOut)
+	gr.POST("/:id/check-in", r.checkIn)
+}
+
+func newExpeditionEquipmentRoutes(gr *gin.RouterGroup, equipmentService service.Equipment, authService service.Auth, log *logger.Logger) {
+	r := &equipmentRoutes{
+		equipmentService: equipmentService,
+		authService:      authService,
+		log:              log,
+	}
+
+	gr.GET("/:id/equipment-reconciliation", r.getReconciliation)
 }
 
 func (r *equipmentRoutes) getById(ctx *gin.Context) {
@@ -125,7 +138,19 @@ func (r *equipmentRoutes) create(ctx *gin.Context) {
 	id, err := r.equipmentService.CreateEquipment(ctx, client, &input)
 	if err != nil {
 		r.log.Errorf("equipmentRoutes create: equipmentService.CreateEquipment %v", err)
-		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		switch {
+		case errors.Is(err, service.ErrExpeditionNotFound) ||
+			errors.Is(err, service.ErrInventoryItemNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrInvalidDateRange):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrEquipmentOverbooked) ||
+			errors.Is(err, service.ErrExpeditionClosed) ||
+			errors.Is(err, service.ErrInventoryItemUnserviceable):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
 		return
 	}
 
@@ -161,3 +186,151 @@ func (r *equipmentRoutes) delete(ctx *gin.Context) {
 
 	ctx.Status(http.StatusOK)
 }
+
+func (r *equipmentRoutes) getEvents(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes getEvents: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	equipmentId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("equipmentRoutes getEvents: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	events, err := r.equipmentService.GetEquipmentEvents(ctx, client, equipmentId)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes getEvents: equipmentService.GetEquipmentEvents %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"events": events})
+}
+
+func (r *equipmentRoutes) checkOut(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes checkOut: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	equipmentId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("equipmentRoutes checkOut: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CheckOutEquipmentInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes checkOut: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.equipmentService.CheckOutEquipment(ctx, client, equipmentId, &input)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes checkOut: equipmentService.CheckOutEquipment %v", err)
+		switch {
+		case errors.Is(err, service.ErrEquipmentNotFound) ||
+			errors.Is(err, service.ErrExpeditionNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrEquipmentMemberNotListed):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionClosed) ||
+			errors.Is(err, service.ErrEquipmentOverCheckedOut) ||
+			errors.Is(err, service.ErrEquipmentOverCheckedIn):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *equipmentRoutes) checkIn(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes checkIn: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	equipmentId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("equipmentRoutes checkIn: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CheckInEquipmentInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes checkIn: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.equipmentService.CheckInEquipment(ctx, client, equipmentId, &input)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes checkIn: equipmentService.CheckInEquipment %v", err)
+		switch {
+		case errors.Is(err, service.ErrEquipmentNotFound) ||
+			errors.Is(err, service.ErrExpeditionNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrEquipmentMemberNotListed):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionClosed) ||
+			errors.Is(err, service.ErrEquipmentOverCheckedOut) ||
+			errors.Is(err, service.ErrEquipmentOverCheckedIn):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *equipmentRoutes) getReconciliation(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes getReconciliation: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("equipmentRoutes getReconciliation: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	reconciliation, err := r.equipmentService.GetExpeditionReconciliation(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("equipmentRoutes getReconciliation: equipmentService.GetExpeditionReconciliation %v", err)
+		if errors.Is(err, service.ErrExpeditionNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"reconciliation": reconciliation})
+}
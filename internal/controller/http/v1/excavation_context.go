@@ -0,0 +1,270 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type excavationContextRoutes struct {
+	contextService service.ExcavationContext
+	authService    service.Auth
+	log            *logger.Logger
+}
+
+func newExcavationContextRoutes(gr *gin.RouterGroup, contextService service.ExcavationContext, authService service.Auth, log *logger.Logger) {
+	r := &excavationContextRoutes{
+		contextService: contextService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/:id", r.getById)
+	gr.POST("/", r.create)
+	gr.DELETE("/:id", r.delete)
+}
+
+func newContextRelationRoutes(gr *gin.RouterGroup, contextService service.ExcavationContext, authService service.Auth, log *logger.Logger) {
+	r := &excavationContextRoutes{
+		contextService: contextService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.POST("/", r.createRelation)
+	gr.DELETE("/:id", r.deleteRelation)
+}
+
+func newTrenchContextRoutes(gr *gin.RouterGroup, contextService service.ExcavationContext, authService service.Auth, log *logger.Logger) {
+	r := &excavationContextRoutes{
+		contextService: contextService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/:id/contexts", r.getByTrenchId)
+}
+
+func newHarrisMatrixRoutes(gr *gin.RouterGroup, contextService service.ExcavationContext, authService service.Auth, log *logger.Logger) {
+	r := &excavationContextRoutes{
+		contextService: contextService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/:id/matrix", r.getMatrix)
+}
+
+func (r *excavationContextRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	excavationContext, err := r.contextService.GetContextById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getById: contextService.GetContextById %v", err)
+		if errors.Is(err, service.ErrContextNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"context": excavationContext})
+}
+
+func (r *excavationContextRoutes) getByTrenchId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getByTrenchId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	trenchId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getByTrenchId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	contexts, err := r.contextService.GetTrenchContexts(ctx, client, trenchId)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getByTrenchId: contextService.GetTrenchContexts %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"contexts": contexts})
+}
+
+func (r *excavationContextRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateExcavationContextInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.contextService.CreateContext(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes create: contextService.CreateContext %v", err)
+		if errors.Is(err, service.ErrContextAlreadyExists) {
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *excavationContextRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.contextService.DeleteContext(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes delete: contextService.DeleteContext %v", err)
+		if errors.Is(err, service.ErrContextNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *excavationContextRoutes) createRelation(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes createRelation: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateContextRelationInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes createRelation: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.contextService.CreateContextRelation(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes createRelation: contextService.CreateContextRelation %v", err)
+		switch {
+		case errors.Is(err, service.ErrContextNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrContextLocationMismatch):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrContextRelationAlreadyExists) ||
+			errors.Is(err, service.ErrContextRelationCycle):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *excavationContextRoutes) deleteRelation(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes deleteRelation: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes deleteRelation: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.contextService.DeleteContextRelation(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes deleteRelation: contextService.DeleteContextRelation %v", err)
+		if errors.Is(err, service.ErrContextRelationNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *excavationContextRoutes) getMatrix(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getMatrix: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	locationId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getMatrix: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	matrix, err := r.contextService.GetHarrisMatrix(ctx, client, locationId)
+	if err != nil {
+		r.log.Errorf("excavationContextRoutes getMatrix: contextService.GetHarrisMatrix %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"matrix": matrix})
+}
@@ -28,6 +28,7 @@ func newExpeditionRoutes(gr *gin.RouterGroup, expeditionService service.Expediti
 	gr.POST("/", r.create)
 	gr.PATCH("/:id", r.updateDates)
 	gr.DELETE("/:id", r.delete)
+	gr.POST("/:id/close", r.close)
 }
 
 func (r *expeditionRoutes) getById(ctx *gin.Context) {
@@ -178,3 +179,37 @@ func (r *expeditionRoutes) delete(ctx *gin.Context) {
 
 	ctx.Status(http.StatusOK)
 }
+
+func (r *expeditionRoutes) close(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("expeditionRoutes close: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("expeditionRoutes close: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.expeditionService.CloseExpedition(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("expeditionRoutes close: expeditionService.CloseExpedition %v", err)
+		switch {
+		case errors.Is(err, service.ErrExpeditionNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionClosed) ||
+			errors.Is(err, service.ErrExpeditionNotReconciled):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
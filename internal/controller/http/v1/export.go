@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"encoding/xml"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type exportRoutes struct {
+	exportService service.Export
+	authService   service.Auth
+	log           *logger.Logger
+}
+
+func newExportRoutes(gr *gin.RouterGroup, exportService service.Export, authService service.Auth, log *logger.Logger) {
+	r := &exportRoutes{
+		exportService: exportService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/geojson", r.geoJSON)
+	gr.GET("/kml", r.kml)
+}
+
+func (r *exportRoutes) geoJSON(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("exportRoutes geoJSON: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseExportFilter(ctx)
+	if err != nil {
+		r.log.Errorf("exportRoutes geoJSON: parseExportFilter %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	collection, err := r.exportService.ExportGeoJSON(ctx, client, filter)
+	if err != nil {
+		r.log.Errorf("exportRoutes geoJSON: exportService.ExportGeoJSON %v", err)
+		if errors.Is(err, service.ErrPeriodNotFound) ||
+			errors.Is(err, service.ErrInvalidYearRange) ||
+			errors.Is(err, service.ErrInvalidBoundingBox) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Type", "application/geo+json")
+	ctx.JSON(http.StatusOK, collection)
+}
+
+func (r *exportRoutes) kml(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("exportRoutes kml: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseExportFilter(ctx)
+	if err != nil {
+		r.log.Errorf("exportRoutes kml: parseExportFilter %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	doc, err := r.exportService.ExportKML(ctx, client, filter)
+	if err != nil {
+		r.log.Errorf("exportRoutes kml: exportService.ExportKML %v", err)
+		if errors.Is(err, service.ErrPeriodNotFound) ||
+			errors.Is(err, service.ErrInvalidYearRange) ||
+			errors.Is(err, service.ErrInvalidBoundingBox) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		r.log.Errorf("exportRoutes kml: xml.MarshalIndent %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/vnd.google-earth.kml+xml", append([]byte(xml.Header), data...))
+}
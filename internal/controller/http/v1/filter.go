@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+)
+
+func parseBoundingBox(ctx *gin.Context) (*entity.BoundingBox, error) {
+	value := ctx.Query("bbox")
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("bbox must be min_lon,min_lat,max_lon,max_lat")
+	}
+
+	coords := make([]float64, len(parts))
+	for i, part := range parts {
+		c, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bbox coordinate %q", part)
+		}
+		coords[i] = c
+	}
+
+	return &entity.BoundingBox{
+		MinLongitude: coords[0],
+		MinLatitude:  coords[1],
+		MaxLongitude: coords[2],
+		MaxLatitude:  coords[3],
+	}, nil
+}
+
+func parseLocationFilter(ctx *gin.Context) (*entity.LocationFilter, error) {
+	bbox, err := parseBoundingBox(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &entity.LocationFilter{BoundingBox: bbox}
+	if value := ctx.Query("country"); value != "" {
+		country, ok := entity.NormalizeCountry(value)
+		if !ok {
+			return nil, fmt.Errorf("unknown country %q", value)
+		}
+		filter.Country = country
+	}
+
+	return filter, nil
+}
+
+func parseExportFilter(ctx *gin.Context) (*entity.ExportFilter, error) {
+	locations, err := parseLocationFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := &entity.ArtifactFilter{}
+	if artifacts.PeriodId, err = parseOptionalIntQuery(ctx, "period"); err != nil {
+		return nil, err
+	}
+	if artifacts.DatedFrom, err = parseOptionalIntQuery(ctx, "from"); err != nil {
+		return nil, err
+	}
+	if artifacts.DatedTo, err = parseOptionalIntQuery(ctx, "to"); err != nil {
+		return nil, err
+	}
+	if artifacts.CuratorId, err = parseOptionalIntQuery(ctx, "curator"); err != nil {
+		return nil, err
+	}
+
+	return &entity.ExportFilter{Locations: locations, Artifacts: artifacts}, nil
+}
+
+func requestLanguage(ctx *gin.Context) string {
+	if lang := ctx.Query("lang"); lang != "" {
+		return entity.ParseLanguage(lang)
+	}
+
+	return entity.ParseLanguage(ctx.GetHeader("Accept-Language"))
+}
+
+func parseFloatQuery(ctx *gin.Context, key string) (float64, error) {
+	value, err := strconv.ParseFloat(ctx.Query(key), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", key)
+	}
+
+	return value, nil
+}
+
+func parseOptionalIntQuery(ctx *gin.Context, key string) (*int, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+
+	return &n, nil
+}
+
+func parseIntListQuery(ctx *gin.Context, key string) ([]int, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	values := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", key, part)
+		}
+		values[i] = n
+	}
+
+	return values, nil
+}
@@ -0,0 +1,269 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type iiifRoutes struct {
+	iiifService service.IIIF
+	authService service.Auth
+	links       *entity.Links
+	log         *logger.Logger
+}
+
+func newIIIFRoutes(gr *gin.RouterGroup, iiifService service.IIIF, authService service.Auth, links *entity.Links, log *logger.Logger) {
+	r := &iiifRoutes{
+		iiifService: iiifService,
+		authService: authService,
+		links:       links,
+		log:         log,
+	}
+
+	gr.GET("/artifacts/:id/manifest", r.getArtifactManifest)
+	gr.GET("/artifacts/:id/canvas/:image", r.getArtifactCanvas)
+	gr.GET("/artifacts/:id/canvas/:image/page", r.getArtifactAnnotationPage)
+	gr.GET("/artifacts/:id/canvas/:image/annotation", r.getArtifactAnnotation)
+	gr.GET("/locations/:id/collection", r.getLocationCollection)
+	gr.GET("/images/:id", r.getImage)
+	gr.GET("/images/:id/thumbnail", r.getImageThumbnail)
+}
+
+func (r *iiifRoutes) getArtifactManifest(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactManifest: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactManifest: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	manifest, err := r.iiifService.GetArtifactManifest(ctx, client, r.links, id)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactManifest: iiifService.GetArtifactManifest %v", err)
+		r.writeError(ctx, err)
+		return
+	}
+
+	r.writeIIIF(ctx, manifest)
+}
+
+func (r *iiifRoutes) getArtifactCanvas(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactCanvas: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, imageId, err := canvasParams(ctx)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactCanvas: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	canvas, err := r.iiifService.GetArtifactCanvas(ctx, client, r.links, id, imageId)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactCanvas: iiifService.GetArtifactCanvas %v", err)
+		r.writeError(ctx, err)
+		return
+	}
+
+	r.writeIIIF(ctx, canvas)
+}
+
+func (r *iiifRoutes) getArtifactAnnotationPage(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactAnnotationPage: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, imageId, err := canvasParams(ctx)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactAnnotationPage: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	page, err := r.iiifService.GetArtifactAnnotationPage(ctx, client, r.links, id, imageId)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactAnnotationPage: iiifService.GetArtifactAnnotationPage %v", err)
+		r.writeError(ctx, err)
+		return
+	}
+
+	r.writeIIIF(ctx, page)
+}
+
+func (r *iiifRoutes) getArtifactAnnotation(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactAnnotation: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, imageId, err := canvasParams(ctx)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactAnnotation: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	annotation, err := r.iiifService.GetArtifactAnnotation(ctx, client, r.links, id, imageId)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getArtifactAnnotation: iiifService.GetArtifactAnnotation %v", err)
+		r.writeError(ctx, err)
+		return
+	}
+
+	r.writeIIIF(ctx, annotation)
+}
+
+func (r *iiifRoutes) getLocationCollection(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getLocationCollection: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("iiifRoutes getLocationCollection: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	collection, err := r.iiifService.GetLocationCollection(ctx, client, r.links, id)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getLocationCollection: iiifService.GetLocationCollection %v", err)
+		r.writeError(ctx, err)
+		return
+	}
+
+	r.writeIIIF(ctx, collection)
+}
+
+func (r *iiifRoutes) getImage(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getImage: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("iiifRoutes getImage: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	content, err := r.iiifService.GetImageContent(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getImage: iiifService.GetImageContent %v", err)
+		r.writeError(ctx, err)
+		return
+	}
+	defer content.Content.Close()
+
+	a := content.Attachment
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.DataFromReader(http.StatusOK, a.Size, a.ContentType, content.Content, map[string]string{
+		"Content-Disposition": "inline",
+		"ETag":                `"` + a.Sha256 + `"`,
+	})
+}
+
+func (r *iiifRoutes) getImageThumbnail(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getImageThumbnail: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("iiifRoutes getImageThumbnail: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	content, err := r.iiifService.GetImageThumbnail(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("iiifRoutes getImageThumbnail: iiifService.GetImageThumbnail %v", err)
+		r.writeError(ctx, err)
+		return
+	}
+	defer content.Content.Close()
+
+	t := content.Thumbnail
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.DataFromReader(http.StatusOK, t.Bytes, "image/jpeg", content.Content, map[string]string{
+		"Content-Disposition": "inline",
+		"ETag":                `"` + t.Sha256 + `"`,
+	})
+}
+
+func (r *iiifRoutes) writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrArtifactNotFound),
+		errors.Is(err, service.ErrArtifactHasNoImages),
+		errors.Is(err, service.ErrLocationNotFound),
+		errors.Is(err, service.ErrAttachmentNotFound),
+		errors.Is(err, service.ErrAttachmentParentNotFound),
+		errors.Is(err, service.ErrThumbnailNotFound):
+		ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+	}
+}
+
+func (r *iiifRoutes) writeIIIF(ctx *gin.Context, doc any) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		r.log.Errorf("iiifRoutes writeIIIF: json.Marshal %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, entity.IIIFMediaType, data)
+}
+
+func canvasParams(ctx *gin.Context) (int, int, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("Atoi id %v", err)
+	}
+	imageId, err := strconv.Atoi(ctx.Param("image"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("Atoi image %v", err)
+	}
+
+	return id, imageId, nil
+}
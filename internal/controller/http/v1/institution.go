@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type institutionRoutes struct {
+	institutionService service.Institution
+	authService        service.Auth
+	log                *logger.Logger
+}
+
+func newInstitutionRoutes(gr *gin.RouterGroup, institutionService service.Institution, authService service.Auth, log *logger.Logger) {
+	r := &institutionRoutes{
+		institutionService: institutionService,
+		authService:        authService,
+		log:                log,
+	}
+
+	gr.GET("/:id", r.getById)
+	gr.GET("/", r.getAll)
+	gr.POST("/", r.create)
+	gr.DELETE("/:id", r.delete)
+}
+
+func (r *institutionRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("institutionRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("institutionRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	institution, err := r.institutionService.GetInstitutionById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("institutionRoutes getById: institutionService.GetInstitutionById %v", err)
+		if errors.Is(err, service.ErrInstitutionNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	institution.LocalizeCountry(requestLanguage(ctx))
+	ctx.JSON(http.StatusOK, map[string]interface{}{"institution": institution})
+}
+
+func (r *institutionRoutes) getAll(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("institutionRoutes getAll: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	institutions, err := r.institutionService.GetAllInstitutions(ctx, client)
+	if err != nil {
+		r.log.Errorf("institutionRoutes getAll: institutionService.GetAllInstitutions %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	institutions.LocalizeCountry(requestLanguage(ctx))
+	ctx.JSON(http.StatusOK, map[string]interface{}{"institutions": institutions})
+}
+
+func (r *institutionRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("institutionRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateInstitutionInput
+	err = ctx.ShouldBindJSON(&input)
+	if err == nil {
+		err = input.IsValid()
+	}
+	if err != nil {
+		r.log.Errorf("institutionRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.institutionService.CreateInstitution(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("institutionRoutes create: institutionService.CreateInstitution %v", err)
+		if errors.Is(err, service.ErrInstitutionAlreadyExists) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *institutionRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("institutionRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("institutionRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.institutionService.DeleteInstitution(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("institutionRoutes delete: institutionService.DeleteInstitution %v", err)
+		if errors.Is(err, service.ErrInstitutionNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type inventoryRoutes struct {
+	inventoryService service.Inventory
+	authService      service.Auth
+	log              *logger.Logger
+}
+
+func newInventoryRoutes(gr *gin.RouterGroup, inventoryService service.Inventory, authService service.Auth, log *logger.Logger) {
+	r := &inventoryRoutes{
+		inventoryService: inventoryService,
+		authService:      authService,
+		log:              log,
+	}
+
+	gr.GET("/availability", r.getAvailability)
+	gr.GET("/:id", r.getById)
+	gr.GET("/", r.getAll)
+	gr.POST("/", r.create)
+	gr.DELETE("/:id", r.delete)
+}
+
+func (r *inventoryRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("inventoryRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	item, err := r.inventoryService.GetInventoryItemById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes getById: inventoryService.GetInventoryItemById %v", err)
+		if errors.Is(err, service.ErrInventoryItemNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"item": item})
+}
+
+func (r *inventoryRoutes) getAll(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes getAll: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	items, err := r.inventoryService.GetAllInventoryItems(ctx, client)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes getAll: inventoryService.GetAllInventoryItems %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"items": items})
+}
+
+func (r *inventoryRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateInventoryItemInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.inventoryService.CreateInventoryItem(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes create: inventoryService.CreateInventoryItem %v", err)
+		if errors.Is(err, service.ErrInventoryItemAlreadyExists) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *inventoryRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("inventoryRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.inventoryService.DeleteInventoryItem(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes delete: inventoryService.DeleteInventoryItem %v", err)
+		switch {
+		case errors.Is(err, service.ErrInventoryItemNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrInventoryItemReserved):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *inventoryRoutes) getAvailability(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("inventoryRoutes getAvailability: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	itemId, err := parseOptionalIntQuery(ctx, "item")
+	if err != nil {
+		r.log.Errorf("inventoryRoutes getAvailability: parseOptionalIntQuery %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	availability, err := r.inventoryService.GetItemAvailability(ctx, client, itemId, ctx.Query("from"), ctx.Query("to"))
+	if err != nil {
+		r.log.Errorf("inventoryRoutes getAvailability: inventoryService.GetItemAvailability %v", err)
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"availability": availability})
+}
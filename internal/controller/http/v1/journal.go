@@ -0,0 +1,289 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type journalRoutes struct {
+	journalService service.Journal
+	authService    service.Auth
+	log            *logger.Logger
+}
+
+func newExpeditionJournalRoutes(gr *gin.RouterGroup, journalService service.Journal, authService service.Auth, log *logger.Logger) {
+	r := &journalRoutes{
+		journalService: journalService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/:id/journal", r.getByExpeditionId)
+	gr.POST("/:id/journal", r.create)
+	gr.GET("/:id/journal/locks", r.getLocks)
+	gr.POST("/:id/journal/locks", r.lockDay)
+	gr.GET("/:id/journal/document", r.compile)
+}
+
+func newJournalEntryRoutes(gr *gin.RouterGroup, journalService service.Journal, authService service.Auth, log *logger.Logger) {
+	r := &journalRoutes{
+		journalService: journalService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.PUT("/:id", r.update)
+}
+
+func (r *journalRoutes) getByExpeditionId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes getByExpeditionId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("journalRoutes getByExpeditionId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	entries, err := r.journalService.GetExpeditionJournal(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("journalRoutes getByExpeditionId: journalService.GetExpeditionJournal %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"journal_entries": entries})
+}
+
+func (r *journalRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes create: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("journalRoutes create: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.JournalEntryInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("journalRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.journalService.CreateJournalEntry(ctx, client, user, expeditionId, &input)
+	if err != nil {
+		r.log.Errorf("journalRoutes create: journalService.CreateJournalEntry %v", err)
+		switch {
+		case errors.Is(err, service.ErrJournalMemberNotListed):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrJournalDateOutOfRange) ||
+			errors.Is(err, service.ErrJournalArtifactMismatch):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionClosed) ||
+			errors.Is(err, service.ErrJournalDayLocked):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *journalRoutes) update(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes update: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes update: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("journalRoutes update: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.JournalEntryInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("journalRoutes update: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.journalService.UpdateJournalEntry(ctx, client, user, id, &input)
+	if err != nil {
+		r.log.Errorf("journalRoutes update: journalService.UpdateJournalEntry %v", err)
+		switch {
+		case errors.Is(err, service.ErrJournalEntryForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrJournalEntryNotFound) ||
+			errors.Is(err, service.ErrExpeditionNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrJournalDateOutOfRange) ||
+			errors.Is(err, service.ErrJournalArtifactMismatch):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionClosed) ||
+			errors.Is(err, service.ErrJournalDayLocked):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *journalRoutes) getLocks(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes getLocks: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("journalRoutes getLocks: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	locks, err := r.journalService.GetExpeditionJournalLocks(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("journalRoutes getLocks: journalService.GetExpeditionJournalLocks %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"journal_locks": locks})
+}
+
+func (r *journalRoutes) lockDay(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes lockDay: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes lockDay: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("journalRoutes lockDay: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.LockJournalDayInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("journalRoutes lockDay: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.journalService.LockJournalDay(ctx, client, user, expeditionId, &input)
+	if err != nil {
+		r.log.Errorf("journalRoutes lockDay: journalService.LockJournalDay %v", err)
+		switch {
+		case errors.Is(err, service.ErrJournalLockForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrJournalDateOutOfRange):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrJournalDayLocked):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (r *journalRoutes) compile(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("journalRoutes compile: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("journalRoutes compile: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	doc, err := r.journalService.CompileExpeditionJournal(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("journalRoutes compile: journalService.CompileExpeditionJournal %v", err)
+		if errors.Is(err, service.ErrExpeditionNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if ctx.Query("format") == "markdown" {
+		ctx.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(doc.Markdown()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"journal": doc})
+}
@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type labelRoutes struct {
+	labelService service.Label
+	authService  service.Auth
+	links        *entity.Links
+	log          *logger.Logger
+}
+
+func newLabelRoutes(gr *gin.RouterGroup, labelService service.Label, authService service.Auth, links *entity.Links, log *logger.Logger) {
+	r := &labelRoutes{
+		labelService: labelService,
+		authService:  authService,
+		links:        links,
+		log:          log,
+	}
+
+	gr.GET("/", r.getLabels)
+}
+
+func (r *labelRoutes) getLabels(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("labelRoutes getLabels: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var query entity.LabelQuery
+	err = ctx.ShouldBindQuery(&query)
+	if err == nil {
+		err = query.IsValid()
+	}
+	if err != nil {
+		r.log.Errorf("labelRoutes getLabels: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	sheet, err := r.labelService.GetLabelSheet(ctx, client, r.links, &query)
+	if err != nil {
+		r.log.Errorf("labelRoutes getLabels: labelService.GetLabelSheet %v", err)
+		switch {
+		case errors.Is(err, service.ErrArtifactNotFound),
+			errors.Is(err, service.ErrExpeditionNotFound),
+			errors.Is(err, service.ErrLocationNotFound),
+			errors.Is(err, service.ErrNoArtifactsToLabel):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	contentType, fileName := "application/pdf", "labels.pdf"
+	render := sheet.PDF
+	if query.Format == entity.LabelFormatSVG {
+		contentType, fileName = "image/svg+xml", "labels.svg"
+		render = sheet.SVG
+	}
+
+	data, err := render()
+	if err != nil {
+		r.log.Errorf("labelRoutes getLabels: render %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", "inline; filename=\""+fileName+"\"")
+	ctx.Data(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,231 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type loanRoutes struct {
+	loanService service.Loan
+	authService service.Auth
+	log         *logger.Logger
+}
+
+func newLoanRoutes(gr *gin.RouterGroup, loanService service.Loan, authService service.Auth, log *logger.Logger) {
+	r := &loanRoutes{
+		loanService: loanService,
+		authService: authService,
+		log:         log,
+	}
+
+	gr.GET("/overdue", r.getOverdue)
+	gr.GET("/:id", r.getById)
+	gr.GET("/", r.getAll)
+	gr.POST("/", r.create)
+	gr.PATCH("/:id/status", r.updateStatus)
+}
+
+func newArtifactLoanRoutes(gr *gin.RouterGroup, loanService service.Loan, authService service.Auth, log *logger.Logger) {
+	r := &loanRoutes{
+		loanService: loanService,
+		authService: authService,
+		log:         log,
+	}
+
+	gr.GET("/:id/loans", r.getByArtifactId)
+}
+
+func (r *loanRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("loanRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("loanRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	loan, err := r.loanService.GetLoanById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("loanRoutes getById: loanService.GetLoanById %v", err)
+		if errors.Is(err, service.ErrLoanNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"loan": loan})
+}
+
+func (r *loanRoutes) getByArtifactId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("loanRoutes getByArtifactId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("loanRoutes getByArtifactId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	loans, err := r.loanService.GetArtifactLoans(ctx, client, artifactId)
+	if err != nil {
+		r.log.Errorf("loanRoutes getByArtifactId: loanService.GetArtifactLoans %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"loans": loans})
+}
+
+func (r *loanRoutes) getAll(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("loanRoutes getAll: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	loans, err := r.loanService.GetAllLoans(ctx, client)
+	if err != nil {
+		r.log.Errorf("loanRoutes getAll: loanService.GetAllLoans %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"loans": loans})
+}
+
+func (r *loanRoutes) getOverdue(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("loanRoutes getOverdue: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	loans, err := r.loanService.GetOverdueLoans(ctx, client)
+	if err != nil {
+		r.log.Errorf("loanRoutes getOverdue: loanService.GetOverdueLoans %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"overdue_loans": loans})
+}
+
+func (r *loanRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("loanRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("loanRoutes create: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateLoanInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("loanRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.loanService.CreateLoan(ctx, client, user, &input)
+	if err != nil {
+		r.log.Errorf("loanRoutes create: loanService.CreateLoan %v", err)
+		switch {
+		case errors.Is(err, service.ErrLoanForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrArtifactNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrLoanOverlap):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *loanRoutes) updateStatus(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("loanRoutes updateStatus: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("loanRoutes updateStatus: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("loanRoutes updateStatus: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.UpdateLoanStatusInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("loanRoutes updateStatus: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.loanService.UpdateLoanStatus(ctx, client, user, id, &input)
+	if err != nil {
+		r.log.Errorf("loanRoutes updateStatus: loanService.UpdateLoanStatus %v", err)
+		switch {
+		case errors.Is(err, service.ErrLoanForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrLoanNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrInvalidLoanTransition):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrLoanConflict) ||
+			errors.Is(err, service.ErrCustodyConflict):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
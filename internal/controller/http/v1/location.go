@@ -25,6 +25,7 @@ func newLocationRoutes(gr *gin.RouterGroup, locationService service.Location, au
 
 	gr.GET("/:id", r.getById)
 	gr.GET("/", r.getAll)
+	gr.GET("/near", r.getNearby)
 	gr.POST("/", r.create)
 	gr.DELETE("/:id", r.delete)
 }
@@ -56,6 +57,7 @@ func (r *locationRoutes) getById(ctx *gin.Context) {
 		return
 	}
 
+	location.LocalizeCountry(requestLanguage(ctx))
 	ctx.JSON(http.StatusOK, map[string]interface{}{"location": location})
 }
 
@@ -68,13 +70,59 @@ func (r *locationRoutes) getAll(ctx *gin.Context) {
 		return
 	}
 
-	locations, err := r.locationService.GetAllLocations(ctx, client)
+	filter, err := parseLocationFilter(ctx)
+	if err != nil {
+		r.log.Errorf("locationRoutes getAll: parseLocationFilter %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	locations, err := r.locationService.GetAllLocations(ctx, client, filter)
 	if err != nil {
 		r.log.Errorf("locationRoutes getAll: locationService.GetAllLocations %v", err)
+		if errors.Is(err, service.ErrInvalidBoundingBox) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
 		return
 	}
 
+	locations.LocalizeCountry(requestLanguage(ctx))
+	ctx.JSON(http.StatusOK, map[string]interface{}{"locations": locations})
+}
+
+func (r *locationRoutes) getNearby(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("locationRoutes getNearby: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var query entity.NearbyQuery
+	for key, value := range map[string]*float64{"lat": &query.Latitude, "lon": &query.Longitude, "radius_km": &query.RadiusKm} {
+		*value, err = parseFloatQuery(ctx, key)
+		if err != nil {
+			r.log.Errorf("locationRoutes getNearby: parseFloatQuery %v", err)
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+	}
+
+	locations, err := r.locationService.GetNearbyLocations(ctx, client, &query)
+	if err != nil {
+		r.log.Errorf("locationRoutes getNearby: locationService.GetNearbyLocations %v", err)
+		if errors.Is(err, service.ErrInvalidCoordinates) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	locations.LocalizeCountry(requestLanguage(ctx))
 	ctx.JSON(http.StatusOK, map[string]interface{}{"locations": locations})
 }
 
@@ -89,6 +137,9 @@ func (r *locationRoutes) create(ctx *gin.Context) {
 
 	var input entity.CreateLocationInput
 	err = ctx.ShouldBindJSON(&input)
+	if err == nil {
+		err = input.IsValid()
+	}
 	if err != nil {
 		r.log.Errorf("locationRoutes create: %v", err)
 		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
@@ -0,0 +1,178 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type maintenanceRoutes struct {
+	maintenanceService service.Maintenance
+	authService        service.Auth
+	log                *logger.Logger
+}
+
+func newInventoryMaintenanceRoutes(gr *gin.RouterGroup, maintenanceService service.Maintenance, authService service.Auth, log *logger.Logger) {
+	r := &maintenanceRoutes{
+		maintenanceService: maintenanceService,
+		authService:        authService,
+		log:                log,
+	}
+
+	gr.GET("/:id/maintenance", r.getByItemId)
+	gr.POST("/:id/maintenance", r.create)
+	gr.PUT("/:id/maintenance-schedule", r.updateSchedule)
+}
+
+func newExpeditionMaintenanceRoutes(gr *gin.RouterGroup, maintenanceService service.Maintenance, authService service.Auth, log *logger.Logger) {
+	r := &maintenanceRoutes{
+		maintenanceService: maintenanceService,
+		authService:        authService,
+		log:                log,
+	}
+
+	gr.GET("/:id/maintenance-due", r.getDue)
+}
+
+func (r *maintenanceRoutes) getByItemId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes getByItemId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	itemId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes getByItemId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	logs, err := r.maintenanceService.GetItemMaintenanceLogs(ctx, client, itemId)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes getByItemId: maintenanceService.GetItemMaintenanceLogs %v", err)
+		if errors.Is(err, service.ErrInventoryItemNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"maintenance_logs": logs})
+}
+
+func (r *maintenanceRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	itemId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes create: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateMaintenanceLogInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.maintenanceService.CreateMaintenanceLog(ctx, client, itemId, &input)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes create: maintenanceService.CreateMaintenanceLog %v", err)
+		switch {
+		case errors.Is(err, service.ErrInventoryItemNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrMaintenanceInFuture):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *maintenanceRoutes) updateSchedule(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes updateSchedule: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	itemId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes updateSchedule: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.UpdateMaintenanceScheduleInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes updateSchedule: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.maintenanceService.UpdateMaintenanceSchedule(ctx, client, itemId, &input)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes updateSchedule: maintenanceService.UpdateMaintenanceSchedule %v", err)
+		if errors.Is(err, service.ErrInventoryItemNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *maintenanceRoutes) getDue(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes getDue: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes getDue: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	dues, err := r.maintenanceService.GetMaintenanceDue(ctx, client, expeditionId)
+	if err != nil {
+		r.log.Errorf("maintenanceRoutes getDue: maintenanceService.GetMaintenanceDue %v", err)
+		if errors.Is(err, service.ErrExpeditionNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"maintenance_due": dues})
+}
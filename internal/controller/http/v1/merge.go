@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type mergeRoutes struct {
+	mergeService service.Merge
+	authService  service.Auth
+	log          *logger.Logger
+}
+
+func newMergeRoutes(gr *gin.RouterGroup, mergeService service.Merge, authService service.Auth, log *logger.Logger) {
+	r := &mergeRoutes{
+		mergeService: mergeService,
+		authService:  authService,
+		log:          log,
+	}
+
+	gr.GET("/", r.getCandidates)
+	gr.GET("/history", r.getHistory)
+	gr.POST("/:type/merge", r.merge)
+}
+
+func (r *mergeRoutes) getCandidates(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("mergeRoutes getCandidates: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("mergeRoutes getCandidates: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var query entity.DuplicateQuery
+	err = ctx.ShouldBindQuery(&query)
+	if err == nil {
+		err = query.IsValid()
+	}
+	if err != nil {
+		r.log.Errorf("mergeRoutes getCandidates: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	candidates, err := r.mergeService.GetDuplicateCandidates(ctx, client, user, &query)
+	if err != nil {
+		r.log.Errorf("mergeRoutes getCandidates: mergeService.GetDuplicateCandidates %v", err)
+		if errors.Is(err, service.ErrDuplicatesForbidden) {
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"duplicates": candidates})
+}
+
+func (r *mergeRoutes) getHistory(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("mergeRoutes getHistory: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("mergeRoutes getHistory: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	entityType := ctx.Query("type")
+	if entityType != "" {
+		query := entity.DuplicateQuery{Type: entityType}
+		if err = query.IsValid(); err != nil {
+			r.log.Errorf("mergeRoutes getHistory: %v", err)
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+	}
+
+	history, err := r.mergeService.GetMergeHistory(ctx, client, user, entityType)
+	if err != nil {
+		r.log.Errorf("mergeRoutes getHistory: mergeService.GetMergeHistory %v", err)
+		if errors.Is(err, service.ErrDuplicatesForbidden) {
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"merges": history})
+}
+
+func (r *mergeRoutes) merge(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("mergeRoutes merge: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("mergeRoutes merge: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	entityType := ctx.Param("type")
+	query := entity.DuplicateQuery{Type: entityType}
+	var input entity.MergeInput
+	err = query.IsValid()
+	if err == nil {
+		err = ctx.ShouldBindJSON(&input)
+	}
+	if err == nil {
+		err = input.IsValid()
+	}
+	if err != nil {
+		r.log.Errorf("mergeRoutes merge: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	record, err := r.mergeService.Merge(ctx, client, user, entityType, &input)
+	if err != nil {
+		r.log.Errorf("mergeRoutes merge: mergeService.Merge %v", err)
+		switch {
+		case errors.Is(err, service.ErrMergeForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrMergeTargetNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrMergeConflict):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"merge": record})
+}
@@ -0,0 +1,240 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type packingRoutes struct {
+	packingService service.Packing
+	authService    service.Auth
+	log            *logger.Logger
+}
+
+func newPackingTemplateRoutes(gr *gin.RouterGroup, packingService service.Packing, authService service.Auth, log *logger.Logger) {
+	r := &packingRoutes{
+		packingService: packingService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/:id", r.getById)
+	gr.GET("/", r.getAll)
+	gr.POST("/", r.create)
+	gr.DELETE("/:id", r.delete)
+}
+
+func newExpeditionPackingRoutes(gr *gin.RouterGroup, packingService service.Packing, authService service.Auth, log *logger.Logger) {
+	r := &packingRoutes{
+		packingService: packingService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.POST("/:id/packing-lists", r.apply)
+	gr.GET("/:id/packing-lists/:template_id/diff", r.getDiff)
+}
+
+func (r *packingRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("packingRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("packingRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	template, err := r.packingService.GetPackingTemplateById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("packingRoutes getById: packingService.GetPackingTemplateById %v", err)
+		if errors.Is(err, service.ErrPackingTemplateNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"packing_template": template})
+}
+
+func (r *packingRoutes) getAll(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("packingRoutes getAll: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	templates, err := r.packingService.GetAllPackingTemplates(ctx, client)
+	if err != nil {
+		r.log.Errorf("packingRoutes getAll: packingService.GetAllPackingTemplates %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"packing_templates": templates})
+}
+
+func (r *packingRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("packingRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreatePackingTemplateInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("packingRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.packingService.CreatePackingTemplate(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("packingRoutes create: packingService.CreatePackingTemplate %v", err)
+		switch {
+		case errors.Is(err, service.ErrInventoryItemNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrPackingTemplateAlreadyExists):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *packingRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("packingRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("packingRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.packingService.DeletePackingTemplate(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("packingRoutes delete: packingService.DeletePackingTemplate %v", err)
+		if errors.Is(err, service.ErrPackingTemplateNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *packingRoutes) apply(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("packingRoutes apply: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("packingRoutes apply: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.ApplyPackingTemplateInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("packingRoutes apply: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.packingService.ApplyPackingTemplate(ctx, client, expeditionId, &input)
+	if err != nil {
+		r.log.Errorf("packingRoutes apply: packingService.ApplyPackingTemplate %v", err)
+		switch {
+		case errors.Is(err, service.ErrExpeditionNotFound) ||
+			errors.Is(err, service.ErrPackingTemplateNotFound) ||
+			errors.Is(err, service.ErrInventoryItemNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrExpeditionClosed) ||
+			errors.Is(err, service.ErrPackingListAlreadyApplied) ||
+			errors.Is(err, service.ErrInventoryItemUnserviceable) ||
+			errors.Is(err, service.ErrEquipmentOverbooked):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (r *packingRoutes) getDiff(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("packingRoutes getDiff: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("packingRoutes getDiff: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	templateId, err := strconv.Atoi(ctx.Param("template_id"))
+	if err != nil {
+		r.log.Errorf("packingRoutes getDiff: Atoi template_id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	diff, err := r.packingService.GetPackingListDiff(ctx, client, expeditionId, templateId)
+	if err != nil {
+		r.log.Errorf("packingRoutes getDiff: packingService.GetPackingListDiff %v", err)
+		switch {
+		case errors.Is(err, service.ErrExpeditionNotFound) ||
+			errors.Is(err, service.ErrPackingTemplateNotFound) ||
+			errors.Is(err, service.ErrPackingListNotApplied):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"packing_list_diff": diff})
+}
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type periodRoutes struct {
+	periodService service.Period
+	authService   service.Auth
+	log           *logger.Logger
+}
+
+func newPeriodRoutes(gr *gin.RouterGroup, periodService service.Period, authService service.Auth, log *logger.Logger) {
+	r := &periodRoutes{
+		periodService: periodService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/:id", r.getById)
+	gr.GET("/", r.getAll)
+	gr.POST("/", r.create)
+	gr.DELETE("/:id", r.delete)
+}
+
+func (r *periodRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("periodRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("periodRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	period, err := r.periodService.GetPeriodById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("periodRoutes getById: periodService.GetPeriodById %v", err)
+		if errors.Is(err, service.ErrPeriodNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"period": period})
+}
+
+func (r *periodRoutes) getAll(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("periodRoutes getAll: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	periods, err := r.periodService.GetAllPeriods(ctx, client)
+	if err != nil {
+		r.log.Errorf("periodRoutes getAll: periodService.GetAllPeriods %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"periods": periods})
+}
+
+func (r *periodRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("periodRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreatePeriodInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("periodRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.periodService.CreatePeriod(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("periodRoutes create: periodService.CreatePeriod %v", err)
+		switch {
+		case errors.Is(err, service.ErrPeriodNotFound) ||
+			errors.Is(err, service.ErrPeriodOutsideParent):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrPeriodAlreadyExists):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *periodRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("periodRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("periodRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.periodService.DeletePeriod(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("periodRoutes delete: periodService.DeletePeriod %v", err)
+		if errors.Is(err, service.ErrPeriodNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -1,14 +1,16 @@
 package v1
 
 import (
+	"db_cp_6/internal/entity"
 	"db_cp_6/internal/service"
 	"db_cp_6/pkg/logger"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"strings"
 )
 
-func NewRouter(handler *gin.Engine, services *service.Services, log *logger.Logger) {
+func NewRouter(handler *gin.Engine, services *service.Services, publicURL string, log *logger.Logger) {
 	gin.DisableConsoleColor()
 
 	handler.Use(gin.LoggerWithWriter(log.Writer()))
@@ -17,8 +19,11 @@ func NewRouter(handler *gin.Engine, services *service.Services, log *logger.Logg
 	handler.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	mainGroup := handler.Group("/api/v1")
+	auth := mainGroup.Group("/auth")
 	newAuthRoutes(auth, services.Auth)
 
+	links := &entity.Links{BaseURL: strings.TrimRight(publicURL, "/") + "/api/v1"}
+
 	authMiddleware := &AuthMiddleware{
 		services.Auth,
 		log,
@@ -28,9 +33,49 @@ func NewRouter(handler *gin.Engine, services *service.Services, log *logger.Logg
 		newLeaderRoutes(withAuth.Group("/leaders"), services.Leader, services.Auth, log)
 		newMemberRoutes(withAuth.Group("/members"), services.Member, services.Auth, log)
 		newCuratorRoutes(withAuth.Group("/curators"), services.Curator, services.Auth, log)
+		newInstitutionRoutes(withAuth.Group("/institutions"), services.Institution, services.Auth, log)
+		newInstitutionCuratorRoutes(withAuth.Group("/institutions"), services.Curator, services.Auth, log)
 		newLocationRoutes(withAuth.Group("/locations"), services.Location, services.Auth, log)
+		newLocationTrenchRoutes(withAuth.Group("/locations"), services.Trench, services.Auth, log)
+		newHarrisMatrixRoutes(withAuth.Group("/locations"), services.ExcavationContext, services.Auth, log)
 		newExpeditionRoutes(withAuth.Group("/expeditions"), services.Expedition, services.Auth, log)
+		newExpeditionArtifactRoutes(withAuth.Group("/expeditions"), services.Artifact, services.Auth, log)
+		newTrenchRoutes(withAuth.Group("/trenches"), services.Trench, services.Auth, log)
+		newTrenchContextRoutes(withAuth.Group("/trenches"), services.ExcavationContext, services.Auth, log)
+		newExcavationContextRoutes(withAuth.Group("/contexts"), services.ExcavationContext, services.Auth, log)
+		newContextArtifactRoutes(withAuth.Group("/contexts"), services.Artifact, services.Auth, log)
+		newContextRelationRoutes(withAuth.Group("/context-relations"), services.ExcavationContext, services.Auth, log)
 		newArtifactRoutes(withAuth.Group("/artifacts"), services.Artifact, services.Auth, log)
+		newCustodyRoutes(withAuth.Group("/artifacts"), services.Custody, services.Auth, log)
+		newArtifactCuratorRoutes(withAuth.Group("/artifacts"), services.Curator, services.Auth, log)
+		newConditionReportRoutes(withAuth.Group("/artifacts"), services.ConditionReport, services.Auth, log)
+		newOverdueInspectionRoutes(withAuth.Group("/condition-reports"), services.ConditionReport, services.Auth, log)
+		newPeriodRoutes(withAuth.Group("/periods"), services.Period, services.Auth, log)
+		newSampleRoutes(withAuth.Group("/samples"), services.Sample, services.Auth, log)
+		newStorageRoutes(withAuth.Group("/storage"), services.Storage, services.Auth, log)
+		newArtifactStorageRoutes(withAuth.Group("/artifacts"), services.Storage, services.Auth, log)
+		newLoanRoutes(withAuth.Group("/loans"), services.Loan, services.Auth, log)
+		newArtifactLoanRoutes(withAuth.Group("/artifacts"), services.Loan, services.Auth, log)
+		newInventoryRoutes(withAuth.Group("/inventory"), services.Inventory, services.Auth, log)
+		newInventoryMaintenanceRoutes(withAuth.Group("/inventory"), services.Maintenance, services.Auth, log)
+		newExpeditionMaintenanceRoutes(withAuth.Group("/expeditions"), services.Maintenance, services.Auth, log)
 		newEquipmentRoutes(withAuth.Group("/equipments"), services.Equipment, services.Auth, log)
+		newExpeditionEquipmentRoutes(withAuth.Group("/expeditions"), services.Equipment, services.Auth, log)
+		newPackingTemplateRoutes(withAuth.Group("/packing-templates"), services.Packing, services.Auth, log)
+		newExpeditionPackingRoutes(withAuth.Group("/expeditions"), services.Packing, services.Auth, log)
+		newExpeditionBudgetRoutes(withAuth.Group("/expeditions"), services.Budget, services.Auth, log)
+		newLocationBudgetRoutes(withAuth.Group("/locations"), services.Budget, services.Auth, log)
+		newExchangeRateRoutes(withAuth.Group("/exchange-rates"), services.Budget, services.Auth, log)
+		newExpeditionJournalRoutes(withAuth.Group("/expeditions"), services.Journal, services.Auth, log)
+		newJournalEntryRoutes(withAuth.Group("/journal-entries"), services.Journal, services.Auth, log)
+		newAttachmentRoutes(withAuth.Group("/attachments"), services.Attachment, services.Auth, log)
+		newArtifactAttachmentRoutes(withAuth.Group("/artifacts"), services.Attachment, services.Auth, log)
+		newIIIFRoutes(withAuth.Group("/iiif"), services.IIIF, services.Auth, links, log)
+		newLabelRoutes(withAuth.Group("/labels"), services.Label, services.Auth, links, log)
+		newCatalogRoutes(withAuth.Group("/catalog"), services.Catalog, services.Auth, log)
+		newSearchRoutes(withAuth.Group("/search"), services.Search, services.Auth, log)
+		newMergeRoutes(withAuth.Group("/duplicates"), services.Merge, services.Auth, log)
+		newCountryRoutes(withAuth.Group("/countries"), services.Country, services.Auth, log)
+		newExportRoutes(withAuth.Group("/export"), services.Export, services.Auth, log)
 	}
 }
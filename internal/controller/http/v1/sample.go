@@ -0,0 +1,200 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type sampleRoutes struct {
+	sampleService service.Sample
+	authService   service.Auth
+	log           *logger.Logger
+}
+
+func newSampleRoutes(gr *gin.RouterGroup, sampleService service.Sample, authService service.Auth, log *logger.Logger) {
+	r := &sampleRoutes{
+		sampleService: sampleService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/:id", r.getById)
+	gr.GET("/", r.getAll)
+	gr.POST("/", r.create)
+	gr.PATCH("/:id", r.update)
+	gr.DELETE("/:id", r.delete)
+}
+
+func (r *sampleRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("sampleRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("sampleRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	sample, err := r.sampleService.GetSampleById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("sampleRoutes getById: sampleService.GetSampleById %v", err)
+		if errors.Is(err, service.ErrSampleNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"sample": sample})
+}
+
+func (r *sampleRoutes) getAll(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("sampleRoutes getAll: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	expeditionId, err := parseOptionalIntQuery(ctx, "expedition")
+	if err != nil {
+		r.log.Errorf("sampleRoutes getAll: parseOptionalIntQuery %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	filter := &entity.SampleFilter{
+		ExpeditionId: expeditionId,
+		Status:       ctx.Query("status"),
+		Lab:          ctx.Query("lab"),
+	}
+
+	samples, err := r.sampleService.GetAllSamples(ctx, client, filter)
+	if err != nil {
+		r.log.Errorf("sampleRoutes getAll: sampleService.GetAllSamples %v", err)
+		if errors.Is(err, service.ErrInvalidSampleStatus) {
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"samples": samples})
+}
+
+func (r *sampleRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("sampleRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateSampleInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("sampleRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.sampleService.CreateSample(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("sampleRoutes create: sampleService.CreateSample %v", err)
+		switch {
+		case errors.Is(err, service.ErrExpeditionNotFound) ||
+			errors.Is(err, service.ErrArtifactNotFound) ||
+			errors.Is(err, service.ErrContextNotFound) ||
+			errors.Is(err, service.ErrSampleLocationMismatch):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *sampleRoutes) update(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("sampleRoutes update: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("sampleRoutes update: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.UpdateSampleInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("sampleRoutes update: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.sampleService.UpdateSample(ctx, client, id, &input)
+	if err != nil {
+		r.log.Errorf("sampleRoutes update: sampleService.UpdateSample %v", err)
+		if errors.Is(err, service.ErrSampleNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *sampleRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("sampleRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("sampleRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.sampleService.DeleteSample(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("sampleRoutes delete: sampleService.DeleteSample %v", err)
+		if errors.Is(err, service.ErrSampleNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type searchRoutes struct {
+	searchService service.Search
+	authService   service.Auth
+	log           *logger.Logger
+}
+
+func newSearchRoutes(gr *gin.RouterGroup, searchService service.Search, authService service.Auth, log *logger.Logger) {
+	r := &searchRoutes{
+		searchService: searchService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/", r.search)
+}
+
+func (r *searchRoutes) search(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("searchRoutes search: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("searchRoutes search: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var query entity.SearchQuery
+	err = ctx.ShouldBindQuery(&query)
+	if err == nil {
+		err = query.IsValid()
+	}
+	if err != nil {
+		r.log.Errorf("searchRoutes search: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	hits, err := r.searchService.Search(ctx, client, user, &query)
+	if err != nil {
+		r.log.Errorf("searchRoutes search: searchService.Search %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"results": hits})
+}
@@ -0,0 +1,312 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type storageRoutes struct {
+	storageService service.Storage
+	authService    service.Auth
+	log            *logger.Logger
+}
+
+func newStorageRoutes(gr *gin.RouterGroup, storageService service.Storage, authService service.Auth, log *logger.Logger) {
+	r := &storageRoutes{
+		storageService: storageService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/pick-list", r.getPickList)
+	gr.GET("/:id", r.getById)
+	gr.GET("/:id/contents", r.getContents)
+	gr.GET("/", r.getAll)
+	gr.POST("/", r.create)
+	gr.DELETE("/:id", r.delete)
+}
+
+func newArtifactStorageRoutes(gr *gin.RouterGroup, storageService service.Storage, authService service.Auth, log *logger.Logger) {
+	r := &storageRoutes{
+		storageService: storageService,
+		authService:    authService,
+		log:            log,
+	}
+
+	gr.GET("/:id/storage-moves", r.getMoves)
+	gr.POST("/:id/storage-moves", r.move)
+}
+
+func (r *storageRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("storageRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	node, err := r.storageService.GetStorageNodeById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("storageRoutes getById: storageService.GetStorageNodeById %v", err)
+		if errors.Is(err, service.ErrStorageNodeNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"storage_node": node})
+}
+
+func (r *storageRoutes) getContents(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes getContents: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	nodeId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("storageRoutes getContents: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifacts, err := r.storageService.GetStorageContents(ctx, client, nodeId)
+	if err != nil {
+		r.log.Errorf("storageRoutes getContents: storageService.GetStorageContents %v", err)
+		if errors.Is(err, service.ErrStorageNodeNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"artifacts": artifacts})
+}
+
+func (r *storageRoutes) getAll(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes getAll: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	nodes, err := r.storageService.GetAllStorageNodes(ctx, client)
+	if err != nil {
+		r.log.Errorf("storageRoutes getAll: storageService.GetAllStorageNodes %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"storage_nodes": nodes})
+}
+
+func (r *storageRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes create: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateStorageNodeInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("storageRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.storageService.CreateStorageNode(ctx, client, user, &input)
+	if err != nil {
+		r.log.Errorf("storageRoutes create: storageService.CreateStorageNode %v", err)
+		switch {
+		case errors.Is(err, service.ErrStorageForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrStorageNodeNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrStorageHierarchy):
+			ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrStorageNodeAlreadyExists):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *storageRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes delete: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("storageRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.storageService.DeleteStorageNode(ctx, client, user, id)
+	if err != nil {
+		r.log.Errorf("storageRoutes delete: storageService.DeleteStorageNode %v", err)
+		switch {
+		case errors.Is(err, service.ErrStorageForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrStorageNodeNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrStorageNodeNotEmpty):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (r *storageRoutes) getPickList(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes getPickList: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactIds, err := parseIntListQuery(ctx, "ids")
+	if err != nil {
+		r.log.Errorf("storageRoutes getPickList: parseIntListQuery %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifacts, err := r.storageService.GetPickList(ctx, client, artifactIds)
+	if err != nil {
+		r.log.Errorf("storageRoutes getPickList: storageService.GetPickList %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"pick_list": artifacts})
+}
+
+func (r *storageRoutes) getMoves(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes getMoves: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("storageRoutes getMoves: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	moves, err := r.storageService.GetArtifactStorageMoves(ctx, client, artifactId)
+	if err != nil {
+		r.log.Errorf("storageRoutes getMoves: storageService.GetArtifactStorageMoves %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"storage_moves": moves})
+}
+
+func (r *storageRoutes) move(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes move: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	user, err := r.authService.GetUser(token)
+	if err != nil {
+		r.log.Errorf("storageRoutes move: authService.GetUser %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	artifactId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("storageRoutes move: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.MoveArtifactInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("storageRoutes move: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.storageService.MoveArtifact(ctx, client, user, artifactId, &input)
+	if err != nil {
+		r.log.Errorf("storageRoutes move: storageService.MoveArtifact %v", err)
+		switch {
+		case errors.Is(err, service.ErrStorageForbidden):
+			ctx.JSON(http.StatusForbidden, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrArtifactNotFound) ||
+			errors.Is(err, service.ErrStorageNodeNotFound):
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, service.ErrStorageNodeFull):
+			ctx.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
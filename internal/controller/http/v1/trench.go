@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"db_cp_6/pkg/logger"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type trenchRoutes struct {
+	trenchService service.Trench
+	authService   service.Auth
+	log           *logger.Logger
+}
+
+func newTrenchRoutes(gr *gin.RouterGroup, trenchService service.Trench, authService service.Auth, log *logger.Logger) {
+	r := &trenchRoutes{
+		trenchService: trenchService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/:id", r.getById)
+	gr.POST("/", r.create)
+	gr.DELETE("/:id", r.delete)
+}
+
+func newLocationTrenchRoutes(gr *gin.RouterGroup, trenchService service.Trench, authService service.Auth, log *logger.Logger) {
+	r := &trenchRoutes{
+		trenchService: trenchService,
+		authService:   authService,
+		log:           log,
+	}
+
+	gr.GET("/:id/trenches", r.getByLocationId)
+}
+
+func (r *trenchRoutes) getById(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("trenchRoutes getById: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("trenchRoutes getById: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	trench, err := r.trenchService.GetTrenchById(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("trenchRoutes getById: trenchService.GetTrenchById %v", err)
+		if errors.Is(err, service.ErrTrenchNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"trench": trench})
+}
+
+func (r *trenchRoutes) getByLocationId(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("trenchRoutes getByLocationId: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	locationId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("trenchRoutes getByLocationId: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	trenches, err := r.trenchService.GetLocationTrenches(ctx, client, locationId)
+	if err != nil {
+		r.log.Errorf("trenchRoutes getByLocationId: trenchService.GetLocationTrenches %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{"trenches": trenches})
+}
+
+func (r *trenchRoutes) create(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("trenchRoutes create: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var input entity.CreateTrenchInput
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		r.log.Errorf("trenchRoutes create: %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := r.trenchService.CreateTrench(ctx, client, &input)
+	if err != nil {
+		r.log.Errorf("trenchRoutes create: trenchService.CreateTrench %v", err)
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, map[string]interface{}{"Id": id})
+}
+
+func (r *trenchRoutes) delete(ctx *gin.Context) {
+	token := ctx.Query("token")
+	client, err := r.authService.GetClient(token)
+	if err != nil {
+		r.log.Errorf("trenchRoutes delete: authService.GetClient %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		r.log.Errorf("trenchRoutes delete: Atoi id %v", err)
+		ctx.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	err = r.trenchService.DeleteTrench(ctx, client, id)
+	if err != nil {
+		r.log.Errorf("trenchRoutes delete: trenchService.DeleteTrench %v", err)
+		if errors.Is(err, service.ErrTrenchNotFound) {
+			ctx.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
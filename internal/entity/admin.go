@@ -0,0 +1,8 @@
+package entity
+
+type Admin struct {
+	Id       int    `db:"id"`
+	Name     string `db:"name"`
+	Login    string `db:"login"`
+	Password string `db:"password"`
+}
@@ -1,20 +1,49 @@
 package entity
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type Artifact struct {
-	Id         int    `db:"id"`
-	LocationId int    `json:"location_id" db:"location_id"`
-	Name       string `json:"name" db:"name"`
-	Age        int    `json:"age" db:"age"`
+	Id                   int         `db:"id"`
+	LocationId           int         `json:"location_id" db:"location_id"`
+	ContextId            *int        `json:"context_id" db:"context_id"`
+	ExpeditionId         *int        `json:"expedition_id" db:"expedition_id"`
+	FoundByMemberId      *int        `json:"found_by_member_id" db:"found_by_member_id"`
+	FoundOn              *time.Time  `json:"found_on" db:"found_on"`
+	FindContext          string      `json:"find_context" db:"find_context"`
+	FindSpot             Coordinates `json:"find_spot" db:"-"`
+	Name                 string      `json:"name" db:"name"`
+	CatalogNumber        string      `json:"catalog_number" db:"catalog_number"`
+	Dating               Dating      `json:"dating" db:"-"`
+	StorageNodeId        *int        `json:"storage_node_id" db:"storage_node_id"`
+	ResponsibleCuratorId *int        `json:"responsible_curator_id" db:"responsible_curator_id"`
+
+	CurrentHolder    *CustodyRecord   `json:"current_holder,omitempty" db:"-"`
+	CurrentCondition *ConditionReport `json:"current_condition,omitempty" db:"-"`
 }
 
 type Artifacts []*Artifact
 
+type ArtifactFilter struct {
+	BoundingBox *BoundingBox
+	PeriodId    *int
+	DatedFrom   *int
+	DatedTo     *int
+	CuratorId   *int
+}
+
 type CreateArtifactInput struct {
-	LocationId int    `json:"location_id"`
-	Name       string `json:"name"`
-	Age        int    `json:"age"`
+	LocationId      int         `json:"location_id"`
+	ContextId       *int        `json:"context_id"`
+	ExpeditionId    *int        `json:"expedition_id"`
+	FoundByMemberId *int        `json:"found_by_member_id"`
+	FoundOn         string      `json:"found_on"`
+	FindContext     string      `json:"find_context"`
+	FindSpot        Coordinates `json:"find_spot"`
+	Name            string      `json:"name"`
+	Dating          Dating      `json:"dating"`
 }
 
 func (input *CreateArtifactInput) IsValid() error {
@@ -23,9 +52,21 @@ func (input *CreateArtifactInput) IsValid() error {
 	switch {
 	case input.Name == "":
 		err = fmt.Errorf("invalid artifact name")
-	case input.Age < 1:
-		err = fmt.Errorf("invalid artifact age")
+	case input.FoundOn != "" && !isValidDate(input.FoundOn):
+		err = fmt.Errorf("invalid artifact find date")
+	case input.FoundByMemberId != nil && input.ExpeditionId == nil:
+		err = fmt.Errorf("artifact finder requires an expedition")
+	default:
+		err = input.FindSpot.IsValid()
+	}
+	if err == nil {
+		err = input.Dating.IsValid()
 	}
 
 	return err
 }
+
+func isValidDate(date string) bool {
+	_, err := time.Parse("2006-01-02", date)
+	return err == nil
+}
@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	AttachmentParentArtifact     = "artifact"
+	AttachmentParentLocation     = "location"
+	AttachmentParentExpedition   = "expedition"
+	AttachmentParentJournalEntry = "journal_entry"
+)
+
+const (
+	AttachmentKindPhoto    = "photo"
+	AttachmentKindDrawing  = "drawing"
+	AttachmentKindDocument = "document"
+)
+
+const (
+	AttachmentProcessingNone    = "none"
+	AttachmentProcessingPending = "pending"
+	AttachmentProcessingRunning = "processing"
+	AttachmentProcessingDone    = "done"
+	AttachmentProcessingFailed  = "failed"
+)
+
+type ThumbnailSize struct {
+	Name    string
+	MaxEdge int
+}
+
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "small", MaxEdge: 160},
+	{Name: "medium", MaxEdge: 480},
+	{Name: "large", MaxEdge: 1024},
+}
+
+type Attachment struct {
+	Id             int       `db:"id"`
+	ParentType     string    `json:"parent_type" db:"parent_type"`
+	ParentId       int       `json:"parent_id" db:"parent_id"`
+	Kind           string    `json:"kind" db:"kind"`
+	FileName       string    `json:"file_name" db:"file_name"`
+	ContentType    string    `json:"content_type" db:"content_type"`
+	Size           int64     `json:"size" db:"size"`
+	Sha256         string    `json:"sha256" db:"sha256"`
+	Description    string    `json:"description" db:"description"`
+	UploadedByRole string    `json:"uploaded_by_role" db:"uploaded_by_role"`
+	UploadedById   *int      `json:"uploaded_by_id" db:"uploaded_by_id"`
+	UploadedAt     time.Time `json:"uploaded_at" db:"uploaded_at"`
+
+	ProcessingStatus  string         `json:"processing_status" db:"processing_status"`
+	ProcessingError   string         `json:"processing_error,omitempty" db:"processing_error"`
+	Metadata          *PhotoMetadata `json:"metadata,omitempty" db:"-"`
+	Thumbnails        Thumbnails     `json:"thumbnails,omitempty" db:"-"`
+	SuggestedFindSpot *Coordinates   `json:"suggested_find_spot,omitempty" db:"-"`
+}
+
+type Attachments []*Attachment
+
+type AttachmentParent struct {
+	Type string `form:"parent_type"`
+	Id   int    `form:"parent_id"`
+}
+
+func (p *AttachmentParent) IsValid() error {
+	switch p.Type {
+	case AttachmentParentArtifact, AttachmentParentLocation, AttachmentParentExpedition, AttachmentParentJournalEntry:
+	default:
+		return fmt.Errorf("invalid attachment parent type")
+	}
+	if p.Id <= 0 {
+		return fmt.Errorf("invalid attachment parent id")
+	}
+
+	return nil
+}
+
+type UploadAttachmentInput struct {
+	AttachmentParent
+	Kind        string `form:"kind"`
+	Description string `form:"description"`
+}
+
+func (input *UploadAttachmentInput) IsValid() error {
+	if err := input.AttachmentParent.IsValid(); err != nil {
+		return err
+	}
+
+	switch input.Kind {
+	case "", AttachmentKindPhoto, AttachmentKindDrawing, AttachmentKindDocument:
+	default:
+		return fmt.Errorf("invalid attachment kind")
+	}
+
+	return nil
+}
+
+type AttachmentFile struct {
+	Name    string
+	Content io.Reader
+}
+
+func (f *AttachmentFile) IsValid() error {
+	name := strings.TrimSpace(f.Name)
+	if name == "" || len(name) > 255 || strings.ContainsAny(name, "/\\\x00") {
+		return fmt.Errorf("invalid attachment file name")
+	}
+
+	return nil
+}
+
+type AttachmentContent struct {
+	Attachment *Attachment
+	Content    io.ReadCloser
+}
+
+type PhotoMetadata struct {
+	Width       int         `json:"width" db:"image_width"`
+	Height      int         `json:"height" db:"image_height"`
+	CapturedAt  *time.Time  `json:"captured_at" db:"captured_at"`
+	CameraMake  string      `json:"camera_make" db:"camera_make"`
+	CameraModel string      `json:"camera_model" db:"camera_model"`
+	Gps         Coordinates `json:"gps" db:"-"`
+}
+
+type Thumbnail struct {
+	Size   string `json:"size" db:"size"`
+	Width  int    `json:"width" db:"width"`
+	Height int    `json:"height" db:"height"`
+	Bytes  int64  `json:"bytes" db:"bytes"`
+	Sha256 string `json:"-" db:"blob_sha256"`
+}
+
+type Thumbnails []*Thumbnail
+
+type ThumbnailContent struct {
+	Thumbnail *Thumbnail
+	Content   io.ReadCloser
+}
+
+type FindSpotSuggestion struct {
+	AttachmentId int         `json:"attachment_id"`
+	FileName     string      `json:"file_name"`
+	CapturedAt   *time.Time  `json:"captured_at"`
+	FindSpot     Coordinates `json:"find_spot"`
+}
+
+type FindSpotSuggestions []*FindSpotSuggestion
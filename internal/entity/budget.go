@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"db_cp_6/pkg/decimal"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+const (
+	amountScale = 2
+	rateScale   = 8
+)
+
+type BudgetLine struct {
+	Category string          `json:"category" db:"category"`
+	Planned  decimal.Decimal `json:"planned" db:"planned"`
+}
+
+type BudgetLines []*BudgetLine
+
+type Budget struct {
+	ExpeditionId int         `json:"expedition_id" db:"expedition_id"`
+	Currency     string      `json:"currency" db:"currency"`
+	Lines        BudgetLines `json:"lines"`
+}
+
+func (b *Budget) HasCategory(category string) bool {
+	for _, line := range b.Lines {
+		if line.Category == category {
+			return true
+		}
+	}
+	return false
+}
+
+type SetBudgetInput struct {
+	Currency string      `json:"currency"`
+	Lines    BudgetLines `json:"lines"`
+}
+
+func (input *SetBudgetInput) IsValid() error {
+	if !currencyPattern.MatchString(input.Currency) {
+		return fmt.Errorf("invalid budget currency")
+	}
+	if len(input.Lines) == 0 {
+		return fmt.Errorf("budget has no lines")
+	}
+
+	seen := make(map[string]bool, len(input.Lines))
+	for _, line := range input.Lines {
+		switch {
+		case line.Category == "":
+			return fmt.Errorf("invalid budget category")
+		case line.Planned.Sign() < 0 || !line.Planned.FitsScale(amountScale):
+			return fmt.Errorf("invalid planned amount for %s", line.Category)
+		case seen[line.Category]:
+			return fmt.Errorf("duplicate budget category %s", line.Category)
+		}
+		seen[line.Category] = true
+	}
+
+	return nil
+}
+
+type Expense struct {
+	Id             int             `db:"id"`
+	ExpeditionId   int             `json:"expedition_id" db:"expedition_id"`
+	Category       string          `json:"category" db:"category"`
+	Amount         decimal.Decimal `json:"amount" db:"amount"`
+	Currency       string          `json:"currency" db:"currency"`
+	ExchangeRate   decimal.Decimal `json:"exchange_rate" db:"exchange_rate"`
+	BaseAmount     decimal.Decimal `json:"base_amount" db:"base_amount"`
+	SpentOn        time.Time       `json:"spent_on" db:"spent_on"`
+	Description    string          `json:"description" db:"description"`
+	Vendor         string          `json:"vendor" db:"vendor"`
+	ReceiptNumber  string          `json:"receipt_number" db:"receipt_number"`
+	RecordedById   int             `json:"recorded_by_id" db:"recorded_by_id"`
+	RecordedByRole string          `json:"recorded_by_role" db:"recorded_by_role"`
+	RecordedAt     time.Time       `json:"recorded_at" db:"recorded_at"`
+}
+
+type Expenses []*Expense
+
+type CreateExpenseInput struct {
+	Category      string           `json:"category"`
+	Amount        decimal.Decimal  `json:"amount"`
+	Currency      string           `json:"currency"`
+	ExchangeRate  *decimal.Decimal `json:"exchange_rate"`
+	SpentOn       string           `json:"spent_on"`
+	Description   string           `json:"description"`
+	Vendor        string           `json:"vendor"`
+	ReceiptNumber string           `json:"receipt_number"`
+}
+
+func (input *CreateExpenseInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.Category == "":
+		err = fmt.Errorf("invalid expense category")
+	case input.Amount.Sign() <= 0 || !input.Amount.FitsScale(amountScale):
+		err = fmt.Errorf("invalid expense amount")
+	case !currencyPattern.MatchString(input.Currency):
+		err = fmt.Errorf("invalid expense currency")
+	case input.ExchangeRate != nil && (input.ExchangeRate.Sign() <= 0 || !input.ExchangeRate.FitsScale(rateScale)):
+		err = fmt.Errorf("invalid exchange rate")
+	case input.SpentOn != "" && !isValidDate(input.SpentOn):
+		err = fmt.Errorf("invalid expense date")
+	}
+
+	return err
+}
+
+type ExchangeRate struct {
+	Id           int             `db:"id"`
+	Currency     string          `json:"currency" db:"currency"`
+	BaseCurrency string          `json:"base_currency" db:"base_currency"`
+	Rate         decimal.Decimal `json:"rate" db:"rate"`
+	EffectiveOn  time.Time       `json:"effective_on" db:"effective_on"`
+}
+
+type ExchangeRates []*ExchangeRate
+
+type CreateExchangeRateInput struct {
+	Currency     string          `json:"currency"`
+	BaseCurrency string          `json:"base_currency"`
+	Rate         decimal.Decimal `json:"rate"`
+	EffectiveOn  string          `json:"effective_on"`
+}
+
+func (input *CreateExchangeRateInput) IsValid() error {
+	var err error
+
+	switch {
+	case !currencyPattern.MatchString(input.Currency):
+		err = fmt.Errorf("invalid currency")
+	case !currencyPattern.MatchString(input.BaseCurrency):
+		err = fmt.Errorf("invalid base currency")
+	case input.Currency == input.BaseCurrency:
+		err = fmt.Errorf("exchange rate currencies must differ")
+	case input.Rate.Sign() <= 0 || !input.Rate.FitsScale(rateScale):
+		err = fmt.Errorf("invalid exchange rate")
+	case !isValidDate(input.EffectiveOn):
+		err = fmt.Errorf("invalid exchange rate date")
+	}
+
+	return err
+}
+
+type BudgetReportLine struct {
+	Category string          `json:"category" db:"category"`
+	Currency string          `json:"currency" db:"currency"`
+	Planned  decimal.Decimal `json:"planned" db:"planned"`
+	Actual   decimal.Decimal `json:"actual" db:"actual"`
+	Variance decimal.Decimal `json:"variance" db:"variance"`
+}
+
+type BudgetReportLines []*BudgetReportLine
+
+type BudgetReport struct {
+	Lines  BudgetReportLines `json:"lines"`
+	Totals BudgetReportLines `json:"totals"`
+}
@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const MaxCatalogCandidates = 5
+
+var (
+	catalogSeparators  = regexp.MustCompile(`[^0-9A-Z]+`)
+	catalogArtifactURL = regexp.MustCompile(`(?i)/artifacts/(\d+)/?$`)
+)
+
+type CatalogNumber struct {
+	LocationId int
+	Year       int
+	Seq        int
+	CheckDigit string
+}
+
+func (n *CatalogNumber) String() string {
+	return fmt.Sprintf("%d-%d-%04d", n.LocationId, n.Year, n.Seq)
+}
+
+func (n *CatalogNumber) HasValidCheckDigit() bool {
+	return n.CheckDigit != "" && n.CheckDigit == CatalogCheckDigit(n.String())
+}
+
+func CatalogCheckDigit(number string) string {
+	sum := 0
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			sum = (sum + int(r-'0')) * 2 % 11
+		}
+	}
+
+	check := (12 - sum) % 11
+	if check == 10 {
+		return "X"
+	}
+
+	return strconv.Itoa(check)
+}
+
+func WithCheckDigit(number string) string {
+	if number == "" {
+		return ""
+	}
+
+	return number + "-" + CatalogCheckDigit(number)
+}
+
+type CatalogCode struct {
+	ArtifactId *int
+	Number     *CatalogNumber
+}
+
+func ParseCatalogCode(code string) (*CatalogCode, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, fmt.Errorf("empty catalog number")
+	}
+
+	if u, err := url.Parse(code); err == nil && u.Scheme != "" {
+		match := catalogArtifactURL.FindStringSubmatch(u.Path)
+		if match == nil {
+			return nil, fmt.Errorf("unrecognized label link")
+		}
+		id, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid artifact id in label link")
+		}
+		return &CatalogCode{ArtifactId: &id}, nil
+	}
+
+	groups := catalogSeparators.Split(strings.ToUpper(code), -1)
+	parts := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if group != "" {
+			parts = append(parts, group)
+		}
+	}
+	if len(parts) == 3 && len(parts[2]) > 1 && strings.HasSuffix(parts[2], "X") {
+		parts = append(parts[:2], parts[2][:len(parts[2])-1], "X")
+	}
+	if len(parts) == 3 && len(parts[2]) == 5 && strings.HasPrefix(parts[2], "0") {
+		parts = append(parts[:2], parts[2][:4], parts[2][4:])
+	}
+
+	var number CatalogNumber
+	switch {
+	case len(parts) == 4 && len(parts[3]) == 1 && strings.ContainsAny(parts[3], "0123456789X"):
+		number.CheckDigit = parts[3]
+	case len(parts) != 3:
+		return nil, fmt.Errorf("catalog number must look like <location>-<year>-<number>")
+	}
+
+	values := []*int{&number.LocationId, &number.Year, &number.Seq}
+	for i, value := range values {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid catalog number component %q", parts[i])
+		}
+		*value = n
+	}
+
+	return &CatalogCode{Number: &number}, nil
+}
+
+type CatalogLookupQuery struct {
+	Code string `form:"code"`
+}
+
+type CatalogLookup struct {
+	CatalogNumber string          `json:"catalog_number"`
+	Artifact      *Artifact       `json:"artifact"`
+	Custody       *CustodyRecord  `json:"custody"`
+	Storage       *StoredArtifact `json:"storage"`
+}
+
+type CatalogCandidate struct {
+	ArtifactId    int    `json:"artifact_id"`
+	CatalogNumber string `json:"catalog_number"`
+	Name          string `json:"name"`
+}
+
+type CatalogCandidates []*CatalogCandidate
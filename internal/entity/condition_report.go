@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ConditionExcellent = "excellent"
+	ConditionGood      = "good"
+	ConditionFair      = "fair"
+	ConditionPoor      = "poor"
+	ConditionCritical  = "critical"
+)
+
+const DefaultInspectionIntervalDays = 365
+
+type ConditionReport struct {
+	Id             int       `db:"id"`
+	ArtifactId     int       `json:"artifact_id" db:"artifact_id"`
+	Grade          string    `json:"grade" db:"grade"`
+	DamageNotes    string    `json:"damage_notes" db:"damage_notes"`
+	Treatments     string    `json:"treatments" db:"treatments"`
+	Materials      string    `json:"materials" db:"materials"`
+	Conservator    string    `json:"conservator" db:"conservator"`
+	InspectedOn    time.Time `json:"inspected_on" db:"inspected_on"`
+	RecordedById   int       `json:"recorded_by_id" db:"recorded_by_id"`
+	RecordedByRole string    `json:"recorded_by_role" db:"recorded_by_role"`
+}
+
+type ConditionReports []*ConditionReport
+
+type CreateConditionReportInput struct {
+	Grade       string `json:"grade"`
+	DamageNotes string `json:"damage_notes"`
+	Treatments  string `json:"treatments"`
+	Materials   string `json:"materials"`
+	Conservator string `json:"conservator"`
+	InspectedOn string `json:"inspected_on"`
+}
+
+func (input *CreateConditionReportInput) IsValid() error {
+	var err error
+
+	switch {
+	case !isValidConditionGrade(input.Grade):
+		err = fmt.Errorf("invalid condition grade")
+	case input.Conservator == "":
+		err = fmt.Errorf("invalid conservator")
+	case input.InspectedOn != "" && !isValidDate(input.InspectedOn):
+		err = fmt.Errorf("invalid inspection date")
+	}
+
+	return err
+}
+
+type OverdueInspection struct {
+	ArtifactId      int        `json:"artifact_id" db:"artifact_id"`
+	Name            string     `json:"name" db:"name"`
+	LocationId      int        `json:"location_id" db:"location_id"`
+	LastInspectedOn *time.Time `json:"last_inspected_on" db:"last_inspected_on"`
+	LastGrade       *string    `json:"last_grade" db:"last_grade"`
+}
+
+type OverdueInspections []*OverdueInspection
+
+func isValidConditionGrade(grade string) bool {
+	switch grade {
+	case ConditionExcellent, ConditionGood, ConditionFair, ConditionPoor, ConditionCritical:
+		return true
+	}
+
+	return false
+}
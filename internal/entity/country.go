@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"db_cp_6/pkg/iso3166"
+	"strings"
+)
+
+const (
+	LanguageEn = iso3166.English
+	LanguageRu = iso3166.Russian
+)
+
+func ParseLanguage(value string) string {
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.ToLower(strings.TrimSpace(strings.Split(tag, ";")[0]))
+		switch {
+		case tag == LanguageRu || strings.HasPrefix(tag, LanguageRu+"-"):
+			return LanguageRu
+		case tag == LanguageEn || strings.HasPrefix(tag, LanguageEn+"-"):
+			return LanguageEn
+		}
+	}
+
+	return LanguageEn
+}
+
+func NormalizeCountry(value string) (string, bool) {
+	country, ok := iso3166.Find(value)
+	if !ok {
+		return "", false
+	}
+
+	return country.Alpha2, true
+}
+
+func isKnownCountry(value string) bool {
+	_, ok := iso3166.Find(value)
+	return ok
+}
+
+func CountryName(code string, lang string) string {
+	country, ok := iso3166.ByAlpha2(code)
+	if !ok {
+		return ""
+	}
+
+	return country.Name(lang)
+}
+
+type Country struct {
+	Code    string   `json:"code"`
+	Alpha3  string   `json:"alpha3"`
+	Name    string   `json:"name"`
+	NameEn  string   `json:"name_en"`
+	NameRu  string   `json:"name_ru"`
+	Aliases []string `json:"aliases"`
+}
+
+type Countries []*Country
+
+func GetCountries(lang string) Countries {
+	all := iso3166.All()
+	countries := make(Countries, 0, len(all))
+	for _, c := range all {
+		countries = append(countries, &Country{
+			Code:    c.Alpha2,
+			Alpha3:  c.Alpha3,
+			Name:    c.Name(lang),
+			NameEn:  c.NameEn,
+			NameRu:  c.NameRu,
+			Aliases: c.Aliases,
+		})
+	}
+
+	return countries
+}
+
+type CountryUsage struct {
+	Table string `json:"table" db:"table_name"`
+	Value string `json:"value" db:"country"`
+	Rows  int64  `json:"rows" db:"rows"`
+}
+
+type CountryUsages []*CountryUsage
+
+type CountryMapping struct {
+	Table string `json:"table"`
+	Value string `json:"value"`
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Rows  int64  `json:"rows"`
+}
+
+type CountryMappings []*CountryMapping
+
+type CountryMigrationReport struct {
+	Applied    bool            `json:"applied"`
+	Normalized int64           `json:"normalized_rows"`
+	Mapped     CountryMappings `json:"mapped"`
+	Unmapped   CountryUsages   `json:"unmapped"`
+}
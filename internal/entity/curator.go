@@ -1,24 +1,57 @@
 package entity
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Curator struct {
-	Id   int    `db:"id"`
-	Name string `json:"name" db:"name"`
+	Id            int    `db:"id"`
+	Name          string `json:"name" db:"name"`
+	Email         string `json:"email" db:"email"`
+	Phone         string `json:"phone" db:"phone"`
+	InstitutionId *int   `json:"institution_id" db:"institution_id"`
+	Login         string `json:"login" db:"login"`
+	Password      string `json:"-" db:"password"`
 }
 
 type Curators []*Curator
 
 type CreateCuratorInput struct {
-	Name string `json:"name"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Phone         string `json:"phone"`
+	InstitutionId *int   `json:"institution_id"`
+	Login         string `json:"login"`
+	Password      string `json:"password"`
 }
 
 func (input *CreateCuratorInput) IsValid() error {
 	var err error
 
-	if input.Name == "" {
+	switch {
+	case input.Name == "":
 		err = fmt.Errorf("invalid curator name")
+	case input.Email != "" && !strings.Contains(input.Email, "@"):
+		err = fmt.Errorf("invalid curator email")
+	case input.Login != "" && input.Password == "":
+		err = fmt.Errorf("invalid curator password")
 	}
 
 	return err
 }
+
+type AssignCuratorInput struct {
+	CuratorId *int `json:"curator_id"`
+}
+
+type CuratorWorkload struct {
+	CuratorId               int    `json:"curator_id" db:"curator_id"`
+	Name                    string `json:"name" db:"name"`
+	InstitutionId           *int   `json:"institution_id" db:"institution_id"`
+	Artifacts               int    `json:"artifacts" db:"artifacts"`
+	PendingConditionReports int    `json:"pending_condition_reports" db:"pending_condition_reports"`
+	ActiveLoans             int    `json:"active_loans" db:"active_loans"`
+}
+
+type CuratorWorkloads []*CuratorWorkload
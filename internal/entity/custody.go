@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	HolderMember   = "member"
+	HolderCurator  = "curator"
+	HolderFacility = "facility"
+)
+
+type CustodyRecord struct {
+	Id            int       `db:"id"`
+	ArtifactId    int       `json:"artifact_id" db:"artifact_id"`
+	PreviousId    *int      `json:"previous_id" db:"previous_id"`
+	HolderType    string    `json:"holder_type" db:"holder_type"`
+	HolderId      *int      `json:"holder_id" db:"holder_id"`
+	HolderName    string    `json:"holder_name" db:"holder_name"`
+	Reason        string    `json:"reason" db:"reason"`
+	TransferredAt time.Time `json:"transferred_at" db:"transferred_at"`
+	SignedById    int       `json:"signed_by_id" db:"signed_by_id"`
+	SignedByRole  string    `json:"signed_by_role" db:"signed_by_role"`
+}
+
+type CustodyRecords []*CustodyRecord
+
+func (r *CustodyRecord) IsHeldBy(user *User) bool {
+	if r.HolderId == nil {
+		return false
+	}
+
+	switch r.HolderType {
+	case HolderMember:
+		return user.Role == RoleMember && *r.HolderId == user.Id
+	case HolderCurator:
+		return user.Role == RoleCurator && *r.HolderId == user.Id
+	}
+
+	return false
+}
+
+type TransferArtifactInput struct {
+	HolderType string `json:"holder_type"`
+	HolderId   *int   `json:"holder_id"`
+	HolderName string `json:"holder_name"`
+	Reason     string `json:"reason"`
+}
+
+func (input *TransferArtifactInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.HolderType != HolderMember && input.HolderType != HolderCurator && input.HolderType != HolderFacility:
+		err = fmt.Errorf("invalid custody holder type")
+	case input.HolderType == HolderFacility && input.HolderName == "":
+		err = fmt.Errorf("invalid custody facility name")
+	case input.HolderType != HolderFacility && input.HolderId == nil:
+		err = fmt.Errorf("invalid custody holder id")
+	case input.Reason == "":
+		err = fmt.Errorf("invalid custody transfer reason")
+	}
+
+	return err
+}
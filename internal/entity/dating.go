@@ -0,0 +1,92 @@
+package entity
+
+import "fmt"
+
+const (
+	DatingTypological   = "typological"
+	DatingRadiocarbon   = "radiocarbon"
+	DatingDendro        = "dendro"
+	DatingStratigraphic = "stratigraphic"
+)
+
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+type Dating struct {
+	EarliestYear     *int   `json:"earliest_year" db:"earliest_year"`
+	LatestYear       *int   `json:"latest_year" db:"latest_year"`
+	CentralYear      *int   `json:"central_year" db:"central_year"`
+	ErrorYears       *int   `json:"error_years" db:"error_years"`
+	Method           string `json:"method" db:"dating_method"`
+	Confidence       string `json:"confidence" db:"dating_confidence"`
+	PeriodId         *int   `json:"period_id" db:"period_id"`
+	RadiocarbonAgeBP *int   `json:"radiocarbon_age_bp" db:"radiocarbon_age_bp"`
+	RadiocarbonSigma *int   `json:"radiocarbon_sigma" db:"radiocarbon_sigma"`
+}
+
+func (d *Dating) IsSet() bool {
+	return d.EarliestYear != nil || d.LatestYear != nil || d.CentralYear != nil
+}
+
+func (d *Dating) IsValid() error {
+	var err error
+
+	switch {
+	case !isValidDatingMethod(d.Method):
+		err = fmt.Errorf("invalid dating method")
+	case !isValidConfidence(d.Confidence):
+		err = fmt.Errorf("invalid dating confidence")
+	case d.CentralYear == nil && d.ErrorYears != nil:
+		err = fmt.Errorf("dating error requires a central year")
+	case d.CentralYear != nil && (d.EarliestYear != nil || d.LatestYear != nil):
+		err = fmt.Errorf("dating must be either a range or a central year")
+	case d.ErrorYears != nil && *d.ErrorYears < 0:
+		err = fmt.Errorf("invalid dating error")
+	case d.EarliestYear != nil && d.LatestYear != nil && *d.EarliestYear > *d.LatestYear:
+		err = fmt.Errorf("dating earliest year is after latest year")
+	case (d.RadiocarbonAgeBP == nil) != (d.RadiocarbonSigma == nil):
+		err = fmt.Errorf("radiocarbon age requires both age and sigma")
+	case d.RadiocarbonAgeBP != nil && (*d.RadiocarbonAgeBP < 0 || *d.RadiocarbonSigma < 0):
+		err = fmt.Errorf("invalid radiocarbon age")
+	case d.RadiocarbonAgeBP != nil && d.Method != DatingRadiocarbon:
+		err = fmt.Errorf("radiocarbon age requires the radiocarbon dating method")
+	case (d.Method != "" || d.Confidence != "") && !d.IsSet() && d.PeriodId == nil && d.RadiocarbonAgeBP == nil:
+		err = fmt.Errorf("dating method and confidence require a date or a period")
+	}
+
+	return err
+}
+
+func (d Dating) Normalized() Dating {
+	if d.CentralYear != nil {
+		errorYears := 0
+		if d.ErrorYears != nil {
+			errorYears = *d.ErrorYears
+		}
+		earliest, latest := *d.CentralYear-errorYears, *d.CentralYear+errorYears
+		d.EarliestYear, d.LatestYear, d.ErrorYears = &earliest, &latest, &errorYears
+	}
+
+	return d
+}
+
+func isValidDatingMethod(method string) bool {
+	switch method {
+	case "", DatingTypological, DatingRadiocarbon, DatingDendro, DatingStratigraphic:
+		return true
+	}
+
+	return false
+}
+
+func isValidConfidence(confidence string) bool {
+	switch confidence {
+	case "", ConfidenceLow, ConfidenceMedium, ConfidenceHigh:
+		return true
+	}
+
+	return false
+}
@@ -1,31 +1,122 @@
 package entity
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type Equipment struct {
-	Id           int    `db:"id"`
-	ExpeditionId int    `json:"expedition_id" db:"expedition_id"`
-	Name         string `json:"name" db:"name"`
-	Amount       int    `json:"amount" db:"amount"`
+	Id           int       `db:"id"`
+	ExpeditionId int       `json:"expedition_id" db:"expedition_id"`
+	ItemId       int       `json:"item_id" db:"item_id"`
+	Name         string    `json:"name" db:"name"`
+	Amount       int       `json:"amount" db:"amount"`
+	ReservedFrom time.Time `json:"reserved_from" db:"reserved_from"`
+	ReservedTo   time.Time `json:"reserved_to" db:"reserved_to"`
 }
 
 type Equipments []*Equipment
 
 type CreateEquipmentInput struct {
 	ExpeditionId int    `json:"expedition_id"`
-	Name         string `json:"name"`
+	ItemId       int    `json:"item_id"`
 	Amount       int    `json:"amount" db:"amount"`
+	ReservedFrom string `json:"reserved_from"`
+	ReservedTo   string `json:"reserved_to"`
 }
 
 func (input *CreateEquipmentInput) IsValid() error {
 	var err error
 
 	switch {
-	case input.Name == "":
-		err = fmt.Errorf("invalid equipment name")
 	case input.Amount < 1:
 		err = fmt.Errorf("invalid equipment amount")
+	case input.ReservedFrom != "" && !isValidDate(input.ReservedFrom):
+		err = fmt.Errorf("invalid reservation start date")
+	case input.ReservedTo != "" && !isValidDate(input.ReservedTo):
+		err = fmt.Errorf("invalid reservation end date")
+	case input.ReservedFrom != "" && input.ReservedTo != "" && input.ReservedTo < input.ReservedFrom:
+		err = fmt.Errorf("reservation ends before it starts")
+	}
+
+	return err
+}
+
+const (
+	EquipmentCheckOut = "check_out"
+	EquipmentCheckIn  = "check_in"
+)
+
+type EquipmentEvent struct {
+	Id          int       `db:"id"`
+	EquipmentId int       `json:"equipment_id" db:"equipment_id"`
+	Kind        string    `json:"kind" db:"kind"`
+	Quantity    int       `json:"quantity" db:"quantity"`
+	Returned    int       `json:"returned" db:"returned"`
+	Lost        int       `json:"lost" db:"lost"`
+	Damaged     int       `json:"damaged" db:"damaged"`
+	MemberId    int       `json:"member_id" db:"member_id"`
+	RecordedAt  time.Time `json:"recorded_at" db:"recorded_at"`
+}
+
+type EquipmentEvents []*EquipmentEvent
+
+type CheckOutEquipmentInput struct {
+	MemberId int `json:"member_id"`
+	Quantity int `json:"quantity"`
+}
+
+func (input *CheckOutEquipmentInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.MemberId == 0:
+		err = fmt.Errorf("invalid responsible member")
+	case input.Quantity < 1:
+		err = fmt.Errorf("invalid check-out quantity")
 	}
 
 	return err
 }
+
+type CheckInEquipmentInput struct {
+	MemberId int `json:"member_id"`
+	Returned int `json:"returned"`
+	Lost     int `json:"lost"`
+	Damaged  int `json:"damaged"`
+}
+
+func (input *CheckInEquipmentInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.MemberId == 0:
+		err = fmt.Errorf("invalid responsible member")
+	case input.Returned < 0 || input.Lost < 0 || input.Damaged < 0:
+		err = fmt.Errorf("invalid check-in quantity")
+	case input.Returned+input.Lost+input.Damaged == 0:
+		err = fmt.Errorf("empty check-in")
+	}
+
+	return err
+}
+
+type EquipmentBalance struct {
+	EquipmentId int    `json:"equipment_id" db:"equipment_id"`
+	ItemId      int    `json:"item_id" db:"item_id"`
+	Name        string `json:"name" db:"name"`
+	Reserved    int    `json:"reserved" db:"reserved"`
+	CheckedOut  int    `json:"checked_out" db:"checked_out"`
+	Returned    int    `json:"returned" db:"returned"`
+	Lost        int    `json:"lost" db:"lost"`
+	Damaged     int    `json:"damaged" db:"damaged"`
+	Outstanding int    `json:"outstanding" db:"outstanding"`
+}
+
+type EquipmentBalances []*EquipmentBalance
+
+type EquipmentReconciliation struct {
+	ExpeditionId int               `json:"expedition_id"`
+	Reconciled   bool              `json:"reconciled"`
+	Balances     EquipmentBalances `json:"balances"`
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "fmt"
+
+const (
+	RelationAbove = "above"
+	RelationBelow = "below"
+	RelationCuts  = "cuts"
+)
+
+type ExcavationContext struct {
+	Id          int    `db:"id"`
+	TrenchId    int    `json:"trench_id" db:"trench_id"`
+	LocationId  int    `json:"location_id" db:"location_id"`
+	Code        string `json:"code" db:"code"`
+	Description string `json:"description" db:"description"`
+}
+
+type ExcavationContexts []*ExcavationContext
+
+type CreateExcavationContextInput struct {
+	TrenchId    int    `json:"trench_id"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+func (input *CreateExcavationContextInput) IsValid() error {
+	var err error
+
+	if input.Code == "" {
+		err = fmt.Errorf("invalid context code")
+	}
+
+	return err
+}
+
+type ContextRelation struct {
+	Id            int    `db:"id"`
+	FromContextId int    `json:"from_context_id" db:"from_context_id"`
+	ToContextId   int    `json:"to_context_id" db:"to_context_id"`
+	Relation      string `json:"relation" db:"relation"`
+}
+
+type ContextRelations []*ContextRelation
+
+type CreateContextRelationInput struct {
+	FromContextId int    `json:"from_context_id"`
+	ToContextId   int    `json:"to_context_id"`
+	Relation      string `json:"relation"`
+}
+
+func (input *CreateContextRelationInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.Relation != RelationAbove && input.Relation != RelationBelow && input.Relation != RelationCuts:
+		err = fmt.Errorf("invalid context relation")
+	case input.FromContextId == input.ToContextId:
+		err = fmt.Errorf("context can not be related to itself")
+	}
+
+	return err
+}
+
+type HarrisMatrix struct {
+	LocationId int                `json:"location_id"`
+	Contexts   ExcavationContexts `json:"nodes"`
+	Relations  ContextRelations   `json:"edges"`
+}
@@ -6,10 +6,11 @@ import (
 )
 
 type Expedition struct {
-	Id         int       `db:"id"`
-	LocationId int       `json:"location_id" db:"location_id"`
-	StartDate  time.Time `json:"start_date" db:"start_date"`
-	EndDate    time.Time `json:"end_date" db:"end_date"`
+	Id         int        `db:"id"`
+	LocationId int        `json:"location_id" db:"location_id"`
+	StartDate  time.Time  `json:"start_date" db:"start_date"`
+	EndDate    time.Time  `json:"end_date" db:"end_date"`
+	ClosedOn   *time.Time `json:"closed_on" db:"closed_on"`
 }
 
 type Expeditions []*Expedition
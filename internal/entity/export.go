@@ -0,0 +1,70 @@
+package entity
+
+import "encoding/xml"
+
+type SiteSummary struct {
+	Location        *Location
+	ExpeditionCount int
+	ArtifactCount   int
+	Finds           Artifacts
+}
+
+type SiteSummaries []*SiteSummary
+
+type ExportFilter struct {
+	Locations *LocationFilter
+	Artifacts *ArtifactFilter
+}
+
+type GeoJSONFeatureCollection struct {
+	Type     string            `json:"type"`
+	Features []*GeoJSONFeature `json:"features"`
+}
+
+type GeoJSONFeature struct {
+	Type       string           `json:"type"`
+	Id         string           `json:"id"`
+	Geometry   *GeoJSONGeometry `json:"geometry"`
+	Properties map[string]any   `json:"properties"`
+}
+
+type GeoJSONGeometry struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+type KML struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document KMLDocument `xml:"Document"`
+}
+
+type KMLDocument struct {
+	Name    string      `xml:"name"`
+	Folders []KMLFolder `xml:"Folder"`
+}
+
+type KMLFolder struct {
+	Name       string         `xml:"name"`
+	Placemarks []KMLPlacemark `xml:"Placemark"`
+}
+
+type KMLPlacemark struct {
+	Id           string           `xml:"id,attr"`
+	Name         string           `xml:"name"`
+	ExtendedData *KMLExtendedData `xml:"ExtendedData,omitempty"`
+	Point        *KMLPoint        `xml:"Point,omitempty"`
+}
+
+type KMLExtendedData struct {
+	Data []KMLData `xml:"Data"`
+}
+
+type KMLData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type KMLPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
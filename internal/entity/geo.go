@@ -0,0 +1,93 @@
+package entity
+
+import "fmt"
+
+const DefaultDatum = "WGS84"
+
+type Coordinates struct {
+	Latitude  *float64 `json:"latitude" db:"latitude"`
+	Longitude *float64 `json:"longitude" db:"longitude"`
+	Elevation *float64 `json:"elevation" db:"elevation"`
+	Datum     string   `json:"datum" db:"datum"`
+}
+
+func (c *Coordinates) IsSet() bool {
+	return c.Latitude != nil && c.Longitude != nil
+}
+
+func (c *Coordinates) IsValid() error {
+	var err error
+
+	switch {
+	case (c.Latitude == nil) != (c.Longitude == nil):
+		err = fmt.Errorf("latitude and longitude must be set together")
+	case c.Latitude != nil && !isValidLatitude(*c.Latitude):
+		err = fmt.Errorf("invalid latitude")
+	case c.Longitude != nil && !isValidLongitude(*c.Longitude):
+		err = fmt.Errorf("invalid longitude")
+	case !c.IsSet() && (c.Elevation != nil || c.Datum != ""):
+		err = fmt.Errorf("elevation and datum require latitude and longitude")
+	}
+
+	return err
+}
+
+func (c *Coordinates) WithDefaultDatum() Coordinates {
+	res := *c
+	if res.IsSet() && res.Datum == "" {
+		res.Datum = DefaultDatum
+	}
+
+	return res
+}
+
+type BoundingBox struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+func (b *BoundingBox) IsValid() error {
+	var err error
+
+	switch {
+	case !isValidLatitude(b.MinLatitude) || !isValidLatitude(b.MaxLatitude):
+		err = fmt.Errorf("invalid bounding box latitude")
+	case !isValidLongitude(b.MinLongitude) || !isValidLongitude(b.MaxLongitude):
+		err = fmt.Errorf("invalid bounding box longitude")
+	case b.MinLatitude > b.MaxLatitude:
+		err = fmt.Errorf("invalid bounding box latitude range")
+	}
+
+	return err
+}
+
+type NearbyQuery struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	RadiusKm  float64 `json:"radius_km"`
+}
+
+func (q *NearbyQuery) IsValid() error {
+	var err error
+
+	switch {
+	case !isValidLatitude(q.Latitude):
+		err = fmt.Errorf("invalid latitude")
+	case !isValidLongitude(q.Longitude):
+		err = fmt.Errorf("invalid longitude")
+	case q.RadiusKm <= 0:
+		err = fmt.Errorf("invalid search radius")
+	}
+
+	return err
+}
+
+func isValidLatitude(lat float64) bool {
+	return lat >= -90 && lat <= 90
+}
+
+func isValidLongitude(lon float64) bool {
+	return lon >= -180 && lon <= 180
+}
@@ -0,0 +1,82 @@
+package entity
+
+const (
+	IIIFPresentationContext = "http://iiif.io/api/presentation/3/context.json"
+	IIIFMediaType           = `application/ld+json;profile="http://iiif.io/api/presentation/3/context.json"`
+)
+
+type IIIFLanguageMap map[string][]string
+
+func IIIFText(values ...string) IIIFLanguageMap {
+	return IIIFLanguageMap{"none": values}
+}
+
+type IIIFMetadataEntry struct {
+	Label IIIFLanguageMap `json:"label"`
+	Value IIIFLanguageMap `json:"value"`
+}
+
+type IIIFResource struct {
+	Id     string          `json:"id"`
+	Type   string          `json:"type"`
+	Format string          `json:"format,omitempty"`
+	Label  IIIFLanguageMap `json:"label,omitempty"`
+	Width  int             `json:"width,omitempty"`
+	Height int             `json:"height,omitempty"`
+}
+
+type IIIFReference struct {
+	Id        string          `json:"id"`
+	Type      string          `json:"type"`
+	Label     IIIFLanguageMap `json:"label"`
+	Thumbnail []*IIIFResource `json:"thumbnail,omitempty"`
+}
+
+type IIIFAnnotation struct {
+	Context    string        `json:"@context,omitempty"`
+	Id         string        `json:"id"`
+	Type       string        `json:"type"`
+	Motivation string        `json:"motivation"`
+	Body       *IIIFResource `json:"body"`
+	Target     string        `json:"target"`
+}
+
+type IIIFAnnotationPage struct {
+	Context string            `json:"@context,omitempty"`
+	Id      string            `json:"id"`
+	Type    string            `json:"type"`
+	Items   []*IIIFAnnotation `json:"items"`
+}
+
+type IIIFCanvas struct {
+	Context   string                `json:"@context,omitempty"`
+	Id        string                `json:"id"`
+	Type      string                `json:"type"`
+	Label     IIIFLanguageMap       `json:"label,omitempty"`
+	Width     int                   `json:"width"`
+	Height    int                   `json:"height"`
+	Thumbnail []*IIIFResource       `json:"thumbnail,omitempty"`
+	Items     []*IIIFAnnotationPage `json:"items"`
+}
+
+type IIIFManifest struct {
+	Context   string               `json:"@context"`
+	Id        string               `json:"id"`
+	Type      string               `json:"type"`
+	Label     IIIFLanguageMap      `json:"label"`
+	Summary   IIIFLanguageMap      `json:"summary,omitempty"`
+	Metadata  []*IIIFMetadataEntry `json:"metadata"`
+	NavDate   string               `json:"navDate,omitempty"`
+	Thumbnail []*IIIFResource      `json:"thumbnail,omitempty"`
+	PartOf    []*IIIFReference     `json:"partOf,omitempty"`
+	Items     []*IIIFCanvas        `json:"items"`
+}
+
+type IIIFCollection struct {
+	Context  string               `json:"@context"`
+	Id       string               `json:"id"`
+	Type     string               `json:"type"`
+	Label    IIIFLanguageMap      `json:"label"`
+	Metadata []*IIIFMetadataEntry `json:"metadata"`
+	Items    []*IIIFReference     `json:"items"`
+}
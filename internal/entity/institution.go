@@ -0,0 +1,42 @@
+package entity
+
+import "fmt"
+
+type Institution struct {
+	Id          int    `db:"id"`
+	Name        string `json:"name" db:"name"`
+	City        string `json:"city" db:"city"`
+	Country     string `json:"country" db:"country"`
+	CountryName string `json:"country_name" db:"-"`
+}
+
+func (i *Institution) LocalizeCountry(lang string) {
+	i.CountryName = CountryName(i.Country, lang)
+}
+
+type Institutions []*Institution
+
+func (is Institutions) LocalizeCountry(lang string) {
+	for _, i := range is {
+		i.LocalizeCountry(lang)
+	}
+}
+
+type CreateInstitutionInput struct {
+	Name    string `json:"name"`
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+func (input *CreateInstitutionInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.Name == "":
+		err = fmt.Errorf("invalid institution name")
+	case input.Country != "" && !isKnownCountry(input.Country):
+		err = fmt.Errorf("unknown institution country %q", input.Country)
+	}
+
+	return err
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type InventoryItem struct {
+	Id                  int    `db:"id"`
+	Name                string `json:"name" db:"name"`
+	ItemType            string `json:"item_type" db:"item_type"`
+	TotalQuantity       int    `json:"total_quantity" db:"total_quantity"`
+	ServiceIntervalDays *int   `json:"service_interval_days" db:"service_interval_days"`
+	ServiceFieldDays    *int   `json:"service_field_days" db:"service_field_days"`
+	Serviceable         bool   `json:"serviceable" db:"serviceable"`
+}
+
+type InventoryItems []*InventoryItem
+
+type CreateInventoryItemInput struct {
+	Name                string `json:"name"`
+	ItemType            string `json:"item_type"`
+	TotalQuantity       int    `json:"total_quantity"`
+	ServiceIntervalDays *int   `json:"service_interval_days"`
+	ServiceFieldDays    *int   `json:"service_field_days"`
+}
+
+func (input *CreateInventoryItemInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.Name == "":
+		err = fmt.Errorf("invalid inventory item name")
+	case input.ItemType == "":
+		err = fmt.Errorf("invalid inventory item type")
+	case input.TotalQuantity < 0:
+		err = fmt.Errorf("invalid inventory item quantity")
+	case input.ServiceIntervalDays != nil && *input.ServiceIntervalDays < 1:
+		err = fmt.Errorf("invalid service interval")
+	case input.ServiceFieldDays != nil && *input.ServiceFieldDays < 1:
+		err = fmt.Errorf("invalid service field days")
+	}
+
+	return err
+}
+
+type ItemAvailability struct {
+	ItemId        int    `json:"item_id" db:"item_id"`
+	Name          string `json:"name" db:"name"`
+	ItemType      string `json:"item_type" db:"item_type"`
+	TotalQuantity int    `json:"total_quantity" db:"total_quantity"`
+	Reserved      int    `json:"reserved" db:"reserved"`
+	Available     int    `json:"available" db:"available"`
+	Serviceable   bool   `json:"serviceable" db:"serviceable"`
+}
+
+type ItemAvailabilities []*ItemAvailability
+
+type AvailabilityFilter struct {
+	ItemId *int
+	From   time.Time
+	To     time.Time
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type JournalEntry struct {
+	Id           int       `db:"id"`
+	ExpeditionId int       `json:"expedition_id" db:"expedition_id"`
+	MemberId     int       `json:"member_id" db:"member_id"`
+	MemberName   string    `json:"member_name" db:"member_name"`
+	EntryDate    time.Time `json:"entry_date" db:"entry_date"`
+	Weather      string    `json:"weather" db:"weather"`
+	Summary      string    `json:"summary" db:"summary"`
+	HoursWorked  float64   `json:"hours_worked" db:"hours_worked"`
+	ArtifactIds  []int     `json:"artifact_ids" db:"-"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type JournalEntries []*JournalEntry
+
+type JournalEntryInput struct {
+	EntryDate   string  `json:"entry_date"`
+	Weather     string  `json:"weather"`
+	Summary     string  `json:"summary"`
+	HoursWorked float64 `json:"hours_worked"`
+	ArtifactIds []int   `json:"artifact_ids"`
+}
+
+func (input *JournalEntryInput) IsValid() error {
+	var err error
+
+	switch {
+	case !isValidDate(input.EntryDate):
+		err = fmt.Errorf("invalid journal entry date")
+	case strings.TrimSpace(input.Summary) == "":
+		err = fmt.Errorf("invalid journal entry summary")
+	case input.HoursWorked < 0 || input.HoursWorked > 24:
+		err = fmt.Errorf("invalid journal entry hours worked")
+	}
+	if err != nil {
+		return err
+	}
+
+	artifactIds := make(map[int]bool, len(input.ArtifactIds))
+	for _, id := range input.ArtifactIds {
+		if artifactIds[id] {
+			return fmt.Errorf("duplicate journal entry artifact %d", id)
+		}
+		artifactIds[id] = true
+	}
+
+	return nil
+}
+
+type JournalDayLock struct {
+	ExpeditionId int       `json:"expedition_id" db:"expedition_id"`
+	Day          time.Time `json:"day" db:"day"`
+	LockedById   *int      `json:"locked_by_id" db:"locked_by_id"`
+	LockedAt     time.Time `json:"locked_at" db:"locked_at"`
+}
+
+type JournalDayLocks []*JournalDayLock
+
+type LockJournalDayInput struct {
+	Day string `json:"day"`
+}
+
+func (input *LockJournalDayInput) IsValid() error {
+	if !isValidDate(input.Day) {
+		return fmt.Errorf("invalid journal day")
+	}
+
+	return nil
+}
+
+type JournalDay struct {
+	Date        time.Time       `json:"date"`
+	Locked      bool            `json:"locked"`
+	Lock        *JournalDayLock `json:"lock,omitempty"`
+	HoursWorked float64         `json:"hours_worked"`
+	Entries     JournalEntries  `json:"entries"`
+}
+
+type JournalDays []*JournalDay
+
+type JournalDocument struct {
+	ExpeditionId int         `json:"expedition_id"`
+	LocationId   int         `json:"location_id"`
+	StartDate    time.Time   `json:"start_date"`
+	EndDate      time.Time   `json:"end_date"`
+	HoursWorked  float64     `json:"hours_worked"`
+	Days         JournalDays `json:"days"`
+}
+
+func (d *JournalDocument) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Expedition %d field journal\n\n", d.ExpeditionId)
+	fmt.Fprintf(&b, "%s – %s, %s hours worked\n", d.StartDate.Format("2006-01-02"), d.EndDate.Format("2006-01-02"), formatHours(d.HoursWorked))
+
+	for _, day := range d.Days {
+		fmt.Fprintf(&b, "\n## %s", day.Date.Format("2006-01-02"))
+		if day.Locked {
+			b.WriteString(" (locked)")
+		}
+		b.WriteString("\n")
+
+		for _, e := range day.Entries {
+			fmt.Fprintf(&b, "\n### %s, %s h\n\n", e.MemberName, formatHours(e.HoursWorked))
+			if e.Weather != "" {
+				fmt.Fprintf(&b, "Weather: %s\n\n", e.Weather)
+			}
+			fmt.Fprintf(&b, "%s\n", strings.TrimSpace(e.Summary))
+			if len(e.ArtifactIds) > 0 {
+				ids := make([]string, 0, len(e.ArtifactIds))
+				for _, id := range e.ArtifactIds {
+					ids = append(ids, fmt.Sprintf("#%d", id))
+				}
+				fmt.Fprintf(&b, "\nArtifacts: %s\n", strings.Join(ids, ", "))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func formatHours(hours float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", hours), "0"), ".")
+}
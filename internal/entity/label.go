@@ -0,0 +1,259 @@
+package entity
+
+import (
+	"bytes"
+	"db_cp_6/pkg/pdf"
+	"db_cp_6/pkg/qrcode"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LabelFormatPDF = "pdf"
+	LabelFormatSVG = "svg"
+)
+
+const MaxLabelBatch = 500
+
+const (
+	labelPageWidth    = 210.0
+	labelPageHeight   = 297.0
+	labelColumns      = 3
+	labelRows         = 8
+	labelWidth        = 70.0
+	labelHeight       = 37.0
+	labelPadding      = 2.5
+	labelQRSize       = labelHeight - 2*labelPadding
+	labelQRQuietZone  = 2
+	labelTextGap      = 2.0
+	labelNumberSize   = 10.0
+	labelNameSize     = 8.0
+	labelDetailSize   = 7.0
+	labelLineSpacing  = 1.25
+	labelPerPage      = labelColumns * labelRows
+	labelPageMarginX  = (labelPageWidth - labelColumns*labelWidth) / 2
+	labelPageMarginY  = (labelPageHeight - labelRows*labelHeight) / 2
+	labelTextWidthPt  = (labelWidth - 2*labelPadding - labelQRSize - labelTextGap) * pdf.PointsPerMM
+	labelNameMaxLines = 2
+)
+
+type LabelQuery struct {
+	ArtifactIds  []int  `form:"artifact_id"`
+	ExpeditionId *int   `form:"expedition_id"`
+	Date         string `form:"date"`
+	Format       string `form:"format"`
+}
+
+func (q *LabelQuery) IsValid() error {
+	var err error
+
+	switch {
+	case len(q.ArtifactIds) == 0 && q.ExpeditionId == nil:
+		err = fmt.Errorf("labels require artifact ids or an expedition day")
+	case len(q.ArtifactIds) > 0 && q.ExpeditionId != nil:
+		err = fmt.Errorf("labels take either artifact ids or an expedition day")
+	case len(q.ArtifactIds) > MaxLabelBatch:
+		err = fmt.Errorf("at most %d labels can be printed at once", MaxLabelBatch)
+	case q.ExpeditionId != nil && !isValidDate(q.Date):
+		err = fmt.Errorf("invalid label date")
+	case q.Format != "" && q.Format != LabelFormatPDF && q.Format != LabelFormatSVG:
+		err = fmt.Errorf("invalid label format")
+	}
+
+	return err
+}
+
+type Label struct {
+	ArtifactId    int        `json:"artifact_id"`
+	CatalogNumber string     `json:"catalog_number"`
+	Name          string     `json:"name"`
+	LocationName  string     `json:"location_name"`
+	FoundOn       *time.Time `json:"found_on"`
+	URL           string     `json:"url"`
+}
+
+type LabelSheet struct {
+	Labels []*Label
+}
+
+type labelBox struct {
+	page int
+	x    float64
+	y    float64
+	qr   *qrcode.Code
+	text []labelLine
+}
+
+type labelLine struct {
+	font pdf.Font
+	size float64
+	text string
+}
+
+func (s *LabelSheet) layout() ([]labelBox, error) {
+	boxes := make([]labelBox, 0, len(s.Labels))
+	for i, label := range s.Labels {
+		qr, err := qrcode.Encode([]byte(label.URL), qrcode.Medium)
+		if err != nil {
+			return nil, fmt.Errorf("label for artifact %d: %w", label.ArtifactId, err)
+		}
+
+		slot := i % labelPerPage
+		box := labelBox{
+			page: i / labelPerPage,
+			x:    labelPageMarginX + float64(slot%labelColumns)*labelWidth,
+			y:    labelPageMarginY + float64(slot/labelColumns)*labelHeight,
+			qr:   qr,
+			text: []labelLine{{font: pdf.CourierBold, size: labelNumberSize, text: label.CatalogNumber}},
+		}
+		for _, line := range wrapLabelText(label.Name, labelNameSize, labelNameMaxLines) {
+			box.text = append(box.text, labelLine{font: pdf.Helvetica, size: labelNameSize, text: line})
+		}
+		box.text = append(box.text, labelLine{
+			font: pdf.Helvetica,
+			size: labelDetailSize,
+			text: pdf.Truncate(pdf.Helvetica, labelDetailSize, label.LocationName, labelTextWidthPt),
+		})
+		if label.FoundOn != nil {
+			box.text = append(box.text, labelLine{font: pdf.Helvetica, size: labelDetailSize, text: label.FoundOn.Format("2006-01-02")})
+		}
+
+		boxes = append(boxes, box)
+	}
+
+	return boxes, nil
+}
+
+func (s *LabelSheet) PDF() ([]byte, error) {
+	boxes, err := s.layout()
+	if err != nil {
+		return nil, err
+	}
+
+	mm := pdf.PointsPerMM
+	doc := pdf.New(labelPageWidth*mm, labelPageHeight*mm)
+	var page *pdf.Page
+	for i, box := range boxes {
+		if i%labelPerPage == 0 {
+			page = doc.AddPage()
+		}
+
+		module := labelQRSize / float64(box.qr.Size+2*labelQRQuietZone)
+		qrX, qrY := box.x+labelPadding, box.y+labelPadding
+		forEachQRRun(box.qr, func(x int, y int, length int) {
+			page.FillRect(
+				(qrX+float64(x+labelQRQuietZone)*module)*mm,
+				(labelPageHeight-qrY-float64(y+labelQRQuietZone+1)*module)*mm,
+				float64(length)*module*mm,
+				module*mm,
+			)
+		})
+
+		textX := (box.x + labelPadding + labelQRSize + labelTextGap) * mm
+		baseline := (labelPageHeight - box.y - labelPadding) * mm
+		for _, line := range box.text {
+			baseline -= line.size * labelLineSpacing
+			page.Text(textX, baseline, line.font, line.size, line.text)
+		}
+	}
+	if len(boxes) == 0 {
+		doc.AddPage()
+	}
+
+	return doc.Bytes(), nil
+}
+
+func (s *LabelSheet) SVG() ([]byte, error) {
+	boxes, err := s.layout()
+	if err != nil {
+		return nil, err
+	}
+
+	pages := (len(boxes) + labelPerPage - 1) / labelPerPage
+	if pages == 0 {
+		pages = 1
+	}
+	height := float64(pages) * labelPageHeight
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, `<svg xmlns="http://www.w3.org/2000/svg" width="%smm" height="%smm" viewBox="0 0 %s %s">`+"\n",
+		svgNumber(labelPageWidth), svgNumber(height), svgNumber(labelPageWidth), svgNumber(height))
+	fmt.Fprintf(&out, `<rect width="%s" height="%s" fill="#fff"/>`+"\n", svgNumber(labelPageWidth), svgNumber(height))
+
+	for i, box := range boxes {
+		top := float64(box.page)*labelPageHeight + box.y
+		fmt.Fprintf(&out, `<g id="label-%d">`+"\n", s.Labels[i].ArtifactId)
+
+		module := labelQRSize / float64(box.qr.Size+2*labelQRQuietZone)
+		qrX, qrY := box.x+labelPadding, top+labelPadding
+		var path strings.Builder
+		forEachQRRun(box.qr, func(x int, y int, length int) {
+			fmt.Fprintf(&path, "M%s %sh%sv%sh-%sz",
+				svgNumber(qrX+float64(x+labelQRQuietZone)*module), svgNumber(qrY+float64(y+labelQRQuietZone)*module),
+				svgNumber(float64(length)*module), svgNumber(module), svgNumber(float64(length)*module))
+		})
+		fmt.Fprintf(&out, `<path d="%s" fill="#000" shape-rendering="crispEdges"/>`+"\n", path.String())
+
+		textX := box.x + labelPadding + labelQRSize + labelTextGap
+		baseline := top + labelPadding
+		for _, line := range box.text {
+			baseline += line.size * labelLineSpacing / pdf.PointsPerMM
+			family, weight := "Helvetica, Arial, sans-serif", "normal"
+			if line.font == pdf.CourierBold {
+				family, weight = "Courier New, monospace", "bold"
+			}
+			fmt.Fprintf(&out, `<text x="%s" y="%s" font-family="%s" font-weight="%s" font-size="%s">%s</text>`+"\n",
+				svgNumber(textX), svgNumber(baseline), family, weight, svgNumber(line.size/pdf.PointsPerMM), html.EscapeString(line.text))
+		}
+
+		out.WriteString("</g>\n")
+	}
+	out.WriteString("</svg>\n")
+
+	return out.Bytes(), nil
+}
+
+func forEachQRRun(code *qrcode.Code, fn func(x int, y int, length int)) {
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; {
+			if !code.Black(x, y) {
+				x++
+				continue
+			}
+			start := x
+			for x < code.Size && code.Black(x, y) {
+				x++
+			}
+			fn(start, y, x-start)
+		}
+	}
+}
+
+func wrapLabelText(text string, size float64, maxLines int) []string {
+	words := strings.Fields(text)
+	lines := make([]string, 0, maxLines)
+	for len(words) > 0 && len(lines) < maxLines {
+		if len(lines) == maxLines-1 {
+			lines = append(lines, pdf.Truncate(pdf.Helvetica, size, strings.Join(words, " "), labelTextWidthPt))
+			break
+		}
+
+		line := words[0]
+		words = words[1:]
+		for len(words) > 0 && pdf.TextWidth(pdf.Helvetica, size, line+" "+words[0]) <= labelTextWidthPt {
+			line += " " + words[0]
+			words = words[1:]
+		}
+		lines = append(lines, pdf.Truncate(pdf.Helvetica, size, line, labelTextWidthPt))
+	}
+
+	return lines
+}
+
+func svgNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1000)/1000, 'f', -1, 64)
+}
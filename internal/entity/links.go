@@ -0,0 +1,11 @@
+package entity
+
+import "strings"
+
+type Links struct {
+	BaseURL string
+}
+
+func (l *Links) URL(path string) string {
+	return strings.TrimRight(l.BaseURL, "/") + path
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	LoanRequested = "requested"
+	LoanApproved  = "approved"
+	LoanShipped   = "shipped"
+	LoanReturned  = "returned"
+	LoanCancelled = "cancelled"
+)
+
+var loanTransitions = map[string][]string{
+	LoanRequested: {LoanApproved, LoanCancelled},
+	LoanApproved:  {LoanShipped, LoanCancelled},
+	LoanShipped:   {LoanReturned},
+}
+
+type Loan struct {
+	Id             int        `db:"id"`
+	Borrower       string     `json:"borrower" db:"borrower"`
+	ArtifactIds    []int      `json:"artifact_ids" db:"-"`
+	OutDate        time.Time  `json:"out_date" db:"out_date"`
+	DueDate        time.Time  `json:"due_date" db:"due_date"`
+	ReturnedOn     *time.Time `json:"returned_on" db:"returned_on"`
+	InsuranceValue float64    `json:"insurance_value" db:"insurance_value"`
+	Status         string     `json:"status" db:"status"`
+	CreatedById    int        `json:"created_by_id" db:"created_by_id"`
+	CreatedByRole  string     `json:"created_by_role" db:"created_by_role"`
+}
+
+type Loans []*Loan
+
+func (l *Loan) CanMoveTo(status string) bool {
+	for _, next := range loanTransitions[l.Status] {
+		if next == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+type CreateLoanInput struct {
+	Borrower       string  `json:"borrower"`
+	ArtifactIds    []int   `json:"artifact_ids"`
+	OutDate        string  `json:"out_date"`
+	DueDate        string  `json:"due_date"`
+	InsuranceValue float64 `json:"insurance_value"`
+}
+
+func (input *CreateLoanInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.Borrower == "":
+		err = fmt.Errorf("invalid loan borrower")
+	case len(input.ArtifactIds) == 0:
+		err = fmt.Errorf("loan must include at least one artifact")
+	case !isValidDate(input.OutDate):
+		err = fmt.Errorf("invalid loan out date")
+	case !isValidDate(input.DueDate):
+		err = fmt.Errorf("invalid loan due date")
+	case input.DueDate < input.OutDate:
+		err = fmt.Errorf("loan due date is before out date")
+	case input.InsuranceValue < 0:
+		err = fmt.Errorf("invalid loan insurance value")
+	}
+
+	return err
+}
+
+type UpdateLoanStatusInput struct {
+	Status string `json:"status"`
+}
+
+type OverdueLoan struct {
+	LoanId      int       `json:"loan_id" db:"loan_id"`
+	Borrower    string    `json:"borrower" db:"borrower"`
+	ArtifactIds []int     `json:"artifact_ids" db:"artifact_ids"`
+	DueDate     time.Time `json:"due_date" db:"due_date"`
+	DaysOverdue int       `json:"days_overdue" db:"days_overdue"`
+}
+
+type OverdueLoans []*OverdueLoan
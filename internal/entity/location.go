@@ -6,15 +6,46 @@ type Location struct {
 	Id          int    `db:"id"`
 	Name        string `json:"name" db:"name"`
 	Country     string `json:"country" db:"country"`
+	CountryName string `json:"country_name" db:"-"`
 	NearestTown string `json:"nearest_town" db:"nearest_town"`
+	Coordinates
+}
+
+func (l *Location) LocalizeCountry(lang string) {
+	l.CountryName = CountryName(l.Country, lang)
 }
 
 type Locations []*Location
 
+func (ls Locations) LocalizeCountry(lang string) {
+	for _, l := range ls {
+		l.LocalizeCountry(lang)
+	}
+}
+
+type NearbyLocation struct {
+	Location
+	DistanceKm float64 `json:"distance_km" db:"distance_km"`
+}
+
+type NearbyLocations []*NearbyLocation
+
+func (ls NearbyLocations) LocalizeCountry(lang string) {
+	for _, l := range ls {
+		l.LocalizeCountry(lang)
+	}
+}
+
+type LocationFilter struct {
+	BoundingBox *BoundingBox
+	Country     string
+}
+
 type CreateLocationInput struct {
 	Name        string `json:"name"`
 	Country     string `json:"country"`
 	NearestTown string `json:"nearest_town"`
+	Coordinates
 }
 
 func (input *CreateLocationInput) IsValid() error {
@@ -25,8 +56,12 @@ func (input *CreateLocationInput) IsValid() error {
 		err = fmt.Errorf("invalid location name")
 	case input.Country == "":
 		err = fmt.Errorf("invalid location country")
+	case !isKnownCountry(input.Country):
+		err = fmt.Errorf("unknown location country %q", input.Country)
 	case input.NearestTown == "":
 		err = fmt.Errorf("invalid location nearest town")
+	default:
+		err = input.Coordinates.IsValid()
 	}
 
 	return err
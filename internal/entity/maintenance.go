@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type MaintenanceLog struct {
+	Id          int       `db:"id"`
+	ItemId      int       `json:"item_id" db:"item_id"`
+	PerformedOn time.Time `json:"performed_on" db:"performed_on"`
+	Technician  string    `json:"technician" db:"technician"`
+	Description string    `json:"description" db:"description"`
+	Serviceable bool      `json:"serviceable" db:"serviceable"`
+}
+
+type MaintenanceLogs []*MaintenanceLog
+
+type CreateMaintenanceLogInput struct {
+	PerformedOn string `json:"performed_on"`
+	Technician  string `json:"technician"`
+	Description string `json:"description"`
+	Serviceable bool   `json:"serviceable"`
+}
+
+func (input *CreateMaintenanceLogInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.Technician == "":
+		err = fmt.Errorf("invalid technician")
+	case input.Description == "":
+		err = fmt.Errorf("invalid maintenance description")
+	case input.PerformedOn != "" && !isValidDate(input.PerformedOn):
+		err = fmt.Errorf("invalid maintenance date")
+	}
+
+	return err
+}
+
+type UpdateMaintenanceScheduleInput struct {
+	ServiceIntervalDays *int `json:"service_interval_days"`
+	ServiceFieldDays    *int `json:"service_field_days"`
+}
+
+func (input *UpdateMaintenanceScheduleInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.ServiceIntervalDays != nil && *input.ServiceIntervalDays < 1:
+		err = fmt.Errorf("invalid service interval")
+	case input.ServiceFieldDays != nil && *input.ServiceFieldDays < 1:
+		err = fmt.Errorf("invalid service field days")
+	}
+
+	return err
+}
+
+type MaintenanceDue struct {
+	ItemId              int        `json:"item_id" db:"item_id"`
+	Name                string     `json:"name" db:"name"`
+	ItemType            string     `json:"item_type" db:"item_type"`
+	Serviceable         bool       `json:"serviceable" db:"serviceable"`
+	LastServicedOn      *time.Time `json:"last_serviced_on" db:"last_serviced_on"`
+	ServiceIntervalDays *int       `json:"service_interval_days" db:"service_interval_days"`
+	DueOn               *time.Time `json:"due_on" db:"due_on"`
+	ServiceFieldDays    *int       `json:"service_field_days" db:"service_field_days"`
+	FieldDays           int        `json:"field_days" db:"field_days"`
+}
+
+type MaintenanceDues []*MaintenanceDue
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	MergeLocation = "location"
+	MergeMember   = "member"
+	MergeLeader   = "leader"
+	MergeCurator  = "curator"
+)
+
+const (
+	DefaultDuplicateThreshold = 0.6
+	MinDuplicateThreshold     = 0.3
+	DefaultDuplicateLimit     = 50
+	MaxDuplicateLimit         = 500
+)
+
+func isMergeType(t string) bool {
+	return t == MergeLocation || t == MergeMember || t == MergeLeader || t == MergeCurator
+}
+
+type DuplicateQuery struct {
+	Type      string  `form:"type"`
+	Threshold float64 `form:"threshold"`
+	Limit     int     `form:"limit"`
+}
+
+func (q *DuplicateQuery) IsValid() error {
+	var err error
+
+	switch {
+	case !isMergeType(q.Type):
+		err = fmt.Errorf("invalid duplicate type")
+	case q.Threshold != 0 && (q.Threshold < MinDuplicateThreshold || q.Threshold > 1):
+		err = fmt.Errorf("duplicate threshold must be between %.1f and 1", MinDuplicateThreshold)
+	case q.Limit < 0 || q.Limit > MaxDuplicateLimit:
+		err = fmt.Errorf("duplicate limit must be between 1 and %d", MaxDuplicateLimit)
+	}
+
+	return err
+}
+
+type DuplicateRecord struct {
+	Id      int    `json:"id"`
+	Name    string `json:"name"`
+	Details string `json:"details"`
+}
+
+type DuplicateCandidate struct {
+	Type       string           `json:"type"`
+	First      *DuplicateRecord `json:"first"`
+	Second     *DuplicateRecord `json:"second"`
+	Similarity float64          `json:"similarity"`
+}
+
+type DuplicateCandidates []*DuplicateCandidate
+
+type MergeInput struct {
+	SurvivorId  int `json:"survivor_id"`
+	DuplicateId int `json:"duplicate_id"`
+}
+
+func (input *MergeInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.SurvivorId <= 0:
+		err = fmt.Errorf("invalid survivor id")
+	case input.DuplicateId <= 0:
+		err = fmt.Errorf("invalid duplicate id")
+	case input.SurvivorId == input.DuplicateId:
+		err = fmt.Errorf("a record cannot be merged into itself")
+	}
+
+	return err
+}
+
+type MergeRecord struct {
+	Id           int              `json:"id" db:"id"`
+	EntityType   string           `json:"entity_type" db:"entity_type"`
+	SurvivorId   int              `json:"survivor_id" db:"survivor_id"`
+	MergedId     int              `json:"merged_id" db:"merged_id"`
+	MergedName   string           `json:"merged_name" db:"merged_name"`
+	Reassigned   map[string]int64 `json:"reassigned" db:"reassigned"`
+	MergedById   int              `json:"merged_by_id" db:"merged_by_id"`
+	MergedByRole string           `json:"merged_by_role" db:"merged_by_role"`
+	MergedAt     time.Time        `json:"merged_at" db:"merged_at"`
+}
+
+type MergeRecords []*MergeRecord
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+)
+
+const packingQuantityPrecision = 1e6
+
+type PackingTemplateItem struct {
+	ItemId       int     `json:"item_id" db:"item_id"`
+	Name         string  `json:"name" db:"name"`
+	BaseQuantity float64 `json:"base_quantity" db:"base_quantity"`
+	PerPerson    float64 `json:"per_person" db:"per_person"`
+	PerDay       float64 `json:"per_day" db:"per_day"`
+	PerPersonDay float64 `json:"per_person_day" db:"per_person_day"`
+}
+
+type PackingTemplateItems []*PackingTemplateItem
+
+func (item *PackingTemplateItem) Quantity(members int, days int) int {
+	m, d := float64(members), float64(days)
+	quantity := item.BaseQuantity + item.PerPerson*m + item.PerDay*d + item.PerPersonDay*m*d
+	return int(math.Ceil(math.Round(quantity*packingQuantityPrecision) / packingQuantityPrecision))
+}
+
+func (item *PackingTemplateItem) IsValid() error {
+	var err error
+
+	switch {
+	case item.ItemId == 0:
+		err = fmt.Errorf("invalid packing template item")
+	case item.BaseQuantity < 0 || item.PerPerson < 0 || item.PerDay < 0 || item.PerPersonDay < 0:
+		err = fmt.Errorf("invalid packing template quantity formula")
+	case item.BaseQuantity+item.PerPerson+item.PerDay+item.PerPersonDay == 0:
+		err = fmt.Errorf("empty packing template quantity formula")
+	}
+
+	return err
+}
+
+type PackingTemplate struct {
+	Id    int                  `db:"id"`
+	Name  string               `json:"name" db:"name"`
+	Items PackingTemplateItems `json:"items"`
+}
+
+type PackingTemplates []*PackingTemplate
+
+type CreatePackingTemplateInput struct {
+	Name  string               `json:"name"`
+	Items PackingTemplateItems `json:"items"`
+}
+
+func (input *CreatePackingTemplateInput) IsValid() error {
+	if input.Name == "" {
+		return fmt.Errorf("invalid packing template name")
+	}
+	if len(input.Items) == 0 {
+		return fmt.Errorf("packing template has no items")
+	}
+
+	seen := make(map[int]bool, len(input.Items))
+	for _, item := range input.Items {
+		if err := item.IsValid(); err != nil {
+			return err
+		}
+		if seen[item.ItemId] {
+			return fmt.Errorf("duplicate packing template item %d", item.ItemId)
+		}
+		seen[item.ItemId] = true
+	}
+
+	return nil
+}
+
+type ApplyPackingTemplateInput struct {
+	TemplateId int `json:"template_id"`
+}
+
+type PackingListLine struct {
+	ItemId   int    `json:"item_id" db:"item_id"`
+	Name     string `json:"name" db:"name"`
+	Reserved int    `json:"reserved" db:"reserved"`
+	Required int    `json:"required"`
+	Delta    int    `json:"delta"`
+}
+
+type PackingListLines []*PackingListLine
+
+type PackingListDiff struct {
+	ExpeditionId int              `json:"expedition_id"`
+	TemplateId   int              `json:"template_id"`
+	Members      int              `json:"members"`
+	Days         int              `json:"days"`
+	Lines        PackingListLines `json:"lines"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "fmt"
+
+type Period struct {
+	Id        int    `db:"id"`
+	ParentId  *int   `json:"parent_id" db:"parent_id"`
+	Name      string `json:"name" db:"name"`
+	StartYear int    `json:"start_year" db:"start_year"`
+	EndYear   int    `json:"end_year" db:"end_year"`
+}
+
+type Periods []*Period
+
+type CreatePeriodInput struct {
+	ParentId  *int   `json:"parent_id"`
+	Name      string `json:"name"`
+	StartYear int    `json:"start_year"`
+	EndYear   int    `json:"end_year"`
+}
+
+func (input *CreatePeriodInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.Name == "":
+		err = fmt.Errorf("invalid period name")
+	case input.StartYear > input.EndYear:
+		err = fmt.Errorf("period start year is after end year")
+	}
+
+	return err
+}
@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	SampleSoil     = "soil"
+	SampleBone     = "bone"
+	SampleCharcoal = "charcoal"
+	SampleWood     = "wood"
+	SampleShell    = "shell"
+	SampleOther    = "other"
+)
+
+const (
+	SampleCollected  = "collected"
+	SampleSubmitted  = "submitted"
+	SampleInAnalysis = "in_analysis"
+	SampleReported   = "reported"
+	SampleAccepted   = "accepted"
+	SampleRejected   = "rejected"
+)
+
+const radiocarbonPresentYear = 1950
+
+type RadiocarbonResult struct {
+	AgeBP   int    `json:"age_bp"`
+	Sigma   int    `json:"sigma"`
+	LabCode string `json:"lab_code"`
+}
+
+func (r *RadiocarbonResult) IsValid() error {
+	var err error
+
+	switch {
+	case r.AgeBP < 0:
+		err = fmt.Errorf("invalid radiocarbon age")
+	case r.Sigma < 0:
+		err = fmt.Errorf("invalid radiocarbon sigma")
+	case r.LabCode == "":
+		err = fmt.Errorf("radiocarbon result requires a lab code")
+	}
+
+	return err
+}
+
+func (r *RadiocarbonResult) Dating() Dating {
+	ageBP, sigma := r.AgeBP, r.Sigma
+	central, errorYears := radiocarbonPresentYear-r.AgeBP, 2*r.Sigma
+
+	return Dating{
+		CentralYear:      &central,
+		ErrorYears:       &errorYears,
+		Method:           DatingRadiocarbon,
+		Confidence:       ConfidenceMedium,
+		RadiocarbonAgeBP: &ageBP,
+		RadiocarbonSigma: &sigma,
+	}.Normalized()
+}
+
+type SampleResults struct {
+	Radiocarbon *RadiocarbonResult `json:"radiocarbon,omitempty"`
+	Notes       string             `json:"notes,omitempty"`
+}
+
+type Sample struct {
+	Id           int           `db:"id"`
+	ExpeditionId int           `json:"expedition_id" db:"expedition_id"`
+	ArtifactId   *int          `json:"artifact_id" db:"artifact_id"`
+	ContextId    *int          `json:"context_id" db:"context_id"`
+	Type         string        `json:"type" db:"sample_type"`
+	Lab          string        `json:"lab" db:"lab"`
+	SubmittedOn  *time.Time    `json:"submitted_on" db:"submitted_on"`
+	Status       string        `json:"status" db:"status"`
+	Results      SampleResults `json:"results" db:"results"`
+}
+
+type Samples []*Sample
+
+type SampleFilter struct {
+	ExpeditionId *int
+	Status       string
+	Lab          string
+}
+
+type CreateSampleInput struct {
+	ExpeditionId int           `json:"expedition_id"`
+	ArtifactId   *int          `json:"artifact_id"`
+	ContextId    *int          `json:"context_id"`
+	Type         string        `json:"type"`
+	Lab          string        `json:"lab"`
+	SubmittedOn  string        `json:"submitted_on"`
+	Status       string        `json:"status"`
+	Results      SampleResults `json:"results"`
+}
+
+func (input *CreateSampleInput) IsValid() error {
+	var err error
+
+	switch {
+	case !isValidSampleType(input.Type):
+		err = fmt.Errorf("invalid sample type")
+	case input.Status != "" && !IsValidSampleStatus(input.Status):
+		err = fmt.Errorf("invalid sample status")
+	case input.SubmittedOn != "" && !isValidDate(input.SubmittedOn):
+		err = fmt.Errorf("invalid sample submission date")
+	case input.Results.Radiocarbon != nil:
+		err = input.Results.Radiocarbon.IsValid()
+	}
+
+	return err
+}
+
+type UpdateSampleInput struct {
+	Lab         string        `json:"lab"`
+	SubmittedOn string        `json:"submitted_on"`
+	Status      string        `json:"status"`
+	Results     SampleResults `json:"results"`
+}
+
+func (input *UpdateSampleInput) IsValid() error {
+	var err error
+
+	switch {
+	case !IsValidSampleStatus(input.Status):
+		err = fmt.Errorf("invalid sample status")
+	case input.SubmittedOn != "" && !isValidDate(input.SubmittedOn):
+		err = fmt.Errorf("invalid sample submission date")
+	case input.Results.Radiocarbon != nil:
+		err = input.Results.Radiocarbon.IsValid()
+	}
+
+	return err
+}
+
+func IsValidSampleStatus(status string) bool {
+	switch status {
+	case SampleCollected, SampleSubmitted, SampleInAnalysis, SampleReported, SampleAccepted, SampleRejected:
+		return true
+	}
+
+	return false
+}
+
+func isValidSampleType(sampleType string) bool {
+	switch sampleType {
+	case SampleSoil, SampleBone, SampleCharcoal, SampleWood, SampleShell, SampleOther:
+		return true
+	}
+
+	return false
+}
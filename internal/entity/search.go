@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	SearchTypeArtifact = "artifact"
+	SearchTypeLocation = "location"
+	SearchTypeLeader   = "leader"
+	SearchTypeMember   = "member"
+	SearchTypeCurator  = "curator"
+)
+
+var SearchTypes = []string{SearchTypeArtifact, SearchTypeLocation, SearchTypeLeader, SearchTypeMember, SearchTypeCurator}
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	MaxSearchLength    = 200
+)
+
+const (
+	SearchHighlightStart = "\x02"
+	SearchHighlightStop  = "\x03"
+)
+
+type SearchQuery struct {
+	Text   string   `form:"q"`
+	Types  []string `form:"type"`
+	Limit  int      `form:"limit"`
+	Offset int      `form:"offset"`
+}
+
+func (q *SearchQuery) IsValid() error {
+	var err error
+
+	text := strings.TrimSpace(q.Text)
+	switch {
+	case text == "":
+		err = fmt.Errorf("empty search query")
+	case utf8.RuneCountInString(text) > MaxSearchLength:
+		err = fmt.Errorf("search query is longer than %d characters", MaxSearchLength)
+	case q.Limit < 0 || q.Limit > MaxSearchLimit:
+		err = fmt.Errorf("search limit must be between 1 and %d", MaxSearchLimit)
+	case q.Offset < 0:
+		err = fmt.Errorf("invalid search offset")
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, t := range q.Types {
+		if !isSearchType(t) {
+			return fmt.Errorf("invalid search type %q", t)
+		}
+	}
+
+	return nil
+}
+
+func isSearchType(t string) bool {
+	for _, known := range SearchTypes {
+		if t == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+type SearchScope struct {
+	Text                  string
+	Types                 []string
+	UserRole              string
+	UserId                int
+	SharedExpeditionsOnly bool
+	Limit                 int
+	Offset                int
+}
+
+type SearchHit struct {
+	Type      string  `json:"type"`
+	Id        int     `json:"id"`
+	Title     string  `json:"title"`
+	Subtitle  string  `json:"subtitle"`
+	Highlight string  `json:"highlight"`
+	Rank      float64 `json:"rank"`
+}
+
+type SearchHits []*SearchHit
+
+func HighlightHTML(headline string) string {
+	escaped := html.EscapeString(headline)
+	escaped = strings.ReplaceAll(escaped, SearchHighlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, SearchHighlightStop, "</mark>")
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	StorageMuseum  = "museum"
+	StorageRoom    = "room"
+	StorageCabinet = "cabinet"
+	StorageShelf   = "shelf"
+	StorageBox     = "box"
+)
+
+var storageLevels = map[string]int{
+	StorageMuseum:  0,
+	StorageRoom:    1,
+	StorageCabinet: 2,
+	StorageShelf:   3,
+	StorageBox:     4,
+}
+
+type StorageNode struct {
+	Id       int    `db:"id"`
+	ParentId *int   `json:"parent_id" db:"parent_id"`
+	Kind     string `json:"kind" db:"kind"`
+	Name     string `json:"name" db:"name"`
+	Capacity *int   `json:"capacity" db:"capacity"`
+	Path     string `json:"path" db:"-"`
+}
+
+type StorageNodes []*StorageNode
+
+func (n *StorageNode) CanContain(kind string) bool {
+	return storageLevels[kind] > storageLevels[n.Kind]
+}
+
+type CreateStorageNodeInput struct {
+	ParentId *int   `json:"parent_id"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Capacity *int   `json:"capacity"`
+}
+
+func (input *CreateStorageNodeInput) IsValid() error {
+	var err error
+
+	_, validKind := storageLevels[input.Kind]
+	switch {
+	case !validKind:
+		err = fmt.Errorf("invalid storage kind")
+	case input.Name == "":
+		err = fmt.Errorf("invalid storage name")
+	case input.Capacity != nil && *input.Capacity < 0:
+		err = fmt.Errorf("invalid storage capacity")
+	case input.ParentId == nil && input.Kind != StorageMuseum:
+		err = fmt.Errorf("top-level storage must be a museum")
+	}
+
+	return err
+}
+
+type StorageMove struct {
+	Id          int       `db:"id"`
+	ArtifactId  int       `json:"artifact_id" db:"artifact_id"`
+	FromNodeId  *int      `json:"from_node_id" db:"from_node_id"`
+	ToNodeId    int       `json:"to_node_id" db:"to_node_id"`
+	Reason      string    `json:"reason" db:"reason"`
+	MovedAt     time.Time `json:"moved_at" db:"moved_at"`
+	MovedById   int       `json:"moved_by_id" db:"moved_by_id"`
+	MovedByRole string    `json:"moved_by_role" db:"moved_by_role"`
+}
+
+type StorageMoves []*StorageMove
+
+type MoveArtifactInput struct {
+	NodeId int    `json:"node_id"`
+	Reason string `json:"reason"`
+}
+
+type StoredArtifact struct {
+	ArtifactId    int    `json:"artifact_id" db:"artifact_id"`
+	Name          string `json:"name" db:"name"`
+	StorageNodeId *int   `json:"storage_node_id" db:"storage_node_id"`
+	Path          string `json:"path" db:"path"`
+}
+
+type StoredArtifacts []*StoredArtifact
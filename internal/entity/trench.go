@@ -0,0 +1,28 @@
+package entity
+
+import "fmt"
+
+type Trench struct {
+	Id          int    `db:"id"`
+	LocationId  int    `json:"location_id" db:"location_id"`
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+}
+
+type Trenches []*Trench
+
+type CreateTrenchInput struct {
+	LocationId  int    `json:"location_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (input *CreateTrenchInput) IsValid() error {
+	var err error
+
+	if input.Name == "" {
+		err = fmt.Errorf("invalid trench name")
+	}
+
+	return err
+}
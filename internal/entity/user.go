@@ -0,0 +1,40 @@
+package entity
+
+import "fmt"
+
+const (
+	RoleMember  = "member"
+	RoleLeader  = "leader"
+	RoleCurator = "curator"
+	RoleAdmin   = "admin"
+)
+
+type User struct {
+	Id   int    `json:"id"`
+	Role string `json:"role"`
+}
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+type SignInInput struct {
+	Role     string `json:"role"`
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+func (input *SignInInput) IsValid() error {
+	var err error
+
+	switch {
+	case input.Role != RoleMember && input.Role != RoleLeader && input.Role != RoleCurator && input.Role != RoleAdmin:
+		err = fmt.Errorf("invalid role")
+	case input.Login == "":
+		err = fmt.Errorf("invalid login")
+	case input.Password == "":
+		err = fmt.Errorf("invalid password")
+	}
+
+	return err
+}
@@ -0,0 +1,38 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	pkgErrors "github.com/pkg/errors"
+)
+
+type AdminRepo struct {
+}
+
+func NewAdminRepo() *AdminRepo {
+	return &AdminRepo{}
+}
+
+func (r *AdminRepo) GetAdminByLogin(ctx context.Context, client any, login string) (*entity.Admin, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, login, password
+		FROM admins
+		WHERE login = $1
+	`
+	var a entity.Admin
+	err := pgClient.QueryRow(ctx, q, login).Scan(&a.Id, &a.Name, &a.Login, &a.Password)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("AdminRepo GetAdminByLogin: %v", err)
+	}
+
+	return &a, nil
+}
@@ -20,12 +20,16 @@ func NewArtifactRepo() *ArtifactRepo {
 func (r *ArtifactRepo) GetArtifactById(ctx context.Context, client any, id int) (*entity.Artifact, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, location_id, name, age
+		SELECT id, location_id, context_id, expedition_id, found_by_member_id, found_on, find_context,
+			find_latitude, find_longitude, find_elevation, find_datum, name, catalog_number,
+			earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id, radiocarbon_age_bp, radiocarbon_sigma, storage_node_id, responsible_curator_id
 		FROM artifacts
 		WHERE id = $1
 	`
 	var ar entity.Artifact
-	err := pgClient.QueryRow(ctx, q, id).Scan(&ar.Id, &ar.LocationId, &ar.Name, &ar.Age)
+	err := pgClient.QueryRow(ctx, q, id).Scan(&ar.Id, &ar.LocationId, &ar.ContextId, &ar.ExpeditionId, &ar.FoundByMemberId, &ar.FoundOn, &ar.FindContext,
+		&ar.FindSpot.Latitude, &ar.FindSpot.Longitude, &ar.FindSpot.Elevation, &ar.FindSpot.Datum, &ar.Name, &ar.CatalogNumber,
+		&ar.Dating.EarliestYear, &ar.Dating.LatestYear, &ar.Dating.CentralYear, &ar.Dating.ErrorYears, &ar.Dating.Method, &ar.Dating.Confidence, &ar.Dating.PeriodId, &ar.Dating.RadiocarbonAgeBP, &ar.Dating.RadiocarbonSigma, &ar.StorageNodeId, &ar.ResponsibleCuratorId)
 
 	if err != nil {
 		if pkgErrors.Is(err, pgx.ErrNoRows) {
@@ -37,10 +41,107 @@ func (r *ArtifactRepo) GetArtifactById(ctx context.Context, client any, id int)
 	return &ar, nil
 }
 
+func (r *ArtifactRepo) GetArtifactsByIds(ctx context.Context, client any, ids []int) (entity.Artifacts, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, location_id, context_id, expedition_id, found_by_member_id, found_on, find_context,
+			find_latitude, find_longitude, find_elevation, find_datum, name, catalog_number,
+			earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id, radiocarbon_age_bp, radiocarbon_sigma, storage_node_id, responsible_curator_id
+		FROM artifacts
+		WHERE id = ANY($1::int[])
+		ORDER BY array_position($1::int[], id)
+	`
+	rows, err := pgClient.Query(ctx, q, ids)
+	if err != nil {
+		return nil, fmt.Errorf("ArtifactRepo GetArtifactsByIds: %v", err)
+	}
+
+	artifacts := make(entity.Artifacts, 0)
+	for rows.Next() {
+		var ar entity.Artifact
+
+		err = rows.Scan(&ar.Id, &ar.LocationId, &ar.ContextId, &ar.ExpeditionId, &ar.FoundByMemberId, &ar.FoundOn, &ar.FindContext,
+			&ar.FindSpot.Latitude, &ar.FindSpot.Longitude, &ar.FindSpot.Elevation, &ar.FindSpot.Datum, &ar.Name, &ar.CatalogNumber,
+			&ar.Dating.EarliestYear, &ar.Dating.LatestYear, &ar.Dating.CentralYear, &ar.Dating.ErrorYears, &ar.Dating.Method, &ar.Dating.Confidence, &ar.Dating.PeriodId, &ar.Dating.RadiocarbonAgeBP, &ar.Dating.RadiocarbonSigma, &ar.StorageNodeId, &ar.ResponsibleCuratorId)
+		if err != nil {
+			return nil, fmt.Errorf("ArtifactRepo GetArtifactsByIds: %v", err)
+		}
+
+		artifacts = append(artifacts, &ar)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ArtifactRepo GetArtifactsByIds: %v", err)
+	}
+
+	return artifacts, nil
+}
+
+func (r *ArtifactRepo) GetArtifactByCatalogNumber(ctx context.Context, client any, number *entity.CatalogNumber) (*entity.Artifact, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, location_id, context_id, expedition_id, found_by_member_id, found_on, find_context,
+			find_latitude, find_longitude, find_elevation, find_datum, name, catalog_number,
+			earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id, radiocarbon_age_bp, radiocarbon_sigma, storage_node_id, responsible_curator_id
+		FROM artifacts
+		WHERE catalog_location_id = $1 AND catalog_year = $2 AND catalog_seq = $3
+	`
+	var ar entity.Artifact
+	err := pgClient.QueryRow(ctx, q, number.LocationId, number.Year, number.Seq).Scan(&ar.Id, &ar.LocationId, &ar.ContextId, &ar.ExpeditionId, &ar.FoundByMemberId, &ar.FoundOn, &ar.FindContext,
+		&ar.FindSpot.Latitude, &ar.FindSpot.Longitude, &ar.FindSpot.Elevation, &ar.FindSpot.Datum, &ar.Name, &ar.CatalogNumber,
+		&ar.Dating.EarliestYear, &ar.Dating.LatestYear, &ar.Dating.CentralYear, &ar.Dating.ErrorYears, &ar.Dating.Method, &ar.Dating.Confidence, &ar.Dating.PeriodId, &ar.Dating.RadiocarbonAgeBP, &ar.Dating.RadiocarbonSigma, &ar.StorageNodeId, &ar.ResponsibleCuratorId)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("ArtifactRepo GetArtifactByCatalogNumber: %v", err)
+	}
+
+	return &ar, nil
+}
+
+func (r *ArtifactRepo) GetCatalogCandidates(ctx context.Context, client any, number *entity.CatalogNumber, limit int) (entity.CatalogCandidates, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, catalog_number, name
+		FROM artifacts
+		WHERE (catalog_location_id = $1 AND catalog_year = $2)
+			OR (catalog_location_id = $1 AND catalog_seq = $3)
+			OR (catalog_year = $2 AND catalog_seq = $3)
+		ORDER BY (catalog_location_id = $1)::int + (catalog_year = $2)::int + (catalog_seq = $3)::int DESC, abs(catalog_seq - $3), id
+		LIMIT $4
+	`
+	rows, err := pgClient.Query(ctx, q, number.LocationId, number.Year, number.Seq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ArtifactRepo GetCatalogCandidates: %v", err)
+	}
+
+	candidates := make(entity.CatalogCandidates, 0)
+	for rows.Next() {
+		var c entity.CatalogCandidate
+
+		err = rows.Scan(&c.ArtifactId, &c.CatalogNumber, &c.Name)
+		if err != nil {
+			return nil, fmt.Errorf("ArtifactRepo GetCatalogCandidates: %v", err)
+		}
+
+		candidates = append(candidates, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ArtifactRepo GetCatalogCandidates: %v", err)
+	}
+
+	return candidates, nil
+}
+
 func (r *ArtifactRepo) GetLocationArtifacts(ctx context.Context, client any, locationId int) (entity.Artifacts, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, location_id, name, age
+		SELECT id, location_id, context_id, expedition_id, found_by_member_id, found_on, find_context,
+			find_latitude, find_longitude, find_elevation, find_datum, name, catalog_number,
+			earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id, radiocarbon_age_bp, radiocarbon_sigma, storage_node_id, responsible_curator_id
 		FROM artifacts
 		WHERE location_id = $1
 	`
@@ -53,7 +154,9 @@ func (r *ArtifactRepo) GetLocationArtifacts(ctx context.Context, client any, loc
 	for rows.Next() {
 		var ar entity.Artifact
 
-		err = rows.Scan(&ar.Id, &ar.LocationId, &ar.Name, &ar.Age)
+		err = rows.Scan(&ar.Id, &ar.LocationId, &ar.ContextId, &ar.ExpeditionId, &ar.FoundByMemberId, &ar.FoundOn, &ar.FindContext,
+			&ar.FindSpot.Latitude, &ar.FindSpot.Longitude, &ar.FindSpot.Elevation, &ar.FindSpot.Datum, &ar.Name, &ar.CatalogNumber,
+			&ar.Dating.EarliestYear, &ar.Dating.LatestYear, &ar.Dating.CentralYear, &ar.Dating.ErrorYears, &ar.Dating.Method, &ar.Dating.Confidence, &ar.Dating.PeriodId, &ar.Dating.RadiocarbonAgeBP, &ar.Dating.RadiocarbonSigma, &ar.StorageNodeId, &ar.ResponsibleCuratorId)
 		if err != nil {
 			return nil, fmt.Errorf("ArtifactRepo GetLocationArtifacts: %v", err)
 		}
@@ -68,13 +171,94 @@ func (r *ArtifactRepo) GetLocationArtifacts(ctx context.Context, client any, loc
 	return artifacts, nil
 }
 
-func (r *ArtifactRepo) GetAllArtifacts(ctx context.Context, client any) (entity.Artifacts, error) {
+func (r *ArtifactRepo) GetExpeditionArtifacts(ctx context.Context, client any, expeditionId int) (entity.Artifacts, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, location_id, context_id, expedition_id, found_by_member_id, found_on, find_context,
+			find_latitude, find_longitude, find_elevation, find_datum, name, catalog_number,
+			earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id, radiocarbon_age_bp, radiocarbon_sigma, storage_node_id, responsible_curator_id
+		FROM artifacts
+		WHERE expedition_id = $1
+		ORDER BY found_on, id
+	`
+	rows, err := pgClient.Query(ctx, q, expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("ArtifactRepo GetExpeditionArtifacts: %v", err)
+	}
+
+	artifacts := make(entity.Artifacts, 0)
+	for rows.Next() {
+		var ar entity.Artifact
+
+		err = rows.Scan(&ar.Id, &ar.LocationId, &ar.ContextId, &ar.ExpeditionId, &ar.FoundByMemberId, &ar.FoundOn, &ar.FindContext,
+			&ar.FindSpot.Latitude, &ar.FindSpot.Longitude, &ar.FindSpot.Elevation, &ar.FindSpot.Datum, &ar.Name, &ar.CatalogNumber,
+			&ar.Dating.EarliestYear, &ar.Dating.LatestYear, &ar.Dating.CentralYear, &ar.Dating.ErrorYears, &ar.Dating.Method, &ar.Dating.Confidence, &ar.Dating.PeriodId, &ar.Dating.RadiocarbonAgeBP, &ar.Dating.RadiocarbonSigma, &ar.StorageNodeId, &ar.ResponsibleCuratorId)
+		if err != nil {
+			return nil, fmt.Errorf("ArtifactRepo GetExpeditionArtifacts: %v", err)
+		}
+
+		artifacts = append(artifacts, &ar)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ArtifactRepo GetExpeditionArtifacts: %v", err)
+	}
+
+	return artifacts, nil
+}
+
+func (r *ArtifactRepo) GetContextArtifacts(ctx context.Context, client any, contextId int) (entity.Artifacts, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, location_id, name, age
+		SELECT id, location_id, context_id, expedition_id, found_by_member_id, found_on, find_context,
+			find_latitude, find_longitude, find_elevation, find_datum, name, catalog_number,
+			earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id, radiocarbon_age_bp, radiocarbon_sigma, storage_node_id, responsible_curator_id
 		FROM artifacts
+		WHERE context_id = $1
 	`
-	rows, err := pgClient.Query(ctx, q)
+	rows, err := pgClient.Query(ctx, q, contextId)
+	if err != nil {
+		return nil, fmt.Errorf("ArtifactRepo GetContextArtifacts: %v", err)
+	}
+
+	artifacts := make(entity.Artifacts, 0)
+	for rows.Next() {
+		var ar entity.Artifact
+
+		err = rows.Scan(&ar.Id, &ar.LocationId, &ar.ContextId, &ar.ExpeditionId, &ar.FoundByMemberId, &ar.FoundOn, &ar.FindContext,
+			&ar.FindSpot.Latitude, &ar.FindSpot.Longitude, &ar.FindSpot.Elevation, &ar.FindSpot.Datum, &ar.Name, &ar.CatalogNumber,
+			&ar.Dating.EarliestYear, &ar.Dating.LatestYear, &ar.Dating.CentralYear, &ar.Dating.ErrorYears, &ar.Dating.Method, &ar.Dating.Confidence, &ar.Dating.PeriodId, &ar.Dating.RadiocarbonAgeBP, &ar.Dating.RadiocarbonSigma, &ar.StorageNodeId, &ar.ResponsibleCuratorId)
+		if err != nil {
+			return nil, fmt.Errorf("ArtifactRepo GetContextArtifacts: %v", err)
+		}
+
+		artifacts = append(artifacts, &ar)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ArtifactRepo GetContextArtifacts: %v", err)
+	}
+
+	return artifacts, nil
+}
+
+func (r *ArtifactRepo) GetAllArtifacts(ctx context.Context, client any, filter *entity.ArtifactFilter) (entity.Artifacts, error) {
+	pgClient := client.(postgres.Client)
+	var cond conditions
+	if filter != nil {
+		cond.addBoundingBox("find_latitude", "find_longitude", filter.BoundingBox)
+		cond.addYearOverlap("earliest_year", "latest_year", filter.DatedFrom, filter.DatedTo)
+		if filter.CuratorId != nil {
+			cond.add("responsible_curator_id = %s", *filter.CuratorId)
+		}
+	}
+	q := `
+		SELECT id, location_id, context_id, expedition_id, found_by_member_id, found_on, find_context,
+			find_latitude, find_longitude, find_elevation, find_datum, name, catalog_number,
+			earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id, radiocarbon_age_bp, radiocarbon_sigma, storage_node_id, responsible_curator_id
+		FROM artifacts
+	` + cond.sql()
+	rows, err := pgClient.Query(ctx, q, cond.args...)
 	if err != nil {
 		return nil, fmt.Errorf("ArtifactRepo GetAllArtifacts: %v", err)
 	}
@@ -83,7 +267,9 @@ func (r *ArtifactRepo) GetAllArtifacts(ctx context.Context, client any) (entity.
 	for rows.Next() {
 		var ar entity.Artifact
 
-		err = rows.Scan(&ar.Id, &ar.LocationId, &ar.Name, &ar.Age)
+		err = rows.Scan(&ar.Id, &ar.LocationId, &ar.ContextId, &ar.ExpeditionId, &ar.FoundByMemberId, &ar.FoundOn, &ar.FindContext,
+			&ar.FindSpot.Latitude, &ar.FindSpot.Longitude, &ar.FindSpot.Elevation, &ar.FindSpot.Datum, &ar.Name, &ar.CatalogNumber,
+			&ar.Dating.EarliestYear, &ar.Dating.LatestYear, &ar.Dating.CentralYear, &ar.Dating.ErrorYears, &ar.Dating.Method, &ar.Dating.Confidence, &ar.Dating.PeriodId, &ar.Dating.RadiocarbonAgeBP, &ar.Dating.RadiocarbonSigma, &ar.StorageNodeId, &ar.ResponsibleCuratorId)
 		if err != nil {
 			return nil, fmt.Errorf("ArtifactRepo GetAllArtifacts: %v", err)
 		}
@@ -102,16 +288,62 @@ func (r *ArtifactRepo) CreateArtifact(ctx context.Context, client any, artifact
 	pgClient := client.(postgres.Client)
 	q := `
 		INSERT INTO artifacts
-		    (location_id, name, age) 
+		    (location_id, context_id, expedition_id, found_by_member_id, found_on, find_context,
+		     find_latitude, find_longitude, find_elevation, find_datum, name,
+		     earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id,
+		     radiocarbon_age_bp, radiocarbon_sigma) 
 		VALUES 
-		    ($1, $2, $3) 
+		    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20) 
 		RETURNING id
 	`
 	var id int
-	err := pgClient.QueryRow(ctx, q, artifact.LocationId, artifact.Name, artifact.Age).Scan(&id)
+	err := pgClient.QueryRow(ctx, q, artifact.LocationId, artifact.ContextId, artifact.ExpeditionId, artifact.FoundByMemberId, artifact.FoundOn,
+		artifact.FindContext, artifact.FindSpot.Latitude, artifact.FindSpot.Longitude, artifact.FindSpot.Elevation, artifact.FindSpot.Datum,
+		artifact.Name, artifact.Dating.EarliestYear, artifact.Dating.LatestYear, artifact.Dating.CentralYear, artifact.Dating.ErrorYears,
+		artifact.Dating.Method, artifact.Dating.Confidence, artifact.Dating.PeriodId, artifact.Dating.RadiocarbonAgeBP, artifact.Dating.RadiocarbonSigma).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("ArtifactRepo CreateArtifact: %v", err)
 	}
 
 	return id, nil
 }
+
+func (r *ArtifactRepo) UpdateArtifactDating(ctx context.Context, client any, id int, dating *entity.Dating) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		UPDATE artifacts
+		SET
+			earliest_year = $1, latest_year = $2, central_year = $3, error_years = $4,
+			dating_method = $5, dating_confidence = $6, period_id = $7, radiocarbon_age_bp = $8, radiocarbon_sigma = $9,
+			radiocarbon_sample_id = NULL, prior_dating = NULL
+		WHERE id = $10
+	`
+	commandTag, err := pgClient.Exec(ctx, q, dating.EarliestYear, dating.LatestYear, dating.CentralYear, dating.ErrorYears,
+		dating.Method, dating.Confidence, dating.PeriodId, dating.RadiocarbonAgeBP, dating.RadiocarbonSigma, id)
+	if err != nil {
+		return fmt.Errorf("ArtifactRepo UpdateArtifactDating: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ArtifactRepo) UpdateArtifactCurator(ctx context.Context, client any, id int, curatorId *int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		UPDATE artifacts
+		SET responsible_curator_id = $1
+		WHERE id = $2
+	`
+	commandTag, err := pgClient.Exec(ctx, q, curatorId, id)
+	if err != nil {
+		return fmt.Errorf("ArtifactRepo UpdateArtifactCurator: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,442 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"sort"
+)
+
+type AttachmentRepo struct {
+}
+
+func NewAttachmentRepo() *AttachmentRepo {
+	return &AttachmentRepo{}
+}
+
+var attachmentParentColumns = map[string]string{
+	entity.AttachmentParentArtifact:     "artifact_id",
+	entity.AttachmentParentLocation:     "location_id",
+	entity.AttachmentParentExpedition:   "expedition_id",
+	entity.AttachmentParentJournalEntry: "journal_entry_id",
+}
+
+const attachmentQuery = `
+	SELECT a.id,
+	       CASE
+	           WHEN a.artifact_id IS NOT NULL THEN 'artifact'
+	           WHEN a.location_id IS NOT NULL THEN 'location'
+	           WHEN a.expedition_id IS NOT NULL THEN 'expedition'
+	           ELSE 'journal_entry'
+	       END,
+	       coalesce(a.artifact_id, a.location_id, a.expedition_id, a.journal_entry_id),
+	       a.kind, a.file_name, b.content_type, b.size, b.sha256, a.description,
+	       a.uploaded_by_role, a.uploaded_by_id, a.uploaded_at, a.processing_status, a.processing_error,
+	       a.image_width, a.image_height, a.captured_at, a.camera_make, a.camera_model,
+	       a.gps_latitude, a.gps_longitude, a.gps_altitude
+	FROM attachments a
+	JOIN blobs b ON b.sha256 = a.blob_sha256
+`
+
+func (r *AttachmentRepo) GetAttachmentById(ctx context.Context, client any, id int) (*entity.Attachment, error) {
+	pgClient := client.(postgres.Client)
+	q := attachmentQuery + `
+		WHERE a.id = $1
+	`
+	attachments, err := r.queryAttachments(ctx, pgClient, "GetAttachmentById", q, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, repoerrs.ErrNotFound
+	}
+
+	return attachments[0], nil
+}
+
+func (r *AttachmentRepo) GetParentAttachments(ctx context.Context, client any, parentType string, parentId int) (entity.Attachments, error) {
+	pgClient := client.(postgres.Client)
+	column, ok := attachmentParentColumns[parentType]
+	if !ok {
+		return nil, fmt.Errorf("AttachmentRepo GetParentAttachments: unknown parent type %q", parentType)
+	}
+
+	q := attachmentQuery + fmt.Sprintf(`
+		WHERE a.%s = $1
+		ORDER BY a.uploaded_at, a.id
+	`, column)
+	return r.queryAttachments(ctx, pgClient, "GetParentAttachments", q, parentId)
+}
+
+func (r *AttachmentRepo) CreateAttachment(ctx context.Context, client any, attachment *entity.Attachment, storeBlob func(hash string) error) (int, error) {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("AttachmentRepo CreateAttachment: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockBlobs(ctx, tx, []string{attachment.Sha256}); err != nil {
+		return 0, fmt.Errorf("AttachmentRepo CreateAttachment: %v", err)
+	}
+
+	q := `
+		WITH blob AS (
+			INSERT INTO blobs
+			    (sha256, size, content_type)
+			VALUES
+			    ($1, $2, $3)
+			ON CONFLICT (sha256) DO NOTHING
+		)
+		INSERT INTO attachments
+		    (blob_sha256, artifact_id, location_id, expedition_id, journal_entry_id,
+		     kind, file_name, description, uploaded_by_role, uploaded_by_id, processing_status)
+		VALUES
+		    ($1, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`
+	parents := make(map[string]*int, len(attachmentParentColumns))
+	parents[attachment.ParentType] = &attachment.ParentId
+
+	var id int
+	err = tx.QueryRow(ctx, q, attachment.Sha256, attachment.Size, attachment.ContentType,
+		parents[entity.AttachmentParentArtifact], parents[entity.AttachmentParentLocation],
+		parents[entity.AttachmentParentExpedition], parents[entity.AttachmentParentJournalEntry],
+		attachment.Kind, attachment.FileName, attachment.Description, attachment.UploadedByRole, attachment.UploadedById,
+		attachment.ProcessingStatus).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			switch pgErr.Code {
+			case "23505":
+				return 0, repoerrs.ErrAlreadyExists
+			case "23503":
+				return 0, repoerrs.ErrNotFound
+			}
+		}
+		return 0, fmt.Errorf("AttachmentRepo CreateAttachment: %v", err)
+	}
+
+	if err = storeBlob(attachment.Sha256); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("AttachmentRepo CreateAttachment: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *AttachmentRepo) DeleteAttachment(ctx context.Context, client any, id int, removeBlob func(hash string) error) error {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepo DeleteAttachment: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := `
+		WITH thumbnails AS (
+			SELECT blob_sha256
+			FROM attachment_thumbnails
+			WHERE attachment_id = $1
+		), removed AS (
+			DELETE FROM attachments
+			WHERE id = $1
+			RETURNING blob_sha256
+		)
+		SELECT blob_sha256 FROM removed
+		UNION
+		SELECT blob_sha256 FROM thumbnails WHERE EXISTS (SELECT 1 FROM removed)
+	`
+	hashes, err := queryBlobHashes(ctx, tx, q, id)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepo DeleteAttachment: %v", err)
+	}
+	if len(hashes) == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	if err = lockBlobs(ctx, tx, hashes); err != nil {
+		return fmt.Errorf("AttachmentRepo DeleteAttachment: %v", err)
+	}
+
+	released, err := deleteUnreferencedBlobs(ctx, tx, hashes)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepo DeleteAttachment: %v", err)
+	}
+
+	for _, hash := range released {
+		if err = removeBlob(hash); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("AttachmentRepo DeleteAttachment: %v", err)
+	}
+
+	return nil
+}
+
+func lockBlobs(ctx context.Context, tx pgx.Tx, hashes []string) error {
+	sorted := append([]string(nil), hashes...)
+	sort.Strings(sorted)
+
+	for _, hash := range sorted {
+		_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deleteUnreferencedBlobs(ctx context.Context, tx pgx.Tx, hashes []string) ([]string, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer savepoint.Rollback(ctx)
+
+	q := `
+		DELETE FROM blobs b
+		WHERE b.sha256 = ANY($1::text[])
+			AND NOT EXISTS (SELECT 1 FROM attachments a WHERE a.blob_sha256 = b.sha256)
+			AND NOT EXISTS (SELECT 1 FROM attachment_thumbnails t WHERE t.blob_sha256 = b.sha256)
+		RETURNING b.sha256
+	`
+	released, err := queryBlobHashes(ctx, savepoint, q, hashes)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok && pgErr.Code == "23503" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return released, savepoint.Commit(ctx)
+}
+
+func queryBlobHashes(ctx context.Context, tx pgx.Tx, q string, args ...any) ([]string, error) {
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0)
+	for rows.Next() {
+		var hash string
+
+		err = rows.Scan(&hash)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+func (r *AttachmentRepo) GetAttachmentsByProcessingStatus(ctx context.Context, client any, statuses []string) (entity.Attachments, error) {
+	pgClient := client.(postgres.Client)
+	q := attachmentQuery + `
+		WHERE a.processing_status = ANY($1::text[])
+		ORDER BY a.uploaded_at, a.id
+	`
+	return r.queryAttachments(ctx, pgClient, "GetAttachmentsByProcessingStatus", q, statuses)
+}
+
+func (r *AttachmentRepo) GetAttachmentThumbnails(ctx context.Context, client any, attachmentId int) (entity.Thumbnails, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT t.size, t.width, t.height, b.size, t.blob_sha256
+		FROM attachment_thumbnails t
+		JOIN blobs b ON b.sha256 = t.blob_sha256
+		WHERE t.attachment_id = $1
+		ORDER BY t.width * t.height
+	`
+	rows, err := pgClient.Query(ctx, q, attachmentId)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentRepo GetAttachmentThumbnails: %v", err)
+	}
+
+	thumbnails := make(entity.Thumbnails, 0)
+	for rows.Next() {
+		var t entity.Thumbnail
+
+		err = rows.Scan(&t.Size, &t.Width, &t.Height, &t.Bytes, &t.Sha256)
+		if err != nil {
+			return nil, fmt.Errorf("AttachmentRepo GetAttachmentThumbnails: %v", err)
+		}
+
+		thumbnails = append(thumbnails, &t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("AttachmentRepo GetAttachmentThumbnails: %v", err)
+	}
+
+	return thumbnails, nil
+}
+
+func (r *AttachmentRepo) StartAttachmentProcessing(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		UPDATE attachments
+		SET processing_status = 'processing', processing_error = ''
+		WHERE id = $1 AND processing_status IN ('pending', 'processing')
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepo StartAttachmentProcessing: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrConflict
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepo) CompleteAttachmentProcessing(ctx context.Context, client any, id int, metadata *entity.PhotoMetadata, thumbnails entity.Thumbnails,
+	storeBlob func(hash string) error) error {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepo CompleteAttachmentProcessing: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := `
+		WITH thumbnail_blobs AS (
+			INSERT INTO blobs
+			    (sha256, size, content_type)
+			SELECT t.sha256, t.bytes, 'image/jpeg'
+			FROM unnest($11::text[], $14::bigint[]) AS t(sha256, bytes)
+			ON CONFLICT (sha256) DO NOTHING
+		), stored AS (
+			INSERT INTO attachment_thumbnails
+			    (attachment_id, size, width, height, blob_sha256)
+			SELECT $1, t.size, t.width, t.height, t.sha256
+			FROM unnest($10::text[], $12::int[], $13::int[], $11::text[]) AS t(size, width, height, sha256)
+			ON CONFLICT (attachment_id, size) DO UPDATE
+			SET width = excluded.width, height = excluded.height, blob_sha256 = excluded.blob_sha256
+		)
+		UPDATE attachments
+		SET processing_status = 'done', processing_error = '',
+		    image_width = $2, image_height = $3, captured_at = $4, camera_make = $5, camera_model = $6,
+		    gps_latitude = $7, gps_longitude = $8, gps_altitude = $9
+		WHERE id = $1
+	`
+	sizes := make([]string, 0, len(thumbnails))
+	hashes := make([]string, 0, len(thumbnails))
+	widths := make([]int, 0, len(thumbnails))
+	heights := make([]int, 0, len(thumbnails))
+	lengths := make([]int64, 0, len(thumbnails))
+	for _, t := range thumbnails {
+		sizes = append(sizes, t.Size)
+		hashes = append(hashes, t.Sha256)
+		widths = append(widths, t.Width)
+		heights = append(heights, t.Height)
+		lengths = append(lengths, t.Bytes)
+	}
+
+	if err = lockBlobs(ctx, tx, hashes); err != nil {
+		return fmt.Errorf("AttachmentRepo CompleteAttachmentProcessing: %v", err)
+	}
+
+	commandTag, err := tx.Exec(ctx, q, id, metadata.Width, metadata.Height, metadata.CapturedAt, metadata.CameraMake,
+		metadata.CameraModel, metadata.Gps.Latitude, metadata.Gps.Longitude, metadata.Gps.Elevation,
+		sizes, hashes, widths, heights, lengths)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepo CompleteAttachmentProcessing: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	for _, hash := range hashes {
+		if err = storeBlob(hash); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("AttachmentRepo CompleteAttachmentProcessing: %v", err)
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepo) FailAttachmentProcessing(ctx context.Context, client any, id int, message string) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		UPDATE attachments
+		SET processing_status = 'failed', processing_error = $2
+		WHERE id = $1
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id, message)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepo FailAttachmentProcessing: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepo) queryAttachments(ctx context.Context, pgClient postgres.Client, method string, q string, args ...any) (entity.Attachments, error) {
+	rows, err := pgClient.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentRepo %s: %v", method, err)
+	}
+
+	attachments := make(entity.Attachments, 0)
+	for rows.Next() {
+		var a entity.Attachment
+		var m entity.PhotoMetadata
+		var width, height *int
+		var cameraMake, cameraModel *string
+
+		err = rows.Scan(&a.Id, &a.ParentType, &a.ParentId, &a.Kind, &a.FileName, &a.ContentType, &a.Size, &a.Sha256,
+			&a.Description, &a.UploadedByRole, &a.UploadedById, &a.UploadedAt, &a.ProcessingStatus, &a.ProcessingError,
+			&width, &height, &m.CapturedAt, &cameraMake, &cameraModel,
+			&m.Gps.Latitude, &m.Gps.Longitude, &m.Gps.Elevation)
+		if err != nil {
+			return nil, fmt.Errorf("AttachmentRepo %s: %v", method, err)
+		}
+
+		if width != nil && height != nil {
+			m.Width, m.Height = *width, *height
+			if cameraMake != nil {
+				m.CameraMake = *cameraMake
+			}
+			if cameraModel != nil {
+				m.CameraModel = *cameraModel
+			}
+			m.Gps = m.Gps.WithDefaultDatum()
+			a.Metadata = &m
+		}
+
+		attachments = append(attachments, &a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("AttachmentRepo %s: %v", method, err)
+	}
+
+	return attachments, nil
+}
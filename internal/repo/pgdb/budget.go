@@ -0,0 +1,315 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/decimal"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pkgErrors "github.com/pkg/errors"
+	"time"
+)
+
+type BudgetRepo struct {
+}
+
+func NewBudgetRepo() *BudgetRepo {
+	return &BudgetRepo{}
+}
+
+func (r *BudgetRepo) GetExpeditionBudget(ctx context.Context, client any, expeditionId int) (*entity.Budget, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT b.expedition_id, b.currency, bl.category, bl.planned
+		FROM expedition_budgets b
+		LEFT JOIN budget_lines bl ON bl.expedition_id = b.expedition_id
+		WHERE b.expedition_id = $1
+		ORDER BY bl.category
+	`
+	rows, err := pgClient.Query(ctx, q, expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("BudgetRepo GetExpeditionBudget: %v", err)
+	}
+
+	var budget *entity.Budget
+	for rows.Next() {
+		var b entity.Budget
+		var category *string
+		var planned decimal.Decimal
+
+		err = rows.Scan(&b.ExpeditionId, &b.Currency, &category, &planned)
+		if err != nil {
+			return nil, fmt.Errorf("BudgetRepo GetExpeditionBudget: %v", err)
+		}
+
+		if budget == nil {
+			b.Lines = make(entity.BudgetLines, 0)
+			budget = &b
+		}
+		if category != nil {
+			budget.Lines = append(budget.Lines, &entity.BudgetLine{Category: *category, Planned: planned})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("BudgetRepo GetExpeditionBudget: %v", err)
+	}
+	if budget == nil {
+		return nil, repoerrs.ErrNotFound
+	}
+
+	return budget, nil
+}
+
+func (r *BudgetRepo) SetExpeditionBudget(ctx context.Context, client any, budget *entity.Budget) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH budget AS (
+			INSERT INTO expedition_budgets
+			    (expedition_id, currency)
+			VALUES
+			    ($1, $2)
+			ON CONFLICT (expedition_id) DO UPDATE
+			SET currency = excluded.currency
+			WHERE expedition_budgets.currency = excluded.currency
+				OR NOT EXISTS (SELECT 1 FROM expenses WHERE expedition_id = $1)
+			RETURNING expedition_id
+		), removed AS (
+			DELETE FROM budget_lines bl
+			USING budget
+			WHERE bl.expedition_id = budget.expedition_id AND NOT (bl.category = ANY($3::text[]))
+		), upserted AS (
+			INSERT INTO budget_lines
+			    (expedition_id, category, planned)
+			SELECT budget.expedition_id, l.category, l.planned::numeric
+			FROM budget, unnest($3::text[], $4::text[]) AS l(category, planned)
+			ON CONFLICT (expedition_id, category) DO UPDATE
+			SET planned = excluded.planned
+		)
+		SELECT count(*) FROM budget
+	`
+	categories := make([]string, 0, len(budget.Lines))
+	planned := make([]string, 0, len(budget.Lines))
+	for _, line := range budget.Lines {
+		categories = append(categories, line.Category)
+		planned = append(planned, line.Planned.String())
+	}
+
+	var count int
+	err := pgClient.QueryRow(ctx, q, budget.ExpeditionId, budget.Currency, categories, planned).Scan(&count)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23503" {
+				if pgErr.TableName == "expedition_budgets" {
+					return repoerrs.ErrNotFound
+				}
+				return repoerrs.ErrInUse
+			}
+		}
+		return fmt.Errorf("BudgetRepo SetExpeditionBudget: %v", err)
+	}
+	if count != 1 {
+		return repoerrs.ErrConflict
+	}
+
+	return nil
+}
+
+func (r *BudgetRepo) GetExpeditionExpenses(ctx context.Context, client any, expeditionId int) (entity.Expenses, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, expedition_id, category, amount, currency, exchange_rate, base_amount, spent_on,
+		       description, vendor, receipt_number, recorded_by_id, recorded_by_role, recorded_at
+		FROM expenses
+		WHERE expedition_id = $1
+		ORDER BY spent_on, id
+	`
+	rows, err := pgClient.Query(ctx, q, expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("BudgetRepo GetExpeditionExpenses: %v", err)
+	}
+
+	expenses := make(entity.Expenses, 0)
+	for rows.Next() {
+		var e entity.Expense
+
+		err = rows.Scan(&e.Id, &e.ExpeditionId, &e.Category, &e.Amount, &e.Currency, &e.ExchangeRate, &e.BaseAmount, &e.SpentOn,
+			&e.Description, &e.Vendor, &e.ReceiptNumber, &e.RecordedById, &e.RecordedByRole, &e.RecordedAt)
+		if err != nil {
+			return nil, fmt.Errorf("BudgetRepo GetExpeditionExpenses: %v", err)
+		}
+
+		expenses = append(expenses, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("BudgetRepo GetExpeditionExpenses: %v", err)
+	}
+
+	return expenses, nil
+}
+
+func (r *BudgetRepo) CreateExpense(ctx context.Context, client any, expense *entity.Expense) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO expenses
+		    (expedition_id, category, amount, currency, exchange_rate, spent_on, description, vendor, receipt_number, recorded_by_id, recorded_by_role)
+		VALUES
+		    ($1, $2, $3::numeric, $4, $5::numeric, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, expense.ExpeditionId, expense.Category, expense.Amount.String(), expense.Currency, expense.ExchangeRate.String(),
+		expense.SpentOn, expense.Description, expense.Vendor, expense.ReceiptNumber, expense.RecordedById, expense.RecordedByRole).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23503" {
+				return 0, repoerrs.ErrNotFound
+			}
+		}
+		return 0, fmt.Errorf("BudgetRepo CreateExpense: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *BudgetRepo) GetExchangeRate(ctx context.Context, client any, currency string, baseCurrency string, on time.Time) (*entity.ExchangeRate, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, currency, base_currency, rate, effective_on
+		FROM exchange_rates
+		WHERE currency = $1 AND base_currency = $2 AND effective_on <= $3
+		ORDER BY effective_on DESC
+		LIMIT 1
+	`
+	var er entity.ExchangeRate
+	err := pgClient.QueryRow(ctx, q, currency, baseCurrency, on).Scan(&er.Id, &er.Currency, &er.BaseCurrency, &er.Rate, &er.EffectiveOn)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("BudgetRepo GetExchangeRate: %v", err)
+	}
+
+	return &er, nil
+}
+
+func (r *BudgetRepo) GetAllExchangeRates(ctx context.Context, client any) (entity.ExchangeRates, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, currency, base_currency, rate, effective_on
+		FROM exchange_rates
+		ORDER BY base_currency, currency, effective_on DESC
+	`
+	rows, err := pgClient.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("BudgetRepo GetAllExchangeRates: %v", err)
+	}
+
+	rates := make(entity.ExchangeRates, 0)
+	for rows.Next() {
+		var er entity.ExchangeRate
+
+		err = rows.Scan(&er.Id, &er.Currency, &er.BaseCurrency, &er.Rate, &er.EffectiveOn)
+		if err != nil {
+			return nil, fmt.Errorf("BudgetRepo GetAllExchangeRates: %v", err)
+		}
+
+		rates = append(rates, &er)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("BudgetRepo GetAllExchangeRates: %v", err)
+	}
+
+	return rates, nil
+}
+
+func (r *BudgetRepo) CreateExchangeRate(ctx context.Context, client any, rate *entity.ExchangeRate) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO exchange_rates
+		    (currency, base_currency, rate, effective_on)
+		VALUES
+		    ($1, $2, $3::numeric, $4)
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, rate.Currency, rate.BaseCurrency, rate.Rate.String(), rate.EffectiveOn).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return 0, repoerrs.ErrAlreadyExists
+			}
+		}
+		return 0, fmt.Errorf("BudgetRepo CreateExchangeRate: %v", err)
+	}
+
+	return id, nil
+}
+
+const budgetReportQuery = `
+	SELECT bl.category, b.currency, sum(bl.planned), sum(coalesce(a.actual, 0)),
+		sum(bl.planned) - sum(coalesce(a.actual, 0))
+	FROM expedition_budgets b
+	JOIN expeditions x ON x.id = b.expedition_id
+	JOIN budget_lines bl ON bl.expedition_id = b.expedition_id
+	LEFT JOIN LATERAL (
+		SELECT sum(e.base_amount) AS actual
+		FROM expenses e
+		WHERE e.expedition_id = bl.expedition_id AND e.category = bl.category
+	) a ON true
+`
+
+func (r *BudgetRepo) GetExpeditionBudgetReport(ctx context.Context, client any, expeditionId int) (entity.BudgetReportLines, error) {
+	pgClient := client.(postgres.Client)
+	q := budgetReportQuery + `
+		WHERE b.expedition_id = $1
+		GROUP BY bl.category, b.currency
+		ORDER BY b.currency, bl.category
+	`
+	return r.queryBudgetReport(ctx, pgClient, "GetExpeditionBudgetReport", q, expeditionId)
+}
+
+func (r *BudgetRepo) GetLocationBudgetReport(ctx context.Context, client any, locationId int) (entity.BudgetReportLines, error) {
+	pgClient := client.(postgres.Client)
+	q := budgetReportQuery + `
+		WHERE x.location_id = $1
+		GROUP BY bl.category, b.currency
+		ORDER BY b.currency, bl.category
+	`
+	return r.queryBudgetReport(ctx, pgClient, "GetLocationBudgetReport", q, locationId)
+}
+
+func (r *BudgetRepo) queryBudgetReport(ctx context.Context, pgClient postgres.Client, method string, q string, args ...any) (entity.BudgetReportLines, error) {
+	rows, err := pgClient.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("BudgetRepo %s: %v", method, err)
+	}
+
+	lines := make(entity.BudgetReportLines, 0)
+	for rows.Next() {
+		var l entity.BudgetReportLine
+
+		err = rows.Scan(&l.Category, &l.Currency, &l.Planned, &l.Actual, &l.Variance)
+		if err != nil {
+			return nil, fmt.Errorf("BudgetRepo %s: %v", method, err)
+		}
+
+		lines = append(lines, &l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("BudgetRepo %s: %v", method, err)
+	}
+
+	return lines, nil
+}
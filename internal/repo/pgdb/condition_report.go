@@ -0,0 +1,133 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	pkgErrors "github.com/pkg/errors"
+	"time"
+)
+
+type ConditionReportRepo struct {
+}
+
+func NewConditionReportRepo() *ConditionReportRepo {
+	return &ConditionReportRepo{}
+}
+
+func (r *ConditionReportRepo) GetArtifactConditionReports(ctx context.Context, client any, artifactId int) (entity.ConditionReports, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, artifact_id, grade, damage_notes, treatments, materials, conservator, inspected_on, recorded_by_id, recorded_by_role
+		FROM condition_reports
+		WHERE artifact_id = $1
+		ORDER BY inspected_on, id
+	`
+	rows, err := pgClient.Query(ctx, q, artifactId)
+	if err != nil {
+		return nil, fmt.Errorf("ConditionReportRepo GetArtifactConditionReports: %v", err)
+	}
+
+	reports := make(entity.ConditionReports, 0)
+	for rows.Next() {
+		var cr entity.ConditionReport
+
+		err = rows.Scan(&cr.Id, &cr.ArtifactId, &cr.Grade, &cr.DamageNotes, &cr.Treatments, &cr.Materials, &cr.Conservator, &cr.InspectedOn,
+			&cr.RecordedById, &cr.RecordedByRole)
+		if err != nil {
+			return nil, fmt.Errorf("ConditionReportRepo GetArtifactConditionReports: %v", err)
+		}
+
+		reports = append(reports, &cr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ConditionReportRepo GetArtifactConditionReports: %v", err)
+	}
+
+	return reports, nil
+}
+
+func (r *ConditionReportRepo) GetCurrentCondition(ctx context.Context, client any, artifactId int) (*entity.ConditionReport, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, artifact_id, grade, damage_notes, treatments, materials, conservator, inspected_on, recorded_by_id, recorded_by_role
+		FROM condition_reports
+		WHERE artifact_id = $1
+		ORDER BY inspected_on DESC, id DESC
+		LIMIT 1
+	`
+	var cr entity.ConditionReport
+	err := pgClient.QueryRow(ctx, q, artifactId).Scan(&cr.Id, &cr.ArtifactId, &cr.Grade, &cr.DamageNotes, &cr.Treatments, &cr.Materials,
+		&cr.Conservator, &cr.InspectedOn, &cr.RecordedById, &cr.RecordedByRole)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("ConditionReportRepo GetCurrentCondition: %v", err)
+	}
+
+	return &cr, nil
+}
+
+func (r *ConditionReportRepo) CreateConditionReport(ctx context.Context, client any, report *entity.ConditionReport) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO condition_reports
+		    (artifact_id, grade, damage_notes, treatments, materials, conservator, inspected_on, recorded_by_id, recorded_by_role) 
+		VALUES 
+		    ($1, $2, $3, $4, $5, $6, $7, $8, $9) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, report.ArtifactId, report.Grade, report.DamageNotes, report.Treatments, report.Materials,
+		report.Conservator, report.InspectedOn, report.RecordedById, report.RecordedByRole).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("ConditionReportRepo CreateConditionReport: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *ConditionReportRepo) GetOverdueInspections(ctx context.Context, client any, inspectedBefore time.Time) (entity.OverdueInspections, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT a.id, a.name, a.location_id, cr.inspected_on, cr.grade
+		FROM artifacts a
+		LEFT JOIN LATERAL (
+			SELECT inspected_on, grade
+			FROM condition_reports
+			WHERE artifact_id = a.id
+			ORDER BY inspected_on DESC, id DESC
+			LIMIT 1
+		) cr ON true
+		WHERE cr.inspected_on IS NULL OR cr.inspected_on < $1
+		ORDER BY cr.inspected_on NULLS FIRST, a.id
+	`
+	rows, err := pgClient.Query(ctx, q, inspectedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("ConditionReportRepo GetOverdueInspections: %v", err)
+	}
+
+	inspections := make(entity.OverdueInspections, 0)
+	for rows.Next() {
+		var oi entity.OverdueInspection
+
+		err = rows.Scan(&oi.ArtifactId, &oi.Name, &oi.LocationId, &oi.LastInspectedOn, &oi.LastGrade)
+		if err != nil {
+			return nil, fmt.Errorf("ConditionReportRepo GetOverdueInspections: %v", err)
+		}
+
+		inspections = append(inspections, &oi)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ConditionReportRepo GetOverdueInspections: %v", err)
+	}
+
+	return inspections, nil
+}
@@ -0,0 +1,80 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/pkg/postgres"
+	"fmt"
+)
+
+var countryTables = map[string]string{
+	"locations":    `UPDATE locations SET country = $1 WHERE country = $2`,
+	"institutions": `UPDATE institutions SET country = $1 WHERE country = $2`,
+}
+
+type CountryRepo struct {
+}
+
+func NewCountryRepo() *CountryRepo {
+	return &CountryRepo{}
+}
+
+func (r *CountryRepo) GetCountryUsages(ctx context.Context, client any) (entity.CountryUsages, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT 'locations', country, count(*) FROM locations GROUP BY country
+		UNION ALL
+		SELECT 'institutions', country, count(*) FROM institutions WHERE country <> '' GROUP BY country
+		ORDER BY 1, 2
+	`
+	rows, err := pgClient.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("CountryRepo GetCountryUsages: %v", err)
+	}
+
+	usages := make(entity.CountryUsages, 0)
+	for rows.Next() {
+		var u entity.CountryUsage
+
+		err = rows.Scan(&u.Table, &u.Value, &u.Rows)
+		if err != nil {
+			return nil, fmt.Errorf("CountryRepo GetCountryUsages: %v", err)
+		}
+
+		usages = append(usages, &u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CountryRepo GetCountryUsages: %v", err)
+	}
+
+	return usages, nil
+}
+
+func (r *CountryRepo) ApplyCountryMappings(ctx context.Context, client any, mappings entity.CountryMappings) error {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("CountryRepo ApplyCountryMappings: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, m := range mappings {
+		q, ok := countryTables[m.Table]
+		if !ok {
+			return fmt.Errorf("CountryRepo ApplyCountryMappings: unknown table %q", m.Table)
+		}
+
+		commandTag, err := tx.Exec(ctx, q, m.Code, m.Value)
+		if err != nil {
+			return fmt.Errorf("CountryRepo ApplyCountryMappings: %v", err)
+		}
+		m.Rows = commandTag.RowsAffected()
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("CountryRepo ApplyCountryMappings: %v", err)
+	}
+
+	return nil
+}
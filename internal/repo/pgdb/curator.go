@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	pkgErrors "github.com/pkg/errors"
+	"time"
 )
 
 type CuratorRepo struct {
@@ -22,12 +23,12 @@ func NewCuratorRepo() *CuratorRepo {
 func (r *CuratorRepo) GetCuratorById(ctx context.Context, client any, id int) (*entity.Curator, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, name
+		SELECT id, name, email, phone, institution_id, login, password
 		FROM curators
 		WHERE id = $1
 	`
 	var c entity.Curator
-	err := pgClient.QueryRow(ctx, q, id).Scan(&c.Id, &c.Name)
+	err := pgClient.QueryRow(ctx, q, id).Scan(&c.Id, &c.Name, &c.Email, &c.Phone, &c.InstitutionId, &c.Login, &c.Password)
 
 	if err != nil {
 		if pkgErrors.Is(err, pgx.ErrNoRows) {
@@ -39,10 +40,30 @@ func (r *CuratorRepo) GetCuratorById(ctx context.Context, client any, id int) (*
 	return &c, nil
 }
 
+func (r *CuratorRepo) GetCuratorByLogin(ctx context.Context, client any, login string) (*entity.Curator, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, email, phone, institution_id, login, password
+		FROM curators
+		WHERE login = $1 AND login <> ''
+	`
+	var c entity.Curator
+	err := pgClient.QueryRow(ctx, q, login).Scan(&c.Id, &c.Name, &c.Email, &c.Phone, &c.InstitutionId, &c.Login, &c.Password)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("CuratorRepo GetCuratorByLogin: %v", err)
+	}
+
+	return &c, nil
+}
+
 func (r *CuratorRepo) GetExpeditionCurators(ctx context.Context, client any, expeditionId int) (entity.Curators, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT c.id, c.name
+		SELECT c.id, c.name, c.email, c.phone, c.institution_id, c.login, c.password
 		FROM curators c
 		JOIN expeditions_curators ec ON ec.curator_id = c.id
 		WHERE ec.expedition_id = $1
@@ -56,7 +77,7 @@ func (r *CuratorRepo) GetExpeditionCurators(ctx context.Context, client any, exp
 	for rows.Next() {
 		var c entity.Curator
 
-		err = rows.Scan(&c.Id, &c.Name)
+		err = rows.Scan(&c.Id, &c.Name, &c.Email, &c.Phone, &c.InstitutionId, &c.Login, &c.Password)
 		if err != nil {
 			return nil, fmt.Errorf("CuratorRepo GetExpeditionCurators: %v", err)
 		}
@@ -71,10 +92,41 @@ func (r *CuratorRepo) GetExpeditionCurators(ctx context.Context, client any, exp
 	return curators, nil
 }
 
+func (r *CuratorRepo) GetInstitutionCurators(ctx context.Context, client any, institutionId int) (entity.Curators, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, email, phone, institution_id, login, password
+		FROM curators
+		WHERE institution_id = $1
+	`
+	rows, err := pgClient.Query(ctx, q, institutionId)
+	if err != nil {
+		return nil, fmt.Errorf("CuratorRepo GetInstitutionCurators: %v", err)
+	}
+
+	curators := make(entity.Curators, 0)
+	for rows.Next() {
+		var c entity.Curator
+
+		err = rows.Scan(&c.Id, &c.Name, &c.Email, &c.Phone, &c.InstitutionId, &c.Login, &c.Password)
+		if err != nil {
+			return nil, fmt.Errorf("CuratorRepo GetInstitutionCurators: %v", err)
+		}
+
+		curators = append(curators, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CuratorRepo GetInstitutionCurators: %v", err)
+	}
+
+	return curators, nil
+}
+
 func (r *CuratorRepo) GetAllCurators(ctx context.Context, client any) (entity.Curators, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, name
+		SELECT id, name, email, phone, institution_id, login, password
 		FROM curators
 	`
 	rows, err := pgClient.Query(ctx, q)
@@ -86,7 +138,7 @@ func (r *CuratorRepo) GetAllCurators(ctx context.Context, client any) (entity.Cu
 	for rows.Next() {
 		var c entity.Curator
 
-		err = rows.Scan(&c.Id, &c.Name)
+		err = rows.Scan(&c.Id, &c.Name, &c.Email, &c.Phone, &c.InstitutionId, &c.Login, &c.Password)
 		if err != nil {
 			return nil, fmt.Errorf("CuratorRepo GetAllCurators: %v", err)
 		}
@@ -105,18 +157,21 @@ func (r *CuratorRepo) CreateCurator(ctx context.Context, client any, curator *en
 	pgClient := client.(postgres.Client)
 	q := `
 		INSERT INTO curators
-		    (name) 
+		    (name, email, phone, institution_id, login, password) 
 		VALUES 
-		    ($1) 
+		    ($1, $2, $3, $4, $5, $6) 
 		RETURNING id
 	`
 	var id int
-	err := pgClient.QueryRow(ctx, q, curator.Name).Scan(&id)
+	err := pgClient.QueryRow(ctx, q, curator.Name, curator.Email, curator.Phone, curator.InstitutionId, curator.Login, curator.Password).Scan(&id)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if ok := errors.As(err, &pgErr); ok {
-			if pgErr.Code == "23505" {
+			switch pgErr.Code {
+			case "23505":
 				return 0, repoerrs.ErrAlreadyExists
+			case "23503":
+				return 0, repoerrs.ErrNotFound
 			}
 		}
 		return 0, fmt.Errorf("CuratorRepo CreateCurator: %v", err)
@@ -141,3 +196,51 @@ func (r *CuratorRepo) DeleteCurator(ctx context.Context, client any, id int) err
 
 	return nil
 }
+
+func (r *CuratorRepo) GetCuratorWorkloads(ctx context.Context, client any, inspectedBefore time.Time) (entity.CuratorWorkloads, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT c.id, c.name, c.institution_id,
+			count(a.id),
+			count(a.id) FILTER (
+				WHERE NOT EXISTS (
+					SELECT 1
+					FROM condition_reports cr
+					WHERE cr.artifact_id = a.id AND cr.inspected_on >= $1
+				)
+			),
+			(
+				SELECT count(DISTINCT la.loan_id)
+				FROM loan_artifacts la
+				JOIN loans l ON l.id = la.loan_id
+				JOIN artifacts la_a ON la_a.id = la.artifact_id
+				WHERE la_a.responsible_curator_id = c.id AND l.status = 'shipped'
+			)
+		FROM curators c
+		LEFT JOIN artifacts a ON a.responsible_curator_id = c.id
+		GROUP BY c.id
+		ORDER BY c.name
+	`
+	rows, err := pgClient.Query(ctx, q, inspectedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("CuratorRepo GetCuratorWorkloads: %v", err)
+	}
+
+	workloads := make(entity.CuratorWorkloads, 0)
+	for rows.Next() {
+		var w entity.CuratorWorkload
+
+		err = rows.Scan(&w.CuratorId, &w.Name, &w.InstitutionId, &w.Artifacts, &w.PendingConditionReports, &w.ActiveLoans)
+		if err != nil {
+			return nil, fmt.Errorf("CuratorRepo GetCuratorWorkloads: %v", err)
+		}
+
+		workloads = append(workloads, &w)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CuratorRepo GetCuratorWorkloads: %v", err)
+	}
+
+	return workloads, nil
+}
@@ -0,0 +1,102 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pkgErrors "github.com/pkg/errors"
+)
+
+type CustodyRepo struct {
+}
+
+func NewCustodyRepo() *CustodyRepo {
+	return &CustodyRepo{}
+}
+
+func (r *CustodyRepo) GetArtifactCustody(ctx context.Context, client any, artifactId int) (entity.CustodyRecords, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, artifact_id, previous_id, holder_type, holder_id, holder_name, reason, transferred_at, signed_by_id, signed_by_role
+		FROM artifact_custody
+		WHERE artifact_id = $1
+		ORDER BY transferred_at, id
+	`
+	rows, err := pgClient.Query(ctx, q, artifactId)
+	if err != nil {
+		return nil, fmt.Errorf("CustodyRepo GetArtifactCustody: %v", err)
+	}
+
+	records := make(entity.CustodyRecords, 0)
+	for rows.Next() {
+		var cr entity.CustodyRecord
+
+		err = rows.Scan(&cr.Id, &cr.ArtifactId, &cr.PreviousId, &cr.HolderType, &cr.HolderId, &cr.HolderName, &cr.Reason,
+			&cr.TransferredAt, &cr.SignedById, &cr.SignedByRole)
+		if err != nil {
+			return nil, fmt.Errorf("CustodyRepo GetArtifactCustody: %v", err)
+		}
+
+		records = append(records, &cr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CustodyRepo GetArtifactCustody: %v", err)
+	}
+
+	return records, nil
+}
+
+func (r *CustodyRepo) GetCurrentCustody(ctx context.Context, client any, artifactId int) (*entity.CustodyRecord, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, artifact_id, previous_id, holder_type, holder_id, holder_name, reason, transferred_at, signed_by_id, signed_by_role
+		FROM artifact_custody
+		WHERE artifact_id = $1
+		ORDER BY transferred_at DESC, id DESC
+		LIMIT 1
+	`
+	var cr entity.CustodyRecord
+	err := pgClient.QueryRow(ctx, q, artifactId).Scan(&cr.Id, &cr.ArtifactId, &cr.PreviousId, &cr.HolderType, &cr.HolderId, &cr.HolderName,
+		&cr.Reason, &cr.TransferredAt, &cr.SignedById, &cr.SignedByRole)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("CustodyRepo GetCurrentCustody: %v", err)
+	}
+
+	return &cr, nil
+}
+
+const insertCustodyRecord = `
+	INSERT INTO artifact_custody
+	    (artifact_id, previous_id, holder_type, holder_id, holder_name, reason, signed_by_id, signed_by_role) 
+	VALUES 
+	    ($1, $2, $3, $4, $5, $6, $7, $8) 
+	RETURNING id
+`
+
+func (r *CustodyRepo) CreateCustodyRecord(ctx context.Context, client any, record *entity.CustodyRecord) (int, error) {
+	pgClient := client.(postgres.Client)
+	var id int
+	err := pgClient.QueryRow(ctx, insertCustodyRecord, record.ArtifactId, record.PreviousId, record.HolderType, record.HolderId, record.HolderName, record.Reason,
+		record.SignedById, record.SignedByRole).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return 0, repoerrs.ErrAlreadyExists
+			}
+		}
+		return 0, fmt.Errorf("CustodyRepo CreateCustodyRecord: %v", err)
+	}
+
+	return id, nil
+}
@@ -5,8 +5,10 @@ import (
 	"db_cp_6/internal/entity"
 	"db_cp_6/internal/repo/repoerrs"
 	"db_cp_6/pkg/postgres"
+	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	pkgErrors "github.com/pkg/errors"
 )
 
@@ -20,12 +22,13 @@ func NewEquipmentRepo() *EquipmentRepo {
 func (r *EquipmentRepo) GetEquipmentById(ctx context.Context, client any, id int) (*entity.Equipment, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, expedition_id, name, amount
-		FROM equipments
-		WHERE id = $1
+		SELECT e.id, e.expedition_id, e.item_id, i.name, e.amount, e.reserved_from, e.reserved_to
+		FROM equipments e
+		JOIN inventory_items i ON i.id = e.item_id
+		WHERE e.id = $1
 	`
 	var eq entity.Equipment
-	err := pgClient.QueryRow(ctx, q, id).Scan(&eq.Id, &eq.ExpeditionId, &eq.Name, &eq.Amount)
+	err := pgClient.QueryRow(ctx, q, id).Scan(&eq.Id, &eq.ExpeditionId, &eq.ItemId, &eq.Name, &eq.Amount, &eq.ReservedFrom, &eq.ReservedTo)
 
 	if err != nil {
 		if pkgErrors.Is(err, pgx.ErrNoRows) {
@@ -40,9 +43,10 @@ func (r *EquipmentRepo) GetEquipmentById(ctx context.Context, client any, id int
 func (r *EquipmentRepo) GetExpeditionEquipments(ctx context.Context, client any, expeditionId int) (entity.Equipments, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, expedition_id, name, amount
-		FROM equipments
-		WHERE expedition_id = $1
+		SELECT e.id, e.expedition_id, e.item_id, i.name, e.amount, e.reserved_from, e.reserved_to
+		FROM equipments e
+		JOIN inventory_items i ON i.id = e.item_id
+		WHERE e.expedition_id = $1
 	`
 	rows, err := pgClient.Query(ctx, q, expeditionId)
 	if err != nil {
@@ -53,7 +57,7 @@ func (r *EquipmentRepo) GetExpeditionEquipments(ctx context.Context, client any,
 	for rows.Next() {
 		var eq entity.Equipment
 
-		err = rows.Scan(&eq.Id, &eq.ExpeditionId, &eq.Name, &eq.Amount)
+		err = rows.Scan(&eq.Id, &eq.ExpeditionId, &eq.ItemId, &eq.Name, &eq.Amount, &eq.ReservedFrom, &eq.ReservedTo)
 		if err != nil {
 			return nil, fmt.Errorf("EquipmentRepo GetExpeditionEquipments: %v", err)
 		}
@@ -71,8 +75,9 @@ func (r *EquipmentRepo) GetExpeditionEquipments(ctx context.Context, client any,
 func (r *EquipmentRepo) GetAllEquipments(ctx context.Context, client any) (entity.Equipments, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, expedition_id, name, amount
-		FROM equipments
+		SELECT e.id, e.expedition_id, e.item_id, i.name, e.amount, e.reserved_from, e.reserved_to
+		FROM equipments e
+		JOIN inventory_items i ON i.id = e.item_id
 	`
 	rows, err := pgClient.Query(ctx, q)
 	if err != nil {
@@ -83,7 +88,7 @@ func (r *EquipmentRepo) GetAllEquipments(ctx context.Context, client any) (entit
 	for rows.Next() {
 		var eq entity.Equipment
 
-		err = rows.Scan(&eq.Id, &eq.ExpeditionId, &eq.Name, &eq.Amount)
+		err = rows.Scan(&eq.Id, &eq.ExpeditionId, &eq.ItemId, &eq.Name, &eq.Amount, &eq.ReservedFrom, &eq.ReservedTo)
 		if err != nil {
 			return nil, fmt.Errorf("EquipmentRepo GetAllEquipments: %v", err)
 		}
@@ -102,14 +107,24 @@ func (r *EquipmentRepo) CreateEquipment(ctx context.Context, client any, equipme
 	pgClient := client.(postgres.Client)
 	q := `
 		INSERT INTO equipments
-		    (expedition_id, name, amount) 
+		    (expedition_id, item_id, amount, reserved_from, reserved_to) 
 		VALUES 
-		    ($1, $2, $3) 
+		    ($1, $2, $3, $4, $5) 
 		RETURNING id
 	`
 	var id int
-	err := pgClient.QueryRow(ctx, q, equipment.ExpeditionId, equipment.Name, equipment.Amount).Scan(&id)
+	err := pgClient.QueryRow(ctx, q, equipment.ExpeditionId, equipment.ItemId, equipment.Amount,
+		equipment.ReservedFrom, equipment.ReservedTo).Scan(&id)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			switch pgErr.Code {
+			case "23514":
+				return 0, repoerrs.ErrConflict
+			case "23503":
+				return 0, repoerrs.ErrNotFound
+			}
+		}
 		return 0, fmt.Errorf("EquipmentRepo CreateEquipment: %v", err)
 	}
 
@@ -132,3 +147,103 @@ func (r *EquipmentRepo) DeleteEquipment(ctx context.Context, client any, id int)
 
 	return nil
 }
+
+func (r *EquipmentRepo) GetEquipmentEvents(ctx context.Context, client any, equipmentId int) (entity.EquipmentEvents, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, equipment_id, kind, quantity, returned, lost, damaged, member_id, recorded_at
+		FROM equipment_events
+		WHERE equipment_id = $1
+		ORDER BY recorded_at, id
+	`
+	rows, err := pgClient.Query(ctx, q, equipmentId)
+	if err != nil {
+		return nil, fmt.Errorf("EquipmentRepo GetEquipmentEvents: %v", err)
+	}
+
+	events := make(entity.EquipmentEvents, 0)
+	for rows.Next() {
+		var ev entity.EquipmentEvent
+
+		err = rows.Scan(&ev.Id, &ev.EquipmentId, &ev.Kind, &ev.Quantity, &ev.Returned, &ev.Lost, &ev.Damaged, &ev.MemberId, &ev.RecordedAt)
+		if err != nil {
+			return nil, fmt.Errorf("EquipmentRepo GetEquipmentEvents: %v", err)
+		}
+
+		events = append(events, &ev)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("EquipmentRepo GetEquipmentEvents: %v", err)
+	}
+
+	return events, nil
+}
+
+func (r *EquipmentRepo) CreateEquipmentEvent(ctx context.Context, client any, event *entity.EquipmentEvent) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO equipment_events
+		    (equipment_id, kind, quantity, returned, lost, damaged, member_id) 
+		VALUES 
+		    ($1, $2, $3, $4, $5, $6, $7) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, event.EquipmentId, event.Kind, event.Quantity, event.Returned,
+		event.Lost, event.Damaged, event.MemberId).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			switch pgErr.Code {
+			case "23514":
+				return 0, repoerrs.ErrConflict
+			case "23503":
+				return 0, repoerrs.ErrNotFound
+			}
+		}
+		return 0, fmt.Errorf("EquipmentRepo CreateEquipmentEvent: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *EquipmentRepo) GetExpeditionEquipmentBalances(ctx context.Context, client any, expeditionId int) (entity.EquipmentBalances, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT e.id, e.item_id, i.name, e.amount,
+			coalesce(sum(ev.quantity), 0),
+			coalesce(sum(ev.returned), 0),
+			coalesce(sum(ev.lost), 0),
+			coalesce(sum(ev.damaged), 0),
+			coalesce(sum(ev.quantity - ev.returned - ev.lost - ev.damaged), 0)
+		FROM equipments e
+		JOIN inventory_items i ON i.id = e.item_id
+		LEFT JOIN equipment_events ev ON ev.equipment_id = e.id
+		WHERE e.expedition_id = $1
+		GROUP BY e.id, i.name
+		ORDER BY e.id
+	`
+	rows, err := pgClient.Query(ctx, q, expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("EquipmentRepo GetExpeditionEquipmentBalances: %v", err)
+	}
+
+	balances := make(entity.EquipmentBalances, 0)
+	for rows.Next() {
+		var b entity.EquipmentBalance
+
+		err = rows.Scan(&b.EquipmentId, &b.ItemId, &b.Name, &b.Reserved, &b.CheckedOut, &b.Returned, &b.Lost, &b.Damaged, &b.Outstanding)
+		if err != nil {
+			return nil, fmt.Errorf("EquipmentRepo GetExpeditionEquipmentBalances: %v", err)
+		}
+
+		balances = append(balances, &b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("EquipmentRepo GetExpeditionEquipmentBalances: %v", err)
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,220 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pkgErrors "github.com/pkg/errors"
+)
+
+type ExcavationContextRepo struct {
+}
+
+func NewExcavationContextRepo() *ExcavationContextRepo {
+	return &ExcavationContextRepo{}
+}
+
+func (r *ExcavationContextRepo) GetContextById(ctx context.Context, client any, id int) (*entity.ExcavationContext, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT c.id, c.trench_id, t.location_id, c.code, c.description
+		FROM contexts c
+		JOIN trenches t ON t.id = c.trench_id
+		WHERE c.id = $1
+	`
+	var c entity.ExcavationContext
+	err := pgClient.QueryRow(ctx, q, id).Scan(&c.Id, &c.TrenchId, &c.LocationId, &c.Code, &c.Description)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("ExcavationContextRepo GetContextById: %v", err)
+	}
+
+	return &c, nil
+}
+
+func (r *ExcavationContextRepo) GetTrenchContexts(ctx context.Context, client any, trenchId int) (entity.ExcavationContexts, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT c.id, c.trench_id, t.location_id, c.code, c.description
+		FROM contexts c
+		JOIN trenches t ON t.id = c.trench_id
+		WHERE c.trench_id = $1
+	`
+	rows, err := pgClient.Query(ctx, q, trenchId)
+	if err != nil {
+		return nil, fmt.Errorf("ExcavationContextRepo GetTrenchContexts: %v", err)
+	}
+
+	contexts := make(entity.ExcavationContexts, 0)
+	for rows.Next() {
+		var c entity.ExcavationContext
+
+		err = rows.Scan(&c.Id, &c.TrenchId, &c.LocationId, &c.Code, &c.Description)
+		if err != nil {
+			return nil, fmt.Errorf("ExcavationContextRepo GetTrenchContexts: %v", err)
+		}
+
+		contexts = append(contexts, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ExcavationContextRepo GetTrenchContexts: %v", err)
+	}
+
+	return contexts, nil
+}
+
+func (r *ExcavationContextRepo) GetLocationContexts(ctx context.Context, client any, locationId int) (entity.ExcavationContexts, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT c.id, c.trench_id, t.location_id, c.code, c.description
+		FROM contexts c
+		JOIN trenches t ON t.id = c.trench_id
+		WHERE t.location_id = $1
+	`
+	rows, err := pgClient.Query(ctx, q, locationId)
+	if err != nil {
+		return nil, fmt.Errorf("ExcavationContextRepo GetLocationContexts: %v", err)
+	}
+
+	contexts := make(entity.ExcavationContexts, 0)
+	for rows.Next() {
+		var c entity.ExcavationContext
+
+		err = rows.Scan(&c.Id, &c.TrenchId, &c.LocationId, &c.Code, &c.Description)
+		if err != nil {
+			return nil, fmt.Errorf("ExcavationContextRepo GetLocationContexts: %v", err)
+		}
+
+		contexts = append(contexts, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ExcavationContextRepo GetLocationContexts: %v", err)
+	}
+
+	return contexts, nil
+}
+
+func (r *ExcavationContextRepo) CreateContext(ctx context.Context, client any, excavationContext *entity.ExcavationContext) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO contexts
+		    (trench_id, code, description) 
+		VALUES 
+		    ($1, $2, $3) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, excavationContext.TrenchId, excavationContext.Code, excavationContext.Description).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return 0, repoerrs.ErrAlreadyExists
+			}
+		}
+		return 0, fmt.Errorf("ExcavationContextRepo CreateContext: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *ExcavationContextRepo) DeleteContext(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		DELETE FROM contexts
+		WHERE id = $1
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("ExcavationContextRepo DeleteContext: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ExcavationContextRepo) GetLocationContextRelations(ctx context.Context, client any, locationId int) (entity.ContextRelations, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT cr.id, cr.from_context_id, cr.to_context_id, cr.relation
+		FROM context_relations cr
+		JOIN contexts c ON c.id = cr.from_context_id
+		JOIN trenches t ON t.id = c.trench_id
+		WHERE t.location_id = $1
+	`
+	rows, err := pgClient.Query(ctx, q, locationId)
+	if err != nil {
+		return nil, fmt.Errorf("ExcavationContextRepo GetLocationContextRelations: %v", err)
+	}
+
+	relations := make(entity.ContextRelations, 0)
+	for rows.Next() {
+		var cr entity.ContextRelation
+
+		err = rows.Scan(&cr.Id, &cr.FromContextId, &cr.ToContextId, &cr.Relation)
+		if err != nil {
+			return nil, fmt.Errorf("ExcavationContextRepo GetLocationContextRelations: %v", err)
+		}
+
+		relations = append(relations, &cr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ExcavationContextRepo GetLocationContextRelations: %v", err)
+	}
+
+	return relations, nil
+}
+
+func (r *ExcavationContextRepo) CreateContextRelation(ctx context.Context, client any, relation *entity.ContextRelation) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO context_relations
+		    (from_context_id, to_context_id, relation) 
+		VALUES 
+		    ($1, $2, $3) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, relation.FromContextId, relation.ToContextId, relation.Relation).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return 0, repoerrs.ErrAlreadyExists
+			}
+		}
+		return 0, fmt.Errorf("ExcavationContextRepo CreateContextRelation: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *ExcavationContextRepo) DeleteContextRelation(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		DELETE FROM context_relations
+		WHERE id = $1
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("ExcavationContextRepo DeleteContextRelation: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 	"db_cp_6/internal/entity"
 	"db_cp_6/internal/repo/repoerrs"
 	"db_cp_6/pkg/postgres"
+	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	pkgErrors "github.com/pkg/errors"
 	"time"
 )
@@ -21,12 +23,12 @@ func NewExpeditionRepo() *ExpeditionRepo {
 func (r *ExpeditionRepo) GetExpeditionById(ctx context.Context, client any, id int) (*entity.Expedition, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, location_id, start_date, end_date
+		SELECT id, location_id, start_date, end_date, closed_on
 		FROM expeditions
 		WHERE id = $1
 	`
 	var exp entity.Expedition
-	err := pgClient.QueryRow(ctx, q, id).Scan(&exp.Id, &exp.LocationId, &exp.StartDate, &exp.EndDate)
+	err := pgClient.QueryRow(ctx, q, id).Scan(&exp.Id, &exp.LocationId, &exp.StartDate, &exp.EndDate, &exp.ClosedOn)
 
 	if err != nil {
 		if pkgErrors.Is(err, pgx.ErrNoRows) {
@@ -41,7 +43,7 @@ func (r *ExpeditionRepo) GetExpeditionById(ctx context.Context, client any, id i
 func (r *ExpeditionRepo) GetAllExpeditions(ctx context.Context, client any) (entity.Expeditions, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, location_id, start_date, end_date
+		SELECT id, location_id, start_date, end_date, closed_on
 		FROM expeditions
 	`
 	rows, err := pgClient.Query(ctx, q)
@@ -53,7 +55,7 @@ func (r *ExpeditionRepo) GetAllExpeditions(ctx context.Context, client any) (ent
 	for rows.Next() {
 		var exp entity.Expedition
 
-		err = rows.Scan(&exp.Id, &exp.LocationId, &exp.StartDate, &exp.EndDate)
+		err = rows.Scan(&exp.Id, &exp.LocationId, &exp.StartDate, &exp.EndDate, &exp.ClosedOn)
 		if err != nil {
 			return nil, fmt.Errorf("ExpeditionRepo GetAllExpeditions: %v", err)
 		}
@@ -121,3 +123,28 @@ func (r *ExpeditionRepo) DeleteExpedition(ctx context.Context, client any, id in
 
 	return nil
 }
+
+func (r *ExpeditionRepo) CloseExpedition(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		UPDATE expeditions
+		SET
+			closed_on = CURRENT_DATE
+		WHERE id = $1 AND closed_on IS NULL
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23514" {
+				return repoerrs.ErrConflict
+			}
+		}
+		return fmt.Errorf("ExpeditionRepo CloseExpedition: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
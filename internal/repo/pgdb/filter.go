@@ -0,0 +1,65 @@
+package pgdb
+
+import (
+	"db_cp_6/internal/entity"
+	"fmt"
+	"strings"
+)
+
+type conditions struct {
+	where []string
+	args  []any
+}
+
+func (c *conditions) add(format string, values ...any) {
+	placeholders := make([]any, len(values))
+	for i, v := range values {
+		c.args = append(c.args, v)
+		placeholders[i] = fmt.Sprintf("$%d", len(c.args))
+	}
+
+	c.where = append(c.where, fmt.Sprintf(format, placeholders...))
+}
+
+func (c *conditions) addBoundingBox(latColumn string, lonColumn string, bbox *entity.BoundingBox) {
+	if bbox == nil {
+		return
+	}
+
+	c.add(latColumn+" BETWEEN %s AND %s", bbox.MinLatitude, bbox.MaxLatitude)
+	if bbox.MinLongitude <= bbox.MaxLongitude {
+		c.add(lonColumn+" BETWEEN %s AND %s", bbox.MinLongitude, bbox.MaxLongitude)
+	} else {
+		c.add("("+lonColumn+" >= %s OR "+lonColumn+" <= %s)", bbox.MinLongitude, bbox.MaxLongitude)
+	}
+}
+
+func (c *conditions) addYearOverlap(earliestColumn string, latestColumn string, from *int, to *int) {
+	if from == nil && to == nil {
+		return
+	}
+
+	c.add("(" + earliestColumn + " IS NOT NULL OR " + latestColumn + " IS NOT NULL)")
+	if to != nil {
+		c.add("("+earliestColumn+" IS NULL OR "+earliestColumn+" <= %s)", *to)
+	}
+	if from != nil {
+		c.add("("+latestColumn+" IS NULL OR "+latestColumn+" >= %s)", *from)
+	}
+}
+
+func (c *conditions) and() string {
+	if len(c.where) == 0 {
+		return ""
+	}
+
+	return "AND " + strings.Join(c.where, " AND ")
+}
+
+func (c *conditions) sql() string {
+	if len(c.where) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(c.where, " AND ")
+}
@@ -0,0 +1,112 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pkgErrors "github.com/pkg/errors"
+)
+
+type InstitutionRepo struct {
+}
+
+func NewInstitutionRepo() *InstitutionRepo {
+	return &InstitutionRepo{}
+}
+
+func (r *InstitutionRepo) GetInstitutionById(ctx context.Context, client any, id int) (*entity.Institution, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, city, country
+		FROM institutions
+		WHERE id = $1
+	`
+	var i entity.Institution
+	err := pgClient.QueryRow(ctx, q, id).Scan(&i.Id, &i.Name, &i.City, &i.Country)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("InstitutionRepo GetInstitutionById: %v", err)
+	}
+
+	return &i, nil
+}
+
+func (r *InstitutionRepo) GetAllInstitutions(ctx context.Context, client any) (entity.Institutions, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, city, country
+		FROM institutions
+		ORDER BY name
+	`
+	rows, err := pgClient.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("InstitutionRepo GetAllInstitutions: %v", err)
+	}
+
+	institutions := make(entity.Institutions, 0)
+	for rows.Next() {
+		var i entity.Institution
+
+		err = rows.Scan(&i.Id, &i.Name, &i.City, &i.Country)
+		if err != nil {
+			return nil, fmt.Errorf("InstitutionRepo GetAllInstitutions: %v", err)
+		}
+
+		institutions = append(institutions, &i)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("InstitutionRepo GetAllInstitutions: %v", err)
+	}
+
+	return institutions, nil
+}
+
+func (r *InstitutionRepo) CreateInstitution(ctx context.Context, client any, institution *entity.Institution) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO institutions
+		    (name, city, country) 
+		VALUES 
+		    ($1, $2, $3) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, institution.Name, institution.City, institution.Country).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return 0, repoerrs.ErrAlreadyExists
+			}
+		}
+		return 0, fmt.Errorf("InstitutionRepo CreateInstitution: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *InstitutionRepo) DeleteInstitution(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		DELETE FROM institutions
+		WHERE id = $1
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("InstitutionRepo DeleteInstitution: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pkgErrors "github.com/pkg/errors"
+)
+
+type InventoryRepo struct {
+}
+
+func NewInventoryRepo() *InventoryRepo {
+	return &InventoryRepo{}
+}
+
+func (r *InventoryRepo) GetInventoryItemById(ctx context.Context, client any, id int) (*entity.InventoryItem, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, item_type, total_quantity, service_interval_days, service_field_days, serviceable
+		FROM inventory_items
+		WHERE id = $1
+	`
+	var it entity.InventoryItem
+	err := pgClient.QueryRow(ctx, q, id).Scan(&it.Id, &it.Name, &it.ItemType, &it.TotalQuantity, &it.ServiceIntervalDays, &it.ServiceFieldDays, &it.Serviceable)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("InventoryRepo GetInventoryItemById: %v", err)
+	}
+
+	return &it, nil
+}
+
+func (r *InventoryRepo) GetAllInventoryItems(ctx context.Context, client any) (entity.InventoryItems, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, item_type, total_quantity, service_interval_days, service_field_days, serviceable
+		FROM inventory_items
+		ORDER BY item_type, name
+	`
+	rows, err := pgClient.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("InventoryRepo GetAllInventoryItems: %v", err)
+	}
+
+	items := make(entity.InventoryItems, 0)
+	for rows.Next() {
+		var it entity.InventoryItem
+
+		err = rows.Scan(&it.Id, &it.Name, &it.ItemType, &it.TotalQuantity, &it.ServiceIntervalDays, &it.ServiceFieldDays, &it.Serviceable)
+		if err != nil {
+			return nil, fmt.Errorf("InventoryRepo GetAllInventoryItems: %v", err)
+		}
+
+		items = append(items, &it)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("InventoryRepo GetAllInventoryItems: %v", err)
+	}
+
+	return items, nil
+}
+
+func (r *InventoryRepo) CreateInventoryItem(ctx context.Context, client any, item *entity.InventoryItem) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO inventory_items
+		    (name, item_type, total_quantity, service_interval_days, service_field_days) 
+		VALUES 
+		    ($1, $2, $3, $4, $5) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, item.Name, item.ItemType, item.TotalQuantity,
+		item.ServiceIntervalDays, item.ServiceFieldDays).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return 0, repoerrs.ErrAlreadyExists
+			}
+		}
+		return 0, fmt.Errorf("InventoryRepo CreateInventoryItem: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *InventoryRepo) DeleteInventoryItem(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		DELETE FROM inventory_items
+		WHERE id = $1
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23503" {
+				return repoerrs.ErrInUse
+			}
+		}
+		return fmt.Errorf("InventoryRepo DeleteInventoryItem: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *InventoryRepo) GetItemAvailability(ctx context.Context, client any, filter *entity.AvailabilityFilter) (entity.ItemAvailabilities, error) {
+	pgClient := client.(postgres.Client)
+	cond := conditions{args: []any{filter.From, filter.To}}
+	if filter.ItemId != nil {
+		cond.add("i.id = %s", *filter.ItemId)
+	}
+	q := `
+		SELECT i.id, i.name, i.item_type, i.total_quantity,
+			coalesce(sum(e.amount), 0),
+			i.total_quantity - coalesce(sum(e.amount), 0),
+			i.serviceable
+		FROM inventory_items i
+		LEFT JOIN equipments e ON e.item_id = i.id
+			AND daterange(e.reserved_from, e.reserved_to, '[]') && daterange($1::date, $2::date, '[]')
+	` + cond.sql() + `
+		GROUP BY i.id
+		ORDER BY i.item_type, i.name
+	`
+	rows, err := pgClient.Query(ctx, q, cond.args...)
+	if err != nil {
+		return nil, fmt.Errorf("InventoryRepo GetItemAvailability: %v", err)
+	}
+
+	availabilities := make(entity.ItemAvailabilities, 0)
+	for rows.Next() {
+		var a entity.ItemAvailability
+
+		err = rows.Scan(&a.ItemId, &a.Name, &a.ItemType, &a.TotalQuantity, &a.Reserved, &a.Available, &a.Serviceable)
+		if err != nil {
+			return nil, fmt.Errorf("InventoryRepo GetItemAvailability: %v", err)
+		}
+
+		availabilities = append(availabilities, &a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("InventoryRepo GetItemAvailability: %v", err)
+	}
+
+	return availabilities, nil
+}
@@ -0,0 +1,220 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type JournalRepo struct {
+}
+
+func NewJournalRepo() *JournalRepo {
+	return &JournalRepo{}
+}
+
+const journalEntryQuery = `
+	SELECT je.id, je.expedition_id, je.member_id, m.name, je.entry_date, je.weather, je.summary,
+	       je.hours_worked, je.created_at, je.updated_at,
+	       coalesce(array_agg(jea.artifact_id ORDER BY jea.artifact_id) FILTER (WHERE jea.artifact_id IS NOT NULL), '{}')
+	FROM journal_entries je
+	JOIN members m ON m.id = je.member_id
+	LEFT JOIN journal_entry_artifacts jea ON jea.entry_id = je.id
+`
+
+func (r *JournalRepo) GetJournalEntryById(ctx context.Context, client any, id int) (*entity.JournalEntry, error) {
+	pgClient := client.(postgres.Client)
+	q := journalEntryQuery + `
+		WHERE je.id = $1
+		GROUP BY je.id, m.name
+	`
+	entries, err := r.queryJournalEntries(ctx, pgClient, "GetJournalEntryById", q, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, repoerrs.ErrNotFound
+	}
+
+	return entries[0], nil
+}
+
+func (r *JournalRepo) GetExpeditionJournalEntries(ctx context.Context, client any, expeditionId int) (entity.JournalEntries, error) {
+	pgClient := client.(postgres.Client)
+	q := journalEntryQuery + `
+		WHERE je.expedition_id = $1
+		GROUP BY je.id, m.name
+		ORDER BY je.entry_date, je.created_at, je.id
+	`
+	return r.queryJournalEntries(ctx, pgClient, "GetExpeditionJournalEntries", q, expeditionId)
+}
+
+func (r *JournalRepo) CreateJournalEntry(ctx context.Context, client any, entry *entity.JournalEntry) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH entry AS (
+			INSERT INTO journal_entries
+			    (expedition_id, member_id, entry_date, weather, summary, hours_worked)
+			VALUES
+			    ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		), linked AS (
+			INSERT INTO journal_entry_artifacts
+			    (entry_id, artifact_id)
+			SELECT entry.id, a.artifact_id
+			FROM entry, unnest($7::int[]) AS a(artifact_id)
+		)
+		SELECT id FROM entry
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, entry.ExpeditionId, entry.MemberId, entry.EntryDate, entry.Weather, entry.Summary,
+		entry.HoursWorked, journalArtifactIds(entry)).Scan(&id)
+	if err != nil {
+		return 0, journalWriteError("CreateJournalEntry", err)
+	}
+
+	return id, nil
+}
+
+func (r *JournalRepo) UpdateJournalEntry(ctx context.Context, client any, entry *entity.JournalEntry) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH entry AS (
+			UPDATE journal_entries
+			SET entry_date = $2, weather = $3, summary = $4, hours_worked = $5, updated_at = now()
+			WHERE id = $1
+			RETURNING id
+		), unlinked AS (
+			DELETE FROM journal_entry_artifacts jea
+			USING entry
+			WHERE jea.entry_id = entry.id AND NOT (jea.artifact_id = ANY($6::int[]))
+		), linked AS (
+			INSERT INTO journal_entry_artifacts
+			    (entry_id, artifact_id)
+			SELECT entry.id, a.artifact_id
+			FROM entry, unnest($6::int[]) AS a(artifact_id)
+			ON CONFLICT DO NOTHING
+		)
+		SELECT count(*) FROM entry
+	`
+	var count int
+	err := pgClient.QueryRow(ctx, q, entry.Id, entry.EntryDate, entry.Weather, entry.Summary, entry.HoursWorked,
+		journalArtifactIds(entry)).Scan(&count)
+	if err != nil {
+		return journalWriteError("UpdateJournalEntry", err)
+	}
+	if count != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *JournalRepo) GetExpeditionJournalLocks(ctx context.Context, client any, expeditionId int) (entity.JournalDayLocks, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT expedition_id, day, locked_by_id, locked_at
+		FROM journal_day_locks
+		WHERE expedition_id = $1
+		ORDER BY day
+	`
+	rows, err := pgClient.Query(ctx, q, expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("JournalRepo GetExpeditionJournalLocks: %v", err)
+	}
+
+	locks := make(entity.JournalDayLocks, 0)
+	for rows.Next() {
+		var l entity.JournalDayLock
+
+		err = rows.Scan(&l.ExpeditionId, &l.Day, &l.LockedById, &l.LockedAt)
+		if err != nil {
+			return nil, fmt.Errorf("JournalRepo GetExpeditionJournalLocks: %v", err)
+		}
+
+		locks = append(locks, &l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("JournalRepo GetExpeditionJournalLocks: %v", err)
+	}
+
+	return locks, nil
+}
+
+func (r *JournalRepo) LockJournalDay(ctx context.Context, client any, lock *entity.JournalDayLock) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO journal_day_locks
+		    (expedition_id, day, locked_by_id)
+		VALUES
+		    ($1, $2, $3)
+	`
+	_, err := pgClient.Exec(ctx, q, lock.ExpeditionId, lock.Day, lock.LockedById)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			switch pgErr.Code {
+			case "23505":
+				return repoerrs.ErrAlreadyExists
+			case "23503":
+				return repoerrs.ErrNotFound
+			case "23514":
+				return repoerrs.ErrConflict
+			}
+		}
+		return fmt.Errorf("JournalRepo LockJournalDay: %v", err)
+	}
+
+	return nil
+}
+
+func (r *JournalRepo) queryJournalEntries(ctx context.Context, pgClient postgres.Client, method string, q string, args ...any) (entity.JournalEntries, error) {
+	rows, err := pgClient.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("JournalRepo %s: %v", method, err)
+	}
+
+	entries := make(entity.JournalEntries, 0)
+	for rows.Next() {
+		var e entity.JournalEntry
+
+		err = rows.Scan(&e.Id, &e.ExpeditionId, &e.MemberId, &e.MemberName, &e.EntryDate, &e.Weather, &e.Summary,
+			&e.HoursWorked, &e.CreatedAt, &e.UpdatedAt, &e.ArtifactIds)
+		if err != nil {
+			return nil, fmt.Errorf("JournalRepo %s: %v", method, err)
+		}
+
+		entries = append(entries, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("JournalRepo %s: %v", method, err)
+	}
+
+	return entries, nil
+}
+
+func journalArtifactIds(entry *entity.JournalEntry) []int {
+	if entry.ArtifactIds == nil {
+		return []int{}
+	}
+	return entry.ArtifactIds
+}
+
+func journalWriteError(method string, err error) error {
+	var pgErr *pgconn.PgError
+	if ok := errors.As(err, &pgErr); ok {
+		switch pgErr.Code {
+		case "23503":
+			return repoerrs.ErrNotFound
+		case "23514":
+			return repoerrs.ErrConflict
+		}
+	}
+	return fmt.Errorf("JournalRepo %s: %v", method, err)
+}
@@ -39,6 +39,26 @@ func (r *LeaderRepo) GetLeaderById(ctx context.Context, client any, id int) (*en
 	return &l, nil
 }
 
+func (r *LeaderRepo) GetLeaderByLogin(ctx context.Context, client any, login string) (*entity.Leader, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, phone_number, login, password
+		FROM leaders
+		WHERE login = $1
+	`
+	var l entity.Leader
+	err := pgClient.QueryRow(ctx, q, login).Scan(&l.Id, &l.Name, &l.PhoneNumber, &l.Login, &l.Password)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("LeaderRepo GetLeaderByLogin: %v", err)
+	}
+
+	return &l, nil
+}
+
 func (r *LeaderRepo) GetExpeditionLeaders(ctx context.Context, client any, expeditionId int) (entity.Leaders, error) {
 	pgClient := client.(postgres.Client)
 	q := `
@@ -0,0 +1,222 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pkgErrors "github.com/pkg/errors"
+	"time"
+)
+
+type LoanRepo struct {
+}
+
+func NewLoanRepo() *LoanRepo {
+	return &LoanRepo{}
+}
+
+func (r *LoanRepo) GetLoanById(ctx context.Context, client any, id int) (*entity.Loan, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT l.id, l.borrower, array_agg(la.artifact_id ORDER BY la.artifact_id), l.out_date, l.due_date, l.returned_on,
+		       l.insurance_value, l.status, l.created_by_id, l.created_by_role
+		FROM loans l
+		JOIN loan_artifacts la ON la.loan_id = l.id
+		WHERE l.id = $1
+		GROUP BY l.id
+	`
+	var l entity.Loan
+	err := pgClient.QueryRow(ctx, q, id).Scan(&l.Id, &l.Borrower, &l.ArtifactIds, &l.OutDate, &l.DueDate, &l.ReturnedOn,
+		&l.InsuranceValue, &l.Status, &l.CreatedById, &l.CreatedByRole)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("LoanRepo GetLoanById: %v", err)
+	}
+
+	return &l, nil
+}
+
+func (r *LoanRepo) GetAllLoans(ctx context.Context, client any) (entity.Loans, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT l.id, l.borrower, array_agg(la.artifact_id ORDER BY la.artifact_id), l.out_date, l.due_date, l.returned_on,
+		       l.insurance_value, l.status, l.created_by_id, l.created_by_role
+		FROM loans l
+		JOIN loan_artifacts la ON la.loan_id = l.id
+		GROUP BY l.id
+		ORDER BY l.out_date DESC, l.id
+	`
+	return r.queryLoans(ctx, pgClient, "GetAllLoans", q)
+}
+
+func (r *LoanRepo) GetArtifactLoans(ctx context.Context, client any, artifactId int) (entity.Loans, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT l.id, l.borrower, array_agg(la.artifact_id ORDER BY la.artifact_id), l.out_date, l.due_date, l.returned_on,
+		       l.insurance_value, l.status, l.created_by_id, l.created_by_role
+		FROM loans l
+		JOIN loan_artifacts la ON la.loan_id = l.id
+		WHERE l.id IN (SELECT loan_id FROM loan_artifacts WHERE artifact_id = $1)
+		GROUP BY l.id
+		ORDER BY l.out_date, l.id
+	`
+	return r.queryLoans(ctx, pgClient, "GetArtifactLoans", q, artifactId)
+}
+
+func (r *LoanRepo) queryLoans(ctx context.Context, pgClient postgres.Client, method string, q string, args ...any) (entity.Loans, error) {
+	rows, err := pgClient.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("LoanRepo %s: %v", method, err)
+	}
+
+	loans := make(entity.Loans, 0)
+	for rows.Next() {
+		var l entity.Loan
+
+		err = rows.Scan(&l.Id, &l.Borrower, &l.ArtifactIds, &l.OutDate, &l.DueDate, &l.ReturnedOn,
+			&l.InsuranceValue, &l.Status, &l.CreatedById, &l.CreatedByRole)
+		if err != nil {
+			return nil, fmt.Errorf("LoanRepo %s: %v", method, err)
+		}
+
+		loans = append(loans, &l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("LoanRepo %s: %v", method, err)
+	}
+
+	return loans, nil
+}
+
+func (r *LoanRepo) CreateLoan(ctx context.Context, client any, loan *entity.Loan) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH loan AS (
+			INSERT INTO loans
+			    (borrower, out_date, due_date, insurance_value, status, created_by_id, created_by_role)
+			VALUES
+			    ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, out_date, due_date
+		), items AS (
+			INSERT INTO loan_artifacts
+			    (loan_id, artifact_id, out_date, due_date)
+			SELECT loan.id, a.artifact_id, loan.out_date, loan.due_date
+			FROM loan, unnest($8::int[]) AS a(artifact_id)
+		)
+		SELECT id FROM loan
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, loan.Borrower, loan.OutDate, loan.DueDate, loan.InsuranceValue, loan.Status,
+		loan.CreatedById, loan.CreatedByRole, loan.ArtifactIds).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			switch pgErr.Code {
+			case "23P01":
+				return 0, repoerrs.ErrAlreadyExists
+			case "23503":
+				return 0, repoerrs.ErrNotFound
+			}
+		}
+		return 0, fmt.Errorf("LoanRepo CreateLoan: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *LoanRepo) UpdateLoanStatus(ctx context.Context, client any, id int, from string, to string, returnedOn *time.Time,
+	custody entity.CustodyRecords) error {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("LoanRepo UpdateLoanStatus: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := `
+		WITH loan AS (
+			UPDATE loans
+			SET status = $3, returned_on = $4
+			WHERE id = $1 AND status = $2
+			RETURNING id, status
+		), items AS (
+			UPDATE loan_artifacts la
+			SET active = false
+			FROM loan
+			WHERE la.loan_id = loan.id AND loan.status IN ('returned', 'cancelled')
+		)
+		SELECT id FROM loan
+	`
+	var updatedId int
+	err = tx.QueryRow(ctx, q, id, from, to, returnedOn).Scan(&updatedId)
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("LoanRepo UpdateLoanStatus: %v", err)
+	}
+
+	for _, record := range custody {
+		var recordId int
+		err = tx.QueryRow(ctx, insertCustodyRecord, record.ArtifactId, record.PreviousId, record.HolderType, record.HolderId, record.HolderName,
+			record.Reason, record.SignedById, record.SignedByRole).Scan(&recordId)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if ok := errors.As(err, &pgErr); ok {
+				if pgErr.Code == "23505" {
+					return repoerrs.ErrAlreadyExists
+				}
+			}
+			return fmt.Errorf("LoanRepo UpdateLoanStatus: %v", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("LoanRepo UpdateLoanStatus: %v", err)
+	}
+
+	return nil
+}
+
+func (r *LoanRepo) GetOverdueLoans(ctx context.Context, client any, today time.Time) (entity.OverdueLoans, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT l.id, l.borrower, array_agg(la.artifact_id ORDER BY la.artifact_id), l.due_date, $1::date - l.due_date
+		FROM loans l
+		JOIN loan_artifacts la ON la.loan_id = l.id
+		WHERE l.status = 'shipped' AND l.due_date < $1::date
+		GROUP BY l.id
+		ORDER BY l.due_date, l.id
+	`
+	rows, err := pgClient.Query(ctx, q, today)
+	if err != nil {
+		return nil, fmt.Errorf("LoanRepo GetOverdueLoans: %v", err)
+	}
+
+	loans := make(entity.OverdueLoans, 0)
+	for rows.Next() {
+		var l entity.OverdueLoan
+
+		err = rows.Scan(&l.LoanId, &l.Borrower, &l.ArtifactIds, &l.DueDate, &l.DaysOverdue)
+		if err != nil {
+			return nil, fmt.Errorf("LoanRepo GetOverdueLoans: %v", err)
+		}
+
+		loans = append(loans, &l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("LoanRepo GetOverdueLoans: %v", err)
+	}
+
+	return loans, nil
+}
@@ -20,12 +20,12 @@ func NewLocationRepo() *LocationRepo {
 func (r *LocationRepo) GetLocationById(ctx context.Context, client any, id int) (*entity.Location, error) {
 	pgClient := client.(postgres.Client)
 	q := `
-		SELECT id, name, country, nearest_town
+		SELECT id, name, country, nearest_town, latitude, longitude, elevation, datum
 		FROM locations
 		WHERE id = $1
 	`
 	var l entity.Location
-	err := pgClient.QueryRow(ctx, q, id).Scan(&l.Id, &l.Name, &l.Country, &l.NearestTown)
+	err := pgClient.QueryRow(ctx, q, id).Scan(&l.Id, &l.Name, &l.Country, &l.NearestTown, &l.Latitude, &l.Longitude, &l.Elevation, &l.Datum)
 
 	if err != nil {
 		if pkgErrors.Is(err, pgx.ErrNoRows) {
@@ -37,13 +37,20 @@ func (r *LocationRepo) GetLocationById(ctx context.Context, client any, id int)
 	return &l, nil
 }
 
-func (r *LocationRepo) GetAllLocations(ctx context.Context, client any) (entity.Locations, error) {
+func (r *LocationRepo) GetAllLocations(ctx context.Context, client any, filter *entity.LocationFilter) (entity.Locations, error) {
 	pgClient := client.(postgres.Client)
+	var cond conditions
+	if filter != nil {
+		cond.addBoundingBox("latitude", "longitude", filter.BoundingBox)
+		if filter.Country != "" {
+			cond.add("country = %s", filter.Country)
+		}
+	}
 	q := `
-		SELECT id, name, country, nearest_town
+		SELECT id, name, country, nearest_town, latitude, longitude, elevation, datum
 		FROM locations
-	`
-	rows, err := pgClient.Query(ctx, q)
+	` + cond.sql()
+	rows, err := pgClient.Query(ctx, q, cond.args...)
 	if err != nil {
 		return nil, fmt.Errorf("LocationRepo GetAllLocations: %v", err)
 	}
@@ -52,7 +59,7 @@ func (r *LocationRepo) GetAllLocations(ctx context.Context, client any) (entity.
 	for rows.Next() {
 		var l entity.Location
 
-		err = rows.Scan(&l.Id, &l.Name, &l.Country, &l.NearestTown)
+		err = rows.Scan(&l.Id, &l.Name, &l.Country, &l.NearestTown, &l.Latitude, &l.Longitude, &l.Elevation, &l.Datum)
 		if err != nil {
 			return nil, fmt.Errorf("LocationRepo GetAllLocations: %v", err)
 		}
@@ -67,17 +74,112 @@ func (r *LocationRepo) GetAllLocations(ctx context.Context, client any) (entity.
 	return locations, nil
 }
 
+func (r *LocationRepo) GetSiteSummaries(ctx context.Context, client any, filter *entity.ExportFilter) (entity.SiteSummaries, error) {
+	pgClient := client.(postgres.Client)
+	var artifactCond conditions
+	if filter.Artifacts != nil {
+		artifactCond.addYearOverlap("a.earliest_year", "a.latest_year", filter.Artifacts.DatedFrom, filter.Artifacts.DatedTo)
+		if filter.Artifacts.CuratorId != nil {
+			artifactCond.add("a.responsible_curator_id = %s", *filter.Artifacts.CuratorId)
+		}
+	}
+	cond := conditions{args: artifactCond.args}
+	if filter.Locations != nil {
+		cond.addBoundingBox("l.latitude", "l.longitude", filter.Locations.BoundingBox)
+		if filter.Locations.Country != "" {
+			cond.add("l.country = %s", filter.Locations.Country)
+		}
+	}
+	q := `
+		SELECT l.id, l.name, l.country, l.nearest_town, l.latitude, l.longitude, l.elevation, l.datum,
+			coalesce(e.expedition_count, 0), count(a.id)
+		FROM locations l
+		LEFT JOIN (
+			SELECT location_id, count(*) AS expedition_count FROM expeditions GROUP BY location_id
+		) e ON e.location_id = l.id
+		LEFT JOIN artifacts a ON a.location_id = l.id ` + artifactCond.and() + `
+		` + cond.sql() + `
+		GROUP BY l.id, e.expedition_count
+		ORDER BY l.id
+	`
+	rows, err := pgClient.Query(ctx, q, cond.args...)
+	if err != nil {
+		return nil, fmt.Errorf("LocationRepo GetSiteSummaries: %v", err)
+	}
+
+	sites := make(entity.SiteSummaries, 0)
+	for rows.Next() {
+		var l entity.Location
+		var site entity.SiteSummary
+
+		err = rows.Scan(&l.Id, &l.Name, &l.Country, &l.NearestTown, &l.Latitude, &l.Longitude, &l.Elevation, &l.Datum,
+			&site.ExpeditionCount, &site.ArtifactCount)
+		if err != nil {
+			return nil, fmt.Errorf("LocationRepo GetSiteSummaries: %v", err)
+		}
+
+		site.Location = &l
+		sites = append(sites, &site)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("LocationRepo GetSiteSummaries: %v", err)
+	}
+
+	return sites, nil
+}
+
+func (r *LocationRepo) GetNearbyLocations(ctx context.Context, client any, query *entity.NearbyQuery) (entity.NearbyLocations, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, country, nearest_town, latitude, longitude, elevation, datum, distance_km
+		FROM (
+			SELECT *, 2 * 6371.0088 * asin(least(1, sqrt(
+				power(sin(radians(latitude - $1) / 2), 2) +
+				cos(radians($1)) * cos(radians(latitude)) * power(sin(radians(longitude - $2) / 2), 2)
+			))) AS distance_km
+			FROM locations
+			WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+		) l
+		WHERE distance_km <= $3
+		ORDER BY distance_km
+	`
+	rows, err := pgClient.Query(ctx, q, query.Latitude, query.Longitude, query.RadiusKm)
+	if err != nil {
+		return nil, fmt.Errorf("LocationRepo GetNearbyLocations: %v", err)
+	}
+
+	locations := make(entity.NearbyLocations, 0)
+	for rows.Next() {
+		var l entity.NearbyLocation
+
+		err = rows.Scan(&l.Id, &l.Name, &l.Country, &l.NearestTown, &l.Latitude, &l.Longitude, &l.Elevation, &l.Datum, &l.DistanceKm)
+		if err != nil {
+			return nil, fmt.Errorf("LocationRepo GetNearbyLocations: %v", err)
+		}
+
+		locations = append(locations, &l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("LocationRepo GetNearbyLocations: %v", err)
+	}
+
+	return locations, nil
+}
+
 func (r *LocationRepo) CreateLocation(ctx context.Context, client any, location *entity.Location) (int, error) {
 	pgClient := client.(postgres.Client)
 	q := `
 		INSERT INTO locations
-		    (name, country, nearest_town) 
+		    (name, country, nearest_town, latitude, longitude, elevation, datum) 
 		VALUES 
-		    ($1, $2, $3) 
+		    ($1, $2, $3, $4, $5, $6, $7) 
 		RETURNING id
 	`
 	var id int
-	err := pgClient.QueryRow(ctx, q, location.Name, location.Country, location.NearestTown).Scan(&id)
+	err := pgClient.QueryRow(ctx, q, location.Name, location.Country, location.NearestTown,
+		location.Latitude, location.Longitude, location.Elevation, location.Datum).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("LocationRepo CreateLocation: %v", err)
 	}
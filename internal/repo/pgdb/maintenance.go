@@ -0,0 +1,165 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type MaintenanceRepo struct {
+}
+
+func NewMaintenanceRepo() *MaintenanceRepo {
+	return &MaintenanceRepo{}
+}
+
+func (r *MaintenanceRepo) GetItemMaintenanceLogs(ctx context.Context, client any, itemId int) (entity.MaintenanceLogs, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, item_id, performed_on, technician, description, serviceable
+		FROM maintenance_logs
+		WHERE item_id = $1
+		ORDER BY performed_on DESC, id DESC
+	`
+	rows, err := pgClient.Query(ctx, q, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("MaintenanceRepo GetItemMaintenanceLogs: %v", err)
+	}
+
+	logs := make(entity.MaintenanceLogs, 0)
+	for rows.Next() {
+		var l entity.MaintenanceLog
+
+		err = rows.Scan(&l.Id, &l.ItemId, &l.PerformedOn, &l.Technician, &l.Description, &l.Serviceable)
+		if err != nil {
+			return nil, fmt.Errorf("MaintenanceRepo GetItemMaintenanceLogs: %v", err)
+		}
+
+		logs = append(logs, &l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("MaintenanceRepo GetItemMaintenanceLogs: %v", err)
+	}
+
+	return logs, nil
+}
+
+func (r *MaintenanceRepo) CreateMaintenanceLog(ctx context.Context, client any, log *entity.MaintenanceLog) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH inserted AS (
+			INSERT INTO maintenance_logs
+			    (item_id, performed_on, technician, description, serviceable) 
+			VALUES 
+			    ($1, $2, $3, $4, $5) 
+			RETURNING id
+		), updated AS (
+			UPDATE inventory_items
+			SET serviceable = $5
+			WHERE id = $1 AND NOT EXISTS (
+				SELECT 1 FROM maintenance_logs
+				WHERE item_id = $1 AND performed_on > $2
+			)
+		)
+		SELECT id FROM inserted
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, log.ItemId, log.PerformedOn, log.Technician, log.Description, log.Serviceable).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23503" {
+				return 0, repoerrs.ErrNotFound
+			}
+		}
+		return 0, fmt.Errorf("MaintenanceRepo CreateMaintenanceLog: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *MaintenanceRepo) UpdateMaintenanceSchedule(ctx context.Context, client any, itemId int, intervalDays *int, fieldDays *int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		UPDATE inventory_items
+		SET
+			service_interval_days = $1, service_field_days = $2
+		WHERE id = $3
+	`
+	commandTag, err := pgClient.Exec(ctx, q, intervalDays, fieldDays, itemId)
+	if err != nil {
+		return fmt.Errorf("MaintenanceRepo UpdateMaintenanceSchedule: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *MaintenanceRepo) GetMaintenanceDue(ctx context.Context, client any, expeditionId int) (entity.MaintenanceDues, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH target AS (
+			SELECT start_date
+			FROM expeditions
+			WHERE id = $1
+		), last_service AS (
+			SELECT item_id, max(performed_on) AS serviced_on
+			FROM maintenance_logs
+			WHERE serviceable
+			GROUP BY item_id
+		), field AS (
+			SELECT e.item_id, count(DISTINCT d.day) AS field_days
+			FROM equipments e
+			CROSS JOIN target t
+			LEFT JOIN last_service l ON l.item_id = e.item_id
+			CROSS JOIN LATERAL generate_series(
+				greatest(e.reserved_from, coalesce(l.serviced_on + 1, e.reserved_from)),
+				least(e.reserved_to, t.start_date - 1),
+				interval '1 day'
+			) AS d(day)
+			GROUP BY e.item_id
+		)
+		SELECT i.id, i.name, i.item_type, i.serviceable, l.serviced_on,
+			i.service_interval_days, l.serviced_on + i.service_interval_days,
+			i.service_field_days, coalesce(f.field_days, 0)
+		FROM inventory_items i
+		CROSS JOIN target t
+		LEFT JOIN last_service l ON l.item_id = i.id
+		LEFT JOIN field f ON f.item_id = i.id
+		WHERE NOT i.serviceable
+			OR (i.service_interval_days IS NOT NULL
+				AND (l.serviced_on IS NULL OR l.serviced_on + i.service_interval_days <= t.start_date))
+			OR (i.service_field_days IS NOT NULL AND coalesce(f.field_days, 0) >= i.service_field_days)
+		ORDER BY i.item_type, i.name
+	`
+	rows, err := pgClient.Query(ctx, q, expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("MaintenanceRepo GetMaintenanceDue: %v", err)
+	}
+
+	dues := make(entity.MaintenanceDues, 0)
+	for rows.Next() {
+		var d entity.MaintenanceDue
+
+		err = rows.Scan(&d.ItemId, &d.Name, &d.ItemType, &d.Serviceable, &d.LastServicedOn,
+			&d.ServiceIntervalDays, &d.DueOn, &d.ServiceFieldDays, &d.FieldDays)
+		if err != nil {
+			return nil, fmt.Errorf("MaintenanceRepo GetMaintenanceDue: %v", err)
+		}
+
+		dues = append(dues, &d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("MaintenanceRepo GetMaintenanceDue: %v", err)
+	}
+
+	return dues, nil
+}
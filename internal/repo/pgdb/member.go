@@ -39,6 +39,26 @@ func (r *MemberRepo) GetMemberById(ctx context.Context, client any, id int) (*en
 	return &m, nil
 }
 
+func (r *MemberRepo) GetMemberByLogin(ctx context.Context, client any, login string) (*entity.Member, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, name, phone_number, login, password
+		FROM members
+		WHERE login = $1
+	`
+	var m entity.Member
+	err := pgClient.QueryRow(ctx, q, login).Scan(&m.Id, &m.Name, &m.PhoneNumber, &m.Login, &m.Password)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("MemberRepo GetMemberByLogin: %v", err)
+	}
+
+	return &m, nil
+}
+
 func (r *MemberRepo) GetExpeditionMembers(ctx context.Context, client any, expeditionId int) (entity.Members, error) {
 	pgClient := client.(postgres.Client)
 	q := `
@@ -0,0 +1,271 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"strings"
+)
+
+type mergeSource struct {
+	table   string
+	details string
+}
+
+var mergeSources = map[string]mergeSource{
+	entity.MergeLocation: {table: "locations", details: "concat_ws(', ', nullif(x.nearest_town, ''), nullif(x.country, ''))"},
+	entity.MergeMember:   {table: "members", details: "x.phone_number"},
+	entity.MergeLeader:   {table: "leaders", details: "x.phone_number"},
+	entity.MergeCurator:  {table: "curators", details: "concat_ws(', ', nullif(x.email, ''), nullif(x.phone, ''))"},
+}
+
+type mergeStep struct {
+	key    string
+	query  string
+	signed bool
+}
+
+var mergeSteps = map[string][]mergeStep{
+	entity.MergeLocation: {
+		{key: "expeditions", query: `UPDATE expeditions SET location_id = $1 WHERE location_id = $2`},
+		{key: "trenches", query: `UPDATE trenches SET location_id = $1 WHERE location_id = $2`},
+		{key: "artifacts", query: `UPDATE artifacts SET location_id = $1 WHERE location_id = $2`},
+		{key: "attachments_dropped", query: `
+			DELETE FROM attachments d
+			WHERE d.location_id = $2
+				AND EXISTS (SELECT 1 FROM attachments s WHERE s.location_id = $1 AND s.blob_sha256 = d.blob_sha256)
+		`},
+		{key: "attachments", query: `UPDATE attachments SET location_id = $1 WHERE location_id = $2`},
+	},
+	entity.MergeMember: {
+		{key: "artifacts", query: `UPDATE artifacts SET found_by_member_id = $1 WHERE found_by_member_id = $2`},
+		{key: "expeditions_members_dropped", query: `
+			DELETE FROM expeditions_members d
+			WHERE d.member_id = $2
+				AND EXISTS (SELECT 1 FROM expeditions_members s WHERE s.member_id = $1 AND s.expedition_id = d.expedition_id)
+		`},
+		{key: "expeditions_members", query: `UPDATE expeditions_members SET member_id = $1 WHERE member_id = $2`},
+		{key: "journal_entries", query: `UPDATE journal_entries SET member_id = $1 WHERE member_id = $2`},
+		{key: "equipment_events", query: `UPDATE equipment_events SET member_id = $1 WHERE member_id = $2`},
+		{key: "attachments", query: `UPDATE attachments SET uploaded_by_id = $1 WHERE uploaded_by_role = 'member' AND uploaded_by_id = $2`},
+		{key: "artifact_custody_signed", query: `UPDATE artifact_custody SET signed_by_id = $1 WHERE signed_by_role = 'member' AND signed_by_id = $2`},
+		{key: "artifact_custody", signed: true, query: `
+			INSERT INTO artifact_custody
+			    (artifact_id, previous_id, holder_type, holder_id, holder_name, reason, signed_by_id, signed_by_role)
+			SELECT c.artifact_id, c.id, 'member', $1, '', format('merged duplicate member #%s', $2::int), $3, $4
+			FROM artifact_custody c
+			WHERE c.holder_type = 'member' AND c.holder_id = $2
+				AND NOT EXISTS (SELECT 1 FROM artifact_custody n WHERE n.previous_id = c.id)
+		`},
+	},
+	entity.MergeLeader: {
+		{key: "expeditions_leaders_dropped", query: `
+			DELETE FROM expeditions_leaders d
+			WHERE d.leader_id = $2
+				AND EXISTS (SELECT 1 FROM expeditions_leaders s WHERE s.leader_id = $1 AND s.expedition_id = d.expedition_id)
+		`},
+		{key: "expeditions_leaders", query: `UPDATE expeditions_leaders SET leader_id = $1 WHERE leader_id = $2`},
+		{key: "journal_day_locks", query: `UPDATE journal_day_locks SET locked_by_id = $1 WHERE locked_by_id = $2`},
+		{key: "attachments", query: `UPDATE attachments SET uploaded_by_id = $1 WHERE uploaded_by_role = 'leader' AND uploaded_by_id = $2`},
+		{key: "artifact_custody_signed", query: `UPDATE artifact_custody SET signed_by_id = $1 WHERE signed_by_role = 'leader' AND signed_by_id = $2`},
+		{key: "expenses", query: `UPDATE expenses SET recorded_by_id = $1 WHERE recorded_by_role = 'leader' AND recorded_by_id = $2`},
+	},
+	entity.MergeCurator: {
+		{key: "artifacts", query: `UPDATE artifacts SET responsible_curator_id = $1 WHERE responsible_curator_id = $2`},
+		{key: "expeditions_curators_dropped", query: `
+			DELETE FROM expeditions_curators d
+			WHERE d.curator_id = $2
+				AND EXISTS (SELECT 1 FROM expeditions_curators s WHERE s.curator_id = $1 AND s.expedition_id = d.expedition_id)
+		`},
+		{key: "expeditions_curators", query: `UPDATE expeditions_curators SET curator_id = $1 WHERE curator_id = $2`},
+		{key: "attachments", query: `UPDATE attachments SET uploaded_by_id = $1 WHERE uploaded_by_role = 'curator' AND uploaded_by_id = $2`},
+		{key: "condition_reports", query: `UPDATE condition_reports SET recorded_by_id = $1 WHERE recorded_by_role = 'curator' AND recorded_by_id = $2`},
+		{key: "storage_moves", query: `UPDATE storage_moves SET moved_by_id = $1 WHERE moved_by_role = 'curator' AND moved_by_id = $2`},
+		{key: "loans", query: `UPDATE loans SET created_by_id = $1 WHERE created_by_role = 'curator' AND created_by_id = $2`},
+		{key: "artifact_custody_signed", query: `UPDATE artifact_custody SET signed_by_id = $1 WHERE signed_by_role = 'curator' AND signed_by_id = $2`},
+		{key: "artifact_custody", signed: true, query: `
+			INSERT INTO artifact_custody
+			    (artifact_id, previous_id, holder_type, holder_id, holder_name, reason, signed_by_id, signed_by_role)
+			SELECT c.artifact_id, c.id, 'curator', $1, '', format('merged duplicate curator #%s', $2::int), $3, $4
+			FROM artifact_custody c
+			WHERE c.holder_type = 'curator' AND c.holder_id = $2
+				AND NOT EXISTS (SELECT 1 FROM artifact_custody n WHERE n.previous_id = c.id)
+		`},
+	},
+}
+
+type MergeRepo struct {
+}
+
+func NewMergeRepo() *MergeRepo {
+	return &MergeRepo{}
+}
+
+func (r *MergeRepo) GetDuplicateCandidates(ctx context.Context, client any, entityType string, threshold float64, limit int) (entity.DuplicateCandidates, error) {
+	pgClient := client.(postgres.Client)
+	source, ok := mergeSources[entityType]
+	if !ok {
+		return nil, fmt.Errorf("MergeRepo GetDuplicateCandidates: unknown type %q", entityType)
+	}
+
+	q := fmt.Sprintf(`
+		SELECT a.id, a.name, %s, b.id, b.name, %s, similarity(name_key(a.name), name_key(b.name)) AS score
+		FROM %s a
+		JOIN %s b ON a.id < b.id AND name_key(a.name) %% name_key(b.name)
+		WHERE similarity(name_key(a.name), name_key(b.name)) >= $1
+		ORDER BY score DESC, a.id, b.id
+		LIMIT $2
+	`, strings.ReplaceAll(source.details, "x.", "a."), strings.ReplaceAll(source.details, "x.", "b."), source.table, source.table)
+	rows, err := pgClient.Query(ctx, q, threshold, limit)
+	if err != nil {
+		return nil, fmt.Errorf("MergeRepo GetDuplicateCandidates: %v", err)
+	}
+
+	candidates := make(entity.DuplicateCandidates, 0)
+	for rows.Next() {
+		c := entity.DuplicateCandidate{Type: entityType, First: &entity.DuplicateRecord{}, Second: &entity.DuplicateRecord{}}
+
+		err = rows.Scan(&c.First.Id, &c.First.Name, &c.First.Details, &c.Second.Id, &c.Second.Name, &c.Second.Details, &c.Similarity)
+		if err != nil {
+			return nil, fmt.Errorf("MergeRepo GetDuplicateCandidates: %v", err)
+		}
+
+		candidates = append(candidates, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("MergeRepo GetDuplicateCandidates: %v", err)
+	}
+
+	return candidates, nil
+}
+
+func (r *MergeRepo) Merge(ctx context.Context, client any, record *entity.MergeRecord) (int, error) {
+	pgClient := client.(postgres.Client)
+	source, ok := mergeSources[record.EntityType]
+	if !ok {
+		return 0, fmt.Errorf("MergeRepo Merge: unknown type %q", record.EntityType)
+	}
+
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("MergeRepo Merge: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `SELECT set_config('app.merging', 'on', true)`); err != nil {
+		return 0, fmt.Errorf("MergeRepo Merge: %v", err)
+	}
+
+	q := fmt.Sprintf(`SELECT id, name FROM %s WHERE id = ANY(ARRAY[$1, $2]::int[]) ORDER BY id FOR UPDATE`, source.table)
+	rows, err := tx.Query(ctx, q, record.SurvivorId, record.MergedId)
+	if err != nil {
+		return 0, fmt.Errorf("MergeRepo Merge: %v", err)
+	}
+	found := 0
+	for rows.Next() {
+		var id int
+		var name string
+		if err = rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("MergeRepo Merge: %v", err)
+		}
+		if id == record.MergedId {
+			record.MergedName = name
+		}
+		found++
+	}
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("MergeRepo Merge: %v", err)
+	}
+	if found != 2 {
+		return 0, repoerrs.ErrNotFound
+	}
+
+	record.Reassigned = make(map[string]int64)
+	for _, step := range mergeSteps[record.EntityType] {
+		args := []any{record.SurvivorId, record.MergedId}
+		if step.signed {
+			args = append(args, record.MergedById, record.MergedByRole)
+		}
+
+		commandTag, err := tx.Exec(ctx, step.query, args...)
+		if err != nil {
+			return 0, mergeError(step.key, err)
+		}
+		record.Reassigned[step.key] = commandTag.RowsAffected()
+	}
+
+	q = fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, source.table)
+	if _, err = tx.Exec(ctx, q, record.MergedId); err != nil {
+		return 0, mergeError("delete", err)
+	}
+
+	q = `
+		INSERT INTO merge_history
+		    (entity_type, survivor_id, merged_id, merged_name, reassigned, merged_by_id, merged_by_role)
+		VALUES
+		    ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, merged_at
+	`
+	err = tx.QueryRow(ctx, q, record.EntityType, record.SurvivorId, record.MergedId, record.MergedName, record.Reassigned,
+		record.MergedById, record.MergedByRole).Scan(&record.Id, &record.MergedAt)
+	if err != nil {
+		return 0, fmt.Errorf("MergeRepo Merge: %v", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("MergeRepo Merge: %v", err)
+	}
+
+	return record.Id, nil
+}
+
+func (r *MergeRepo) GetMergeHistory(ctx context.Context, client any, entityType string) (entity.MergeRecords, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, entity_type, survivor_id, merged_id, merged_name, reassigned, merged_by_id, merged_by_role, merged_at
+		FROM merge_history
+		WHERE $1 = '' OR entity_type = $1
+		ORDER BY merged_at DESC, id DESC
+	`
+	rows, err := pgClient.Query(ctx, q, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("MergeRepo GetMergeHistory: %v", err)
+	}
+
+	records := make(entity.MergeRecords, 0)
+	for rows.Next() {
+		var mr entity.MergeRecord
+
+		err = rows.Scan(&mr.Id, &mr.EntityType, &mr.SurvivorId, &mr.MergedId, &mr.MergedName, &mr.Reassigned,
+			&mr.MergedById, &mr.MergedByRole, &mr.MergedAt)
+		if err != nil {
+			return nil, fmt.Errorf("MergeRepo GetMergeHistory: %v", err)
+		}
+
+		records = append(records, &mr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("MergeRepo GetMergeHistory: %v", err)
+	}
+
+	return records, nil
+}
+
+func mergeError(step string, err error) error {
+	var pgErr *pgconn.PgError
+	if ok := errors.As(err, &pgErr); ok {
+		switch pgErr.Code {
+		case "23505", "23503", "23514", "23P01", "P0001":
+			return fmt.Errorf("%w: %s: %s", repoerrs.ErrConflict, step, pgErr.Message)
+		}
+	}
+
+	return fmt.Errorf("MergeRepo Merge %s: %v", step, err)
+}
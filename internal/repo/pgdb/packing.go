@@ -0,0 +1,236 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+type PackingRepo struct {
+}
+
+func NewPackingRepo() *PackingRepo {
+	return &PackingRepo{}
+}
+
+const packingTemplatesQuery = `
+	SELECT t.id, t.name, ti.item_id, i.name, ti.base_quantity, ti.per_person, ti.per_day, ti.per_person_day
+	FROM packing_templates t
+	JOIN packing_template_items ti ON ti.template_id = t.id
+	JOIN inventory_items i ON i.id = ti.item_id
+`
+
+func (r *PackingRepo) GetPackingTemplateById(ctx context.Context, client any, id int) (*entity.PackingTemplate, error) {
+	pgClient := client.(postgres.Client)
+	q := packingTemplatesQuery + `
+		WHERE t.id = $1
+		ORDER BY t.id, i.name
+	`
+	templates, err := r.queryPackingTemplates(ctx, pgClient, "GetPackingTemplateById", q, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return nil, repoerrs.ErrNotFound
+	}
+
+	return templates[0], nil
+}
+
+func (r *PackingRepo) GetAllPackingTemplates(ctx context.Context, client any) (entity.PackingTemplates, error) {
+	pgClient := client.(postgres.Client)
+	q := packingTemplatesQuery + `
+		ORDER BY t.name, t.id, i.name
+	`
+	return r.queryPackingTemplates(ctx, pgClient, "GetAllPackingTemplates", q)
+}
+
+func (r *PackingRepo) CreatePackingTemplate(ctx context.Context, client any, template *entity.PackingTemplate) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH template AS (
+			INSERT INTO packing_templates
+			    (name)
+			VALUES
+			    ($1)
+			RETURNING id
+		), items AS (
+			INSERT INTO packing_template_items
+			    (template_id, item_id, base_quantity, per_person, per_day, per_person_day)
+			SELECT template.id, ti.item_id, ti.base_quantity, ti.per_person, ti.per_day, ti.per_person_day
+			FROM template, unnest($2::int[], $3::numeric[], $4::numeric[], $5::numeric[], $6::numeric[])
+				AS ti(item_id, base_quantity, per_person, per_day, per_person_day)
+		)
+		SELECT id FROM template
+	`
+	itemIds := make([]int, 0, len(template.Items))
+	base := make([]float64, 0, len(template.Items))
+	perPerson := make([]float64, 0, len(template.Items))
+	perDay := make([]float64, 0, len(template.Items))
+	perPersonDay := make([]float64, 0, len(template.Items))
+	for _, item := range template.Items {
+		itemIds = append(itemIds, item.ItemId)
+		base = append(base, item.BaseQuantity)
+		perPerson = append(perPerson, item.PerPerson)
+		perDay = append(perDay, item.PerDay)
+		perPersonDay = append(perPersonDay, item.PerPersonDay)
+	}
+
+	var id int
+	err := pgClient.QueryRow(ctx, q, template.Name, itemIds, base, perPerson, perDay, perPersonDay).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			switch pgErr.Code {
+			case "23505":
+				return 0, repoerrs.ErrAlreadyExists
+			case "23503":
+				return 0, repoerrs.ErrNotFound
+			}
+		}
+		return 0, fmt.Errorf("PackingRepo CreatePackingTemplate: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *PackingRepo) DeletePackingTemplate(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		DELETE FROM packing_templates
+		WHERE id = $1
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("PackingRepo DeletePackingTemplate: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *PackingRepo) ApplyPackingTemplate(ctx context.Context, client any, expeditionId int, templateId int, equipments entity.Equipments) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH applied AS (
+			INSERT INTO expedition_packing_lists
+			    (expedition_id, template_id)
+			VALUES
+			    ($1, $2)
+			RETURNING expedition_id, template_id
+		)
+		INSERT INTO equipments
+		    (expedition_id, item_id, amount, reserved_from, reserved_to, packing_template_id)
+		SELECT applied.expedition_id, e.item_id, e.amount, e.reserved_from, e.reserved_to, applied.template_id
+		FROM applied, unnest($3::int[], $4::int[], $5::date[], $6::date[])
+			AS e(item_id, amount, reserved_from, reserved_to)
+	`
+	itemIds := make([]int, 0, len(equipments))
+	amounts := make([]int, 0, len(equipments))
+	from := make([]time.Time, 0, len(equipments))
+	to := make([]time.Time, 0, len(equipments))
+	for _, eq := range equipments {
+		itemIds = append(itemIds, eq.ItemId)
+		amounts = append(amounts, eq.Amount)
+		from = append(from, eq.ReservedFrom)
+		to = append(to, eq.ReservedTo)
+	}
+
+	_, err := pgClient.Exec(ctx, q, expeditionId, templateId, itemIds, amounts, from, to)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			switch pgErr.Code {
+			case "23505":
+				return repoerrs.ErrAlreadyExists
+			case "23514":
+				return repoerrs.ErrConflict
+			case "23503":
+				return repoerrs.ErrNotFound
+			}
+		}
+		return fmt.Errorf("PackingRepo ApplyPackingTemplate: %v", err)
+	}
+
+	return nil
+}
+
+func (r *PackingRepo) GetPackingListReservations(ctx context.Context, client any, expeditionId int, templateId int) (entity.PackingListLines, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT i.id, i.name, coalesce(sum(e.amount), 0)
+		FROM expedition_packing_lists p
+		JOIN packing_template_items ti ON ti.template_id = p.template_id
+		JOIN inventory_items i ON i.id = ti.item_id
+		LEFT JOIN equipments e ON e.expedition_id = p.expedition_id
+			AND e.packing_template_id = p.template_id
+			AND e.item_id = ti.item_id
+		WHERE p.expedition_id = $1 AND p.template_id = $2
+		GROUP BY i.id
+		ORDER BY i.name
+	`
+	rows, err := pgClient.Query(ctx, q, expeditionId, templateId)
+	if err != nil {
+		return nil, fmt.Errorf("PackingRepo GetPackingListReservations: %v", err)
+	}
+
+	lines := make(entity.PackingListLines, 0)
+	for rows.Next() {
+		var l entity.PackingListLine
+
+		err = rows.Scan(&l.ItemId, &l.Name, &l.Reserved)
+		if err != nil {
+			return nil, fmt.Errorf("PackingRepo GetPackingListReservations: %v", err)
+		}
+
+		lines = append(lines, &l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PackingRepo GetPackingListReservations: %v", err)
+	}
+	if len(lines) == 0 {
+		return nil, repoerrs.ErrNotFound
+	}
+
+	return lines, nil
+}
+
+func (r *PackingRepo) queryPackingTemplates(ctx context.Context, pgClient postgres.Client, method string, q string, args ...any) (entity.PackingTemplates, error) {
+	rows, err := pgClient.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PackingRepo %s: %v", method, err)
+	}
+
+	templates := make(entity.PackingTemplates, 0)
+	var current *entity.PackingTemplate
+	for rows.Next() {
+		var id int
+		var name string
+		var item entity.PackingTemplateItem
+
+		err = rows.Scan(&id, &name, &item.ItemId, &item.Name, &item.BaseQuantity, &item.PerPerson, &item.PerDay, &item.PerPersonDay)
+		if err != nil {
+			return nil, fmt.Errorf("PackingRepo %s: %v", method, err)
+		}
+
+		if current == nil || current.Id != id {
+			current = &entity.PackingTemplate{Id: id, Name: name, Items: make(entity.PackingTemplateItems, 0)}
+			templates = append(templates, current)
+		}
+		current.Items = append(current.Items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PackingRepo %s: %v", method, err)
+	}
+
+	return templates, nil
+}
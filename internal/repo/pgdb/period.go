@@ -0,0 +1,113 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pkgErrors "github.com/pkg/errors"
+)
+
+type PeriodRepo struct {
+}
+
+func NewPeriodRepo() *PeriodRepo {
+	return &PeriodRepo{}
+}
+
+func (r *PeriodRepo) GetPeriodById(ctx context.Context, client any, id int) (*entity.Period, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, parent_id, name, start_year, end_year
+		FROM periods
+		WHERE id = $1
+	`
+	var p entity.Period
+	err := pgClient.QueryRow(ctx, q, id).Scan(&p.Id, &p.ParentId, &p.Name, &p.StartYear, &p.EndYear)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("PeriodRepo GetPeriodById: %v", err)
+	}
+
+	return &p, nil
+}
+
+func (r *PeriodRepo) GetAllPeriods(ctx context.Context, client any) (entity.Periods, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, parent_id, name, start_year, end_year
+		FROM periods
+		ORDER BY start_year, end_year, id
+	`
+	rows, err := pgClient.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("PeriodRepo GetAllPeriods: %v", err)
+	}
+
+	periods := make(entity.Periods, 0)
+	for rows.Next() {
+		var p entity.Period
+
+		err = rows.Scan(&p.Id, &p.ParentId, &p.Name, &p.StartYear, &p.EndYear)
+		if err != nil {
+			return nil, fmt.Errorf("PeriodRepo GetAllPeriods: %v", err)
+		}
+
+		periods = append(periods, &p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PeriodRepo GetAllPeriods: %v", err)
+	}
+
+	return periods, nil
+}
+
+func (r *PeriodRepo) CreatePeriod(ctx context.Context, client any, period *entity.Period) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO periods
+		    (parent_id, name, start_year, end_year) 
+		VALUES 
+		    ($1, $2, $3, $4) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, period.ParentId, period.Name, period.StartYear, period.EndYear).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return 0, repoerrs.ErrAlreadyExists
+			}
+		}
+		return 0, fmt.Errorf("PeriodRepo CreatePeriod: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *PeriodRepo) DeletePeriod(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		DELETE FROM periods
+		WHERE id = $1
+	`
+	ct, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("PeriodRepo DeletePeriod: %v", err)
+	}
+
+	if ct.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	pkgErrors "github.com/pkg/errors"
+)
+
+type SampleRepo struct {
+}
+
+func NewSampleRepo() *SampleRepo {
+	return &SampleRepo{}
+}
+
+func (r *SampleRepo) GetSampleById(ctx context.Context, client any, id int) (*entity.Sample, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, expedition_id, artifact_id, context_id, sample_type, lab, submitted_on, status, results
+		FROM samples
+		WHERE id = $1
+	`
+	var s entity.Sample
+	err := pgClient.QueryRow(ctx, q, id).Scan(&s.Id, &s.ExpeditionId, &s.ArtifactId, &s.ContextId, &s.Type, &s.Lab, &s.SubmittedOn,
+		&s.Status, &s.Results)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("SampleRepo GetSampleById: %v", err)
+	}
+
+	return &s, nil
+}
+
+func (r *SampleRepo) GetAllSamples(ctx context.Context, client any, filter *entity.SampleFilter) (entity.Samples, error) {
+	pgClient := client.(postgres.Client)
+	var cond conditions
+	if filter != nil {
+		if filter.ExpeditionId != nil {
+			cond.add("expedition_id = %s", *filter.ExpeditionId)
+		}
+		if filter.Status != "" {
+			cond.add("status = %s", filter.Status)
+		}
+		if filter.Lab != "" {
+			cond.add("lab = %s", filter.Lab)
+		}
+	}
+	q := `
+		SELECT id, expedition_id, artifact_id, context_id, sample_type, lab, submitted_on, status, results
+		FROM samples
+	` + cond.sql() + `
+		ORDER BY id
+	`
+	rows, err := pgClient.Query(ctx, q, cond.args...)
+	if err != nil {
+		return nil, fmt.Errorf("SampleRepo GetAllSamples: %v", err)
+	}
+
+	samples := make(entity.Samples, 0)
+	for rows.Next() {
+		var s entity.Sample
+
+		err = rows.Scan(&s.Id, &s.ExpeditionId, &s.ArtifactId, &s.ContextId, &s.Type, &s.Lab, &s.SubmittedOn, &s.Status, &s.Results)
+		if err != nil {
+			return nil, fmt.Errorf("SampleRepo GetAllSamples: %v", err)
+		}
+
+		samples = append(samples, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("SampleRepo GetAllSamples: %v", err)
+	}
+
+	return samples, nil
+}
+
+func (r *SampleRepo) CreateSample(ctx context.Context, client any, sample *entity.Sample) (int, error) {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("SampleRepo CreateSample: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := `
+		INSERT INTO samples
+		    (expedition_id, artifact_id, context_id, sample_type, lab, submitted_on, status, results) 
+		VALUES 
+		    ($1, $2, $3, $4, $5, $6, $7, $8) 
+		RETURNING id
+	`
+	var id int
+	err = tx.QueryRow(ctx, q, sample.ExpeditionId, sample.ArtifactId, sample.ContextId, sample.Type, sample.Lab, sample.SubmittedOn,
+		sample.Status, sample.Results).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("SampleRepo CreateSample: %v", err)
+	}
+
+	if sample.ArtifactId != nil {
+		acceptedId := 0
+		if isDatingSample(sample.Status, sample.Results) {
+			acceptedId = id
+		}
+		if err = syncSampleArtifactDating(ctx, tx, *sample.ArtifactId, acceptedId); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("SampleRepo CreateSample: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *SampleRepo) UpdateSample(ctx context.Context, client any, sample *entity.Sample) error {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("SampleRepo UpdateSample: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := `
+		SELECT artifact_id, status, results
+		FROM samples
+		WHERE id = $1
+		FOR UPDATE
+	`
+	var (
+		artifactId *int
+		status     string
+		results    entity.SampleResults
+	)
+	err = tx.QueryRow(ctx, q, sample.Id).Scan(&artifactId, &status, &results)
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("SampleRepo UpdateSample: %v", err)
+	}
+
+	q = `
+		UPDATE samples
+		SET
+			lab = $1, submitted_on = $2, status = $3, results = $4
+		WHERE id = $5
+	`
+	_, err = tx.Exec(ctx, q, sample.Lab, sample.SubmittedOn, sample.Status, sample.Results, sample.Id)
+	if err != nil {
+		return fmt.Errorf("SampleRepo UpdateSample: %v", err)
+	}
+
+	if artifactId != nil {
+		acceptedId := 0
+		if !isDatingSample(status, results) && isDatingSample(sample.Status, sample.Results) {
+			acceptedId = sample.Id
+		}
+		if err = syncSampleArtifactDating(ctx, tx, *artifactId, acceptedId); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("SampleRepo UpdateSample: %v", err)
+	}
+
+	return nil
+}
+
+func (r *SampleRepo) DeleteSample(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("SampleRepo DeleteSample: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := `
+		DELETE FROM samples
+		WHERE id = $1
+		RETURNING artifact_id
+	`
+	var artifactId *int
+	err = tx.QueryRow(ctx, q, id).Scan(&artifactId)
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("SampleRepo DeleteSample: %v", err)
+	}
+
+	if artifactId != nil {
+		if err = syncSampleArtifactDating(ctx, tx, *artifactId, 0); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("SampleRepo DeleteSample: %v", err)
+	}
+
+	return nil
+}
+
+func isDatingSample(status string, results entity.SampleResults) bool {
+	return status == entity.SampleAccepted && results.Radiocarbon != nil
+}
+
+func syncSampleArtifactDating(ctx context.Context, tx pgx.Tx, artifactId int, acceptedId int) error {
+	q := `
+		SELECT
+			earliest_year, latest_year, central_year, error_years, dating_method, dating_confidence, period_id,
+			radiocarbon_age_bp, radiocarbon_sigma, radiocarbon_sample_id, prior_dating
+		FROM artifacts
+		WHERE id = $1
+		FOR UPDATE
+	`
+	var (
+		current  entity.Dating
+		sampleId *int
+		prior    *entity.Dating
+	)
+	err := tx.QueryRow(ctx, q, artifactId).Scan(&current.EarliestYear, &current.LatestYear, &current.CentralYear,
+		&current.ErrorYears, &current.Method, &current.Confidence, &current.PeriodId, &current.RadiocarbonAgeBP,
+		&current.RadiocarbonSigma, &sampleId, &prior)
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("SampleRepo syncSampleArtifactDating: %v", err)
+	}
+
+	q = `
+		SELECT id, results
+		FROM samples
+		WHERE artifact_id = $1 AND status = $2 AND results -> 'radiocarbon' IS NOT NULL
+		ORDER BY id = $3 DESC, coalesce(id = $4, false) DESC, id DESC
+		LIMIT 1
+	`
+	var (
+		datingSampleId int
+		results        entity.SampleResults
+	)
+	err = tx.QueryRow(ctx, q, artifactId, entity.SampleAccepted, acceptedId, sampleId).Scan(&datingSampleId, &results)
+	if err != nil && !pkgErrors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("SampleRepo syncSampleArtifactDating: %v", err)
+	}
+
+	if err != nil {
+		if prior == nil {
+			return nil
+		}
+		return setSampleArtifactDating(ctx, tx, artifactId, prior, nil, nil)
+	}
+
+	if prior == nil {
+		prior = &current
+	}
+	dating := results.Radiocarbon.Dating()
+	dating.PeriodId = current.PeriodId
+
+	return setSampleArtifactDating(ctx, tx, artifactId, &dating, &datingSampleId, prior)
+}
+
+func setSampleArtifactDating(ctx context.Context, tx pgx.Tx, artifactId int, dating *entity.Dating, sampleId *int,
+	prior *entity.Dating) error {
+	q := `
+		UPDATE artifacts
+		SET
+			earliest_year = $1, latest_year = $2, central_year = $3, error_years = $4,
+			dating_method = $5, dating_confidence = $6, period_id = $7, radiocarbon_age_bp = $8, radiocarbon_sigma = $9,
+			radiocarbon_sample_id = $10, prior_dating = $11
+		WHERE id = $12
+	`
+	_, err := tx.Exec(ctx, q, dating.EarliestYear, dating.LatestYear, dating.CentralYear, dating.ErrorYears,
+		dating.Method, dating.Confidence, dating.PeriodId, dating.RadiocarbonAgeBP, dating.RadiocarbonSigma, sampleId, prior,
+		artifactId)
+	if err != nil {
+		return fmt.Errorf("SampleRepo setSampleArtifactDating: %v", err)
+	}
+
+	return nil
+}
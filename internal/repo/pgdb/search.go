@@ -0,0 +1,104 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/pkg/postgres"
+	"fmt"
+)
+
+type SearchRepo struct {
+}
+
+func NewSearchRepo() *SearchRepo {
+	return &SearchRepo{}
+}
+
+func (r *SearchRepo) Search(ctx context.Context, client any, scope *entity.SearchScope) (entity.SearchHits, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		WITH query AS (
+			SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS tsq
+		), shared_expeditions AS (
+			SELECT expedition_id FROM expeditions_members WHERE $3 = 'member' AND member_id = $4
+			UNION
+			SELECT expedition_id FROM expeditions_curators WHERE $3 = 'curator' AND curator_id = $4
+			UNION
+			SELECT expedition_id FROM expeditions_leaders WHERE $3 = 'leader' AND leader_id = $4
+		), hits AS (
+			SELECT 'artifact' AS type, a.id, a.name AS title, a.catalog_number AS subtitle,
+				concat_ws(' — ', a.name, nullif(a.find_context, '')) AS document,
+				ts_rank(a.search_vector, query.tsq) AS rank
+			FROM artifacts a
+			CROSS JOIN query
+			WHERE 'artifact' = ANY($2::text[]) AND a.search_vector @@ query.tsq
+			UNION ALL
+			SELECT 'location', l.id, l.name, concat_ws(', ', nullif(l.nearest_town, ''), nullif(l.country, '')),
+				concat_ws(' — ', l.name, nullif(l.nearest_town, ''), nullif(l.country, '')),
+				ts_rank(l.search_vector, query.tsq)
+			FROM locations l
+			CROSS JOIN query
+			WHERE 'location' = ANY($2::text[]) AND l.search_vector @@ query.tsq
+			UNION ALL
+			SELECT 'curator', c.id, c.name, coalesce(i.name, ''), c.name, ts_rank(c.search_vector, query.tsq)
+			FROM curators c
+			LEFT JOIN institutions i ON i.id = c.institution_id
+			CROSS JOIN query
+			WHERE 'curator' = ANY($2::text[]) AND c.search_vector @@ query.tsq
+			UNION ALL
+			SELECT 'leader', p.id, p.name, '', p.name, ts_rank(p.search_vector, query.tsq)
+			FROM leaders p
+			CROSS JOIN query
+			WHERE 'leader' = ANY($2::text[]) AND p.search_vector @@ query.tsq
+				AND (NOT $5 OR ($3 = 'leader' AND p.id = $4) OR EXISTS (
+					SELECT 1
+					FROM expeditions_leaders el
+					JOIN shared_expeditions se ON se.expedition_id = el.expedition_id
+					WHERE el.leader_id = p.id
+				))
+			UNION ALL
+			SELECT 'member', p.id, p.name, '', p.name, ts_rank(p.search_vector, query.tsq)
+			FROM members p
+			CROSS JOIN query
+			WHERE 'member' = ANY($2::text[]) AND p.search_vector @@ query.tsq
+				AND (NOT $5 OR ($3 = 'member' AND p.id = $4) OR EXISTS (
+					SELECT 1
+					FROM expeditions_members em
+					JOIN shared_expeditions se ON se.expedition_id = em.expedition_id
+					WHERE em.member_id = p.id
+				))
+		)
+		SELECT h.type, h.id, h.title, h.subtitle,
+			ts_headline(CASE WHEN h.document ~ '[А-Яа-яЁё]' THEN 'russian' ELSE 'english' END::regconfig, h.document, query.tsq, $6),
+			h.rank
+		FROM hits h
+		CROSS JOIN query
+		ORDER BY h.rank DESC, h.type, h.id
+		LIMIT $7 OFFSET $8
+	`
+	options := fmt.Sprintf(`StartSel=%s, StopSel=%s, MinWords=8, MaxWords=24, MaxFragments=2, FragmentDelimiter=" … "`,
+		entity.SearchHighlightStart, entity.SearchHighlightStop)
+	rows, err := pgClient.Query(ctx, q, scope.Text, scope.Types, scope.UserRole, scope.UserId, scope.SharedExpeditionsOnly,
+		options, scope.Limit, scope.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("SearchRepo Search: %v", err)
+	}
+
+	hits := make(entity.SearchHits, 0)
+	for rows.Next() {
+		var h entity.SearchHit
+
+		err = rows.Scan(&h.Type, &h.Id, &h.Title, &h.Subtitle, &h.Highlight, &h.Rank)
+		if err != nil {
+			return nil, fmt.Errorf("SearchRepo Search: %v", err)
+		}
+
+		hits = append(hits, &h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("SearchRepo Search: %v", err)
+	}
+
+	return hits, nil
+}
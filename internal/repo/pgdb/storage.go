@@ -0,0 +1,296 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pkgErrors "github.com/pkg/errors"
+)
+
+const storageTree = `
+	WITH RECURSIVE tree AS (
+		SELECT id, parent_id, kind, name, capacity, ARRAY[name] AS path
+		FROM storage_nodes
+		WHERE parent_id IS NULL
+		UNION ALL
+		SELECT n.id, n.parent_id, n.kind, n.name, n.capacity, t.path || n.name
+		FROM storage_nodes n
+		JOIN tree t ON n.parent_id = t.id
+	)
+`
+
+type StorageRepo struct {
+}
+
+func NewStorageRepo() *StorageRepo {
+	return &StorageRepo{}
+}
+
+func (r *StorageRepo) GetStorageNodeById(ctx context.Context, client any, id int) (*entity.StorageNode, error) {
+	pgClient := client.(postgres.Client)
+	q := storageTree + `
+		SELECT id, parent_id, kind, name, capacity, array_to_string(path, ' / ')
+		FROM tree
+		WHERE id = $1
+	`
+	var n entity.StorageNode
+	err := pgClient.QueryRow(ctx, q, id).Scan(&n.Id, &n.ParentId, &n.Kind, &n.Name, &n.Capacity, &n.Path)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("StorageRepo GetStorageNodeById: %v", err)
+	}
+
+	return &n, nil
+}
+
+func (r *StorageRepo) GetAllStorageNodes(ctx context.Context, client any) (entity.StorageNodes, error) {
+	pgClient := client.(postgres.Client)
+	q := storageTree + `
+		SELECT id, parent_id, kind, name, capacity, array_to_string(path, ' / ')
+		FROM tree
+		ORDER BY path
+	`
+	rows, err := pgClient.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("StorageRepo GetAllStorageNodes: %v", err)
+	}
+
+	nodes := make(entity.StorageNodes, 0)
+	for rows.Next() {
+		var n entity.StorageNode
+
+		err = rows.Scan(&n.Id, &n.ParentId, &n.Kind, &n.Name, &n.Capacity, &n.Path)
+		if err != nil {
+			return nil, fmt.Errorf("StorageRepo GetAllStorageNodes: %v", err)
+		}
+
+		nodes = append(nodes, &n)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("StorageRepo GetAllStorageNodes: %v", err)
+	}
+
+	return nodes, nil
+}
+
+func (r *StorageRepo) CreateStorageNode(ctx context.Context, client any, node *entity.StorageNode) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO storage_nodes
+		    (parent_id, kind, name, capacity) 
+		VALUES 
+		    ($1, $2, $3, $4) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, node.ParentId, node.Kind, node.Name, node.Capacity).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return 0, repoerrs.ErrAlreadyExists
+			}
+		}
+		return 0, fmt.Errorf("StorageRepo CreateStorageNode: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *StorageRepo) DeleteStorageNode(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		DELETE FROM storage_nodes
+		WHERE id = $1
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23503" {
+				return repoerrs.ErrInUse
+			}
+		}
+		return fmt.Errorf("StorageRepo DeleteStorageNode: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *StorageRepo) CountStorageNodeArtifacts(ctx context.Context, client any, nodeId int) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT count(*)
+		FROM artifacts
+		WHERE storage_node_id = $1
+	`
+	var count int
+	err := pgClient.QueryRow(ctx, q, nodeId).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("StorageRepo CountStorageNodeArtifacts: %v", err)
+	}
+
+	return count, nil
+}
+
+func (r *StorageRepo) MoveArtifact(ctx context.Context, client any, move *entity.StorageMove) (int, error) {
+	pgClient := client.(postgres.Client)
+	tx, err := pgClient.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("StorageRepo MoveArtifact: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var capacity *int
+	err = tx.QueryRow(ctx, `SELECT capacity FROM storage_nodes WHERE id = $1 FOR UPDATE`, move.ToNodeId).Scan(&capacity)
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return 0, repoerrs.ErrNotFound
+		}
+		return 0, fmt.Errorf("StorageRepo MoveArtifact: %v", err)
+	}
+
+	var fromNodeId *int
+	err = tx.QueryRow(ctx, `SELECT storage_node_id FROM artifacts WHERE id = $1 FOR UPDATE`, move.ArtifactId).Scan(&fromNodeId)
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return 0, repoerrs.ErrNotFound
+		}
+		return 0, fmt.Errorf("StorageRepo MoveArtifact: %v", err)
+	}
+
+	if capacity != nil {
+		var count int
+		q := `SELECT count(*) FROM artifacts WHERE storage_node_id = $1 AND id <> $2`
+		if err = tx.QueryRow(ctx, q, move.ToNodeId, move.ArtifactId).Scan(&count); err != nil {
+			return 0, fmt.Errorf("StorageRepo MoveArtifact: %v", err)
+		}
+		if count >= *capacity {
+			return 0, repoerrs.ErrConflict
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE artifacts SET storage_node_id = $1 WHERE id = $2`, move.ToNodeId, move.ArtifactId); err != nil {
+		return 0, fmt.Errorf("StorageRepo MoveArtifact: %v", err)
+	}
+
+	q := `
+		INSERT INTO storage_moves
+		    (artifact_id, from_node_id, to_node_id, reason, moved_by_id, moved_by_role)
+		VALUES
+		    ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	var id int
+	err = tx.QueryRow(ctx, q, move.ArtifactId, fromNodeId, move.ToNodeId, move.Reason, move.MovedById, move.MovedByRole).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("StorageRepo MoveArtifact: %v", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("StorageRepo MoveArtifact: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *StorageRepo) GetArtifactStorageMoves(ctx context.Context, client any, artifactId int) (entity.StorageMoves, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, artifact_id, from_node_id, to_node_id, reason, moved_at, moved_by_id, moved_by_role
+		FROM storage_moves
+		WHERE artifact_id = $1
+		ORDER BY moved_at, id
+	`
+	rows, err := pgClient.Query(ctx, q, artifactId)
+	if err != nil {
+		return nil, fmt.Errorf("StorageRepo GetArtifactStorageMoves: %v", err)
+	}
+
+	moves := make(entity.StorageMoves, 0)
+	for rows.Next() {
+		var m entity.StorageMove
+
+		err = rows.Scan(&m.Id, &m.ArtifactId, &m.FromNodeId, &m.ToNodeId, &m.Reason, &m.MovedAt, &m.MovedById, &m.MovedByRole)
+		if err != nil {
+			return nil, fmt.Errorf("StorageRepo GetArtifactStorageMoves: %v", err)
+		}
+
+		moves = append(moves, &m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("StorageRepo GetArtifactStorageMoves: %v", err)
+	}
+
+	return moves, nil
+}
+
+func (r *StorageRepo) GetStorageContents(ctx context.Context, client any, nodeId int) (entity.StoredArtifacts, error) {
+	pgClient := client.(postgres.Client)
+	q := storageTree + `, subtree AS (
+		SELECT id
+		FROM storage_nodes
+		WHERE id = $1
+		UNION ALL
+		SELECT n.id
+		FROM storage_nodes n
+		JOIN subtree s ON n.parent_id = s.id
+	)
+		SELECT a.id, a.name, a.storage_node_id, array_to_string(t.path, ' / ')
+		FROM artifacts a
+		JOIN tree t ON t.id = a.storage_node_id
+		WHERE a.storage_node_id IN (SELECT id FROM subtree)
+		ORDER BY t.path, a.id
+	`
+	return r.queryStoredArtifacts(ctx, pgClient, "GetStorageContents", q, nodeId)
+}
+
+func (r *StorageRepo) GetPickList(ctx context.Context, client any, artifactIds []int) (entity.StoredArtifacts, error) {
+	pgClient := client.(postgres.Client)
+	q := storageTree + `
+		SELECT a.id, a.name, a.storage_node_id, coalesce(array_to_string(t.path, ' / '), '')
+		FROM artifacts a
+		LEFT JOIN tree t ON t.id = a.storage_node_id
+		WHERE a.id = ANY($1)
+		ORDER BY t.path NULLS LAST, a.id
+	`
+	return r.queryStoredArtifacts(ctx, pgClient, "GetPickList", q, artifactIds)
+}
+
+func (r *StorageRepo) queryStoredArtifacts(ctx context.Context, pgClient postgres.Client, method string, q string, args ...any) (entity.StoredArtifacts, error) {
+	rows, err := pgClient.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("StorageRepo %s: %v", method, err)
+	}
+
+	artifacts := make(entity.StoredArtifacts, 0)
+	for rows.Next() {
+		var sa entity.StoredArtifact
+
+		err = rows.Scan(&sa.ArtifactId, &sa.Name, &sa.StorageNodeId, &sa.Path)
+		if err != nil {
+			return nil, fmt.Errorf("StorageRepo %s: %v", method, err)
+		}
+
+		artifacts = append(artifacts, &sa)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("StorageRepo %s: %v", method, err)
+	}
+
+	return artifacts, nil
+}
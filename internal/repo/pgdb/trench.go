@@ -0,0 +1,104 @@
+package pgdb
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/postgres"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	pkgErrors "github.com/pkg/errors"
+)
+
+type TrenchRepo struct {
+}
+
+func NewTrenchRepo() *TrenchRepo {
+	return &TrenchRepo{}
+}
+
+func (r *TrenchRepo) GetTrenchById(ctx context.Context, client any, id int) (*entity.Trench, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, location_id, name, description
+		FROM trenches
+		WHERE id = $1
+	`
+	var t entity.Trench
+	err := pgClient.QueryRow(ctx, q, id).Scan(&t.Id, &t.LocationId, &t.Name, &t.Description)
+
+	if err != nil {
+		if pkgErrors.Is(err, pgx.ErrNoRows) {
+			return nil, repoerrs.ErrNotFound
+		}
+		return nil, fmt.Errorf("TrenchRepo GetTrenchById: %v", err)
+	}
+
+	return &t, nil
+}
+
+func (r *TrenchRepo) GetLocationTrenches(ctx context.Context, client any, locationId int) (entity.Trenches, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		SELECT id, location_id, name, description
+		FROM trenches
+		WHERE location_id = $1
+	`
+	rows, err := pgClient.Query(ctx, q, locationId)
+	if err != nil {
+		return nil, fmt.Errorf("TrenchRepo GetLocationTrenches: %v", err)
+	}
+
+	trenches := make(entity.Trenches, 0)
+	for rows.Next() {
+		var t entity.Trench
+
+		err = rows.Scan(&t.Id, &t.LocationId, &t.Name, &t.Description)
+		if err != nil {
+			return nil, fmt.Errorf("TrenchRepo GetLocationTrenches: %v", err)
+		}
+
+		trenches = append(trenches, &t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("TrenchRepo GetLocationTrenches: %v", err)
+	}
+
+	return trenches, nil
+}
+
+func (r *TrenchRepo) CreateTrench(ctx context.Context, client any, trench *entity.Trench) (int, error) {
+	pgClient := client.(postgres.Client)
+	q := `
+		INSERT INTO trenches
+		    (location_id, name, description) 
+		VALUES 
+		    ($1, $2, $3) 
+		RETURNING id
+	`
+	var id int
+	err := pgClient.QueryRow(ctx, q, trench.LocationId, trench.Name, trench.Description).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("TrenchRepo CreateTrench: %v", err)
+	}
+
+	return id, nil
+}
+
+func (r *TrenchRepo) DeleteTrench(ctx context.Context, client any, id int) error {
+	pgClient := client.(postgres.Client)
+	q := `
+		DELETE FROM trenches
+		WHERE id = $1
+	`
+	commandTag, err := pgClient.Exec(ctx, q, id)
+	if err != nil {
+		return fmt.Errorf("TrenchRepo DeleteTrench: %v", err)
+	}
+	if commandTag.RowsAffected() != 1 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
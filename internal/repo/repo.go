@@ -7,8 +7,13 @@ import (
 	"time"
 )
 
+type AdminRepo interface {
+	GetAdminByLogin(ctx context.Context, client any, login string) (*entity.Admin, error)
+}
+
 type LeaderRepo interface {
 	GetLeaderById(ctx context.Context, client any, id int) (*entity.Leader, error)
+	GetLeaderByLogin(ctx context.Context, client any, login string) (*entity.Leader, error)
 	GetExpeditionLeaders(ctx context.Context, client any, expeditionId int) (entity.Leaders, error)
 	GetAllLeaders(ctx context.Context, client any) (entity.Leaders, error)
 	CreateLeader(ctx context.Context, client any, leader *entity.Leader) (int, error)
@@ -17,6 +22,7 @@ type LeaderRepo interface {
 
 type MemberRepo interface {
 	GetMemberById(ctx context.Context, client any, id int) (*entity.Member, error)
+	GetMemberByLogin(ctx context.Context, client any, login string) (*entity.Member, error)
 	GetExpeditionMembers(ctx context.Context, client any, expeditionId int) (entity.Members, error)
 	GetAllMembers(ctx context.Context, client any) (entity.Members, error)
 	CreateMember(ctx context.Context, client any, member *entity.Member) (int, error)
@@ -25,15 +31,27 @@ type MemberRepo interface {
 
 type CuratorRepo interface {
 	GetCuratorById(ctx context.Context, client any, id int) (*entity.Curator, error)
+	GetCuratorByLogin(ctx context.Context, client any, login string) (*entity.Curator, error)
 	GetExpeditionCurators(ctx context.Context, client any, expeditionId int) (entity.Curators, error)
+	GetInstitutionCurators(ctx context.Context, client any, institutionId int) (entity.Curators, error)
 	GetAllCurators(ctx context.Context, client any) (entity.Curators, error)
 	CreateCurator(ctx context.Context, client any, curator *entity.Curator) (int, error)
 	DeleteCurator(ctx context.Context, client any, id int) error
+	GetCuratorWorkloads(ctx context.Context, client any, inspectedBefore time.Time) (entity.CuratorWorkloads, error)
+}
+
+type InstitutionRepo interface {
+	GetInstitutionById(ctx context.Context, client any, id int) (*entity.Institution, error)
+	GetAllInstitutions(ctx context.Context, client any) (entity.Institutions, error)
+	CreateInstitution(ctx context.Context, client any, institution *entity.Institution) (int, error)
+	DeleteInstitution(ctx context.Context, client any, id int) error
 }
 
 type LocationRepo interface {
 	GetLocationById(ctx context.Context, client any, id int) (*entity.Location, error)
-	GetAllLocations(ctx context.Context, client any) (entity.Locations, error)
+	GetAllLocations(ctx context.Context, client any, filter *entity.LocationFilter) (entity.Locations, error)
+	GetSiteSummaries(ctx context.Context, client any, filter *entity.ExportFilter) (entity.SiteSummaries, error)
+	GetNearbyLocations(ctx context.Context, client any, query *entity.NearbyQuery) (entity.NearbyLocations, error)
 	CreateLocation(ctx context.Context, client any, location *entity.Location) (int, error)
 	DeleteLocation(ctx context.Context, client any, id int) error
 }
@@ -44,13 +62,112 @@ type ExpeditionRepo interface {
 	CreateExpedition(ctx context.Context, client any, expedition *entity.Expedition) (int, error)
 	UpdateExpeditionDates(ctx context.Context, client any, id int, start time.Time, end time.Time) error
 	DeleteExpedition(ctx context.Context, client any, id int) error
+	CloseExpedition(ctx context.Context, client any, id int) error
+}
+
+type TrenchRepo interface {
+	GetTrenchById(ctx context.Context, client any, id int) (*entity.Trench, error)
+	GetLocationTrenches(ctx context.Context, client any, locationId int) (entity.Trenches, error)
+	CreateTrench(ctx context.Context, client any, trench *entity.Trench) (int, error)
+	DeleteTrench(ctx context.Context, client any, id int) error
+}
+
+type ExcavationContextRepo interface {
+	GetContextById(ctx context.Context, client any, id int) (*entity.ExcavationContext, error)
+	GetTrenchContexts(ctx context.Context, client any, trenchId int) (entity.ExcavationContexts, error)
+	GetLocationContexts(ctx context.Context, client any, locationId int) (entity.ExcavationContexts, error)
+	CreateContext(ctx context.Context, client any, excavationContext *entity.ExcavationContext) (int, error)
+	DeleteContext(ctx context.Context, client any, id int) error
+	GetLocationContextRelations(ctx context.Context, client any, locationId int) (entity.ContextRelations, error)
+	CreateContextRelation(ctx context.Context, client any, relation *entity.ContextRelation) (int, error)
+	DeleteContextRelation(ctx context.Context, client any, id int) error
 }
 
 type ArtifactRepo interface {
 	GetArtifactById(ctx context.Context, client any, id int) (*entity.Artifact, error)
+	GetArtifactsByIds(ctx context.Context, client any, ids []int) (entity.Artifacts, error)
+	GetArtifactByCatalogNumber(ctx context.Context, client any, number *entity.CatalogNumber) (*entity.Artifact, error)
+	GetCatalogCandidates(ctx context.Context, client any, number *entity.CatalogNumber, limit int) (entity.CatalogCandidates, error)
 	GetLocationArtifacts(ctx context.Context, client any, locationId int) (entity.Artifacts, error)
-	GetAllArtifacts(ctx context.Context, client any) (entity.Artifacts, error)
+	GetExpeditionArtifacts(ctx context.Context, client any, expeditionId int) (entity.Artifacts, error)
+	GetContextArtifacts(ctx context.Context, client any, contextId int) (entity.Artifacts, error)
+	GetAllArtifacts(ctx context.Context, client any, filter *entity.ArtifactFilter) (entity.Artifacts, error)
 	CreateArtifact(ctx context.Context, client any, location *entity.Artifact) (int, error)
+	UpdateArtifactDating(ctx context.Context, client any, id int, dating *entity.Dating) error
+	UpdateArtifactCurator(ctx context.Context, client any, id int, curatorId *int) error
+}
+
+type PeriodRepo interface {
+	GetPeriodById(ctx context.Context, client any, id int) (*entity.Period, error)
+	GetAllPeriods(ctx context.Context, client any) (entity.Periods, error)
+	CreatePeriod(ctx context.Context, client any, period *entity.Period) (int, error)
+	DeletePeriod(ctx context.Context, client any, id int) error
+}
+
+type SampleRepo interface {
+	GetSampleById(ctx context.Context, client any, id int) (*entity.Sample, error)
+	GetAllSamples(ctx context.Context, client any, filter *entity.SampleFilter) (entity.Samples, error)
+	CreateSample(ctx context.Context, client any, sample *entity.Sample) (int, error)
+	UpdateSample(ctx context.Context, client any, sample *entity.Sample) error
+	DeleteSample(ctx context.Context, client any, id int) error
+}
+
+type CustodyRepo interface {
+	GetArtifactCustody(ctx context.Context, client any, artifactId int) (entity.CustodyRecords, error)
+	GetCurrentCustody(ctx context.Context, client any, artifactId int) (*entity.CustodyRecord, error)
+	CreateCustodyRecord(ctx context.Context, client any, record *entity.CustodyRecord) (int, error)
+}
+
+type ConditionReportRepo interface {
+	GetArtifactConditionReports(ctx context.Context, client any, artifactId int) (entity.ConditionReports, error)
+	GetCurrentCondition(ctx context.Context, client any, artifactId int) (*entity.ConditionReport, error)
+	CreateConditionReport(ctx context.Context, client any, report *entity.ConditionReport) (int, error)
+	GetOverdueInspections(ctx context.Context, client any, inspectedBefore time.Time) (entity.OverdueInspections, error)
+}
+
+type StorageRepo interface {
+	GetStorageNodeById(ctx context.Context, client any, id int) (*entity.StorageNode, error)
+	GetAllStorageNodes(ctx context.Context, client any) (entity.StorageNodes, error)
+	CreateStorageNode(ctx context.Context, client any, node *entity.StorageNode) (int, error)
+	DeleteStorageNode(ctx context.Context, client any, id int) error
+	CountStorageNodeArtifacts(ctx context.Context, client any, nodeId int) (int, error)
+	MoveArtifact(ctx context.Context, client any, move *entity.StorageMove) (int, error)
+	GetArtifactStorageMoves(ctx context.Context, client any, artifactId int) (entity.StorageMoves, error)
+	GetStorageContents(ctx context.Context, client any, nodeId int) (entity.StoredArtifacts, error)
+	GetPickList(ctx context.Context, client any, artifactIds []int) (entity.StoredArtifacts, error)
+}
+
+type LoanRepo interface {
+	GetLoanById(ctx context.Context, client any, id int) (*entity.Loan, error)
+	GetAllLoans(ctx context.Context, client any) (entity.Loans, error)
+	GetArtifactLoans(ctx context.Context, client any, artifactId int) (entity.Loans, error)
+	CreateLoan(ctx context.Context, client any, loan *entity.Loan) (int, error)
+	UpdateLoanStatus(ctx context.Context, client any, id int, from string, to string, returnedOn *time.Time, custody entity.CustodyRecords) error
+	GetOverdueLoans(ctx context.Context, client any, today time.Time) (entity.OverdueLoans, error)
+}
+
+type InventoryRepo interface {
+	GetInventoryItemById(ctx context.Context, client any, id int) (*entity.InventoryItem, error)
+	GetAllInventoryItems(ctx context.Context, client any) (entity.InventoryItems, error)
+	CreateInventoryItem(ctx context.Context, client any, item *entity.InventoryItem) (int, error)
+	DeleteInventoryItem(ctx context.Context, client any, id int) error
+	GetItemAvailability(ctx context.Context, client any, filter *entity.AvailabilityFilter) (entity.ItemAvailabilities, error)
+}
+
+type MaintenanceRepo interface {
+	GetItemMaintenanceLogs(ctx context.Context, client any, itemId int) (entity.MaintenanceLogs, error)
+	CreateMaintenanceLog(ctx context.Context, client any, log *entity.MaintenanceLog) (int, error)
+	UpdateMaintenanceSchedule(ctx context.Context, client any, itemId int, intervalDays *int, fieldDays *int) error
+	GetMaintenanceDue(ctx context.Context, client any, expeditionId int) (entity.MaintenanceDues, error)
+}
+
+type PackingRepo interface {
+	GetPackingTemplateById(ctx context.Context, client any, id int) (*entity.PackingTemplate, error)
+	GetAllPackingTemplates(ctx context.Context, client any) (entity.PackingTemplates, error)
+	CreatePackingTemplate(ctx context.Context, client any, template *entity.PackingTemplate) (int, error)
+	DeletePackingTemplate(ctx context.Context, client any, id int) error
+	ApplyPackingTemplate(ctx context.Context, client any, expeditionId int, templateId int, equipments entity.Equipments) error
+	GetPackingListReservations(ctx context.Context, client any, expeditionId int, templateId int) (entity.PackingListLines, error)
 }
 
 type EquipmentRepo interface {
@@ -59,26 +176,115 @@ type EquipmentRepo interface {
 	GetAllEquipments(ctx context.Context, client any) (entity.Equipments, error)
 	CreateEquipment(ctx context.Context, client any, location *entity.Equipment) (int, error)
 	DeleteEquipment(ctx context.Context, client any, id int) error
+	GetEquipmentEvents(ctx context.Context, client any, equipmentId int) (entity.EquipmentEvents, error)
+	CreateEquipmentEvent(ctx context.Context, client any, event *entity.EquipmentEvent) (int, error)
+	GetExpeditionEquipmentBalances(ctx context.Context, client any, expeditionId int) (entity.EquipmentBalances, error)
+}
+
+type BudgetRepo interface {
+	GetExpeditionBudget(ctx context.Context, client any, expeditionId int) (*entity.Budget, error)
+	SetExpeditionBudget(ctx context.Context, client any, budget *entity.Budget) error
+	GetExpeditionExpenses(ctx context.Context, client any, expeditionId int) (entity.Expenses, error)
+	CreateExpense(ctx context.Context, client any, expense *entity.Expense) (int, error)
+	GetExchangeRate(ctx context.Context, client any, currency string, baseCurrency string, on time.Time) (*entity.ExchangeRate, error)
+	GetAllExchangeRates(ctx context.Context, client any) (entity.ExchangeRates, error)
+	CreateExchangeRate(ctx context.Context, client any, rate *entity.ExchangeRate) (int, error)
+	GetExpeditionBudgetReport(ctx context.Context, client any, expeditionId int) (entity.BudgetReportLines, error)
+	GetLocationBudgetReport(ctx context.Context, client any, locationId int) (entity.BudgetReportLines, error)
+}
+
+type JournalRepo interface {
+	GetJournalEntryById(ctx context.Context, client any, id int) (*entity.JournalEntry, error)
+	GetExpeditionJournalEntries(ctx context.Context, client any, expeditionId int) (entity.JournalEntries, error)
+	CreateJournalEntry(ctx context.Context, client any, entry *entity.JournalEntry) (int, error)
+	UpdateJournalEntry(ctx context.Context, client any, entry *entity.JournalEntry) error
+	GetExpeditionJournalLocks(ctx context.Context, client any, expeditionId int) (entity.JournalDayLocks, error)
+	LockJournalDay(ctx context.Context, client any, lock *entity.JournalDayLock) error
+}
+
+type AttachmentRepo interface {
+	GetAttachmentById(ctx context.Context, client any, id int) (*entity.Attachment, error)
+	GetParentAttachments(ctx context.Context, client any, parentType string, parentId int) (entity.Attachments, error)
+	CreateAttachment(ctx context.Context, client any, attachment *entity.Attachment, storeBlob func(hash string) error) (int, error)
+	DeleteAttachment(ctx context.Context, client any, id int, removeBlob func(hash string) error) error
+	GetAttachmentsByProcessingStatus(ctx context.Context, client any, statuses []string) (entity.Attachments, error)
+	GetAttachmentThumbnails(ctx context.Context, client any, attachmentId int) (entity.Thumbnails, error)
+	StartAttachmentProcessing(ctx context.Context, client any, id int) error
+	CompleteAttachmentProcessing(ctx context.Context, client any, id int, metadata *entity.PhotoMetadata, thumbnails entity.Thumbnails, storeBlob func(hash string) error) error
+	FailAttachmentProcessing(ctx context.Context, client any, id int, message string) error
+}
+
+type SearchRepo interface {
+	Search(ctx context.Context, client any, scope *entity.SearchScope) (entity.SearchHits, error)
+}
+
+type MergeRepo interface {
+	GetDuplicateCandidates(ctx context.Context, client any, entityType string, threshold float64, limit int) (entity.DuplicateCandidates, error)
+	Merge(ctx context.Context, client any, record *entity.MergeRecord) (int, error)
+	GetMergeHistory(ctx context.Context, client any, entityType string) (entity.MergeRecords, error)
+}
+
+type CountryRepo interface {
+	GetCountryUsages(ctx context.Context, client any) (entity.CountryUsages, error)
+	ApplyCountryMappings(ctx context.Context, client any, mappings entity.CountryMappings) error
 }
 
 type Repositories struct {
+	AdminRepo
 	LeaderRepo
 	MemberRepo
 	CuratorRepo
+	InstitutionRepo
 	LocationRepo
 	ExpeditionRepo
+	TrenchRepo
+	ExcavationContextRepo
 	ArtifactRepo
+	PeriodRepo
+	SampleRepo
+	CustodyRepo
+	ConditionReportRepo
+	StorageRepo
+	LoanRepo
+	InventoryRepo
+	MaintenanceRepo
 	EquipmentRepo
+	PackingRepo
+	BudgetRepo
+	JournalRepo
+	AttachmentRepo
+	SearchRepo
+	MergeRepo
+	CountryRepo
 }
 
 func NewRepositories() *Repositories {
 	return &Repositories{
-		LeaderRepo:     pgdb.NewLeaderRepo(),
-		MemberRepo:     pgdb.NewMemberRepo(),
-		CuratorRepo:    pgdb.NewCuratorRepo(),
-		LocationRepo:   pgdb.NewLocationRepo(),
-		ExpeditionRepo: pgdb.NewExpeditionRepo(),
-		ArtifactRepo:   pgdb.NewArtifactRepo(),
-		EquipmentRepo:  pgdb.NewEquipmentRepo(),
+		AdminRepo:             pgdb.NewAdminRepo(),
+		LeaderRepo:            pgdb.NewLeaderRepo(),
+		MemberRepo:            pgdb.NewMemberRepo(),
+		CuratorRepo:           pgdb.NewCuratorRepo(),
+		InstitutionRepo:       pgdb.NewInstitutionRepo(),
+		LocationRepo:          pgdb.NewLocationRepo(),
+		ExpeditionRepo:        pgdb.NewExpeditionRepo(),
+		TrenchRepo:            pgdb.NewTrenchRepo(),
+		ExcavationContextRepo: pgdb.NewExcavationContextRepo(),
+		ArtifactRepo:          pgdb.NewArtifactRepo(),
+		PeriodRepo:            pgdb.NewPeriodRepo(),
+		SampleRepo:            pgdb.NewSampleRepo(),
+		CustodyRepo:           pgdb.NewCustodyRepo(),
+		ConditionReportRepo:   pgdb.NewConditionReportRepo(),
+		StorageRepo:           pgdb.NewStorageRepo(),
+		LoanRepo:              pgdb.NewLoanRepo(),
+		InventoryRepo:         pgdb.NewInventoryRepo(),
+		MaintenanceRepo:       pgdb.NewMaintenanceRepo(),
+		EquipmentRepo:         pgdb.NewEquipmentRepo(),
+		PackingRepo:           pgdb.NewPackingRepo(),
+		BudgetRepo:            pgdb.NewBudgetRepo(),
+		JournalRepo:           pgdb.NewJournalRepo(),
+		AttachmentRepo:        pgdb.NewAttachmentRepo(),
+		SearchRepo:            pgdb.NewSearchRepo(),
+		MergeRepo:             pgdb.NewMergeRepo(),
+		CountryRepo:           pgdb.NewCountryRepo(),
 	}
 }
@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+	ErrInUse         = errors.New("in use")
+	ErrConflict      = errors.New("conflict")
 )
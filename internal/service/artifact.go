@@ -6,15 +6,31 @@ import (
 	"db_cp_6/internal/repo"
 	"db_cp_6/internal/repo/repoerrs"
 	"errors"
+	"fmt"
+	"time"
 )
 
 type ArtifactService struct {
-	artifactRepo repo.ArtifactRepo
+	artifactRepo   repo.ArtifactRepo
+	expeditionRepo repo.ExpeditionRepo
+	custodyRepo    repo.CustodyRepo
+	contextRepo    repo.ExcavationContextRepo
+	periodRepo     repo.PeriodRepo
+	conditionRepo  repo.ConditionReportRepo
+	memberRepo     repo.MemberRepo
 }
 
-func NewArtifactService(artifactRepo repo.ArtifactRepo) *ArtifactService {
+func NewArtifactService(artifactRepo repo.ArtifactRepo, expeditionRepo repo.ExpeditionRepo, custodyRepo repo.CustodyRepo,
+	contextRepo repo.ExcavationContextRepo, periodRepo repo.PeriodRepo, conditionRepo repo.ConditionReportRepo,
+	memberRepo repo.MemberRepo) *ArtifactService {
 	return &ArtifactService{
-		artifactRepo: artifactRepo,
+		artifactRepo:   artifactRepo,
+		expeditionRepo: expeditionRepo,
+		custodyRepo:    custodyRepo,
+		contextRepo:    contextRepo,
+		periodRepo:     periodRepo,
+		conditionRepo:  conditionRepo,
+		memberRepo:     memberRepo,
 	}
 }
 
@@ -27,6 +43,18 @@ func (s *ArtifactService) GetArtifactById(ctx context.Context, client any, id in
 		return nil, err
 	}
 
+	holder, err := s.custodyRepo.GetCurrentCustody(ctx, client, id)
+	if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+		return nil, err
+	}
+	artifact.CurrentHolder = holder
+
+	condition, err := s.conditionRepo.GetCurrentCondition(ctx, client, id)
+	if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+		return nil, err
+	}
+	artifact.CurrentCondition = condition
+
 	return artifact, nil
 }
 
@@ -34,8 +62,37 @@ func (s *ArtifactService) GetLocationArtifacts(ctx context.Context, client any,
 	return s.artifactRepo.GetLocationArtifacts(ctx, client, locationId)
 }
 
-func (s *ArtifactService) GetAllArtifacts(ctx context.Context, client any) (entity.Artifacts, error) {
-	return s.artifactRepo.GetAllArtifacts(ctx, client)
+func (s *ArtifactService) GetExpeditionArtifacts(ctx context.Context, client any, expeditionId int) (entity.Artifacts, error) {
+	return s.artifactRepo.GetExpeditionArtifacts(ctx, client, expeditionId)
+}
+
+func (s *ArtifactService) GetContextArtifacts(ctx context.Context, client any, contextId int) (entity.Artifacts, error) {
+	return s.artifactRepo.GetContextArtifacts(ctx, client, contextId)
+}
+
+func (s *ArtifactService) GetAllArtifacts(ctx context.Context, client any, filter *entity.ArtifactFilter) (entity.Artifacts, error) {
+	if filter != nil && filter.BoundingBox != nil {
+		if err := filter.BoundingBox.IsValid(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBoundingBox, err)
+		}
+	}
+
+	if filter != nil && filter.PeriodId != nil {
+		period, err := s.getPeriod(ctx, client, *filter.PeriodId)
+		if err != nil {
+			return nil, err
+		}
+
+		periodFilter := *filter
+		periodFilter.DatedFrom, periodFilter.DatedTo = &period.StartYear, &period.EndYear
+		filter = &periodFilter
+	}
+
+	if filter != nil && filter.DatedFrom != nil && filter.DatedTo != nil && *filter.DatedFrom > *filter.DatedTo {
+		return nil, ErrInvalidYearRange
+	}
+
+	return s.artifactRepo.GetAllArtifacts(ctx, client, filter)
 }
 
 func (s *ArtifactService) CreateArtifact(ctx context.Context, client any, input *entity.CreateArtifactInput) (int, error) {
@@ -44,9 +101,97 @@ func (s *ArtifactService) CreateArtifact(ctx context.Context, client any, input
 	}
 
 	exp := &entity.Artifact{
-		LocationId: input.LocationId,
-		Name:       input.Name,
-		Age:        input.Age,
+		LocationId:      input.LocationId,
+		ContextId:       input.ContextId,
+		ExpeditionId:    input.ExpeditionId,
+		FoundByMemberId: input.FoundByMemberId,
+		FindContext:     input.FindContext,
+		FindSpot:        input.FindSpot.WithDefaultDatum(),
+		Name:            input.Name,
+		Dating:          input.Dating.Normalized(),
+	}
+	if input.FoundOn != "" {
+		foundOn, _ := time.Parse("2006-01-02", input.FoundOn)
+		exp.FoundOn = &foundOn
 	}
+
+	if err := s.checkFindContext(ctx, client, exp); err != nil {
+		return 0, err
+	}
+
+	if exp.Dating.PeriodId != nil {
+		period, err := s.getPeriod(ctx, client, *exp.Dating.PeriodId)
+		if err != nil {
+			return 0, err
+		}
+
+		if !exp.Dating.IsSet() {
+			exp.Dating.EarliestYear, exp.Dating.LatestYear = &period.StartYear, &period.EndYear
+		}
+	}
+
 	return s.artifactRepo.CreateArtifact(ctx, client, exp)
 }
+
+func (s *ArtifactService) checkFindContext(ctx context.Context, client any, artifact *entity.Artifact) error {
+	if artifact.ContextId != nil {
+		excavationContext, err := s.contextRepo.GetContextById(ctx, client, *artifact.ContextId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrContextNotFound
+			}
+			return err
+		}
+
+		if excavationContext.LocationId != artifact.LocationId {
+			return ErrArtifactLocationMismatch
+		}
+	}
+
+	if artifact.ExpeditionId == nil {
+		return nil
+	}
+
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, *artifact.ExpeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrExpeditionNotFound
+		}
+		return err
+	}
+
+	if expedition.LocationId != artifact.LocationId {
+		return ErrArtifactLocationMismatch
+	}
+	if artifact.FoundOn != nil && (artifact.FoundOn.Before(expedition.StartDate) || artifact.FoundOn.After(expedition.EndDate)) {
+		return ErrArtifactFoundOutsideExpedition
+	}
+
+	if artifact.FoundByMemberId == nil {
+		return nil
+	}
+
+	members, err := s.memberRepo.GetExpeditionMembers(ctx, client, expedition.Id)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.Id == *artifact.FoundByMemberId {
+			return nil
+		}
+	}
+
+	return ErrArtifactFinderNotOnExpedition
+}
+
+func (s *ArtifactService) getPeriod(ctx context.Context, client any, id int) (*entity.Period, error) {
+	period, err := s.periodRepo.GetPeriodById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrPeriodNotFound
+		}
+		return nil, err
+	}
+
+	return period, nil
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
 	"db_cp_6/internal/service/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestArtifactService_GetArtifactById(t *testing.T) {
@@ -16,7 +18,22 @@ func TestArtifactService_GetArtifactById(t *testing.T) {
 		id     int
 	}
 
-	type MockBehavior func(m *mocks.MockArtifactRepo, args args)
+	type MockBehavior func(ar *mocks.MockArtifactRepo, cr *mocks.MockCustodyRepo, cnd *mocks.MockConditionReportRepo, args args)
+
+	holderId := 2
+	holder := &entity.CustodyRecord{
+		Id:         3,
+		ArtifactId: 1,
+		HolderType: entity.HolderMember,
+		HolderId:   &holderId,
+		Reason:     "found",
+	}
+	condition := &entity.ConditionReport{
+		Id:          4,
+		ArtifactId:  1,
+		Grade:       entity.ConditionFair,
+		Conservator: "bbb",
+	}
 
 	testCases := []struct {
 		name         string
@@ -32,20 +49,76 @@ func TestArtifactService_GetArtifactById(t *testing.T) {
 				client: nil,
 				id:     1,
 			},
-			mockBehavior: func(m *mocks.MockArtifactRepo, args args) {
-				m.EXPECT().GetArtifactById(args.ctx, args.client, args.id).
+			mockBehavior: func(ar *mocks.MockArtifactRepo, cr *mocks.MockCustodyRepo, cnd *mocks.MockConditionReportRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.id).
 					Return(&entity.Artifact{
 						Id:         1,
 						LocationId: 1,
 						Name:       "aaa",
-						Age:        10000,
 					}, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.id).
+					Return(nil, repoerrs.ErrNotFound)
+				cnd.EXPECT().GetCurrentCondition(args.ctx, args.client, args.id).
+					Return(nil, repoerrs.ErrNotFound)
 			},
 			want: &entity.Artifact{
 				Id:         1,
 				LocationId: 1,
 				Name:       "aaa",
-				Age:        10000,
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK with current holder",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, cr *mocks.MockCustodyRepo, cnd *mocks.MockConditionReportRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.id).
+					Return(&entity.Artifact{
+						Id:         1,
+						LocationId: 1,
+						Name:       "aaa",
+					}, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.id).
+					Return(holder, nil)
+				cnd.EXPECT().GetCurrentCondition(args.ctx, args.client, args.id).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want: &entity.Artifact{
+				Id:            1,
+				LocationId:    1,
+				Name:          "aaa",
+				CurrentHolder: holder,
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK with current condition",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, cr *mocks.MockCustodyRepo, cnd *mocks.MockConditionReportRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.id).
+					Return(&entity.Artifact{
+						Id:         1,
+						LocationId: 1,
+						Name:       "aaa",
+					}, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.id).
+					Return(nil, repoerrs.ErrNotFound)
+				cnd.EXPECT().GetCurrentCondition(args.ctx, args.client, args.id).
+					Return(condition, nil)
+			},
+			want: &entity.Artifact{
+				Id:               1,
+				LocationId:       1,
+				Name:             "aaa",
+				CurrentCondition: condition,
 			},
 			wantErr: false,
 		},
@@ -56,8 +129,8 @@ func TestArtifactService_GetArtifactById(t *testing.T) {
 				client: nil,
 				id:     1,
 			},
-			mockBehavior: func(m *mocks.MockArtifactRepo, args args) {
-				m.EXPECT().GetArtifactById(args.ctx, args.client, args.id).
+			mockBehavior: func(ar *mocks.MockArtifactRepo, cr *mocks.MockCustodyRepo, cnd *mocks.MockConditionReportRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.id).
 					Return(nil, ErrArtifactNotFound)
 			},
 			want:    nil,
@@ -73,10 +146,16 @@ func TestArtifactService_GetArtifactById(t *testing.T) {
 
 			// init mocks
 			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
-			tc.mockBehavior(artifactRepo, tc.args)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			conditionRepo := mocks.NewMockConditionReportRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			tc.mockBehavior(artifactRepo, custodyRepo, conditionRepo, tc.args)
 
 			// init service
-			s := NewArtifactService(artifactRepo)
+			s := NewArtifactService(artifactRepo, expeditionRepo, custodyRepo, contextRepo, periodRepo, conditionRepo, memberRepo)
 
 			// run test
 			got, err := s.GetArtifactById(tc.args.ctx, tc.args.client, tc.args.id)
@@ -121,7 +200,6 @@ func TestArtifactService_GetLocationArtifacts(t *testing.T) {
 							Id:         1,
 							LocationId: 1,
 							Name:       "aaa",
-							Age:        10000,
 						},
 					}, nil)
 			},
@@ -130,7 +208,6 @@ func TestArtifactService_GetLocationArtifacts(t *testing.T) {
 					Id:         1,
 					LocationId: 1,
 					Name:       "aaa",
-					Age:        10000,
 				},
 			},
 			wantErr: false,
@@ -145,10 +222,16 @@ func TestArtifactService_GetLocationArtifacts(t *testing.T) {
 
 			// init mocks
 			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			conditionRepo := mocks.NewMockConditionReportRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
 			tc.mockBehavior(artifactRepo, tc.args)
 
 			// init service
-			s := NewArtifactService(artifactRepo)
+			s := NewArtifactService(artifactRepo, expeditionRepo, custodyRepo, contextRepo, periodRepo, conditionRepo, memberRepo)
 
 			// run test
 			got, err := s.GetLocationArtifacts(tc.args.ctx, tc.args.client, tc.args.locationId)
@@ -167,6 +250,7 @@ func TestArtifactService_GetAllArtifacts(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		client any
+		filter *entity.ArtifactFilter
 	}
 
 	type MockBehavior func(m *mocks.MockArtifactRepo, args args)
@@ -185,13 +269,12 @@ func TestArtifactService_GetAllArtifacts(t *testing.T) {
 				client: nil,
 			},
 			mockBehavior: func(m *mocks.MockArtifactRepo, args args) {
-				m.EXPECT().GetAllArtifacts(args.ctx, args.client).
+				m.EXPECT().GetAllArtifacts(args.ctx, args.client, args.filter).
 					Return(entity.Artifacts{
 						&entity.Artifact{
 							Id:         1,
 							LocationId: 1,
 							Name:       "aaa",
-							Age:        10000,
 						},
 					}, nil)
 			},
@@ -200,7 +283,6 @@ func TestArtifactService_GetAllArtifacts(t *testing.T) {
 					Id:         1,
 					LocationId: 1,
 					Name:       "aaa",
-					Age:        10000,
 				},
 			},
 			wantErr: false,
@@ -215,13 +297,99 @@ func TestArtifactService_GetAllArtifacts(t *testing.T) {
 
 			// init mocks
 			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			conditionRepo := mocks.NewMockConditionReportRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			tc.mockBehavior(artifactRepo, tc.args)
+
+			// init service
+			s := NewArtifactService(artifactRepo, expeditionRepo, custodyRepo, contextRepo, periodRepo, conditionRepo, memberRepo)
+
+			// run test
+			got, err := s.GetAllArtifacts(tc.args.ctx, tc.args.client, tc.args.filter)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestArtifactService_GetExpeditionArtifacts(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		expeditionId int
+	}
+
+	type MockBehavior func(m *mocks.MockArtifactRepo, args args)
+
+	expeditionId := 1
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.Artifacts
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: expeditionId,
+			},
+			mockBehavior: func(m *mocks.MockArtifactRepo, args args) {
+				m.EXPECT().GetExpeditionArtifacts(args.ctx, args.client, args.expeditionId).
+					Return(entity.Artifacts{
+						&entity.Artifact{
+							Id:           1,
+							LocationId:   1,
+							ExpeditionId: &expeditionId,
+							Name:         "aaa",
+						},
+					}, nil)
+			},
+			want: entity.Artifacts{
+				&entity.Artifact{
+					Id:           1,
+					LocationId:   1,
+					ExpeditionId: &expeditionId,
+					Name:         "aaa",
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			conditionRepo := mocks.NewMockConditionReportRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
 			tc.mockBehavior(artifactRepo, tc.args)
 
 			// init service
-			s := NewArtifactService(artifactRepo)
+			s := NewArtifactService(artifactRepo, expeditionRepo, custodyRepo, contextRepo, periodRepo, conditionRepo, memberRepo)
 
 			// run test
-			got, err := s.GetAllArtifacts(tc.args.ctx, tc.args.client)
+			got, err := s.GetExpeditionArtifacts(tc.args.ctx, tc.args.client, tc.args.expeditionId)
 			if tc.wantErr {
 				assert.Error(t, err)
 				return
@@ -240,7 +408,18 @@ func TestArtifactService_CreateArtifact(t *testing.T) {
 		input  *entity.CreateArtifactInput
 	}
 
-	type MockBehavior func(m *mocks.MockArtifactRepo, args args)
+	type MockBehavior func(ar *mocks.MockArtifactRepo, er *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args)
+
+	expeditionId, finderId, strangerId := 1, 7, 8
+	start, _ := time.Parse("2006-01-02", "2024-07-01")
+	end, _ := time.Parse("2006-01-02", "2024-08-01")
+	foundOn, _ := time.Parse("2006-01-02", "2024-07-15")
+	expedition := &entity.Expedition{
+		Id:         expeditionId,
+		LocationId: 1,
+		StartDate:  start,
+		EndDate:    end,
+	}
 
 	testCases := []struct {
 		name         string
@@ -257,20 +436,147 @@ func TestArtifactService_CreateArtifact(t *testing.T) {
 				input: &entity.CreateArtifactInput{
 					LocationId: 1,
 					Name:       "aaa",
-					Age:        10000,
 				},
 			},
-			mockBehavior: func(m *mocks.MockArtifactRepo, args args) {
-				m.EXPECT().CreateArtifact(args.ctx, args.client, &entity.Artifact{
+			mockBehavior: func(ar *mocks.MockArtifactRepo, er *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {
+				ar.EXPECT().CreateArtifact(args.ctx, args.client, &entity.Artifact{
 					LocationId: args.input.LocationId,
 					Name:       args.input.Name,
-					Age:        args.input.Age,
 				}).
 					Return(1, nil)
 			},
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "OK with expedition",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId:   1,
+					ExpeditionId: &expeditionId,
+					FoundOn:      "2024-07-15",
+					FindContext:  "trench A, layer 2",
+					Name:         "aaa",
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, er *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {
+				er.EXPECT().GetExpeditionById(args.ctx, args.client, expeditionId).
+					Return(expedition, nil)
+				ar.EXPECT().CreateArtifact(args.ctx, args.client, &entity.Artifact{
+					LocationId:   args.input.LocationId,
+					ExpeditionId: &expeditionId,
+					FoundOn:      &foundOn,
+					FindContext:  args.input.FindContext,
+					Name:         args.input.Name,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "OK with finder on roster",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId:      1,
+					ExpeditionId:    &expeditionId,
+					FoundByMemberId: &finderId,
+					Name:            "aaa",
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, er *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {
+				er.EXPECT().GetExpeditionById(args.ctx, args.client, expeditionId).
+					Return(expedition, nil)
+				mr.EXPECT().GetExpeditionMembers(args.ctx, args.client, expeditionId).
+					Return(entity.Members{{Id: 3}, {Id: finderId}}, nil)
+				ar.EXPECT().CreateArtifact(args.ctx, args.client, &entity.Artifact{
+					LocationId:      args.input.LocationId,
+					ExpeditionId:    &expeditionId,
+					FoundByMemberId: &finderId,
+					Name:            args.input.Name,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "finder not on roster error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId:      1,
+					ExpeditionId:    &expeditionId,
+					FoundByMemberId: &strangerId,
+					Name:            "aaa",
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, er *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {
+				er.EXPECT().GetExpeditionById(args.ctx, args.client, expeditionId).
+					Return(expedition, nil)
+				mr.EXPECT().GetExpeditionMembers(args.ctx, args.client, expeditionId).
+					Return(entity.Members{{Id: 3}, {Id: finderId}}, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "location mismatch error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId:   2,
+					ExpeditionId: &expeditionId,
+					Name:         "aaa",
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, er *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {
+				er.EXPECT().GetExpeditionById(args.ctx, args.client, expeditionId).
+					Return(expedition, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "find date outside expedition error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId:   1,
+					ExpeditionId: &expeditionId,
+					FoundOn:      "2024-09-01",
+					Name:         "aaa",
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, er *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {
+				er.EXPECT().GetExpeditionById(args.ctx, args.client, expeditionId).
+					Return(expedition, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "invalid find date error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId: 1,
+					FoundOn:    "15.07.2024",
+					Name:       "aaa",
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, er *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -281,10 +587,286 @@ func TestArtifactService_CreateArtifact(t *testing.T) {
 
 			// init mocks
 			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
-			tc.mockBehavior(artifactRepo, tc.args)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			conditionRepo := mocks.NewMockConditionReportRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			tc.mockBehavior(artifactRepo, expeditionRepo, memberRepo, tc.args)
+
+			// init service
+			s := NewArtifactService(artifactRepo, expeditionRepo, custodyRepo, contextRepo, periodRepo, conditionRepo, memberRepo)
+
+			// run test
+			got, err := s.CreateArtifact(tc.args.ctx, tc.args.client, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestArtifactService_GetAllArtifactsByPeriod(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		filter *entity.ArtifactFilter
+	}
+
+	type MockBehavior func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args)
+
+	periodId := 1
+	from, to := -3300, -1200
+	period := &entity.Period{Id: periodId, Name: "Bronze Age", StartYear: from, EndYear: to}
+	laterFrom, earlierTo := 100, -100
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.Artifacts
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ArtifactFilter{PeriodId: &periodId},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args) {
+				pr.EXPECT().GetPeriodById(args.ctx, args.client, periodId).
+					Return(period, nil)
+				ar.EXPECT().GetAllArtifacts(args.ctx, args.client, &entity.ArtifactFilter{
+					PeriodId:  &periodId,
+					DatedFrom: &from,
+					DatedTo:   &to,
+				}).
+					Return(entity.Artifacts{{Id: 1, LocationId: 1, Name: "aaa"}}, nil)
+			},
+			want:    entity.Artifacts{{Id: 1, LocationId: 1, Name: "aaa"}},
+			wantErr: false,
+		},
+		{
+			name: "period not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ArtifactFilter{PeriodId: &periodId},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args) {
+				pr.EXPECT().GetPeriodById(args.ctx, args.client, periodId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid year range error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ArtifactFilter{DatedFrom: &laterFrom, DatedTo: &earlierTo},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args) {},
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			conditionRepo := mocks.NewMockConditionReportRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			tc.mockBehavior(artifactRepo, periodRepo, tc.args)
+
+			// init service
+			s := NewArtifactService(artifactRepo, expeditionRepo, custodyRepo, contextRepo, periodRepo, conditionRepo, memberRepo)
+
+			// run test
+			got, err := s.GetAllArtifacts(tc.args.ctx, tc.args.client, tc.args.filter)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestArtifactService_CreateArtifactDating(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		input  *entity.CreateArtifactInput
+	}
+
+	type MockBehavior func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args)
+
+	periodId := 1
+	period := &entity.Period{Id: periodId, Name: "Bronze Age", StartYear: -3300, EndYear: -1200}
+	central, errorYears := -1500, 40
+	earliest, latest := -1540, -1460
+	invalidEarliest, invalidLatest := 100, -100
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "OK central value with error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId: 1,
+					Name:       "aaa",
+					Dating: entity.Dating{
+						CentralYear: &central,
+						ErrorYears:  &errorYears,
+						Method:      entity.DatingRadiocarbon,
+						Confidence:  entity.ConfidenceHigh,
+					},
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args) {
+				ar.EXPECT().CreateArtifact(args.ctx, args.client, &entity.Artifact{
+					LocationId: 1,
+					Name:       "aaa",
+					Dating: entity.Dating{
+						EarliestYear: &earliest,
+						LatestYear:   &latest,
+						CentralYear:  &central,
+						ErrorYears:   &errorYears,
+						Method:       entity.DatingRadiocarbon,
+						Confidence:   entity.ConfidenceHigh,
+					},
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "OK range from period",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId: 1,
+					Name:       "aaa",
+					Dating: entity.Dating{
+						Method:   entity.DatingTypological,
+						PeriodId: &periodId,
+					},
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args) {
+				pr.EXPECT().GetPeriodById(args.ctx, args.client, periodId).
+					Return(period, nil)
+				ar.EXPECT().CreateArtifact(args.ctx, args.client, &entity.Artifact{
+					LocationId: 1,
+					Name:       "aaa",
+					Dating: entity.Dating{
+						EarliestYear: &period.StartYear,
+						LatestYear:   &period.EndYear,
+						Method:       entity.DatingTypological,
+						PeriodId:     &periodId,
+					},
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "period not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId: 1,
+					Name:       "aaa",
+					Dating:     entity.Dating{PeriodId: &periodId},
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args) {
+				pr.EXPECT().GetPeriodById(args.ctx, args.client, periodId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "earliest after latest error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId: 1,
+					Name:       "aaa",
+					Dating:     entity.Dating{EarliestYear: &invalidEarliest, LatestYear: &invalidLatest},
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+		{
+			name: "unknown method error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateArtifactInput{
+					LocationId: 1,
+					Name:       "aaa",
+					Dating:     entity.Dating{CentralYear: &central, Method: "astrological"},
+				},
+			},
+			mockBehavior: func(ar *mocks.MockArtifactRepo, pr *mocks.MockPeriodRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			conditionRepo := mocks.NewMockConditionReportRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			tc.mockBehavior(artifactRepo, periodRepo, tc.args)
 
 			// init service
-			s := NewArtifactService(artifactRepo)
+			s := NewArtifactService(artifactRepo, expeditionRepo, custodyRepo, contextRepo, periodRepo, conditionRepo, memberRepo)
 
 			// run test
 			got, err := s.CreateArtifact(tc.args.ctx, tc.args.client, tc.args.input)
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/blobstore"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gabriel-vasile/mimetype"
+	"io"
+	"strings"
+)
+
+const DefaultMaxAttachmentSize = 25 << 20
+
+var (
+	imageKinds    = []string{entity.AttachmentKindPhoto, entity.AttachmentKindDrawing, entity.AttachmentKindDocument}
+	pdfKinds      = []string{entity.AttachmentKindDocument, entity.AttachmentKindDrawing}
+	documentKinds = []string{entity.AttachmentKindDocument}
+)
+
+var attachmentTypes = map[string][]string{
+	"image/jpeg":      imageKinds,
+	"image/png":       imageKinds,
+	"image/gif":       imageKinds,
+	"image/webp":      imageKinds,
+	"image/tiff":      imageKinds,
+	"application/pdf": pdfKinds,
+	"text/plain":      documentKinds,
+}
+
+type AttachmentConfig struct {
+	Store   blobstore.Store
+	MaxSize int64
+	Images  ImageConfig
+}
+
+type imageQueue interface {
+	Enqueue(id int) bool
+}
+
+type AttachmentService struct {
+	attachmentRepo repo.AttachmentRepo
+	artifactRepo   repo.ArtifactRepo
+	locationRepo   repo.LocationRepo
+	expeditionRepo repo.ExpeditionRepo
+	journalRepo    repo.JournalRepo
+	images         imageQueue
+	store          blobstore.Store
+	maxSize        int64
+}
+
+func NewAttachmentService(attachmentRepo repo.AttachmentRepo, artifactRepo repo.ArtifactRepo, locationRepo repo.LocationRepo,
+	expeditionRepo repo.ExpeditionRepo, journalRepo repo.JournalRepo, images imageQueue, cfg AttachmentConfig) *AttachmentService {
+	maxSize := cfg.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxAttachmentSize
+	}
+
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		artifactRepo:   artifactRepo,
+		locationRepo:   locationRepo,
+		expeditionRepo: expeditionRepo,
+		journalRepo:    journalRepo,
+		images:         images,
+		store:          cfg.Store,
+		maxSize:        maxSize,
+	}
+}
+
+func (s *AttachmentService) MaxUploadSize() int64 {
+	return s.maxSize
+}
+
+func (s *AttachmentService) GetAttachment(ctx context.Context, client any, id int) (*entity.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetAttachmentById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	parent := &entity.AttachmentParent{Type: attachment.ParentType, Id: attachment.ParentId}
+	if _, err = s.getParent(ctx, client, parent); err != nil {
+		return nil, err
+	}
+
+	if attachment.ProcessingStatus == entity.AttachmentProcessingDone {
+		attachment.Thumbnails, err = s.attachmentRepo.GetAttachmentThumbnails(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	suggestFindSpot(attachment)
+
+	return attachment, nil
+}
+
+func (s *AttachmentService) GetParentAttachments(ctx context.Context, client any, parent *entity.AttachmentParent) (entity.Attachments, error) {
+	if err := parent.IsValid(); err != nil {
+		return nil, err
+	}
+	if _, err := s.getParent(ctx, client, parent); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetParentAttachments(ctx, client, parent.Type, parent.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		suggestFindSpot(attachment)
+	}
+
+	return attachments, nil
+}
+
+func (s *AttachmentService) GetAttachmentThumbnail(ctx context.Context, client any, id int, size string) (*entity.ThumbnailContent, error) {
+	attachment, err := s.GetAttachment(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, thumbnail := range attachment.Thumbnails {
+		if thumbnail.Size != size {
+			continue
+		}
+
+		content, err := s.store.Get(ctx, thumbnail.Sha256)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d thumbnail %s: %w", id, size, err)
+		}
+		return &entity.ThumbnailContent{Thumbnail: thumbnail, Content: content}, nil
+	}
+
+	return nil, ErrThumbnailNotFound
+}
+
+func (s *AttachmentService) GetArtifactFindSpotSuggestions(ctx context.Context, client any, artifactId int) (entity.FindSpotSuggestions, error) {
+	parent := &entity.AttachmentParent{Type: entity.AttachmentParentArtifact, Id: artifactId}
+	attachments, err := s.GetParentAttachments(ctx, client, parent)
+	if err != nil {
+		if errors.Is(err, ErrAttachmentParentNotFound) {
+			return nil, ErrArtifactNotFound
+		}
+		return nil, err
+	}
+
+	suggestions := make(entity.FindSpotSuggestions, 0)
+	for _, attachment := range attachments {
+		if attachment.SuggestedFindSpot == nil {
+			continue
+		}
+		suggestions = append(suggestions, &entity.FindSpotSuggestion{
+			AttachmentId: attachment.Id,
+			FileName:     attachment.FileName,
+			CapturedAt:   attachment.Metadata.CapturedAt,
+			FindSpot:     *attachment.SuggestedFindSpot,
+		})
+	}
+
+	return suggestions, nil
+}
+
+func (s *AttachmentService) GetAttachmentContent(ctx context.Context, client any, id int) (*entity.AttachmentContent, error) {
+	attachment, err := s.GetAttachment(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.Sha256)
+	if err != nil {
+		return nil, fmt.Errorf("attachment %d content: %w", id, err)
+	}
+
+	return &entity.AttachmentContent{Attachment: attachment, Content: content}, nil
+}
+
+func (s *AttachmentService) UploadAttachment(ctx context.Context, client any, user *entity.User, input *entity.UploadAttachmentInput, file *entity.AttachmentFile) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+	if err := file.IsValid(); err != nil {
+		return 0, err
+	}
+	if err := s.checkParentWrite(ctx, client, user, &input.AttachmentParent); err != nil {
+		return 0, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file.Content, s.maxSize+1))
+	if err != nil {
+		return 0, err
+	}
+	if int64(len(data)) > s.maxSize {
+		return 0, ErrAttachmentTooLarge
+	}
+	if len(data) == 0 {
+		return 0, ErrAttachmentEmpty
+	}
+
+	contentType := mimetype.Detect(data).String()
+	baseType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	kinds, ok := attachmentTypes[baseType]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, contentType)
+	}
+	kind, err := attachmentKind(kinds, input.Kind)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", err, baseType)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	attachment := &entity.Attachment{
+		ParentType:     input.Type,
+		ParentId:       input.Id,
+		Kind:           kind,
+		FileName:       strings.TrimSpace(file.Name),
+		ContentType:    contentType,
+		Size:           int64(len(data)),
+		Sha256:         hash,
+		Description:    input.Description,
+		UploadedByRole: user.Role,
+
+		ProcessingStatus: entity.AttachmentProcessingNone,
+	}
+	if !user.IsAdmin() {
+		attachment.UploadedById = &user.Id
+	}
+	if processableImageTypes[baseType] {
+		attachment.ProcessingStatus = entity.AttachmentProcessingPending
+	}
+
+	id, err := s.attachmentRepo.CreateAttachment(ctx, client, attachment, func(hash string) error {
+		return putBlob(ctx, s.store, hash, data, contentType)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return 0, ErrAttachmentAlreadyExists
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return 0, ErrAttachmentParentNotFound
+		}
+		return 0, err
+	}
+	if attachment.ProcessingStatus == entity.AttachmentProcessingPending {
+		s.images.Enqueue(id)
+	}
+
+	return id, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, client any, user *entity.User, id int) error {
+	attachment, err := s.GetAttachment(ctx, client, id)
+	if err != nil {
+		return err
+	}
+
+	uploader := attachment.UploadedByRole == user.Role && attachment.UploadedById != nil && *attachment.UploadedById == user.Id
+	if !uploader && !user.IsAdmin() {
+		return ErrAttachmentForbidden
+	}
+
+	err = s.attachmentRepo.DeleteAttachment(ctx, client, id, func(hash string) error {
+		err := s.store.Delete(ctx, hash)
+		if err != nil && !errors.Is(err, blobstore.ErrNotFound) {
+			return fmt.Errorf("attachment %d blob %s: %w", id, hash, err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func putBlob(ctx context.Context, store blobstore.Store, hash string, data []byte, contentType string) error {
+	exists, err := store.Exists(ctx, hash)
+	if err != nil || exists {
+		return err
+	}
+
+	return store.Put(ctx, hash, bytes.NewReader(data), int64(len(data)), contentType)
+}
+
+func (s *AttachmentService) checkParentWrite(ctx context.Context, client any, user *entity.User, parent *entity.AttachmentParent) error {
+	found, err := s.getParent(ctx, client, parent)
+	if err != nil {
+		return err
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+
+	if entry, ok := found.(*entity.JournalEntry); ok {
+		if user.Role != entity.RoleMember || entry.MemberId != user.Id {
+			return ErrAttachmentForbidden
+		}
+		return nil
+	}
+	if user.Role != entity.RoleLeader && user.Role != entity.RoleCurator {
+		return ErrAttachmentForbidden
+	}
+
+	return nil
+}
+
+func (s *AttachmentService) getParent(ctx context.Context, client any, parent *entity.AttachmentParent) (any, error) {
+	var (
+		found any
+		err   error
+	)
+	switch parent.Type {
+	case entity.AttachmentParentArtifact:
+		found, err = s.artifactRepo.GetArtifactById(ctx, client, parent.Id)
+	case entity.AttachmentParentLocation:
+		found, err = s.locationRepo.GetLocationById(ctx, client, parent.Id)
+	case entity.AttachmentParentExpedition:
+		found, err = s.expeditionRepo.GetExpeditionById(ctx, client, parent.Id)
+	case entity.AttachmentParentJournalEntry:
+		found, err = s.journalRepo.GetJournalEntryById(ctx, client, parent.Id)
+	default:
+		return nil, ErrAttachmentParentNotFound
+	}
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrAttachmentParentNotFound
+		}
+		return nil, err
+	}
+
+	return found, nil
+}
+
+func attachmentKind(kinds []string, requested string) (string, error) {
+	if requested == "" {
+		return kinds[0], nil
+	}
+	for _, kind := range kinds {
+		if kind == requested {
+			return kind, nil
+		}
+	}
+
+	return "", ErrAttachmentKindMismatch
+}
+
+func suggestFindSpot(attachment *entity.Attachment) {
+	if attachment.ParentType != entity.AttachmentParentArtifact || attachment.Metadata == nil || !attachment.Metadata.Gps.IsSet() {
+		return
+	}
+
+	spot := attachment.Metadata.Gps.WithDefaultDatum()
+	attachment.SuggestedFindSpot = &spot
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"db_cp_6/pkg/blobstore"
+	"encoding/hex"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), make([]byte, 32)...)
+
+type attachmentMocks struct {
+	attachmentRepo *mocks.MockAttachmentRepo
+	artifactRepo   *mocks.MockArtifactRepo
+	locationRepo   *mocks.MockLocationRepo
+	expeditionRepo *mocks.MockExpeditionRepo
+	journalRepo    *mocks.MockJournalRepo
+	images         *ImageProcessor
+}
+
+func newAttachmentTestService(t *testing.T, ctrl *gomock.Controller, maxSize int64) (*AttachmentService, *attachmentMocks, blobstore.Store) {
+	m := &attachmentMocks{
+		attachmentRepo: mocks.NewMockAttachmentRepo(ctrl),
+		artifactRepo:   mocks.NewMockArtifactRepo(ctrl),
+		locationRepo:   mocks.NewMockLocationRepo(ctrl),
+		expeditionRepo: mocks.NewMockExpeditionRepo(ctrl),
+		journalRepo:    mocks.NewMockJournalRepo(ctrl),
+	}
+
+	store, err := blobstore.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.images = NewImageProcessor(m.attachmentRepo, store, nil, ImageConfig{QueueSize: 4})
+	s := NewAttachmentService(m.attachmentRepo, m.artifactRepo, m.locationRepo, m.expeditionRepo, m.journalRepo,
+		m.images, AttachmentConfig{Store: store, MaxSize: maxSize})
+
+	return s, m, store
+}
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		client  any
+		user    *entity.User
+		input   *entity.UploadAttachmentInput
+		name    string
+		content []byte
+	}
+
+	type MockBehavior func(m *attachmentMocks, args args)
+
+	sum := sha256.Sum256(testPNG)
+	pngHash := hex.EncodeToString(sum[:])
+	leader := &entity.User{Id: 7, Role: entity.RoleLeader}
+	artifactParent := entity.AttachmentParent{Type: entity.AttachmentParentArtifact, Id: 1}
+	entryParent := entity.AttachmentParent{Type: entity.AttachmentParentJournalEntry, Id: 5}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK photo",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    leader,
+				input:   &entity.UploadAttachmentInput{AttachmentParent: artifactParent, Description: "top view"},
+				name:    " amphora.png ",
+				content: testPNG,
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).
+					Return(&entity.Artifact{Id: 1}, nil)
+				m.attachmentRepo.EXPECT().CreateAttachment(args.ctx, args.client, &entity.Attachment{
+					ParentType:     entity.AttachmentParentArtifact,
+					ParentId:       1,
+					Kind:           entity.AttachmentKindPhoto,
+					FileName:       "amphora.png",
+					ContentType:    "image/png",
+					Size:           int64(len(testPNG)),
+					Sha256:         pngHash,
+					Description:    "top view",
+					UploadedByRole: entity.RoleLeader,
+					UploadedById:   &leader.Id,
+
+					ProcessingStatus: entity.AttachmentProcessingPending,
+				}, gomock.Any()).
+					DoAndReturn(storeAttachment(1))
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "OK document by journal author",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    &entity.User{Id: 3, Role: entity.RoleMember},
+				input:   &entity.UploadAttachmentInput{AttachmentParent: entryParent, Kind: entity.AttachmentKindDrawing},
+				name:    "plan.pdf",
+				content: []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"),
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.journalRepo.EXPECT().GetJournalEntryById(args.ctx, args.client, 5).
+					Return(&entity.JournalEntry{Id: 5, MemberId: 3}, nil)
+				m.attachmentRepo.EXPECT().CreateAttachment(args.ctx, args.client, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ any, a *entity.Attachment, storeBlob func(string) error) (int, error) {
+						assert.Equal(t, "application/pdf", a.ContentType)
+						assert.Equal(t, entity.AttachmentKindDrawing, a.Kind)
+						assert.Equal(t, entity.AttachmentProcessingNone, a.ProcessingStatus)
+						return 2, storeBlob(a.Sha256)
+					})
+			},
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name: "other member journal entry error",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    &entity.User{Id: 4, Role: entity.RoleMember},
+				input:   &entity.UploadAttachmentInput{AttachmentParent: entryParent},
+				name:    "photo.png",
+				content: testPNG,
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.journalRepo.EXPECT().GetJournalEntryById(args.ctx, args.client, 5).
+					Return(&entity.JournalEntry{Id: 5, MemberId: 3}, nil)
+			},
+			want:    0,
+			wantErr: ErrAttachmentForbidden,
+		},
+		{
+			name: "member on artifact error",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    &entity.User{Id: 3, Role: entity.RoleMember},
+				input:   &entity.UploadAttachmentInput{AttachmentParent: artifactParent},
+				name:    "photo.png",
+				content: testPNG,
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).
+					Return(&entity.Artifact{Id: 1}, nil)
+			},
+			want:    0,
+			wantErr: ErrAttachmentForbidden,
+		},
+		{
+			name: "parent not found error",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    leader,
+				input:   &entity.UploadAttachmentInput{AttachmentParent: entity.AttachmentParent{Type: entity.AttachmentParentLocation, Id: 100}},
+				name:    "photo.png",
+				content: testPNG,
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.locationRepo.EXPECT().GetLocationById(args.ctx, args.client, 100).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrAttachmentParentNotFound,
+		},
+		{
+			name: "too large error",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    leader,
+				input:   &entity.UploadAttachmentInput{AttachmentParent: artifactParent},
+				name:    "photo.png",
+				content: append(append([]byte{}, testPNG...), make([]byte, 1024)...),
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).
+					Return(&entity.Artifact{Id: 1}, nil)
+			},
+			want:    0,
+			wantErr: ErrAttachmentTooLarge,
+		},
+		{
+			name: "kind mismatch error",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    leader,
+				input:   &entity.UploadAttachmentInput{AttachmentParent: artifactParent, Kind: entity.AttachmentKindPhoto},
+				name:    "photo.png",
+				content: []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"),
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).
+					Return(&entity.Artifact{Id: 1}, nil)
+			},
+			want:    0,
+			wantErr: ErrAttachmentKindMismatch,
+		},
+		{
+			name: "type not allowed error",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    leader,
+				input:   &entity.UploadAttachmentInput{AttachmentParent: artifactParent},
+				name:    "photo.png",
+				content: []byte("\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x02\x00"),
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).
+					Return(&entity.Artifact{Id: 1}, nil)
+			},
+			want:    0,
+			wantErr: ErrAttachmentTypeNotAllowed,
+		},
+		{
+			name: "already attached error",
+			args: args{
+				ctx:     context.Background(),
+				client:  nil,
+				user:    leader,
+				input:   &entity.UploadAttachmentInput{AttachmentParent: artifactParent},
+				name:    "photo.png",
+				content: testPNG,
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).
+					Return(&entity.Artifact{Id: 1}, nil)
+				m.attachmentRepo.EXPECT().CreateAttachment(args.ctx, args.client, gomock.Any(), gomock.Any()).
+					Return(0, repoerrs.ErrAlreadyExists)
+			},
+			want:    0,
+			wantErr: ErrAttachmentAlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init service
+			s, m, store := newAttachmentTestService(t, ctrl, 512)
+			tc.mockBehavior(m, tc.args)
+
+			// run test
+			file := &entity.AttachmentFile{Name: tc.args.name, Content: bytes.NewReader(tc.args.content)}
+			got, err := s.UploadAttachment(tc.args.ctx, tc.args.client, tc.args.user, tc.args.input, file)
+			sum := sha256.Sum256(tc.args.content)
+			exists, existsErr := store.Exists(tc.args.ctx, hex.EncodeToString(sum[:]))
+			assert.NoError(t, existsErr)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.False(t, exists)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.True(t, exists)
+		})
+	}
+}
+
+func storeAttachment(id int) func(context.Context, any, *entity.Attachment, func(string) error) (int, error) {
+	return func(_ context.Context, _ any, a *entity.Attachment, storeBlob func(string) error) (int, error) {
+		return id, storeBlob(a.Sha256)
+	}
+}
+
+func TestAttachmentService_Dedup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, m, store := newAttachmentTestService(t, ctrl, 0)
+	ctx := context.Background()
+	leader := &entity.User{Id: 7, Role: entity.RoleLeader}
+
+	m.artifactRepo.EXPECT().GetArtifactById(ctx, nil, gomock.Any()).Return(&entity.Artifact{}, nil).Times(2)
+	hashes := make([]string, 0, 2)
+	m.attachmentRepo.EXPECT().CreateAttachment(ctx, nil, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, a *entity.Attachment, storeBlob func(string) error) (int, error) {
+			hashes = append(hashes, a.Sha256)
+			return len(hashes), storeBlob(a.Sha256)
+		}).Times(2)
+
+	for _, artifactId := range []int{1, 2} {
+		input := &entity.UploadAttachmentInput{AttachmentParent: entity.AttachmentParent{Type: entity.AttachmentParentArtifact, Id: artifactId}}
+		_, err := s.UploadAttachment(ctx, nil, leader, input, &entity.AttachmentFile{Name: "a.png", Content: bytes.NewReader(testPNG)})
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, hashes[0], hashes[1])
+	r, err := store.Get(ctx, hashes[0])
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, testPNG, data)
+}
+
+func TestAttachmentService_GetAttachmentContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, m, store := newAttachmentTestService(t, ctrl, 0)
+	ctx := context.Background()
+
+	sum := sha256.Sum256(testPNG)
+	hash := hex.EncodeToString(sum[:])
+	assert.NoError(t, store.Put(ctx, hash, bytes.NewReader(testPNG), int64(len(testPNG)), "image/png"))
+
+	attachment := &entity.Attachment{Id: 1, ParentType: entity.AttachmentParentExpedition, ParentId: 2, Sha256: hash}
+	m.attachmentRepo.EXPECT().GetAttachmentById(ctx, nil, 1).Return(attachment, nil)
+	m.expeditionRepo.EXPECT().GetExpeditionById(ctx, nil, 2).Return(&entity.Expedition{Id: 2}, nil)
+
+	content, err := s.GetAttachmentContent(ctx, nil, 1)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(content.Content)
+	content.Content.Close()
+	assert.Equal(t, testPNG, data)
+
+	m.attachmentRepo.EXPECT().GetAttachmentById(ctx, nil, 3).Return(&entity.Attachment{Id: 3, ParentType: entity.AttachmentParentExpedition, ParentId: 4}, nil)
+	m.expeditionRepo.EXPECT().GetExpeditionById(ctx, nil, 4).Return(nil, repoerrs.ErrNotFound)
+
+	_, err = s.GetAttachmentContent(ctx, nil, 3)
+	assert.ErrorIs(t, err, ErrAttachmentParentNotFound)
+}
+
+func TestAttachmentService_DeleteAttachment(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		user   *entity.User
+		id     int
+	}
+
+	sum := sha256.Sum256(testPNG)
+	hash := hex.EncodeToString(sum[:])
+	uploaderId := 7
+	attachment := &entity.Attachment{Id: 1, ParentType: entity.AttachmentParentLocation, ParentId: 2, Sha256: hash, UploadedByRole: entity.RoleLeader, UploadedById: &uploaderId}
+
+	testCases := []struct {
+		name     string
+		args     args
+		deleted  bool
+		released []string
+		wantErr  error
+	}{
+		{
+			name:     "OK uploader",
+			args:     args{ctx: context.Background(), user: &entity.User{Id: 7, Role: entity.RoleLeader}, id: 1},
+			deleted:  true,
+			released: []string{hash},
+		},
+		{
+			name:     "OK blob still referenced",
+			args:     args{ctx: context.Background(), user: &entity.User{Id: 7, Role: entity.RoleLeader}, id: 1},
+			deleted:  true,
+			released: []string{},
+		},
+		{
+			name:     "OK admin",
+			args:     args{ctx: context.Background(), user: &entity.User{Role: entity.RoleAdmin}, id: 1},
+			deleted:  true,
+			released: []string{hash},
+		},
+		{
+			name:    "curator with same id error",
+			args:    args{ctx: context.Background(), user: &entity.User{Id: 7, Role: entity.RoleCurator}, id: 1},
+			wantErr: ErrAttachmentForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init service
+			s, m, store := newAttachmentTestService(t, ctrl, 0)
+			assert.NoError(t, store.Put(tc.args.ctx, hash, bytes.NewReader(testPNG), int64(len(testPNG)), "image/png"))
+			m.attachmentRepo.EXPECT().GetAttachmentById(tc.args.ctx, tc.args.client, tc.args.id).Return(attachment, nil)
+			m.locationRepo.EXPECT().GetLocationById(tc.args.ctx, tc.args.client, 2).Return(&entity.Location{Id: 2}, nil)
+			if tc.deleted {
+				m.attachmentRepo.EXPECT().DeleteAttachment(tc.args.ctx, tc.args.client, tc.args.id, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ any, _ int, removeBlob func(string) error) error {
+						for _, released := range tc.released {
+							if err := removeBlob(released); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			}
+
+			// run test
+			err := s.DeleteAttachment(tc.args.ctx, tc.args.client, tc.args.user, tc.args.id)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			exists, err := store.Exists(tc.args.ctx, hash)
+			assert.NoError(t, err)
+			assert.Equal(t, len(tc.released) == 0, exists)
+		})
+	}
+}
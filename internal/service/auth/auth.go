@@ -1,28 +1,99 @@
 package auth
 
 import (
-	"db_cp_6/internal/service"
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
 	pkgErrors "github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 	"sync"
 )
 
+var (
+	ErrSessionNotExists   = errors.New("session not exists")
+	ErrInvalidCredentials = errors.New("invalid login or password")
+)
+
 type AuthService struct {
-	member   any
-	leader   any
-	admin    any
-	mx       sync.RWMutex
-	sessions map[string]*session
+	adminRepo   repo.AdminRepo
+	leaderRepo  repo.LeaderRepo
+	memberRepo  repo.MemberRepo
+	curatorRepo repo.CuratorRepo
+	member      any
+	leader      any
+	curator     any
+	admin       any
+	mx          sync.RWMutex
+	sessions    map[string]*session
 }
 
-func NewAuthService(member any, leader any, admin any) *AuthService {
+func NewAuthService(adminRepo repo.AdminRepo, leaderRepo repo.LeaderRepo, memberRepo repo.MemberRepo, curatorRepo repo.CuratorRepo,
+	member any, leader any, curator any, admin any) *AuthService {
 	return &AuthService{
-		member:   member,
-		leader:   leader,
-		admin:    admin,
-		mx:       sync.RWMutex{},
-		sessions: make(map[string]*session),
+		adminRepo:   adminRepo,
+		leaderRepo:  leaderRepo,
+		memberRepo:  memberRepo,
+		curatorRepo: curatorRepo,
+		member:      member,
+		leader:      leader,
+		curator:     curator,
+		admin:       admin,
+		mx:          sync.RWMutex{},
+		sessions:    make(map[string]*session),
+	}
+}
+
+func (s *AuthService) SignIn(ctx context.Context, input *entity.SignInInput) (string, error) {
+	if err := input.IsValid(); err != nil {
+		return "", err
+	}
+
+	var (
+		id   int
+		hash string
+		err  error
+	)
+	switch input.Role {
+	case entity.RoleMember:
+		var m *entity.Member
+		if m, err = s.memberRepo.GetMemberByLogin(ctx, s.admin, input.Login); err == nil {
+			id, hash = m.Id, m.Password
+		}
+	case entity.RoleLeader:
+		var l *entity.Leader
+		if l, err = s.leaderRepo.GetLeaderByLogin(ctx, s.admin, input.Login); err == nil {
+			id, hash = l.Id, l.Password
+		}
+	case entity.RoleCurator:
+		var c *entity.Curator
+		if c, err = s.curatorRepo.GetCuratorByLogin(ctx, s.admin, input.Login); err == nil {
+			id, hash = c.Id, c.Password
+		}
+	case entity.RoleAdmin:
+		var a *entity.Admin
+		if a, err = s.adminRepo.GetAdminByLogin(ctx, s.admin, input.Login); err == nil {
+			id, hash = a.Id, a.Password
+		}
+	}
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return "", ErrInvalidCredentials
+		}
+		return "", err
+	}
+
+	if !checkPassword(input.Password, hash) {
+		return "", ErrInvalidCredentials
 	}
+
+	ses := NewSession(s.member, s.leader, s.curator, s.admin, id, input.Role)
+	s.mx.Lock()
+	s.sessions[ses.GetToken()] = ses
+	s.mx.Unlock()
+
+	return ses.GetToken(), nil
 }
 
 func (s *AuthService) GetSession(token string) bool {
@@ -38,12 +109,26 @@ func (s *AuthService) GetClient(token string) (any, error) {
 	ses, ok := s.sessions[token]
 	s.mx.RUnlock()
 	if !ok {
-		return nil, pkgErrors.WithMessage(service.ErrSessionNotExists, token)
+		return nil, pkgErrors.WithMessage(ErrSessionNotExists, token)
 	}
 
 	return ses.GetClient(), nil
 }
 
+func (s *AuthService) GetUser(token string) (*entity.User, error) {
+	s.mx.RLock()
+	ses, ok := s.sessions[token]
+	s.mx.RUnlock()
+	if !ok {
+		return nil, pkgErrors.WithMessage(ErrSessionNotExists, token)
+	}
+
+	return &entity.User{
+		Id:   ses.GetUserId(),
+		Role: ses.GetRole(),
+	}, nil
+}
+
 func checkPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
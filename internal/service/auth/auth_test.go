@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+func TestAuthService_SignIn(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input *entity.SignInInput
+	}
+
+	type MockBehavior func(cr *mocks.MockCuratorRepo, ar *mocks.MockAdminRepo, args args)
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantRole     string
+		wantClient   any
+		wantErr      bool
+	}{
+		{
+			name: "OK curator",
+			args: args{
+				ctx:   context.Background(),
+				input: &entity.SignInInput{Role: entity.RoleCurator, Login: "aaa", Password: "secret"},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockAdminRepo, args args) {
+				cr.EXPECT().GetCuratorByLogin(args.ctx, "admin", "aaa").
+					Return(&entity.Curator{Id: 7, Name: "aaa", Login: "aaa", Password: string(hash)}, nil)
+			},
+			wantRole:   entity.RoleCurator,
+			wantClient: "curator",
+			wantErr:    false,
+		},
+		{
+			name: "wrong password error",
+			args: args{
+				ctx:   context.Background(),
+				input: &entity.SignInInput{Role: entity.RoleCurator, Login: "aaa", Password: "wrong"},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockAdminRepo, args args) {
+				cr.EXPECT().GetCuratorByLogin(args.ctx, "admin", "aaa").
+					Return(&entity.Curator{Id: 7, Name: "aaa", Login: "aaa", Password: string(hash)}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown login error",
+			args: args{
+				ctx:   context.Background(),
+				input: &entity.SignInInput{Role: entity.RoleCurator, Login: "bbb", Password: "secret"},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockAdminRepo, args args) {
+				cr.EXPECT().GetCuratorByLogin(args.ctx, "admin", "bbb").
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK admin",
+			args: args{
+				ctx:   context.Background(),
+				input: &entity.SignInInput{Role: entity.RoleAdmin, Login: "admin1", Password: "secret"},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockAdminRepo, args args) {
+				ar.EXPECT().GetAdminByLogin(args.ctx, "admin", "admin1").
+					Return(&entity.Admin{Id: 7, Name: "admin", Login: "admin1", Password: string(hash)}, nil)
+			},
+			wantRole:   entity.RoleAdmin,
+			wantClient: "admin",
+			wantErr:    false,
+		},
+		{
+			name: "admin wrong password error",
+			args: args{
+				ctx:   context.Background(),
+				input: &entity.SignInInput{Role: entity.RoleAdmin, Login: "admin1", Password: "wrong"},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockAdminRepo, args args) {
+				ar.EXPECT().GetAdminByLogin(args.ctx, "admin", "admin1").
+					Return(&entity.Admin{Id: 7, Name: "admin", Login: "admin1", Password: string(hash)}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown role error",
+			args: args{
+				ctx:   context.Background(),
+				input: &entity.SignInInput{Role: "root", Login: "aaa", Password: "secret"},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockAdminRepo, args args) {},
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			leaderRepo := mocks.NewMockLeaderRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			adminRepo := mocks.NewMockAdminRepo(ctrl)
+			tc.mockBehavior(curatorRepo, adminRepo, tc.args)
+
+			// init service
+			s := NewAuthService(adminRepo, leaderRepo, memberRepo, curatorRepo, "member", "leader", "curator", "admin")
+
+			// run test
+			token, err := s.SignIn(tc.args.ctx, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			user, err := s.GetUser(token)
+			assert.NoError(t, err)
+			assert.Equal(t, &entity.User{Id: 7, Role: tc.wantRole}, user)
+			client, err := s.GetClient(token)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantClient, client)
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package auth
 
 import (
-	"db_cp_6/internal/service"
+	"db_cp_6/internal/entity"
 	"github.com/google/uuid"
 )
 
@@ -9,10 +9,10 @@ type session struct {
 	token  string
 	userId int
 	role   string
-	client service.Client
+	client any
 }
 
-func NewSession(member service.Client, leader service.Client, admin service.Client, id int, role string) *session {
+func NewSession(member any, leader any, curator any, admin any, id int, role string) *session {
 	ses := &session{
 		token:  uuid.NewString(),
 		userId: id,
@@ -20,11 +20,13 @@ func NewSession(member service.Client, leader service.Client, admin service.Clie
 	}
 
 	switch role {
-	case "member":
+	case entity.RoleMember:
 		ses.client = member
-	case "leader":
+	case entity.RoleLeader:
 		ses.client = leader
-	case "admin":
+	case entity.RoleCurator:
+		ses.client = curator
+	case entity.RoleAdmin:
 		ses.client = admin
 	}
 
@@ -35,10 +37,14 @@ func (s *session) GetToken() string {
 	return s.token
 }
 
+func (s *session) GetUserId() int {
+	return s.userId
+}
+
 func (s *session) GetRole() string {
 	return s.role
 }
 
-func (s *session) GetClient() service.Client {
+func (s *session) GetClient() any {
 	return s.client
 }
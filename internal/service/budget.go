@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/decimal"
+	"errors"
+	"time"
+)
+
+type BudgetService struct {
+	budgetRepo     repo.BudgetRepo
+	expeditionRepo repo.ExpeditionRepo
+	leaderRepo     repo.LeaderRepo
+	locationRepo   repo.LocationRepo
+}
+
+func NewBudgetService(budgetRepo repo.BudgetRepo, expeditionRepo repo.ExpeditionRepo, leaderRepo repo.LeaderRepo, locationRepo repo.LocationRepo) *BudgetService {
+	return &BudgetService{
+		budgetRepo:     budgetRepo,
+		expeditionRepo: expeditionRepo,
+		leaderRepo:     leaderRepo,
+		locationRepo:   locationRepo,
+	}
+}
+
+func (s *BudgetService) GetExpeditionBudget(ctx context.Context, client any, expeditionId int) (*entity.Budget, error) {
+	budget, err := s.budgetRepo.GetExpeditionBudget(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrBudgetNotFound
+		}
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+func (s *BudgetService) SetExpeditionBudget(ctx context.Context, client any, user *entity.User, expeditionId int, input *entity.SetBudgetInput) error {
+	if err := input.IsValid(); err != nil {
+		return err
+	}
+
+	if err := s.checkExpeditionLeader(ctx, client, user, expeditionId); err != nil {
+		return err
+	}
+
+	budget := &entity.Budget{
+		ExpeditionId: expeditionId,
+		Currency:     input.Currency,
+		Lines:        input.Lines,
+	}
+	err := s.budgetRepo.SetExpeditionBudget(ctx, client, budget)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrConflict):
+			return ErrBudgetCurrencyLocked
+		case errors.Is(err, repoerrs.ErrInUse):
+			return ErrBudgetCategoryInUse
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return ErrExpeditionNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *BudgetService) GetExpeditionExpenses(ctx context.Context, client any, expeditionId int) (entity.Expenses, error) {
+	return s.budgetRepo.GetExpeditionExpenses(ctx, client, expeditionId)
+}
+
+func (s *BudgetService) CreateExpense(ctx context.Context, client any, user *entity.User, expeditionId int, input *entity.CreateExpenseInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	if err := s.checkExpeditionLeader(ctx, client, user, expeditionId); err != nil {
+		return 0, err
+	}
+
+	budget, err := s.budgetRepo.GetExpeditionBudget(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrBudgetNotFound
+		}
+		return 0, err
+	}
+	if !budget.HasCategory(input.Category) {
+		return 0, ErrBudgetCategoryNotFound
+	}
+
+	spentOn := time.Now().UTC().Truncate(24 * time.Hour)
+	if input.SpentOn != "" {
+		spentOn, _ = time.Parse("2006-01-02", input.SpentOn)
+	}
+	if spentOn.After(time.Now()) {
+		return 0, ErrExpenseInFuture
+	}
+
+	rate := decimal.New(1, 0)
+	switch {
+	case input.Currency == budget.Currency:
+	case input.ExchangeRate != nil:
+		rate = *input.ExchangeRate
+	default:
+		exchangeRate, err := s.budgetRepo.GetExchangeRate(ctx, client, input.Currency, budget.Currency, spentOn)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return 0, ErrExchangeRateNotFound
+			}
+			return 0, err
+		}
+		rate = exchangeRate.Rate
+	}
+
+	expense := &entity.Expense{
+		ExpeditionId:   expeditionId,
+		Category:       input.Category,
+		Amount:         input.Amount,
+		Currency:       input.Currency,
+		ExchangeRate:   rate,
+		SpentOn:        spentOn,
+		Description:    input.Description,
+		Vendor:         input.Vendor,
+		ReceiptNumber:  input.ReceiptNumber,
+		RecordedById:   user.Id,
+		RecordedByRole: user.Role,
+	}
+	id, err := s.budgetRepo.CreateExpense(ctx, client, expense)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrBudgetCategoryNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *BudgetService) GetAllExchangeRates(ctx context.Context, client any) (entity.ExchangeRates, error) {
+	return s.budgetRepo.GetAllExchangeRates(ctx, client)
+}
+
+func (s *BudgetService) CreateExchangeRate(ctx context.Context, client any, input *entity.CreateExchangeRateInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	effectiveOn, _ := time.Parse("2006-01-02", input.EffectiveOn)
+	rate := &entity.ExchangeRate{
+		Currency:     input.Currency,
+		BaseCurrency: input.BaseCurrency,
+		Rate:         input.Rate,
+		EffectiveOn:  effectiveOn,
+	}
+	id, err := s.budgetRepo.CreateExchangeRate(ctx, client, rate)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrExchangeRateAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *BudgetService) GetExpeditionBudgetReport(ctx context.Context, client any, expeditionId int) (*entity.BudgetReport, error) {
+	_, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrExpeditionNotFound
+		}
+		return nil, err
+	}
+
+	lines, err := s.budgetRepo.GetExpeditionBudgetReport(ctx, client, expeditionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBudgetReport(lines), nil
+}
+
+func (s *BudgetService) GetLocationBudgetReport(ctx context.Context, client any, locationId int) (*entity.BudgetReport, error) {
+	_, err := s.locationRepo.GetLocationById(ctx, client, locationId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, err
+	}
+
+	lines, err := s.budgetRepo.GetLocationBudgetReport(ctx, client, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBudgetReport(lines), nil
+}
+
+func (s *BudgetService) checkExpeditionLeader(ctx context.Context, client any, user *entity.User, expeditionId int) error {
+	if user.Role != entity.RoleLeader && !user.IsAdmin() {
+		return ErrBudgetForbidden
+	}
+
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrExpeditionNotFound
+		}
+		return err
+	}
+	if expedition.ClosedOn != nil {
+		return ErrExpeditionClosed
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+
+	leaders, err := s.leaderRepo.GetExpeditionLeaders(ctx, client, expeditionId)
+	if err != nil {
+		return err
+	}
+	for _, leader := range leaders {
+		if leader.Id == user.Id {
+			return nil
+		}
+	}
+
+	return ErrBudgetForbidden
+}
+
+func newBudgetReport(lines entity.BudgetReportLines) *entity.BudgetReport {
+	report := &entity.BudgetReport{
+		Lines:  lines,
+		Totals: make(entity.BudgetReportLines, 0),
+	}
+
+	totals := make(map[string]*entity.BudgetReportLine)
+	for _, line := range lines {
+		total, ok := totals[line.Currency]
+		if !ok {
+			total = &entity.BudgetReportLine{Currency: line.Currency}
+			totals[line.Currency] = total
+			report.Totals = append(report.Totals, total)
+		}
+		total.Planned = total.Planned.Add(line.Planned)
+		total.Actual = total.Actual.Add(line.Actual)
+		total.Variance = total.Variance.Add(line.Variance)
+	}
+
+	return report
+}
@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"db_cp_6/pkg/decimal"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBudgetService_SetExpeditionBudget(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		user         *entity.User
+		expeditionId int
+		input        *entity.SetBudgetInput
+	}
+
+	type MockBehavior func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args)
+
+	lines := entity.BudgetLines{{Category: "transport", Planned: decimal.MustParse("1000")}, {Category: "food", Planned: decimal.MustParse("500")}}
+	leader := &entity.User{Id: 7, Role: entity.RoleLeader}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         leader,
+				expeditionId: 1,
+				input:        &entity.SetBudgetInput{Currency: "RUB", Lines: lines},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				lr.EXPECT().GetExpeditionLeaders(args.ctx, args.client, 1).
+					Return(entity.Leaders{{Id: 7}}, nil)
+				br.EXPECT().SetExpeditionBudget(args.ctx, args.client, &entity.Budget{ExpeditionId: 1, Currency: "RUB", Lines: lines}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "currency locked error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 1, Role: entity.RoleAdmin},
+				expeditionId: 1,
+				input:        &entity.SetBudgetInput{Currency: "EUR", Lines: lines},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				br.EXPECT().SetExpeditionBudget(args.ctx, args.client, gomock.Any()).
+					Return(repoerrs.ErrConflict)
+			},
+			wantErr: ErrBudgetCurrencyLocked,
+		},
+		{
+			name: "other leader forbidden error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         leader,
+				expeditionId: 1,
+				input:        &entity.SetBudgetInput{Currency: "RUB", Lines: lines},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				lr.EXPECT().GetExpeditionLeaders(args.ctx, args.client, 1).
+					Return(entity.Leaders{{Id: 8}}, nil)
+			},
+			wantErr: ErrBudgetForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			budgetRepo := mocks.NewMockBudgetRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			leaderRepo := mocks.NewMockLeaderRepo(ctrl)
+			locationRepo := mocks.NewMockLocationRepo(ctrl)
+			tc.mockBehavior(budgetRepo, expeditionRepo, leaderRepo, tc.args)
+
+			// init service
+			s := NewBudgetService(budgetRepo, expeditionRepo, leaderRepo, locationRepo)
+
+			// run test
+			err := s.SetExpeditionBudget(tc.args.ctx, tc.args.client, tc.args.user, tc.args.expeditionId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBudgetService_CreateExpense(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		user         *entity.User
+		expeditionId int
+		input        *entity.CreateExpenseInput
+	}
+
+	type MockBehavior func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args)
+
+	leader := &entity.User{Id: 7, Role: entity.RoleLeader}
+	spentOn, _ := time.Parse("2006-01-02", "2024-07-05")
+	budget := &entity.Budget{ExpeditionId: 1, Currency: "RUB", Lines: entity.BudgetLines{{Category: "transport", Planned: decimal.MustParse("1000")}}}
+	override := decimal.MustParse("88.5")
+	owner := func(xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+		xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+			Return(&entity.Expedition{Id: 1}, nil)
+		lr.EXPECT().GetExpeditionLeaders(args.ctx, args.client, 1).
+			Return(entity.Leaders{{Id: 7}}, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK budget currency",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         leader,
+				expeditionId: 1,
+				input:        &entity.CreateExpenseInput{Category: "transport", Amount: decimal.MustParse("300"), Currency: "RUB", ExchangeRate: &override, SpentOn: "2024-07-05"},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+				owner(xr, lr, args)
+				br.EXPECT().GetExpeditionBudget(args.ctx, args.client, 1).
+					Return(budget, nil)
+				br.EXPECT().CreateExpense(args.ctx, args.client, &entity.Expense{
+					ExpeditionId:   1,
+					Category:       "transport",
+					Amount:         decimal.MustParse("300"),
+					Currency:       "RUB",
+					ExchangeRate:   decimal.MustParse("1"),
+					SpentOn:        spentOn,
+					RecordedById:   7,
+					RecordedByRole: entity.RoleLeader,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "OK looked up rate",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         leader,
+				expeditionId: 1,
+				input:        &entity.CreateExpenseInput{Category: "transport", Amount: decimal.MustParse("20"), Currency: "KGS", SpentOn: "2024-07-05", Vendor: "bus"},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+				owner(xr, lr, args)
+				br.EXPECT().GetExpeditionBudget(args.ctx, args.client, 1).
+					Return(budget, nil)
+				br.EXPECT().GetExchangeRate(args.ctx, args.client, "KGS", "RUB", spentOn).
+					Return(&entity.ExchangeRate{Currency: "KGS", BaseCurrency: "RUB", Rate: decimal.MustParse("1.05")}, nil)
+				br.EXPECT().CreateExpense(args.ctx, args.client, &entity.Expense{
+					ExpeditionId:   1,
+					Category:       "transport",
+					Amount:         decimal.MustParse("20"),
+					Currency:       "KGS",
+					ExchangeRate:   decimal.MustParse("1.05"),
+					SpentOn:        spentOn,
+					Vendor:         "bus",
+					RecordedById:   7,
+					RecordedByRole: entity.RoleLeader,
+				}).
+					Return(2, nil)
+			},
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name: "OK explicit rate",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         leader,
+				expeditionId: 1,
+				input:        &entity.CreateExpenseInput{Category: "transport", Amount: decimal.MustParse("10"), Currency: "EUR", ExchangeRate: &override, SpentOn: "2024-07-05"},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+				owner(xr, lr, args)
+				br.EXPECT().GetExpeditionBudget(args.ctx, args.client, 1).
+					Return(budget, nil)
+				br.EXPECT().CreateExpense(args.ctx, args.client, &entity.Expense{
+					ExpeditionId:   1,
+					Category:       "transport",
+					Amount:         decimal.MustParse("10"),
+					Currency:       "EUR",
+					ExchangeRate:   override,
+					SpentOn:        spentOn,
+					RecordedById:   7,
+					RecordedByRole: entity.RoleLeader,
+				}).
+					Return(3, nil)
+			},
+			want:    3,
+			wantErr: nil,
+		},
+		{
+			name: "exchange rate not found error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         leader,
+				expeditionId: 1,
+				input:        &entity.CreateExpenseInput{Category: "transport", Amount: decimal.MustParse("10"), Currency: "ISK", SpentOn: "2024-07-05"},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+				owner(xr, lr, args)
+				br.EXPECT().GetExpeditionBudget(args.ctx, args.client, 1).
+					Return(budget, nil)
+				br.EXPECT().GetExchangeRate(args.ctx, args.client, "ISK", "RUB", spentOn).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrExchangeRateNotFound,
+		},
+		{
+			name: "category not found error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         leader,
+				expeditionId: 1,
+				input:        &entity.CreateExpenseInput{Category: "fuel", Amount: decimal.MustParse("10"), Currency: "RUB"},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {
+				owner(xr, lr, args)
+				br.EXPECT().GetExpeditionBudget(args.ctx, args.client, 1).
+					Return(budget, nil)
+			},
+			want:    0,
+			wantErr: ErrBudgetCategoryNotFound,
+		},
+		{
+			name: "member forbidden error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 3, Role: entity.RoleMember},
+				expeditionId: 1,
+				input:        &entity.CreateExpenseInput{Category: "transport", Amount: decimal.MustParse("10"), Currency: "RUB"},
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, xr *mocks.MockExpeditionRepo, lr *mocks.MockLeaderRepo, args args) {},
+			want:         0,
+			wantErr:      ErrBudgetForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			budgetRepo := mocks.NewMockBudgetRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			leaderRepo := mocks.NewMockLeaderRepo(ctrl)
+			locationRepo := mocks.NewMockLocationRepo(ctrl)
+			tc.mockBehavior(budgetRepo, expeditionRepo, leaderRepo, tc.args)
+
+			// init service
+			s := NewBudgetService(budgetRepo, expeditionRepo, leaderRepo, locationRepo)
+
+			// run test
+			got, err := s.CreateExpense(tc.args.ctx, tc.args.client, tc.args.user, tc.args.expeditionId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBudgetService_GetLocationBudgetReport(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		locationId int
+	}
+
+	type MockBehavior func(br *mocks.MockBudgetRepo, lr *mocks.MockLocationRepo, args args)
+
+	lines := entity.BudgetReportLines{
+		{Category: "food", Currency: "EUR", Planned: decimal.MustParse("100"), Actual: decimal.MustParse("120"), Variance: decimal.MustParse("-20")},
+		{Category: "food", Currency: "RUB", Planned: decimal.MustParse("5000"), Actual: decimal.MustParse("4000"), Variance: decimal.MustParse("1000")},
+		{Category: "transport", Currency: "RUB", Planned: decimal.MustParse("3000"), Actual: decimal.MustParse("3500"), Variance: decimal.MustParse("-500")},
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.BudgetReport
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				locationId: 1,
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, lr *mocks.MockLocationRepo, args args) {
+				lr.EXPECT().GetLocationById(args.ctx, args.client, 1).
+					Return(&entity.Location{Id: 1}, nil)
+				br.EXPECT().GetLocationBudgetReport(args.ctx, args.client, 1).
+					Return(lines, nil)
+			},
+			want: &entity.BudgetReport{
+				Lines: lines,
+				Totals: entity.BudgetReportLines{
+					{Currency: "EUR", Planned: decimal.MustParse("100"), Actual: decimal.MustParse("120"), Variance: decimal.MustParse("-20")},
+					{Currency: "RUB", Planned: decimal.MustParse("8000"), Actual: decimal.MustParse("7500"), Variance: decimal.MustParse("500")},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "location not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				locationId: 100,
+			},
+			mockBehavior: func(br *mocks.MockBudgetRepo, lr *mocks.MockLocationRepo, args args) {
+				lr.EXPECT().GetLocationById(args.ctx, args.client, 100).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrLocationNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			budgetRepo := mocks.NewMockBudgetRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			leaderRepo := mocks.NewMockLeaderRepo(ctrl)
+			locationRepo := mocks.NewMockLocationRepo(ctrl)
+			tc.mockBehavior(budgetRepo, locationRepo, tc.args)
+
+			// init service
+			s := NewBudgetService(budgetRepo, expeditionRepo, leaderRepo, locationRepo)
+
+			// run test
+			got, err := s.GetLocationBudgetReport(tc.args.ctx, tc.args.client, tc.args.locationId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"fmt"
+)
+
+type CatalogService struct {
+	artifactRepo repo.ArtifactRepo
+	custodyRepo  repo.CustodyRepo
+	storageRepo  repo.StorageRepo
+}
+
+func NewCatalogService(artifactRepo repo.ArtifactRepo, custodyRepo repo.CustodyRepo, storageRepo repo.StorageRepo) *CatalogService {
+	return &CatalogService{
+		artifactRepo: artifactRepo,
+		custodyRepo:  custodyRepo,
+		storageRepo:  storageRepo,
+	}
+}
+
+func (s *CatalogService) LookupCatalogCode(ctx context.Context, client any, code string) (*entity.CatalogLookup, entity.CatalogCandidates, error) {
+	parsed, err := entity.ParseCatalogCode(code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidCatalogCode, err)
+	}
+
+	if parsed.ArtifactId != nil {
+		artifact, err := s.artifactRepo.GetArtifactById(ctx, client, *parsed.ArtifactId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return nil, entity.CatalogCandidates{}, ErrCatalogNumberNotFound
+			}
+			return nil, nil, err
+		}
+		return s.lookup(ctx, client, artifact)
+	}
+
+	notFound := ErrCatalogNumberNotFound
+	switch {
+	case parsed.Number.CheckDigit == "":
+		notFound = fmt.Errorf("%w: %s has no check digit", ErrCatalogNumberNotFound, parsed.Number)
+	case parsed.Number.HasValidCheckDigit():
+		artifact, err := s.artifactRepo.GetArtifactByCatalogNumber(ctx, client, parsed.Number)
+		if err == nil {
+			return s.lookup(ctx, client, artifact)
+		}
+		if !errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, nil, err
+		}
+	default:
+		notFound = fmt.Errorf("%w: check digit %s does not match %s", ErrCatalogNumberNotFound, parsed.Number.CheckDigit, parsed.Number)
+	}
+
+	candidates, err := s.artifactRepo.GetCatalogCandidates(ctx, client, parsed.Number, entity.MaxCatalogCandidates)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, candidate := range candidates {
+		candidate.CatalogNumber = entity.WithCheckDigit(candidate.CatalogNumber)
+	}
+
+	return nil, candidates, notFound
+}
+
+func (s *CatalogService) lookup(ctx context.Context, client any, artifact *entity.Artifact) (*entity.CatalogLookup, entity.CatalogCandidates, error) {
+	result := &entity.CatalogLookup{
+		CatalogNumber: entity.WithCheckDigit(artifact.CatalogNumber),
+		Artifact:      artifact,
+	}
+
+	custody, err := s.custodyRepo.GetCurrentCustody(ctx, client, artifact.Id)
+	if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+		return nil, nil, err
+	}
+	result.Custody = custody
+
+	stored, err := s.storageRepo.GetPickList(ctx, client, []int{artifact.Id})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(stored) > 0 && stored[0].StorageNodeId != nil {
+		result.Storage = stored[0]
+	}
+
+	return result, nil, nil
+}
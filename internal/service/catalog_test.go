@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type catalogMocks struct {
+	artifactRepo *mocks.MockArtifactRepo
+	custodyRepo  *mocks.MockCustodyRepo
+	storageRepo  *mocks.MockStorageRepo
+}
+
+func TestCatalogService_LookupCatalogCode(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		code   string
+	}
+
+	type MockBehavior func(m *catalogMocks, args args)
+
+	memberId, nodeId := 7, 9
+	artifact := &entity.Artifact{Id: 1, LocationId: 2, Name: "Red-figure kylix", CatalogNumber: "2-2023-0001"}
+	custody := &entity.CustodyRecord{Id: 4, ArtifactId: 1, HolderType: entity.HolderMember, HolderId: &memberId}
+	stored := &entity.StoredArtifact{ArtifactId: 1, Name: "Red-figure kylix", StorageNodeId: &nodeId, Path: "Museum / Room 2 / Box 14"}
+	number := &entity.CatalogNumber{LocationId: 2, Year: 2023, Seq: 1, CheckDigit: "X"}
+
+	testCases := []struct {
+		name           string
+		args           args
+		mockBehavior   MockBehavior
+		want           *entity.CatalogLookup
+		wantCandidates entity.CatalogCandidates
+		wantErr        error
+	}{
+		{
+			name: "OK sloppy scan",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				code:   " 2/2023/1 x ",
+			},
+			mockBehavior: func(m *catalogMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactByCatalogNumber(args.ctx, args.client, &entity.CatalogNumber{LocationId: 2, Year: 2023, Seq: 1, CheckDigit: "X"}).
+					Return(artifact, nil)
+				m.custodyRepo.EXPECT().GetCurrentCustody(args.ctx, args.client, 1).Return(custody, nil)
+				m.storageRepo.EXPECT().GetPickList(args.ctx, args.client, []int{1}).Return(entity.StoredArtifacts{stored}, nil)
+			},
+			want: &entity.CatalogLookup{
+				CatalogNumber: "2-2023-0001-X",
+				Artifact:      artifact,
+				Custody:       custody,
+				Storage:       stored,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "OK QR payload without custody or storage",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				code:   "https://finds.example/api/v1/artifacts/1",
+			},
+			mockBehavior: func(m *catalogMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).Return(artifact, nil)
+				m.custodyRepo.EXPECT().GetCurrentCustody(args.ctx, args.client, 1).Return(nil, repoerrs.ErrNotFound)
+				m.storageRepo.EXPECT().GetPickList(args.ctx, args.client, []int{1}).
+					Return(entity.StoredArtifacts{{ArtifactId: 1, Name: "Red-figure kylix"}}, nil)
+			},
+			want: &entity.CatalogLookup{
+				CatalogNumber: "2-2023-0001-X",
+				Artifact:      artifact,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "check digit mismatch returns candidates",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				code:   "2-2023-0001-5",
+			},
+			mockBehavior: func(m *catalogMocks, args args) {
+				m.artifactRepo.EXPECT().GetCatalogCandidates(args.ctx, args.client, &entity.CatalogNumber{LocationId: 2, Year: 2023, Seq: 1, CheckDigit: "5"}, entity.MaxCatalogCandidates).
+					Return(entity.CatalogCandidates{{ArtifactId: 1, CatalogNumber: "2-2023-0001", Name: "Red-figure kylix"}}, nil)
+			},
+			wantCandidates: entity.CatalogCandidates{{ArtifactId: 1, CatalogNumber: "2-2023-0001-X", Name: "Red-figure kylix"}},
+			wantErr:        ErrCatalogNumberNotFound,
+		},
+		{
+			name: "missing check digit returns candidates",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				code:   "2-2023-0001",
+			},
+			mockBehavior: func(m *catalogMocks, args args) {
+				m.artifactRepo.EXPECT().GetCatalogCandidates(args.ctx, args.client, &entity.CatalogNumber{LocationId: 2, Year: 2023, Seq: 1}, entity.MaxCatalogCandidates).
+					Return(entity.CatalogCandidates{{ArtifactId: 1, CatalogNumber: "2-2023-0001", Name: "Red-figure kylix"}}, nil)
+			},
+			wantCandidates: entity.CatalogCandidates{{ArtifactId: 1, CatalogNumber: "2-2023-0001-X", Name: "Red-figure kylix"}},
+			wantErr:        ErrCatalogNumberNotFound,
+		},
+		{
+			name: "no exact match returns candidates",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				code:   "2-2023-0001-X",
+			},
+			mockBehavior: func(m *catalogMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactByCatalogNumber(args.ctx, args.client, number).Return(nil, repoerrs.ErrNotFound)
+				m.artifactRepo.EXPECT().GetCatalogCandidates(args.ctx, args.client, number, entity.MaxCatalogCandidates).
+					Return(entity.CatalogCandidates{}, nil)
+			},
+			wantCandidates: entity.CatalogCandidates{},
+			wantErr:        ErrCatalogNumberNotFound,
+		},
+		{
+			name: "unparsable code error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				code:   "kylix",
+			},
+			mockBehavior: func(m *catalogMocks, args args) {},
+			wantErr:      ErrInvalidCatalogCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			m := &catalogMocks{
+				artifactRepo: mocks.NewMockArtifactRepo(ctrl),
+				custodyRepo:  mocks.NewMockCustodyRepo(ctrl),
+				storageRepo:  mocks.NewMockStorageRepo(ctrl),
+			}
+			tc.mockBehavior(m, tc.args)
+
+			// init service
+			s := NewCatalogService(m.artifactRepo, m.custodyRepo, m.storageRepo)
+
+			// run test
+			got, candidates, err := s.LookupCatalogCode(tc.args.ctx, tc.args.client, tc.args.code)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Equal(t, tc.wantCandidates, candidates)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseCatalogCode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		code    string
+		want    *entity.CatalogNumber
+		wantErr bool
+	}{
+		{name: "canonical", code: "12-2024-0007", want: &entity.CatalogNumber{LocationId: 12, Year: 2024, Seq: 7}},
+		{name: "with check digit", code: "12-2024-0007-9", want: &entity.CatalogNumber{LocationId: 12, Year: 2024, Seq: 7, CheckDigit: "9"}},
+		{name: "mixed separators", code: "12 / 2024 . 7", want: &entity.CatalogNumber{LocationId: 12, Year: 2024, Seq: 7}},
+		{name: "lowercase check digit", code: "2_2023_0001_x", want: &entity.CatalogNumber{LocationId: 2, Year: 2023, Seq: 1, CheckDigit: "X"}},
+		{name: "attached check digit", code: "2-2023-0001x", want: &entity.CatalogNumber{LocationId: 2, Year: 2023, Seq: 1, CheckDigit: "X"}},
+		{name: "attached numeric check digit", code: "12-2024-00015", want: &entity.CatalogNumber{LocationId: 12, Year: 2024, Seq: 1, CheckDigit: "5"}},
+		{name: "five digit sequence", code: "12-2024-10015", want: &entity.CatalogNumber{LocationId: 12, Year: 2024, Seq: 10015}},
+		{name: "too few parts", code: "2-2023", wantErr: true},
+		{name: "long check digit", code: "2-2023-0001-12", wantErr: true},
+		{name: "letters", code: "A-2023-0001", wantErr: true},
+		{name: "foreign link", code: "https://example.com/about", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := entity.ParseCatalogCode(tc.code)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.Number)
+		})
+	}
+}
+
+func TestCatalogCheckDigit(t *testing.T) {
+	assert.Equal(t, "X", entity.CatalogCheckDigit("2-2023-0001"))
+	assert.Equal(t, "8", entity.CatalogCheckDigit("2-2023-0002"))
+	assert.Equal(t, "9", entity.CatalogCheckDigit("12-2024-0007"))
+	assert.Equal(t, "12-2024-0007-9", entity.WithCheckDigit("12-2024-0007"))
+
+	number := &entity.CatalogNumber{LocationId: 12, Year: 2024, Seq: 70, CheckDigit: "9"}
+	assert.False(t, number.HasValidCheckDigit())
+
+	number = &entity.CatalogNumber{LocationId: 12, Year: 2024, Seq: 7}
+	assert.False(t, number.HasValidCheckDigit())
+	number.CheckDigit = "9"
+	assert.True(t, number.HasValidCheckDigit())
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"time"
+)
+
+type ConditionReportService struct {
+	reportRepo   repo.ConditionReportRepo
+	artifactRepo repo.ArtifactRepo
+}
+
+func NewConditionReportService(reportRepo repo.ConditionReportRepo, artifactRepo repo.ArtifactRepo) *ConditionReportService {
+	return &ConditionReportService{
+		reportRepo:   reportRepo,
+		artifactRepo: artifactRepo,
+	}
+}
+
+func (s *ConditionReportService) GetArtifactConditionReports(ctx context.Context, client any, artifactId int) (entity.ConditionReports, error) {
+	return s.reportRepo.GetArtifactConditionReports(ctx, client, artifactId)
+}
+
+func (s *ConditionReportService) CreateConditionReport(ctx context.Context, client any, user *entity.User, artifactId int,
+	input *entity.CreateConditionReportInput) (int, error) {
+	if user.Role != entity.RoleCurator && !user.IsAdmin() {
+		return 0, ErrConditionReportForbidden
+	}
+
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	_, err := s.artifactRepo.GetArtifactById(ctx, client, artifactId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrArtifactNotFound
+		}
+		return 0, err
+	}
+
+	inspectedOn := time.Now().UTC().Truncate(24 * time.Hour)
+	if input.InspectedOn != "" {
+		inspectedOn, _ = time.Parse("2006-01-02", input.InspectedOn)
+	}
+	if inspectedOn.After(time.Now()) {
+		return 0, ErrConditionReportInFuture
+	}
+
+	report := &entity.ConditionReport{
+		ArtifactId:     artifactId,
+		Grade:          input.Grade,
+		DamageNotes:    input.DamageNotes,
+		Treatments:     input.Treatments,
+		Materials:      input.Materials,
+		Conservator:    input.Conservator,
+		InspectedOn:    inspectedOn,
+		RecordedById:   user.Id,
+		RecordedByRole: user.Role,
+	}
+	return s.reportRepo.CreateConditionReport(ctx, client, report)
+}
+
+func (s *ConditionReportService) GetOverdueInspections(ctx context.Context, client any, intervalDays int) (entity.OverdueInspections, error) {
+	if intervalDays < 0 {
+		return nil, ErrInvalidInspectionInterval
+	}
+	if intervalDays == 0 {
+		intervalDays = entity.DefaultInspectionIntervalDays
+	}
+
+	inspectedBefore := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -intervalDays)
+	return s.reportRepo.GetOverdueInspections(ctx, client, inspectedBefore)
+}
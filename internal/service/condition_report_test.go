@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConditionReportService_CreateConditionReport(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		user       *entity.User
+		artifactId int
+		input      *entity.CreateConditionReportInput
+	}
+
+	type MockBehavior func(cr *mocks.MockConditionReportRepo, ar *mocks.MockArtifactRepo, args args)
+
+	curator := &entity.User{Id: 5, Role: entity.RoleCurator}
+	leader := &entity.User{Id: 1, Role: entity.RoleLeader}
+	inspectedOn, _ := time.Parse("2006-01-02", "2024-07-15")
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "OK curator adds report",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       curator,
+				artifactId: 1,
+				input: &entity.CreateConditionReportInput{
+					Grade:       entity.ConditionPoor,
+					DamageNotes: "crack along the rim",
+					Treatments:  "consolidation",
+					Materials:   "Paraloid B-72",
+					Conservator: "aaa",
+					InspectedOn: "2024-07-15",
+				},
+			},
+			mockBehavior: func(cr *mocks.MockConditionReportRepo, ar *mocks.MockArtifactRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).
+					Return(&entity.Artifact{Id: 1}, nil)
+				cr.EXPECT().CreateConditionReport(args.ctx, args.client, &entity.ConditionReport{
+					ArtifactId:     1,
+					Grade:          entity.ConditionPoor,
+					DamageNotes:    "crack along the rim",
+					Treatments:     "consolidation",
+					Materials:      "Paraloid B-72",
+					Conservator:    "aaa",
+					InspectedOn:    inspectedOn,
+					RecordedById:   curator.Id,
+					RecordedByRole: entity.RoleCurator,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "leader forbidden error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       leader,
+				artifactId: 1,
+				input: &entity.CreateConditionReportInput{
+					Grade:       entity.ConditionGood,
+					Conservator: "aaa",
+				},
+			},
+			mockBehavior: func(cr *mocks.MockConditionReportRepo, ar *mocks.MockArtifactRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+		{
+			name: "artifact not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       curator,
+				artifactId: 1,
+				input: &entity.CreateConditionReportInput{
+					Grade:       entity.ConditionGood,
+					Conservator: "aaa",
+				},
+			},
+			mockBehavior: func(cr *mocks.MockConditionReportRepo, ar *mocks.MockArtifactRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "inspection in future error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       curator,
+				artifactId: 1,
+				input: &entity.CreateConditionReportInput{
+					Grade:       entity.ConditionGood,
+					Conservator: "aaa",
+					InspectedOn: time.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+				},
+			},
+			mockBehavior: func(cr *mocks.MockConditionReportRepo, ar *mocks.MockArtifactRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).
+					Return(&entity.Artifact{Id: 1}, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "invalid grade error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       curator,
+				artifactId: 1,
+				input: &entity.CreateConditionReportInput{
+					Grade:       "broken",
+					Conservator: "aaa",
+				},
+			},
+			mockBehavior: func(cr *mocks.MockConditionReportRepo, ar *mocks.MockArtifactRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			reportRepo := mocks.NewMockConditionReportRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			tc.mockBehavior(reportRepo, artifactRepo, tc.args)
+
+			// init service
+			s := NewConditionReportService(reportRepo, artifactRepo)
+
+			// run test
+			got, err := s.CreateConditionReport(tc.args.ctx, tc.args.client, tc.args.user, tc.args.artifactId, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestConditionReportService_GetOverdueInspections(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		intervalDays int
+	}
+
+	type MockBehavior func(m *mocks.MockConditionReportRepo, args args)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.OverdueInspections
+		wantErr      bool
+	}{
+		{
+			name: "OK default interval",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+			},
+			mockBehavior: func(m *mocks.MockConditionReportRepo, args args) {
+				m.EXPECT().GetOverdueInspections(args.ctx, args.client, today.AddDate(0, 0, -entity.DefaultInspectionIntervalDays)).
+					Return(entity.OverdueInspections{{ArtifactId: 1, Name: "aaa"}}, nil)
+			},
+			want:    entity.OverdueInspections{{ArtifactId: 1, Name: "aaa"}},
+			wantErr: false,
+		},
+		{
+			name: "OK custom interval",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				intervalDays: 30,
+			},
+			mockBehavior: func(m *mocks.MockConditionReportRepo, args args) {
+				m.EXPECT().GetOverdueInspections(args.ctx, args.client, today.AddDate(0, 0, -30)).
+					Return(entity.OverdueInspections{}, nil)
+			},
+			want:    entity.OverdueInspections{},
+			wantErr: false,
+		},
+		{
+			name: "negative interval error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				intervalDays: -1,
+			},
+			mockBehavior: func(m *mocks.MockConditionReportRepo, args args) {},
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			reportRepo := mocks.NewMockConditionReportRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			tc.mockBehavior(reportRepo, tc.args)
+
+			// init service
+			s := NewConditionReportService(reportRepo, artifactRepo)
+
+			// run test
+			got, err := s.GetOverdueInspections(tc.args.ctx, tc.args.client, tc.args.intervalDays)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
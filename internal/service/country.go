@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+)
+
+type CountryService struct {
+	countryRepo repo.CountryRepo
+}
+
+func NewCountryService(countryRepo repo.CountryRepo) *CountryService {
+	return &CountryService{countryRepo: countryRepo}
+}
+
+func (s *CountryService) GetCountries(lang string) entity.Countries {
+	return entity.GetCountries(lang)
+}
+
+func (s *CountryService) MigrateCountries(ctx context.Context, client any, user *entity.User, apply bool) (*entity.CountryMigrationReport, error) {
+	if !user.IsAdmin() {
+		return nil, ErrCountryMigrationForbidden
+	}
+
+	usages, err := s.countryRepo.GetCountryUsages(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.CountryMigrationReport{
+		Mapped:   make(entity.CountryMappings, 0),
+		Unmapped: make(entity.CountryUsages, 0),
+	}
+	for _, usage := range usages {
+		code, ok := entity.NormalizeCountry(usage.Value)
+		switch {
+		case ok && code == usage.Value:
+			report.Normalized += usage.Rows
+		case ok:
+			report.Mapped = append(report.Mapped, &entity.CountryMapping{
+				Table: usage.Table,
+				Value: usage.Value,
+				Code:  code,
+				Name:  entity.CountryName(code, entity.LanguageEn),
+				Rows:  usage.Rows,
+			})
+		default:
+			report.Unmapped = append(report.Unmapped, usage)
+		}
+	}
+
+	if apply && len(report.Mapped) > 0 {
+		if err = s.countryRepo.ApplyCountryMappings(ctx, client, report.Mapped); err != nil {
+			return nil, err
+		}
+	}
+	report.Applied = apply
+
+	return report, nil
+}
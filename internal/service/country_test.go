@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service/mocks"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCountryService_MigrateCountries(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		user   *entity.User
+		apply  bool
+	}
+
+	type MockBehavior func(m *mocks.MockCountryRepo, args args)
+
+	usages := func() entity.CountryUsages {
+		return entity.CountryUsages{
+			{Table: "institutions", Value: "RU", Rows: 2},
+			{Table: "locations", Value: "KG", Rows: 3},
+			{Table: "locations", Value: "Russian Federation", Rows: 4},
+			{Table: "locations", Value: "РФ", Rows: 1},
+			{Table: "locations", Value: "Атлантида", Rows: 1},
+		}
+	}
+	mapped := entity.CountryMappings{
+		{Table: "locations", Value: "Russian Federation", Code: "RU", Name: "Russia", Rows: 4},
+		{Table: "locations", Value: "РФ", Code: "RU", Name: "Russia", Rows: 1},
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.CountryMigrationReport
+		wantErr      error
+		wantAnyErr   bool
+	}{
+		{
+			name: "OK dry run",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 1, Role: entity.RoleAdmin},
+				apply:  false,
+			},
+			mockBehavior: func(m *mocks.MockCountryRepo, args args) {
+				m.EXPECT().GetCountryUsages(args.ctx, args.client).Return(usages(), nil)
+			},
+			want: &entity.CountryMigrationReport{
+				Applied:    false,
+				Normalized: 5,
+				Mapped:     mapped,
+				Unmapped:   entity.CountryUsages{{Table: "locations", Value: "Атлантида", Rows: 1}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "OK apply",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 1, Role: entity.RoleAdmin},
+				apply:  true,
+			},
+			mockBehavior: func(m *mocks.MockCountryRepo, args args) {
+				m.EXPECT().GetCountryUsages(args.ctx, args.client).Return(usages(), nil)
+				m.EXPECT().ApplyCountryMappings(args.ctx, args.client, mapped).Return(nil)
+			},
+			want: &entity.CountryMigrationReport{
+				Applied:    true,
+				Normalized: 5,
+				Mapped:     mapped,
+				Unmapped:   entity.CountryUsages{{Table: "locations", Value: "Атлантида", Rows: 1}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "OK nothing to map",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 1, Role: entity.RoleAdmin},
+				apply:  true,
+			},
+			mockBehavior: func(m *mocks.MockCountryRepo, args args) {
+				m.EXPECT().GetCountryUsages(args.ctx, args.client).
+					Return(entity.CountryUsages{{Table: "locations", Value: "GR", Rows: 6}}, nil)
+			},
+			want: &entity.CountryMigrationReport{
+				Applied:    true,
+				Normalized: 6,
+				Mapped:     entity.CountryMappings{},
+				Unmapped:   entity.CountryUsages{},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "forbidden error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 2, Role: entity.RoleLeader},
+				apply:  false,
+			},
+			mockBehavior: func(m *mocks.MockCountryRepo, args args) {},
+			want:         nil,
+			wantErr:      ErrCountryMigrationForbidden,
+		},
+		{
+			name: "repo error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 1, Role: entity.RoleAdmin},
+				apply:  true,
+			},
+			mockBehavior: func(m *mocks.MockCountryRepo, args args) {
+				m.EXPECT().GetCountryUsages(args.ctx, args.client).Return(usages(), nil)
+				m.EXPECT().ApplyCountryMappings(args.ctx, args.client, gomock.Any()).Return(errors.New("some error"))
+			},
+			want:       nil,
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			countryRepo := mocks.NewMockCountryRepo(ctrl)
+			tc.mockBehavior(countryRepo, tc.args)
+
+			// init service
+			s := NewCountryService(countryRepo)
+
+			// run test
+			got, err := s.MigrateCountries(tc.args.ctx, tc.args.client, tc.args.user, tc.args.apply)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			if tc.wantAnyErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCountryService_GetCountries(t *testing.T) {
+	s := NewCountryService(nil)
+
+	countries := s.GetCountries(entity.ParseLanguage("ru-RU,ru;q=0.9,en;q=0.8"))
+	assert.Len(t, countries, 249)
+	for _, c := range countries {
+		if c.Code == "KG" {
+			assert.Equal(t, "Киргизия", c.Name)
+			assert.Equal(t, "KGZ", c.Alpha3)
+		}
+	}
+
+	countries = s.GetCountries(entity.ParseLanguage("de-DE"))
+	assert.Equal(t, countries[0].NameEn, countries[0].Name)
+}
@@ -6,15 +6,20 @@ import (
 	"db_cp_6/internal/repo"
 	"db_cp_6/internal/repo/repoerrs"
 	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"time"
 )
 
 type CuratorService struct {
-	curatorRepo repo.CuratorRepo
+	curatorRepo  repo.CuratorRepo
+	artifactRepo repo.ArtifactRepo
 }
 
-func NewCuratorService(curatorRepo repo.CuratorRepo) *CuratorService {
+func NewCuratorService(curatorRepo repo.CuratorRepo, artifactRepo repo.ArtifactRepo) *CuratorService {
 	return &CuratorService{
-		curatorRepo: curatorRepo,
+		curatorRepo:  curatorRepo,
+		artifactRepo: artifactRepo,
 	}
 }
 
@@ -34,6 +39,10 @@ func (s *CuratorService) GetExpeditionCurators(ctx context.Context, client any,
 	return s.curatorRepo.GetExpeditionCurators(ctx, client, expeditionId)
 }
 
+func (s *CuratorService) GetInstitutionCurators(ctx context.Context, client any, institutionId int) (entity.Curators, error) {
+	return s.curatorRepo.GetInstitutionCurators(ctx, client, institutionId)
+}
+
 func (s *CuratorService) GetAllCurators(ctx context.Context, client any) (entity.Curators, error) {
 	return s.curatorRepo.GetAllCurators(ctx, client)
 }
@@ -44,13 +53,27 @@ func (s *CuratorService) CreateCurator(ctx context.Context, client any, input *e
 	}
 
 	m := &entity.Curator{
-		Name: input.Name,
+		Name:          input.Name,
+		Email:         input.Email,
+		Phone:         input.Phone,
+		InstitutionId: input.InstitutionId,
+		Login:         input.Login,
+	}
+	if input.Password != "" {
+		bytes, err := bcrypt.GenerateFromPassword([]byte(input.Password), passwordCost)
+		if err != nil {
+			return 0, fmt.Errorf("CuratorService CreateCurator : %v", err)
+		}
+		m.Password = string(bytes)
 	}
 	id, err := s.curatorRepo.CreateCurator(ctx, client, m)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrAlreadyExists) {
 			return 0, ErrCuratorAlreadyExists
 		}
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrInstitutionNotFound
+		}
 		return 0, err
 	}
 
@@ -68,3 +91,33 @@ func (s *CuratorService) DeleteCurator(ctx context.Context, client any, id int)
 
 	return nil
 }
+
+func (s *CuratorService) AssignArtifactCurator(ctx context.Context, client any, artifactId int, input *entity.AssignCuratorInput) error {
+	if input.CuratorId != nil {
+		if _, err := s.GetCuratorById(ctx, client, *input.CuratorId); err != nil {
+			return err
+		}
+	}
+
+	err := s.artifactRepo.UpdateArtifactCurator(ctx, client, artifactId, input.CuratorId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrArtifactNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *CuratorService) GetCuratorWorkloads(ctx context.Context, client any, intervalDays int) (entity.CuratorWorkloads, error) {
+	if intervalDays < 0 {
+		return nil, ErrInvalidInspectionInterval
+	}
+	if intervalDays == 0 {
+		intervalDays = entity.DefaultInspectionIntervalDays
+	}
+
+	inspectedBefore := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -intervalDays)
+	return s.curatorRepo.GetCuratorWorkloads(ctx, client, inspectedBefore)
+}
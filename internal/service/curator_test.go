@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
 	"db_cp_6/internal/service/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestCuratorService_GetCuratorById(t *testing.T) {
@@ -69,10 +71,11 @@ func TestCuratorService_GetCuratorById(t *testing.T) {
 
 			// init mocks
 			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
 			tc.mockBehavior(curatorRepo, tc.args)
 
 			// init service
-			s := NewCuratorService(curatorRepo)
+			s := NewCuratorService(curatorRepo, artifactRepo)
 
 			// run test
 			got, err := s.GetCuratorById(tc.args.ctx, tc.args.client, tc.args.id)
@@ -137,10 +140,11 @@ func TestCuratorService_GetExpeditionCurators(t *testing.T) {
 
 			// init mocks
 			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
 			tc.mockBehavior(curatorRepo, tc.args)
 
 			// init service
-			s := NewCuratorService(curatorRepo)
+			s := NewCuratorService(curatorRepo, artifactRepo)
 
 			// run test
 			got, err := s.GetExpeditionCurators(tc.args.ctx, tc.args.client, tc.args.expeditionId)
@@ -203,10 +207,11 @@ func TestCuratorService_GetAllCurators(t *testing.T) {
 
 			// init mocks
 			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
 			tc.mockBehavior(curatorRepo, tc.args)
 
 			// init service
-			s := NewCuratorService(curatorRepo)
+			s := NewCuratorService(curatorRepo, artifactRepo)
 
 			// run test
 			got, err := s.GetAllCurators(tc.args.ctx, tc.args.client)
@@ -283,10 +288,11 @@ func TestCuratorService_CreateCurator(t *testing.T) {
 
 			// init mocks
 			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
 			tc.mockBehavior(curatorRepo, tc.args)
 
 			// init service
-			s := NewCuratorService(curatorRepo)
+			s := NewCuratorService(curatorRepo, artifactRepo)
 
 			// run test
 			got, err := s.CreateCurator(tc.args.ctx, tc.args.client, tc.args.input)
@@ -352,10 +358,11 @@ func TestCuratorService_DeleteCurator(t *testing.T) {
 
 			// init mocks
 			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
 			tc.mockBehavior(curatorRepo, tc.args)
 
 			// init service
-			s := NewCuratorService(curatorRepo)
+			s := NewCuratorService(curatorRepo, artifactRepo)
 
 			// run test
 			err := s.DeleteCurator(tc.args.ctx, tc.args.client, tc.args.id)
@@ -368,3 +375,183 @@ func TestCuratorService_DeleteCurator(t *testing.T) {
 		})
 	}
 }
+
+func TestCuratorService_AssignArtifactCurator(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		artifactId int
+		input      *entity.AssignCuratorInput
+	}
+
+	type MockBehavior func(cr *mocks.MockCuratorRepo, ar *mocks.MockArtifactRepo, args args)
+
+	curatorId := 4
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+				input:      &entity.AssignCuratorInput{CuratorId: &curatorId},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockArtifactRepo, args args) {
+				cr.EXPECT().GetCuratorById(args.ctx, args.client, curatorId).
+					Return(&entity.Curator{Id: curatorId, Name: "aaa"}, nil)
+				ar.EXPECT().UpdateArtifactCurator(args.ctx, args.client, args.artifactId, &curatorId).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "OK unassign",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+				input:      &entity.AssignCuratorInput{},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockArtifactRepo, args args) {
+				ar.EXPECT().UpdateArtifactCurator(args.ctx, args.client, args.artifactId, nil).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "curator not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+				input:      &entity.AssignCuratorInput{CuratorId: &curatorId},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockArtifactRepo, args args) {
+				cr.EXPECT().GetCuratorById(args.ctx, args.client, curatorId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: ErrCuratorNotFound,
+		},
+		{
+			name: "artifact not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+				input:      &entity.AssignCuratorInput{CuratorId: &curatorId},
+			},
+			mockBehavior: func(cr *mocks.MockCuratorRepo, ar *mocks.MockArtifactRepo, args args) {
+				cr.EXPECT().GetCuratorById(args.ctx, args.client, curatorId).
+					Return(&entity.Curator{Id: curatorId, Name: "aaa"}, nil)
+				ar.EXPECT().UpdateArtifactCurator(args.ctx, args.client, args.artifactId, &curatorId).
+					Return(repoerrs.ErrNotFound)
+			},
+			wantErr: ErrArtifactNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			tc.mockBehavior(curatorRepo, artifactRepo, tc.args)
+
+			// init service
+			s := NewCuratorService(curatorRepo, artifactRepo)
+
+			// run test
+			err := s.AssignArtifactCurator(tc.args.ctx, tc.args.client, tc.args.artifactId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCuratorService_GetCuratorWorkloads(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		intervalDays int
+	}
+
+	type MockBehavior func(m *mocks.MockCuratorRepo, args args)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	workloads := entity.CuratorWorkloads{
+		{CuratorId: 1, Name: "aaa", Artifacts: 12, PendingConditionReports: 3, ActiveLoans: 1},
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.CuratorWorkloads
+		wantErr      bool
+	}{
+		{
+			name: "OK default interval",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+			},
+			mockBehavior: func(m *mocks.MockCuratorRepo, args args) {
+				m.EXPECT().GetCuratorWorkloads(args.ctx, args.client, today.AddDate(0, 0, -entity.DefaultInspectionIntervalDays)).
+					Return(workloads, nil)
+			},
+			want:    workloads,
+			wantErr: false,
+		},
+		{
+			name: "negative interval error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				intervalDays: -5,
+			},
+			mockBehavior: func(m *mocks.MockCuratorRepo, args args) {},
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			tc.mockBehavior(curatorRepo, tc.args)
+
+			// init service
+			s := NewCuratorService(curatorRepo, artifactRepo)
+
+			// run test
+			got, err := s.GetCuratorWorkloads(tc.args.ctx, tc.args.client, tc.args.intervalDays)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
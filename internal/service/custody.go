@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+)
+
+type CustodyService struct {
+	custodyRepo  repo.CustodyRepo
+	artifactRepo repo.ArtifactRepo
+	memberRepo   repo.MemberRepo
+	curatorRepo  repo.CuratorRepo
+}
+
+func NewCustodyService(custodyRepo repo.CustodyRepo, artifactRepo repo.ArtifactRepo, memberRepo repo.MemberRepo, curatorRepo repo.CuratorRepo) *CustodyService {
+	return &CustodyService{
+		custodyRepo:  custodyRepo,
+		artifactRepo: artifactRepo,
+		memberRepo:   memberRepo,
+		curatorRepo:  curatorRepo,
+	}
+}
+
+func (s *CustodyService) GetArtifactCustody(ctx context.Context, client any, artifactId int) (entity.CustodyRecords, error) {
+	return s.custodyRepo.GetArtifactCustody(ctx, client, artifactId)
+}
+
+func (s *CustodyService) TransferArtifact(ctx context.Context, client any, user *entity.User, artifactId int, input *entity.TransferArtifactInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	artifact, err := s.artifactRepo.GetArtifactById(ctx, client, artifactId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrArtifactNotFound
+		}
+		return 0, err
+	}
+
+	record := &entity.CustodyRecord{
+		ArtifactId:   artifact.Id,
+		HolderType:   input.HolderType,
+		HolderId:     input.HolderId,
+		HolderName:   input.HolderName,
+		Reason:       input.Reason,
+		SignedById:   user.Id,
+		SignedByRole: user.Role,
+	}
+	if input.HolderType == entity.HolderFacility {
+		record.HolderId = nil
+	} else {
+		record.HolderName = ""
+	}
+
+	current, err := s.custodyRepo.GetCurrentCustody(ctx, client, artifact.Id)
+	switch {
+	case errors.Is(err, repoerrs.ErrNotFound):
+		if !canOpenCustody(user, artifact) {
+			return 0, ErrCustodyNotHolder
+		}
+	case err != nil:
+		return 0, err
+	default:
+		if !user.IsAdmin() && !current.IsHeldBy(user) {
+			return 0, ErrCustodyNotHolder
+		}
+		record.PreviousId = &current.Id
+	}
+
+	if record.HolderId != nil {
+		if err = s.checkHolder(ctx, client, record.HolderType, *record.HolderId); err != nil {
+			return 0, err
+		}
+	}
+
+	id, err := s.custodyRepo.CreateCustodyRecord(ctx, client, record)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrCustodyConflict
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *CustodyService) checkHolder(ctx context.Context, client any, holderType string, holderId int) error {
+	var err error
+	switch holderType {
+	case entity.HolderMember:
+		_, err = s.memberRepo.GetMemberById(ctx, client, holderId)
+	case entity.HolderCurator:
+		_, err = s.curatorRepo.GetCuratorById(ctx, client, holderId)
+	}
+	if errors.Is(err, repoerrs.ErrNotFound) {
+		return ErrCustodyHolderNotFound
+	}
+
+	return err
+}
+
+func canOpenCustody(user *entity.User, artifact *entity.Artifact) bool {
+	switch user.Role {
+	case entity.RoleAdmin, entity.RoleLeader:
+		return true
+	case entity.RoleMember:
+		return artifact.FoundByMemberId != nil && *artifact.FoundByMemberId == user.Id
+	}
+
+	return false
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCustodyService_GetArtifactCustody(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		artifactId int
+	}
+
+	type MockBehavior func(m *mocks.MockCustodyRepo, args args)
+
+	holderId := 2
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.CustodyRecords
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+			},
+			mockBehavior: func(m *mocks.MockCustodyRepo, args args) {
+				m.EXPECT().GetArtifactCustody(args.ctx, args.client, args.artifactId).
+					Return(entity.CustodyRecords{
+						&entity.CustodyRecord{
+							Id:         1,
+							ArtifactId: 1,
+							HolderType: entity.HolderMember,
+							HolderId:   &holderId,
+							Reason:     "found",
+						},
+					}, nil)
+			},
+			want: entity.CustodyRecords{
+				&entity.CustodyRecord{
+					Id:         1,
+					ArtifactId: 1,
+					HolderType: entity.HolderMember,
+					HolderId:   &holderId,
+					Reason:     "found",
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			tc.mockBehavior(custodyRepo, tc.args)
+
+			// init service
+			s := NewCustodyService(custodyRepo, artifactRepo, memberRepo, curatorRepo)
+
+			// run test
+			got, err := s.GetArtifactCustody(tc.args.ctx, tc.args.client, tc.args.artifactId)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCustodyService_TransferArtifact(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		user       *entity.User
+		artifactId int
+		input      *entity.TransferArtifactInput
+	}
+
+	type MockBehavior func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args)
+
+	finderId := 2
+	curatorId := 5
+	artifact := &entity.Artifact{
+		Id:              1,
+		LocationId:      1,
+		FoundByMemberId: &finderId,
+		Name:            "aaa",
+	}
+	current := &entity.CustodyRecord{
+		Id:         7,
+		ArtifactId: 1,
+		HolderType: entity.HolderMember,
+		HolderId:   &finderId,
+		Reason:     "found",
+	}
+	toCurator := &entity.TransferArtifactInput{
+		HolderType: entity.HolderCurator,
+		HolderId:   &curatorId,
+		Reason:     "handed over for cataloguing",
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK by current holder",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: finderId, Role: entity.RoleMember},
+				artifactId: 1,
+				input:      toCurator,
+			},
+			mockBehavior: func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).Return(artifact, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.artifactId).Return(current, nil)
+				cur.EXPECT().GetCuratorById(args.ctx, args.client, curatorId).Return(&entity.Curator{Id: curatorId}, nil)
+				cr.EXPECT().CreateCustodyRecord(args.ctx, args.client, &entity.CustodyRecord{
+					ArtifactId:   1,
+					PreviousId:   &current.Id,
+					HolderType:   entity.HolderCurator,
+					HolderId:     &curatorId,
+					Reason:       args.input.Reason,
+					SignedById:   finderId,
+					SignedByRole: entity.RoleMember,
+				}).Return(8, nil)
+			},
+			want: 8,
+		},
+		{
+			name: "OK by admin",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: 1, Role: entity.RoleAdmin},
+				artifactId: 1,
+				input: &entity.TransferArtifactInput{
+					HolderType: entity.HolderFacility,
+					HolderName: "radiocarbon lab",
+					Reason:     "sampling",
+				},
+			},
+			mockBehavior: func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).Return(artifact, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.artifactId).Return(current, nil)
+				cr.EXPECT().CreateCustodyRecord(args.ctx, args.client, &entity.CustodyRecord{
+					ArtifactId:   1,
+					PreviousId:   &current.Id,
+					HolderType:   entity.HolderFacility,
+					HolderName:   "radiocarbon lab",
+					Reason:       args.input.Reason,
+					SignedById:   1,
+					SignedByRole: entity.RoleAdmin,
+				}).Return(8, nil)
+			},
+			want: 8,
+		},
+		{
+			name: "OK first record by finder",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: finderId, Role: entity.RoleMember},
+				artifactId: 1,
+				input: &entity.TransferArtifactInput{
+					HolderType: entity.HolderMember,
+					HolderId:   &finderId,
+					Reason:     "found",
+				},
+			},
+			mockBehavior: func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).Return(artifact, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.artifactId).Return(nil, repoerrs.ErrNotFound)
+				mr.EXPECT().GetMemberById(args.ctx, args.client, finderId).Return(&entity.Member{Id: finderId}, nil)
+				cr.EXPECT().CreateCustodyRecord(args.ctx, args.client, &entity.CustodyRecord{
+					ArtifactId:   1,
+					HolderType:   entity.HolderMember,
+					HolderId:     &finderId,
+					Reason:       "found",
+					SignedById:   finderId,
+					SignedByRole: entity.RoleMember,
+				}).Return(1, nil)
+			},
+			want: 1,
+		},
+		{
+			name: "not current holder error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: 3, Role: entity.RoleMember},
+				artifactId: 1,
+				input:      toCurator,
+			},
+			mockBehavior: func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).Return(artifact, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.artifactId).Return(current, nil)
+			},
+			wantErr: ErrCustodyNotHolder,
+		},
+		{
+			name: "concurrent transfer error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: finderId, Role: entity.RoleMember},
+				artifactId: 1,
+				input:      toCurator,
+			},
+			mockBehavior: func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).Return(artifact, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.artifactId).Return(current, nil)
+				cur.EXPECT().GetCuratorById(args.ctx, args.client, curatorId).Return(&entity.Curator{Id: curatorId}, nil)
+				cr.EXPECT().CreateCustodyRecord(args.ctx, args.client, gomock.Any()).Return(0, repoerrs.ErrAlreadyExists)
+			},
+			wantErr: ErrCustodyConflict,
+		},
+		{
+			name: "curator holder not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: finderId, Role: entity.RoleMember},
+				artifactId: 1,
+				input:      toCurator,
+			},
+			mockBehavior: func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).Return(artifact, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.artifactId).Return(current, nil)
+				cur.EXPECT().GetCuratorById(args.ctx, args.client, curatorId).Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: ErrCustodyHolderNotFound,
+		},
+		{
+			name: "member holder not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: 1, Role: entity.RoleAdmin},
+				artifactId: 1,
+				input: &entity.TransferArtifactInput{
+					HolderType: entity.HolderMember,
+					HolderId:   &curatorId,
+					Reason:     "field study",
+				},
+			},
+			mockBehavior: func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).Return(artifact, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, args.artifactId).Return(current, nil)
+				mr.EXPECT().GetMemberById(args.ctx, args.client, curatorId).Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: ErrCustodyHolderNotFound,
+		},
+		{
+			name: "artifact not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: 1, Role: entity.RoleAdmin},
+				artifactId: 1,
+				input:      toCurator,
+			},
+			mockBehavior: func(cr *mocks.MockCustodyRepo, ar *mocks.MockArtifactRepo, mr *mocks.MockMemberRepo, cur *mocks.MockCuratorRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: ErrArtifactNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			curatorRepo := mocks.NewMockCuratorRepo(ctrl)
+			tc.mockBehavior(custodyRepo, artifactRepo, memberRepo, curatorRepo, tc.args)
+
+			// init service
+			s := NewCustodyService(custodyRepo, artifactRepo, memberRepo, curatorRepo)
+
+			// run test
+			got, err := s.TransferArtifact(tc.args.ctx, tc.args.client, tc.args.user, tc.args.artifactId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
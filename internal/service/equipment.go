@@ -6,15 +6,20 @@ import (
 	"db_cp_6/internal/repo"
 	"db_cp_6/internal/repo/repoerrs"
 	"errors"
+	"time"
 )
 
 type EquipmentService struct {
-	equipmentRepo repo.EquipmentRepo
+	equipmentRepo  repo.EquipmentRepo
+	inventoryRepo  repo.InventoryRepo
+	expeditionRepo repo.ExpeditionRepo
 }
 
-func NewEquipmentService(equipmentRepo repo.EquipmentRepo) *EquipmentService {
+func NewEquipmentService(equipmentRepo repo.EquipmentRepo, inventoryRepo repo.InventoryRepo, expeditionRepo repo.ExpeditionRepo) *EquipmentService {
 	return &EquipmentService{
-		equipmentRepo: equipmentRepo,
+		equipmentRepo:  equipmentRepo,
+		inventoryRepo:  inventoryRepo,
+		expeditionRepo: expeditionRepo,
 	}
 }
 
@@ -43,12 +48,61 @@ func (s *EquipmentService) CreateEquipment(ctx context.Context, client any, inpu
 		return 0, err
 	}
 
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, input.ExpeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrExpeditionNotFound
+		}
+		return 0, err
+	}
+	if expedition.ClosedOn != nil {
+		return 0, ErrExpeditionClosed
+	}
+
 	exp := &entity.Equipment{
 		ExpeditionId: input.ExpeditionId,
-		Name:         input.Name,
+		ItemId:       input.ItemId,
 		Amount:       input.Amount,
+		ReservedFrom: expedition.StartDate,
+		ReservedTo:   expedition.EndDate,
+	}
+	if input.ReservedFrom != "" {
+		exp.ReservedFrom, _ = time.Parse("2006-01-02", input.ReservedFrom)
+	}
+	if input.ReservedTo != "" {
+		exp.ReservedTo, _ = time.Parse("2006-01-02", input.ReservedTo)
 	}
-	return s.equipmentRepo.CreateEquipment(ctx, client, exp)
+	if exp.ReservedTo.Before(exp.ReservedFrom) {
+		return 0, ErrInvalidDateRange
+	}
+
+	availability, err := s.inventoryRepo.GetItemAvailability(ctx, client, &entity.AvailabilityFilter{
+		ItemId: &exp.ItemId,
+		From:   exp.ReservedFrom,
+		To:     exp.ReservedTo,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(availability) == 0 {
+		return 0, ErrInventoryItemNotFound
+	}
+	if !availability[0].Serviceable {
+		return 0, ErrInventoryItemUnserviceable
+	}
+	if availability[0].Available < exp.Amount {
+		return 0, ErrEquipmentOverbooked
+	}
+
+	id, err := s.equipmentRepo.CreateEquipment(ctx, client, exp)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrConflict) {
+			return 0, ErrEquipmentOverbooked
+		}
+		return 0, err
+	}
+
+	return id, nil
 }
 
 func (s *EquipmentService) DeleteEquipment(ctx context.Context, client any, id int) error {
@@ -62,3 +116,127 @@ func (s *EquipmentService) DeleteEquipment(ctx context.Context, client any, id i
 
 	return nil
 }
+
+func (s *EquipmentService) GetEquipmentEvents(ctx context.Context, client any, equipmentId int) (entity.EquipmentEvents, error) {
+	return s.equipmentRepo.GetEquipmentEvents(ctx, client, equipmentId)
+}
+
+func (s *EquipmentService) CheckOutEquipment(ctx context.Context, client any, equipmentId int, input *entity.CheckOutEquipmentInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	balance, err := s.getOpenEquipmentBalance(ctx, client, equipmentId)
+	if err != nil {
+		return 0, err
+	}
+	if balance.CheckedOut+input.Quantity > balance.Reserved {
+		return 0, ErrEquipmentOverCheckedOut
+	}
+
+	return s.createEquipmentEvent(ctx, client, &entity.EquipmentEvent{
+		EquipmentId: equipmentId,
+		Kind:        entity.EquipmentCheckOut,
+		Quantity:    input.Quantity,
+		MemberId:    input.MemberId,
+	}, ErrEquipmentOverCheckedOut)
+}
+
+func (s *EquipmentService) CheckInEquipment(ctx context.Context, client any, equipmentId int, input *entity.CheckInEquipmentInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	balance, err := s.getOpenEquipmentBalance(ctx, client, equipmentId)
+	if err != nil {
+		return 0, err
+	}
+	if input.Returned+input.Lost+input.Damaged > balance.Outstanding {
+		return 0, ErrEquipmentOverCheckedIn
+	}
+
+	return s.createEquipmentEvent(ctx, client, &entity.EquipmentEvent{
+		EquipmentId: equipmentId,
+		Kind:        entity.EquipmentCheckIn,
+		Returned:    input.Returned,
+		Lost:        input.Lost,
+		Damaged:     input.Damaged,
+		MemberId:    input.MemberId,
+	}, ErrEquipmentOverCheckedIn)
+}
+
+func (s *EquipmentService) GetExpeditionReconciliation(ctx context.Context, client any, expeditionId int) (*entity.EquipmentReconciliation, error) {
+	_, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrExpeditionNotFound
+		}
+		return nil, err
+	}
+
+	balances, err := s.equipmentRepo.GetExpeditionEquipmentBalances(ctx, client, expeditionId)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := &entity.EquipmentReconciliation{
+		ExpeditionId: expeditionId,
+		Reconciled:   true,
+		Balances:     balances,
+	}
+	for _, balance := range balances {
+		if balance.Outstanding != 0 {
+			reconciliation.Reconciled = false
+		}
+	}
+
+	return reconciliation, nil
+}
+
+func (s *EquipmentService) getOpenEquipmentBalance(ctx context.Context, client any, equipmentId int) (*entity.EquipmentBalance, error) {
+	equipment, err := s.equipmentRepo.GetEquipmentById(ctx, client, equipmentId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrEquipmentNotFound
+		}
+		return nil, err
+	}
+
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, equipment.ExpeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrExpeditionNotFound
+		}
+		return nil, err
+	}
+	if expedition.ClosedOn != nil {
+		return nil, ErrExpeditionClosed
+	}
+
+	balances, err := s.equipmentRepo.GetExpeditionEquipmentBalances(ctx, client, equipment.ExpeditionId)
+	if err != nil {
+		return nil, err
+	}
+	for _, balance := range balances {
+		if balance.EquipmentId == equipmentId {
+			return balance, nil
+		}
+	}
+
+	return nil, ErrEquipmentNotFound
+}
+
+func (s *EquipmentService) createEquipmentEvent(ctx context.Context, client any, event *entity.EquipmentEvent, errConflict error) (int, error) {
+	id, err := s.equipmentRepo.CreateEquipmentEvent(ctx, client, event)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return 0, ErrEquipmentMemberNotListed
+		case errors.Is(err, repoerrs.ErrConflict):
+			return 0, errConflict
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
 	"db_cp_6/internal/service/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestEquipmentService_GetEquipmentById(t *testing.T) {
@@ -73,10 +75,12 @@ func TestEquipmentService_GetEquipmentById(t *testing.T) {
 
 			// init mocks
 			equipmentRepo := mocks.NewMockEquipmentRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
 			tc.mockBehavior(equipmentRepo, tc.args)
 
 			// init service
-			s := NewEquipmentService(equipmentRepo)
+			s := NewEquipmentService(equipmentRepo, inventoryRepo, expeditionRepo)
 
 			// run test
 			got, err := s.GetEquipmentById(tc.args.ctx, tc.args.client, tc.args.id)
@@ -145,10 +149,12 @@ func TestEquipmentService_GetExpeditionEquipments(t *testing.T) {
 
 			// init mocks
 			equipmentRepo := mocks.NewMockEquipmentRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
 			tc.mockBehavior(equipmentRepo, tc.args)
 
 			// init service
-			s := NewEquipmentService(equipmentRepo)
+			s := NewEquipmentService(equipmentRepo, inventoryRepo, expeditionRepo)
 
 			// run test
 			got, err := s.GetExpeditionEquipments(tc.args.ctx, tc.args.client, tc.args.expeditionId)
@@ -215,10 +221,12 @@ func TestEquipmentService_GetAllEquipments(t *testing.T) {
 
 			// init mocks
 			equipmentRepo := mocks.NewMockEquipmentRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
 			tc.mockBehavior(equipmentRepo, tc.args)
 
 			// init service
-			s := NewEquipmentService(equipmentRepo)
+			s := NewEquipmentService(equipmentRepo, inventoryRepo, expeditionRepo)
 
 			// run test
 			got, err := s.GetAllEquipments(tc.args.ctx, tc.args.client)
@@ -240,36 +248,177 @@ func TestEquipmentService_CreateEquipment(t *testing.T) {
 		input  *entity.CreateEquipmentInput
 	}
 
-	type MockBehavior func(m *mocks.MockEquipmentRepo, args args)
+	type MockBehavior func(er *mocks.MockEquipmentRepo, ir *mocks.MockInventoryRepo, xr *mocks.MockExpeditionRepo, args args)
+
+	start, _ := time.Parse("2006-01-02", "2024-07-01")
+	end, _ := time.Parse("2006-01-02", "2024-08-01")
+	from, _ := time.Parse("2006-01-02", "2024-07-10")
+	itemId := 2
+	expedition := &entity.Expedition{Id: 1, LocationId: 1, StartDate: start, EndDate: end}
 
 	testCases := []struct {
 		name         string
 		args         args
 		mockBehavior MockBehavior
 		want         int
-		wantErr      bool
+		wantErr      error
 	}{
 		{
-			name: "OK",
+			name: "OK expedition dates",
 			args: args{
 				ctx:    context.Background(),
 				client: nil,
 				input: &entity.CreateEquipmentInput{
 					ExpeditionId: 1,
-					Name:         "aaa",
+					ItemId:       itemId,
 					Amount:       10,
 				},
 			},
-			mockBehavior: func(m *mocks.MockEquipmentRepo, args args) {
-				m.EXPECT().CreateEquipment(args.ctx, args.client, &entity.Equipment{
-					ExpeditionId: args.input.ExpeditionId,
-					Name:         args.input.Name,
-					Amount:       args.input.Amount,
+			mockBehavior: func(er *mocks.MockEquipmentRepo, ir *mocks.MockInventoryRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, &entity.AvailabilityFilter{ItemId: &itemId, From: start, To: end}).
+					Return(entity.ItemAvailabilities{{ItemId: itemId, TotalQuantity: 12, Reserved: 2, Available: 10, Serviceable: true}}, nil)
+				er.EXPECT().CreateEquipment(args.ctx, args.client, &entity.Equipment{
+					ExpeditionId: 1,
+					ItemId:       itemId,
+					Amount:       10,
+					ReservedFrom: start,
+					ReservedTo:   end,
 				}).
 					Return(1, nil)
 			},
 			want:    1,
-			wantErr: false,
+			wantErr: nil,
+		},
+		{
+			name: "OK custom dates",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateEquipmentInput{
+					ExpeditionId: 1,
+					ItemId:       itemId,
+					Amount:       1,
+					ReservedFrom: "2024-07-10",
+				},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, ir *mocks.MockInventoryRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, &entity.AvailabilityFilter{ItemId: &itemId, From: from, To: end}).
+					Return(entity.ItemAvailabilities{{ItemId: itemId, TotalQuantity: 1, Available: 1, Serviceable: true}}, nil)
+				er.EXPECT().CreateEquipment(args.ctx, args.client, &entity.Equipment{
+					ExpeditionId: 1,
+					ItemId:       itemId,
+					Amount:       1,
+					ReservedFrom: from,
+					ReservedTo:   end,
+				}).
+					Return(2, nil)
+			},
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name: "overbooked error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateEquipmentInput{
+					ExpeditionId: 1,
+					ItemId:       itemId,
+					Amount:       10,
+				},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, ir *mocks.MockInventoryRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, gomock.Any()).
+					Return(entity.ItemAvailabilities{{ItemId: itemId, TotalQuantity: 10, Reserved: 5, Available: 5, Serviceable: true}}, nil)
+			},
+			want:    0,
+			wantErr: ErrEquipmentOverbooked,
+		},
+		{
+			name: "overbooked concurrently error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateEquipmentInput{
+					ExpeditionId: 1,
+					ItemId:       itemId,
+					Amount:       10,
+				},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, ir *mocks.MockInventoryRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, gomock.Any()).
+					Return(entity.ItemAvailabilities{{ItemId: itemId, TotalQuantity: 10, Available: 10, Serviceable: true}}, nil)
+				er.EXPECT().CreateEquipment(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrConflict)
+			},
+			want:    0,
+			wantErr: ErrEquipmentOverbooked,
+		},
+		{
+			name: "item not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateEquipmentInput{
+					ExpeditionId: 1,
+					ItemId:       itemId,
+					Amount:       10,
+				},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, ir *mocks.MockInventoryRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, gomock.Any()).
+					Return(entity.ItemAvailabilities{}, nil)
+			},
+			want:    0,
+			wantErr: ErrInventoryItemNotFound,
+		},
+		{
+			name: "item unserviceable error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateEquipmentInput{
+					ExpeditionId: 1,
+					ItemId:       itemId,
+					Amount:       1,
+				},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, ir *mocks.MockInventoryRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, gomock.Any()).
+					Return(entity.ItemAvailabilities{{ItemId: itemId, TotalQuantity: 10, Available: 10, Serviceable: false}}, nil)
+			},
+			want:    0,
+			wantErr: ErrInventoryItemUnserviceable,
+		},
+		{
+			name: "expedition not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateEquipmentInput{
+					ExpeditionId: 1,
+					ItemId:       itemId,
+					Amount:       10,
+				},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, ir *mocks.MockInventoryRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrExpeditionNotFound,
 		},
 	}
 
@@ -281,15 +430,17 @@ func TestEquipmentService_CreateEquipment(t *testing.T) {
 
 			// init mocks
 			equipmentRepo := mocks.NewMockEquipmentRepo(ctrl)
-			tc.mockBehavior(equipmentRepo, tc.args)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			tc.mockBehavior(equipmentRepo, inventoryRepo, expeditionRepo, tc.args)
 
 			// init service
-			s := NewEquipmentService(equipmentRepo)
+			s := NewEquipmentService(equipmentRepo, inventoryRepo, expeditionRepo)
 
 			// run test
 			got, err := s.CreateEquipment(tc.args.ctx, tc.args.client, tc.args.input)
-			if tc.wantErr {
-				assert.Error(t, err)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
 				return
 			}
 
@@ -350,10 +501,12 @@ func TestEquipmentService_DeleteEquipment(t *testing.T) {
 
 			// init mocks
 			equipmentRepo := mocks.NewMockEquipmentRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
 			tc.mockBehavior(equipmentRepo, tc.args)
 
 			// init service
-			s := NewEquipmentService(equipmentRepo)
+			s := NewEquipmentService(equipmentRepo, inventoryRepo, expeditionRepo)
 
 			// run test
 			err := s.DeleteEquipment(tc.args.ctx, tc.args.client, tc.args.id)
@@ -366,3 +519,370 @@ func TestEquipmentService_DeleteEquipment(t *testing.T) {
 		})
 	}
 }
+
+func TestEquipmentService_CheckOutEquipment(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		client      any
+		equipmentId int
+		input       *entity.CheckOutEquipmentInput
+	}
+
+	type MockBehavior func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args)
+
+	closedOn, _ := time.Parse("2006-01-02", "2024-08-02")
+	equipment := &entity.Equipment{Id: 1, ExpeditionId: 1, ItemId: 2, Amount: 10}
+	balances := entity.EquipmentBalances{{EquipmentId: 1, ItemId: 2, Reserved: 10, CheckedOut: 6, Outstanding: 6}}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:         context.Background(),
+				client:      nil,
+				equipmentId: 1,
+				input:       &entity.CheckOutEquipmentInput{MemberId: 3, Quantity: 4},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				er.EXPECT().GetEquipmentById(args.ctx, args.client, args.equipmentId).
+					Return(equipment, nil)
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				er.EXPECT().GetExpeditionEquipmentBalances(args.ctx, args.client, 1).
+					Return(balances, nil)
+				er.EXPECT().CreateEquipmentEvent(args.ctx, args.client, &entity.EquipmentEvent{
+					EquipmentId: 1,
+					Kind:        entity.EquipmentCheckOut,
+					Quantity:    4,
+					MemberId:    3,
+				}).
+					Return(5, nil)
+			},
+			want:    5,
+			wantErr: nil,
+		},
+		{
+			name: "exceeds reservation error",
+			args: args{
+				ctx:         context.Background(),
+				client:      nil,
+				equipmentId: 1,
+				input:       &entity.CheckOutEquipmentInput{MemberId: 3, Quantity: 5},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				er.EXPECT().GetEquipmentById(args.ctx, args.client, args.equipmentId).
+					Return(equipment, nil)
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				er.EXPECT().GetExpeditionEquipmentBalances(args.ctx, args.client, 1).
+					Return(balances, nil)
+			},
+			want:    0,
+			wantErr: ErrEquipmentOverCheckedOut,
+		},
+		{
+			name: "expedition closed error",
+			args: args{
+				ctx:         context.Background(),
+				client:      nil,
+				equipmentId: 1,
+				input:       &entity.CheckOutEquipmentInput{MemberId: 3, Quantity: 1},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				er.EXPECT().GetEquipmentById(args.ctx, args.client, args.equipmentId).
+					Return(equipment, nil)
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1, ClosedOn: &closedOn}, nil)
+			},
+			want:    0,
+			wantErr: ErrExpeditionClosed,
+		},
+		{
+			name: "member not on roster error",
+			args: args{
+				ctx:         context.Background(),
+				client:      nil,
+				equipmentId: 1,
+				input:       &entity.CheckOutEquipmentInput{MemberId: 9, Quantity: 1},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				er.EXPECT().GetEquipmentById(args.ctx, args.client, args.equipmentId).
+					Return(equipment, nil)
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				er.EXPECT().GetExpeditionEquipmentBalances(args.ctx, args.client, 1).
+					Return(balances, nil)
+				er.EXPECT().CreateEquipmentEvent(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrEquipmentMemberNotListed,
+		},
+		{
+			name: "equipment not found error",
+			args: args{
+				ctx:         context.Background(),
+				client:      nil,
+				equipmentId: 100,
+				input:       &entity.CheckOutEquipmentInput{MemberId: 3, Quantity: 1},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				er.EXPECT().GetEquipmentById(args.ctx, args.client, args.equipmentId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrEquipmentNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			equipmentRepo := mocks.NewMockEquipmentRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			tc.mockBehavior(equipmentRepo, expeditionRepo, tc.args)
+
+			// init service
+			s := NewEquipmentService(equipmentRepo, inventoryRepo, expeditionRepo)
+
+			// run test
+			got, err := s.CheckOutEquipment(tc.args.ctx, tc.args.client, tc.args.equipmentId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEquipmentService_CheckInEquipment(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		client      any
+		equipmentId int
+		input       *entity.CheckInEquipmentInput
+	}
+
+	type MockBehavior func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args)
+
+	equipment := &entity.Equipment{Id: 1, ExpeditionId: 1, ItemId: 2, Amount: 10}
+	balances := entity.EquipmentBalances{{EquipmentId: 1, ItemId: 2, Reserved: 10, CheckedOut: 6, Returned: 2, Outstanding: 4}}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:         context.Background(),
+				client:      nil,
+				equipmentId: 1,
+				input:       &entity.CheckInEquipmentInput{MemberId: 3, Returned: 2, Lost: 1, Damaged: 1},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				er.EXPECT().GetEquipmentById(args.ctx, args.client, args.equipmentId).
+					Return(equipment, nil)
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				er.EXPECT().GetExpeditionEquipmentBalances(args.ctx, args.client, 1).
+					Return(balances, nil)
+				er.EXPECT().CreateEquipmentEvent(args.ctx, args.client, &entity.EquipmentEvent{
+					EquipmentId: 1,
+					Kind:        entity.EquipmentCheckIn,
+					Returned:    2,
+					Lost:        1,
+					Damaged:     1,
+					MemberId:    3,
+				}).
+					Return(6, nil)
+			},
+			want:    6,
+			wantErr: nil,
+		},
+		{
+			name: "exceeds outstanding error",
+			args: args{
+				ctx:         context.Background(),
+				client:      nil,
+				equipmentId: 1,
+				input:       &entity.CheckInEquipmentInput{MemberId: 3, Returned: 4, Lost: 1},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				er.EXPECT().GetEquipmentById(args.ctx, args.client, args.equipmentId).
+					Return(equipment, nil)
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				er.EXPECT().GetExpeditionEquipmentBalances(args.ctx, args.client, 1).
+					Return(balances, nil)
+			},
+			want:    0,
+			wantErr: ErrEquipmentOverCheckedIn,
+		},
+		{
+			name: "concurrent check-in error",
+			args: args{
+				ctx:         context.Background(),
+				client:      nil,
+				equipmentId: 1,
+				input:       &entity.CheckInEquipmentInput{MemberId: 3, Returned: 4},
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				er.EXPECT().GetEquipmentById(args.ctx, args.client, args.equipmentId).
+					Return(equipment, nil)
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(&entity.Expedition{Id: 1}, nil)
+				er.EXPECT().GetExpeditionEquipmentBalances(args.ctx, args.client, 1).
+					Return(balances, nil)
+				er.EXPECT().CreateEquipmentEvent(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrConflict)
+			},
+			want:    0,
+			wantErr: ErrEquipmentOverCheckedIn,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			equipmentRepo := mocks.NewMockEquipmentRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			tc.mockBehavior(equipmentRepo, expeditionRepo, tc.args)
+
+			// init service
+			s := NewEquipmentService(equipmentRepo, inventoryRepo, expeditionRepo)
+
+			// run test
+			got, err := s.CheckInEquipment(tc.args.ctx, tc.args.client, tc.args.equipmentId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEquipmentService_GetExpeditionReconciliation(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		expeditionId int
+	}
+
+	type MockBehavior func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args)
+
+	reconciled := entity.EquipmentBalances{
+		{EquipmentId: 1, Reserved: 10, CheckedOut: 6, Returned: 4, Lost: 1, Damaged: 1},
+		{EquipmentId: 2, Reserved: 3},
+	}
+	outstanding := entity.EquipmentBalances{
+		{EquipmentId: 1, Reserved: 10, CheckedOut: 6, Returned: 4, Outstanding: 2},
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.EquipmentReconciliation
+		wantErr      error
+	}{
+		{
+			name: "OK reconciled",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, args.expeditionId).
+					Return(&entity.Expedition{Id: 1}, nil)
+				er.EXPECT().GetExpeditionEquipmentBalances(args.ctx, args.client, args.expeditionId).
+					Return(reconciled, nil)
+			},
+			want:    &entity.EquipmentReconciliation{ExpeditionId: 1, Reconciled: true, Balances: reconciled},
+			wantErr: nil,
+		},
+		{
+			name: "OK outstanding",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, args.expeditionId).
+					Return(&entity.Expedition{Id: 1}, nil)
+				er.EXPECT().GetExpeditionEquipmentBalances(args.ctx, args.client, args.expeditionId).
+					Return(outstanding, nil)
+			},
+			want:    &entity.EquipmentReconciliation{ExpeditionId: 1, Reconciled: false, Balances: outstanding},
+			wantErr: nil,
+		},
+		{
+			name: "expedition not found error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 100,
+			},
+			mockBehavior: func(er *mocks.MockEquipmentRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, args.expeditionId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrExpeditionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			equipmentRepo := mocks.NewMockEquipmentRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			tc.mockBehavior(equipmentRepo, expeditionRepo, tc.args)
+
+			// init service
+			s := NewEquipmentService(equipmentRepo, inventoryRepo, expeditionRepo)
+
+			// run test
+			got, err := s.GetExpeditionReconciliation(tc.args.ctx, tc.args.client, tc.args.expeditionId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
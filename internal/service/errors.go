@@ -1,9 +1,13 @@
 package service
 
-import "errors"
+import (
+	"db_cp_6/internal/service/auth"
+	"errors"
+)
 
 var (
-	ErrSessionNotExists = errors.New("session not exists")
+	ErrSessionNotExists   = auth.ErrSessionNotExists
+	ErrInvalidCredentials = auth.ErrInvalidCredentials
 
 	ErrLeaderAlreadyExists = errors.New("leader already exists")
 	ErrLeaderNotFound      = errors.New("leader not found")
@@ -14,11 +18,118 @@ var (
 	ErrCuratorAlreadyExists = errors.New("curator already exists")
 	ErrCuratorNotFound      = errors.New("curator not found")
 
-	ErrLocationNotFound = errors.New("location not found")
+	ErrInstitutionAlreadyExists = errors.New("institution already exists")
+	ErrInstitutionNotFound      = errors.New("institution not found")
+
+	ErrLocationNotFound   = errors.New("location not found")
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	ErrInvalidBoundingBox = errors.New("invalid bounding box")
+
+	ErrExpeditionNotFound      = errors.New("expedition not found")
+	ErrExpeditionClosed        = errors.New("expedition is closed")
+	ErrExpeditionNotReconciled = errors.New("expedition equipment is not reconciled")
+
+	ErrTrenchNotFound = errors.New("trench not found")
+
+	ErrContextAlreadyExists         = errors.New("context already exists")
+	ErrContextNotFound              = errors.New("context not found")
+	ErrContextLocationMismatch      = errors.New("related contexts belong to different locations")
+	ErrContextRelationAlreadyExists = errors.New("context relation already exists")
+	ErrContextRelationNotFound      = errors.New("context relation not found")
+	ErrContextRelationCycle         = errors.New("context relation creates a stratigraphic cycle")
+
+	ErrArtifactNotFound               = errors.New("artifact not found")
+	ErrArtifactLocationMismatch       = errors.New("artifact location does not match expedition or context location")
+	ErrArtifactFoundOutsideExpedition = errors.New("artifact find date is outside expedition dates")
+	ErrArtifactFinderNotOnExpedition  = errors.New("artifact finder is not a member of the expedition")
+
+	ErrPeriodAlreadyExists = errors.New("period already exists")
+	ErrPeriodNotFound      = errors.New("period not found")
+	ErrPeriodOutsideParent = errors.New("period is outside its parent period")
+	ErrInvalidYearRange    = errors.New("invalid year range")
+
+	ErrSampleNotFound         = errors.New("sample not found")
+	ErrSampleLocationMismatch = errors.New("sample artifact or context does not belong to the expedition location")
+	ErrInvalidSampleStatus    = errors.New("invalid sample status")
+
+	ErrCustodyNotHolder      = errors.New("only the current holder or an admin can transfer the artifact")
+	ErrCustodyConflict       = errors.New("artifact custody was changed concurrently")
+	ErrCustodyHolderNotFound = errors.New("custody holder not found")
+
+	ErrConditionReportForbidden  = errors.New("only curators and admins can add condition reports")
+	ErrConditionReportInFuture   = errors.New("inspection date is in the future")
+	ErrInvalidInspectionInterval = errors.New("invalid inspection interval")
+
+	ErrStorageNodeAlreadyExists = errors.New("storage node already exists")
+	ErrStorageNodeNotFound      = errors.New("storage node not found")
+	ErrStorageNodeNotEmpty      = errors.New("storage node is not empty")
+	ErrStorageNodeFull          = errors.New("storage node is full")
+	ErrStorageHierarchy         = errors.New("storage node cannot be placed inside its parent")
+	ErrStorageForbidden         = errors.New("only curators and admins can manage storage")
+
+	ErrLoanNotFound          = errors.New("loan not found")
+	ErrLoanForbidden         = errors.New("only curators and admins can manage loans")
+	ErrLoanOverlap           = errors.New("artifact is already on loan for overlapping dates")
+	ErrLoanConflict          = errors.New("loan status was changed concurrently")
+	ErrInvalidLoanTransition = errors.New("invalid loan status transition")
+
+	ErrInventoryItemAlreadyExists = errors.New("inventory item already exists")
+	ErrInventoryItemNotFound      = errors.New("inventory item not found")
+	ErrInventoryItemReserved      = errors.New("inventory item has reservations")
+	ErrInventoryItemUnserviceable = errors.New("inventory item is unserviceable")
+	ErrInvalidDateRange           = errors.New("invalid date range")
+
+	ErrMaintenanceInFuture = errors.New("maintenance date is in the future")
+
+	ErrEquipmentNotFound        = errors.New("equipment not found")
+	ErrEquipmentOverbooked      = errors.New("not enough stock for the reservation period")
+	ErrEquipmentOverCheckedOut  = errors.New("check-out exceeds the reserved amount")
+	ErrEquipmentOverCheckedIn   = errors.New("check-in exceeds the checked-out amount")
+	ErrEquipmentMemberNotListed = errors.New("responsible member is not on the expedition roster")
+
+	ErrPackingTemplateAlreadyExists = errors.New("packing template already exists")
+	ErrPackingTemplateNotFound      = errors.New("packing template not found")
+	ErrPackingListAlreadyApplied    = errors.New("packing template is already applied to the expedition")
+	ErrPackingListNotApplied        = errors.New("packing template is not applied to the expedition")
+
+	ErrBudgetNotFound            = errors.New("expedition budget not found")
+	ErrBudgetForbidden           = errors.New("only the expedition leaders and admins can manage its budget")
+	ErrBudgetCurrencyLocked      = errors.New("budget currency cannot change once expenses are recorded")
+	ErrBudgetCategoryInUse       = errors.New("budget category has recorded expenses")
+	ErrBudgetCategoryNotFound    = errors.New("budget category not found")
+	ErrExpenseInFuture           = errors.New("expense date is in the future")
+	ErrExchangeRateNotFound      = errors.New("exchange rate not found")
+	ErrExchangeRateAlreadyExists = errors.New("exchange rate already exists")
+
+	ErrJournalEntryNotFound    = errors.New("journal entry not found")
+	ErrJournalMemberNotListed  = errors.New("only members on the expedition roster can write journal entries")
+	ErrJournalEntryForbidden   = errors.New("journal entries can only be edited by their authors")
+	ErrJournalLockForbidden    = errors.New("only the expedition leaders and admins can lock journal days")
+	ErrJournalDateOutOfRange   = errors.New("journal date is outside the expedition dates")
+	ErrJournalArtifactMismatch = errors.New("linked artifacts must be registered by the expedition on the entry date")
+	ErrJournalDayLocked        = errors.New("journal day is locked")
+
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrAttachmentParentNotFound = errors.New("attachment parent not found")
+	ErrAttachmentForbidden      = errors.New("not allowed to change attachments of this record")
+	ErrAttachmentTooLarge       = errors.New("attachment exceeds the size limit")
+	ErrAttachmentEmpty          = errors.New("attachment is empty")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment content type is not allowed")
+	ErrAttachmentKindMismatch   = errors.New("attachment kind does not match its content")
+	ErrAttachmentAlreadyExists  = errors.New("file is already attached to this record")
+	ErrThumbnailNotFound        = errors.New("thumbnail not found")
+
+	ErrArtifactHasNoImages = errors.New("artifact has no processed images")
+
+	ErrNoArtifactsToLabel = errors.New("no artifacts to label")
 
-	ErrExpeditionNotFound = errors.New("expedition not found")
+	ErrInvalidCatalogCode    = errors.New("invalid catalog number")
+	ErrCatalogNumberNotFound = errors.New("catalog number not found")
 
-	ErrArtifactNotFound = errors.New("artifact not found")
+	ErrDuplicatesForbidden = errors.New("only leaders and admins can review duplicates")
+	ErrMergeForbidden      = errors.New("only admins can merge records")
+	ErrMergeTargetNotFound = errors.New("record to merge not found")
+	ErrMergeConflict       = errors.New("records cannot be merged")
 
-	ErrEquipmentNotFound = errors.New("equipment not found")
+	ErrCountryMigrationForbidden = errors.New("only admins can migrate country values")
 )
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+)
+
+type ExcavationContextService struct {
+	contextRepo repo.ExcavationContextRepo
+}
+
+func NewExcavationContextService(contextRepo repo.ExcavationContextRepo) *ExcavationContextService {
+	return &ExcavationContextService{
+		contextRepo: contextRepo,
+	}
+}
+
+func (s *ExcavationContextService) GetContextById(ctx context.Context, client any, id int) (*entity.ExcavationContext, error) {
+	excavationContext, err := s.contextRepo.GetContextById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrContextNotFound
+		}
+		return nil, err
+	}
+
+	return excavationContext, nil
+}
+
+func (s *ExcavationContextService) GetTrenchContexts(ctx context.Context, client any, trenchId int) (entity.ExcavationContexts, error) {
+	return s.contextRepo.GetTrenchContexts(ctx, client, trenchId)
+}
+
+func (s *ExcavationContextService) CreateContext(ctx context.Context, client any, input *entity.CreateExcavationContextInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	c := &entity.ExcavationContext{
+		TrenchId:    input.TrenchId,
+		Code:        input.Code,
+		Description: input.Description,
+	}
+	id, err := s.contextRepo.CreateContext(ctx, client, c)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrContextAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *ExcavationContextService) DeleteContext(ctx context.Context, client any, id int) error {
+	err := s.contextRepo.DeleteContext(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrContextNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *ExcavationContextService) CreateContextRelation(ctx context.Context, client any, input *entity.CreateContextRelationInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	relation := &entity.ContextRelation{
+		FromContextId: input.FromContextId,
+		ToContextId:   input.ToContextId,
+		Relation:      input.Relation,
+	}
+	if relation.Relation == entity.RelationBelow {
+		relation.FromContextId, relation.ToContextId = input.ToContextId, input.FromContextId
+		relation.Relation = entity.RelationAbove
+	}
+
+	from, err := s.GetContextById(ctx, client, relation.FromContextId)
+	if err != nil {
+		return 0, err
+	}
+	to, err := s.GetContextById(ctx, client, relation.ToContextId)
+	if err != nil {
+		return 0, err
+	}
+	if from.LocationId != to.LocationId {
+		return 0, ErrContextLocationMismatch
+	}
+
+	relations, err := s.contextRepo.GetLocationContextRelations(ctx, client, from.LocationId)
+	if err != nil {
+		return 0, err
+	}
+	if createsCycle(relations, relation.FromContextId, relation.ToContextId) {
+		return 0, ErrContextRelationCycle
+	}
+
+	id, err := s.contextRepo.CreateContextRelation(ctx, client, relation)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrContextRelationAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *ExcavationContextService) DeleteContextRelation(ctx context.Context, client any, id int) error {
+	err := s.contextRepo.DeleteContextRelation(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrContextRelationNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *ExcavationContextService) GetHarrisMatrix(ctx context.Context, client any, locationId int) (*entity.HarrisMatrix, error) {
+	contexts, err := s.contextRepo.GetLocationContexts(ctx, client, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	relations, err := s.contextRepo.GetLocationContextRelations(ctx, client, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.HarrisMatrix{
+		LocationId: locationId,
+		Contexts:   contexts,
+		Relations:  relations,
+	}, nil
+}
+
+func createsCycle(relations entity.ContextRelations, from int, to int) bool {
+	earlier := make(map[int][]int)
+	for _, r := range relations {
+		earlier[r.FromContextId] = append(earlier[r.FromContextId], r.ToContextId)
+	}
+
+	visited := make(map[int]bool)
+	stack := []int{to}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if id == from {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		stack = append(stack, earlier[id]...)
+	}
+
+	return false
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExcavationContextService_CreateContextRelation(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		input  *entity.CreateContextRelationInput
+	}
+
+	type MockBehavior func(m *mocks.MockExcavationContextRepo, args args)
+
+	contexts := map[int]*entity.ExcavationContext{
+		1: {Id: 1, TrenchId: 1, LocationId: 1, Code: "1001"},
+		2: {Id: 2, TrenchId: 1, LocationId: 1, Code: "1002"},
+		3: {Id: 3, TrenchId: 1, LocationId: 1, Code: "1003"},
+		4: {Id: 4, TrenchId: 2, LocationId: 2, Code: "2001"},
+	}
+	relations := entity.ContextRelations{
+		&entity.ContextRelation{Id: 1, FromContextId: 1, ToContextId: 2, Relation: entity.RelationAbove},
+		&entity.ContextRelation{Id: 2, FromContextId: 2, ToContextId: 3, Relation: entity.RelationCuts},
+	}
+	getContext := func(m *mocks.MockExcavationContextRepo, args args, ids ...int) {
+		for _, id := range ids {
+			m.EXPECT().GetContextById(args.ctx, args.client, id).Return(contexts[id], nil)
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input:  &entity.CreateContextRelationInput{FromContextId: 1, ToContextId: 3, Relation: entity.RelationCuts},
+			},
+			mockBehavior: func(m *mocks.MockExcavationContextRepo, args args) {
+				getContext(m, args, 1, 3)
+				m.EXPECT().GetLocationContextRelations(args.ctx, args.client, 1).Return(relations, nil)
+				m.EXPECT().CreateContextRelation(args.ctx, args.client, &entity.ContextRelation{
+					FromContextId: 1,
+					ToContextId:   3,
+					Relation:      entity.RelationCuts,
+				}).Return(3, nil)
+			},
+			want: 3,
+		},
+		{
+			name: "OK below is stored as reversed above",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input:  &entity.CreateContextRelationInput{FromContextId: 3, ToContextId: 1, Relation: entity.RelationBelow},
+			},
+			mockBehavior: func(m *mocks.MockExcavationContextRepo, args args) {
+				getContext(m, args, 1, 3)
+				m.EXPECT().GetLocationContextRelations(args.ctx, args.client, 1).Return(relations, nil)
+				m.EXPECT().CreateContextRelation(args.ctx, args.client, &entity.ContextRelation{
+					FromContextId: 1,
+					ToContextId:   3,
+					Relation:      entity.RelationAbove,
+				}).Return(3, nil)
+			},
+			want: 3,
+		},
+		{
+			name: "cycle error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input:  &entity.CreateContextRelationInput{FromContextId: 3, ToContextId: 1, Relation: entity.RelationAbove},
+			},
+			mockBehavior: func(m *mocks.MockExcavationContextRepo, args args) {
+				getContext(m, args, 3, 1)
+				m.EXPECT().GetLocationContextRelations(args.ctx, args.client, 1).Return(relations, nil)
+			},
+			wantErr: ErrContextRelationCycle,
+		},
+		{
+			name: "different locations error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input:  &entity.CreateContextRelationInput{FromContextId: 1, ToContextId: 4, Relation: entity.RelationAbove},
+			},
+			mockBehavior: func(m *mocks.MockExcavationContextRepo, args args) {
+				getContext(m, args, 1, 4)
+			},
+			wantErr: ErrContextLocationMismatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			tc.mockBehavior(contextRepo, tc.args)
+
+			// init service
+			s := NewExcavationContextService(contextRepo)
+
+			// run test
+			got, err := s.CreateContextRelation(tc.args.ctx, tc.args.client, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestExcavationContextService_GetHarrisMatrix(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		locationId int
+	}
+
+	type MockBehavior func(m *mocks.MockExcavationContextRepo, args args)
+
+	contexts := entity.ExcavationContexts{
+		&entity.ExcavationContext{Id: 1, TrenchId: 1, LocationId: 1, Code: "1001"},
+		&entity.ExcavationContext{Id: 2, TrenchId: 1, LocationId: 1, Code: "1002"},
+	}
+	relations := entity.ContextRelations{
+		&entity.ContextRelation{Id: 1, FromContextId: 1, ToContextId: 2, Relation: entity.RelationAbove},
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.HarrisMatrix
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				locationId: 1,
+			},
+			mockBehavior: func(m *mocks.MockExcavationContextRepo, args args) {
+				m.EXPECT().GetLocationContexts(args.ctx, args.client, args.locationId).Return(contexts, nil)
+				m.EXPECT().GetLocationContextRelations(args.ctx, args.client, args.locationId).Return(relations, nil)
+			},
+			want: &entity.HarrisMatrix{
+				LocationId: 1,
+				Contexts:   contexts,
+				Relations:  relations,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			tc.mockBehavior(contextRepo, tc.args)
+
+			// init service
+			s := NewExcavationContextService(contextRepo)
+
+			// run test
+			got, err := s.GetHarrisMatrix(tc.args.ctx, tc.args.client, tc.args.locationId)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
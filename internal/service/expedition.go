@@ -77,3 +77,29 @@ func (s *ExpeditionService) DeleteExpedition(ctx context.Context, client any, id
 
 	return nil
 }
+
+func (s *ExpeditionService) CloseExpedition(ctx context.Context, client any, id int) error {
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrExpeditionNotFound
+		}
+		return err
+	}
+	if expedition.ClosedOn != nil {
+		return ErrExpeditionClosed
+	}
+
+	err = s.expeditionRepo.CloseExpedition(ctx, client, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrConflict):
+			return ErrExpeditionNotReconciled
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return ErrExpeditionClosed
+		}
+		return err
+	}
+
+	return nil
+}
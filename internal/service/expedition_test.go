@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
 	"db_cp_6/internal/service/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -387,3 +388,103 @@ func TestExpeditionService_DeleteExpedition(t *testing.T) {
 		})
 	}
 }
+
+func TestExpeditionService_CloseExpedition(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		id     int
+	}
+
+	type MockBehavior func(m *mocks.MockExpeditionRepo, args args)
+
+	closedOn, _ := time.Parse("2006-01-02", "2024-08-02")
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(m *mocks.MockExpeditionRepo, args args) {
+				m.EXPECT().GetExpeditionById(args.ctx, args.client, args.id).
+					Return(&entity.Expedition{Id: 1}, nil)
+				m.EXPECT().CloseExpedition(args.ctx, args.client, args.id).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expedition not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     100,
+			},
+			mockBehavior: func(m *mocks.MockExpeditionRepo, args args) {
+				m.EXPECT().GetExpeditionById(args.ctx, args.client, args.id).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: ErrExpeditionNotFound,
+		},
+		{
+			name: "expedition already closed error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(m *mocks.MockExpeditionRepo, args args) {
+				m.EXPECT().GetExpeditionById(args.ctx, args.client, args.id).
+					Return(&entity.Expedition{Id: 1, ClosedOn: &closedOn}, nil)
+			},
+			wantErr: ErrExpeditionClosed,
+		},
+		{
+			name: "equipment not reconciled error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(m *mocks.MockExpeditionRepo, args args) {
+				m.EXPECT().GetExpeditionById(args.ctx, args.client, args.id).
+					Return(&entity.Expedition{Id: 1}, nil)
+				m.EXPECT().CloseExpedition(args.ctx, args.client, args.id).
+					Return(repoerrs.ErrConflict)
+			},
+			wantErr: ErrExpeditionNotReconciled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			tc.mockBehavior(expeditionRepo, tc.args)
+
+			// init service
+			s := NewExpeditionService(expeditionRepo)
+
+			// run test
+			err := s.CloseExpedition(tc.args.ctx, tc.args.client, tc.args.id)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type ExportService struct {
+	locationRepo repo.LocationRepo
+	artifactRepo repo.ArtifactRepo
+	periodRepo   repo.PeriodRepo
+}
+
+func NewExportService(locationRepo repo.LocationRepo, artifactRepo repo.ArtifactRepo, periodRepo repo.PeriodRepo) *ExportService {
+	return &ExportService{
+		locationRepo: locationRepo,
+		artifactRepo: artifactRepo,
+		periodRepo:   periodRepo,
+	}
+}
+
+func (s *ExportService) GetSiteSummaries(ctx context.Context, client any, filter *entity.ExportFilter) (entity.SiteSummaries, error) {
+	filter, err := s.resolveFilter(ctx, client, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sites, err := s.locationRepo.GetSiteSummaries(ctx, client, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := s.artifactRepo.GetAllArtifacts(ctx, client, filter.Artifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	siteByLocation := make(map[int]*entity.SiteSummary, len(sites))
+	for _, site := range sites {
+		site.Finds = make(entity.Artifacts, 0)
+		siteByLocation[site.Location.Id] = site
+	}
+	for _, artifact := range artifacts {
+		site, ok := siteByLocation[artifact.LocationId]
+		if ok && artifact.FindSpot.IsSet() {
+			site.Finds = append(site.Finds, artifact)
+		}
+	}
+
+	return sites, nil
+}
+
+func (s *ExportService) resolveFilter(ctx context.Context, client any, filter *entity.ExportFilter) (*entity.ExportFilter, error) {
+	if filter == nil {
+		return &entity.ExportFilter{}, nil
+	}
+
+	if filter.Locations != nil && filter.Locations.BoundingBox != nil {
+		if err := filter.Locations.BoundingBox.IsValid(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBoundingBox, err)
+		}
+	}
+
+	artifacts := filter.Artifacts
+	if artifacts != nil && artifacts.PeriodId != nil {
+		period, err := s.periodRepo.GetPeriodById(ctx, client, *artifacts.PeriodId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return nil, ErrPeriodNotFound
+			}
+			return nil, err
+		}
+
+		periodFilter := *artifacts
+		periodFilter.DatedFrom, periodFilter.DatedTo = &period.StartYear, &period.EndYear
+		artifacts = &periodFilter
+	}
+
+	if artifacts != nil && artifacts.DatedFrom != nil && artifacts.DatedTo != nil && *artifacts.DatedFrom > *artifacts.DatedTo {
+		return nil, ErrInvalidYearRange
+	}
+
+	return &entity.ExportFilter{Locations: filter.Locations, Artifacts: artifacts}, nil
+}
+
+func (s *ExportService) ExportGeoJSON(ctx context.Context, client any, filter *entity.ExportFilter) (*entity.GeoJSONFeatureCollection, error) {
+	sites, err := s.GetSiteSummaries(ctx, client, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &entity.GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]*entity.GeoJSONFeature, 0),
+	}
+	for _, site := range sites {
+		l := site.Location
+		locationFeatureId := fmt.Sprintf("location-%d", l.Id)
+		collection.Features = append(collection.Features, &entity.GeoJSONFeature{
+			Type:     "Feature",
+			Id:       locationFeatureId,
+			Geometry: geoJSONPoint(&l.Coordinates),
+			Properties: map[string]any{
+				"kind":             "location",
+				"location_id":      l.Id,
+				"name":             l.Name,
+				"country":          l.Country,
+				"country_name":     entity.CountryName(l.Country, entity.LanguageEn),
+				"nearest_town":     l.NearestTown,
+				"datum":            l.Datum,
+				"expedition_count": site.ExpeditionCount,
+				"artifact_count":   site.ArtifactCount,
+			},
+		})
+
+		for _, a := range site.Finds {
+			collection.Features = append(collection.Features, &entity.GeoJSONFeature{
+				Type:     "Feature",
+				Id:       fmt.Sprintf("artifact-%d", a.Id),
+				Geometry: geoJSONPoint(&a.FindSpot),
+				Properties: map[string]any{
+					"kind":          "find",
+					"parent":        locationFeatureId,
+					"artifact_id":   a.Id,
+					"location_id":   a.LocationId,
+					"expedition_id": a.ExpeditionId,
+					"name":          a.Name,
+					"found_on":      a.FoundOn,
+					"datum":         a.FindSpot.Datum,
+				},
+			})
+		}
+	}
+
+	return collection, nil
+}
+
+func (s *ExportService) ExportKML(ctx context.Context, client any, filter *entity.ExportFilter) (*entity.KML, error) {
+	sites, err := s.GetSiteSummaries(ctx, client, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &entity.KML{
+		Xmlns: "http://www.opengis.net/kml/2.2",
+		Document: entity.KMLDocument{
+			Name:    "Locations",
+			Folders: make([]entity.KMLFolder, 0, len(sites)),
+		},
+	}
+	for _, site := range sites {
+		l := site.Location
+		folder := entity.KMLFolder{
+			Name: l.Name,
+			Placemarks: []entity.KMLPlacemark{{
+				Id:    fmt.Sprintf("location-%d", l.Id),
+				Name:  l.Name,
+				Point: kmlPoint(&l.Coordinates),
+				ExtendedData: &entity.KMLExtendedData{Data: []entity.KMLData{
+					{Name: "country", Value: l.Country},
+					{Name: "country_name", Value: entity.CountryName(l.Country, entity.LanguageEn)},
+					{Name: "nearest_town", Value: l.NearestTown},
+					{Name: "expedition_count", Value: strconv.Itoa(site.ExpeditionCount)},
+					{Name: "artifact_count", Value: strconv.Itoa(site.ArtifactCount)},
+				}},
+			}},
+		}
+
+		for _, a := range site.Finds {
+			data := []entity.KMLData{{Name: "location_id", Value: strconv.Itoa(a.LocationId)}}
+			if a.FoundOn != nil {
+				data = append(data, entity.KMLData{Name: "found_on", Value: a.FoundOn.Format("2006-01-02")})
+			}
+			folder.Placemarks = append(folder.Placemarks, entity.KMLPlacemark{
+				Id:           fmt.Sprintf("artifact-%d", a.Id),
+				Name:         a.Name,
+				Point:        kmlPoint(&a.FindSpot),
+				ExtendedData: &entity.KMLExtendedData{Data: data},
+			})
+		}
+
+		doc.Document.Folders = append(doc.Document.Folders, folder)
+	}
+
+	return doc, nil
+}
+
+func geoJSONPoint(c *entity.Coordinates) *entity.GeoJSONGeometry {
+	if !c.IsSet() {
+		return nil
+	}
+
+	coords := []float64{*c.Longitude, *c.Latitude}
+	if c.Elevation != nil {
+		coords = append(coords, *c.Elevation)
+	}
+
+	return &entity.GeoJSONGeometry{
+		Type:        "Point",
+		Coordinates: coords,
+	}
+}
+
+func kmlPoint(c *entity.Coordinates) *entity.KMLPoint {
+	if !c.IsSet() {
+		return nil
+	}
+
+	coords := strconv.FormatFloat(*c.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(*c.Latitude, 'f', -1, 64)
+	if c.Elevation != nil {
+		coords += "," + strconv.FormatFloat(*c.Elevation, 'f', -1, 64)
+	}
+
+	return &entity.KMLPoint{Coordinates: coords}
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestExportService_ExportGeoJSON(t *testing.T) {
+	lat, lon := 55.75, 37.61
+	findLat, findLon := 55.76, 37.62
+	periodId, startYear, endYear := 3, -500, -300
+
+	type args struct {
+		ctx    context.Context
+		client any
+		filter *entity.ExportFilter
+	}
+
+	type MockBehavior func(l *mocks.MockLocationRepo, a *mocks.MockArtifactRepo, p *mocks.MockPeriodRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.GeoJSONFeatureCollection
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ExportFilter{Locations: &entity.LocationFilter{}, Artifacts: &entity.ArtifactFilter{}},
+			},
+			mockBehavior: func(l *mocks.MockLocationRepo, a *mocks.MockArtifactRepo, p *mocks.MockPeriodRepo, args args) {
+				l.EXPECT().GetSiteSummaries(args.ctx, args.client, args.filter).
+					Return(entity.SiteSummaries{
+						{
+							Location: &entity.Location{
+								Id:          1,
+								Name:        "aaa",
+								Country:     "KG",
+								NearestTown: "ccc",
+								Coordinates: entity.Coordinates{Latitude: &lat, Longitude: &lon, Datum: "WGS84"},
+							},
+							ExpeditionCount: 2,
+							ArtifactCount:   2,
+						},
+					}, nil)
+				a.EXPECT().GetAllArtifacts(args.ctx, args.client, args.filter.Artifacts).
+					Return(entity.Artifacts{
+						{
+							Id:         1,
+							LocationId: 1,
+							Name:       "ddd",
+							FindSpot:   entity.Coordinates{Latitude: &findLat, Longitude: &findLon, Datum: "WGS84"},
+						},
+						{
+							Id:         2,
+							LocationId: 1,
+							Name:       "eee",
+						},
+						{
+							Id:         3,
+							LocationId: 2,
+							Name:       "fff",
+							FindSpot:   entity.Coordinates{Latitude: &findLat, Longitude: &findLon, Datum: "WGS84"},
+						},
+					}, nil)
+			},
+			want: &entity.GeoJSONFeatureCollection{
+				Type: "FeatureCollection",
+				Features: []*entity.GeoJSONFeature{
+					{
+						Type:     "Feature",
+						Id:       "location-1",
+						Geometry: &entity.GeoJSONGeometry{Type: "Point", Coordinates: []float64{lon, lat}},
+						Properties: map[string]any{
+							"kind":             "location",
+							"location_id":      1,
+							"name":             "aaa",
+							"country":          "KG",
+							"country_name":     "Kyrgyzstan",
+							"nearest_town":     "ccc",
+							"datum":            "WGS84",
+							"expedition_count": 2,
+							"artifact_count":   2,
+						},
+					},
+					{
+						Type:     "Feature",
+						Id:       "artifact-1",
+						Geometry: &entity.GeoJSONGeometry{Type: "Point", Coordinates: []float64{findLon, findLat}},
+						Properties: map[string]any{
+							"kind":          "find",
+							"parent":        "location-1",
+							"artifact_id":   1,
+							"location_id":   1,
+							"expedition_id": (*int)(nil),
+							"name":          "ddd",
+							"found_on":      (*time.Time)(nil),
+							"datum":         "WGS84",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid bounding box error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ExportFilter{
+					Locations: &entity.LocationFilter{BoundingBox: &entity.BoundingBox{MinLatitude: 10, MaxLatitude: -10}},
+				},
+			},
+			mockBehavior: func(l *mocks.MockLocationRepo, a *mocks.MockArtifactRepo, p *mocks.MockPeriodRepo, args args) {
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "OK period resolved to years",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ExportFilter{Artifacts: &entity.ArtifactFilter{PeriodId: &periodId}},
+			},
+			mockBehavior: func(l *mocks.MockLocationRepo, a *mocks.MockArtifactRepo, p *mocks.MockPeriodRepo, args args) {
+				resolved := &entity.ExportFilter{Artifacts: &entity.ArtifactFilter{PeriodId: &periodId, DatedFrom: &startYear, DatedTo: &endYear}}
+				p.EXPECT().GetPeriodById(args.ctx, args.client, periodId).
+					Return(&entity.Period{Id: periodId, StartYear: startYear, EndYear: endYear}, nil)
+				l.EXPECT().GetSiteSummaries(args.ctx, args.client, resolved).
+					Return(entity.SiteSummaries{}, nil)
+				a.EXPECT().GetAllArtifacts(args.ctx, args.client, resolved.Artifacts).
+					Return(entity.Artifacts{}, nil)
+			},
+			want: &entity.GeoJSONFeatureCollection{
+				Type:     "FeatureCollection",
+				Features: []*entity.GeoJSONFeature{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "period not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ExportFilter{Artifacts: &entity.ArtifactFilter{PeriodId: &periodId}},
+			},
+			mockBehavior: func(l *mocks.MockLocationRepo, a *mocks.MockArtifactRepo, p *mocks.MockPeriodRepo, args args) {
+				p.EXPECT().GetPeriodById(args.ctx, args.client, periodId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid year range error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ExportFilter{Artifacts: &entity.ArtifactFilter{DatedFrom: &endYear, DatedTo: &startYear}},
+			},
+			mockBehavior: func(l *mocks.MockLocationRepo, a *mocks.MockArtifactRepo, p *mocks.MockPeriodRepo, args args) {},
+			want:         nil,
+			wantErr:      true,
+		},
+		{
+			name: "get artifacts error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.ExportFilter{},
+			},
+			mockBehavior: func(l *mocks.MockLocationRepo, a *mocks.MockArtifactRepo, p *mocks.MockPeriodRepo, args args) {
+				l.EXPECT().GetSiteSummaries(args.ctx, args.client, args.filter).
+					Return(entity.SiteSummaries{{Location: &entity.Location{Id: 1}}}, nil)
+				a.EXPECT().GetAllArtifacts(args.ctx, args.client, args.filter.Artifacts).
+					Return(nil, ErrArtifactNotFound)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			locationRepo := mocks.NewMockLocationRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			tc.mockBehavior(locationRepo, artifactRepo, periodRepo, tc.args)
+
+			// init service
+			s := NewExportService(locationRepo, artifactRepo, periodRepo)
+
+			// run test
+			got, err := s.ExportGeoJSON(tc.args.ctx, tc.args.client, tc.args.filter)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestExportService_ExportKML(t *testing.T) {
+	lat, lon, elevation := 55.75, 37.61, 120.5
+
+	// init deps
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// init mocks
+	locationRepo := mocks.NewMockLocationRepo(ctrl)
+	artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+	periodRepo := mocks.NewMockPeriodRepo(ctrl)
+
+	locationRepo.EXPECT().GetSiteSummaries(gomock.Any(), nil, &entity.ExportFilter{}).
+		Return(entity.SiteSummaries{
+			{Location: &entity.Location{Id: 1, Name: "aaa", Country: "bbb", NearestTown: "ccc"}, ExpeditionCount: 1, ArtifactCount: 1},
+		}, nil)
+	artifactRepo.EXPECT().GetAllArtifacts(gomock.Any(), nil, nil).
+		Return(entity.Artifacts{
+			{
+				Id:         1,
+				LocationId: 1,
+				Name:       "ddd",
+				FindSpot:   entity.Coordinates{Latitude: &lat, Longitude: &lon, Elevation: &elevation, Datum: "WGS84"},
+			},
+		}, nil)
+
+	// init service
+	s := NewExportService(locationRepo, artifactRepo, periodRepo)
+
+	// run test
+	got, err := s.ExportKML(context.Background(), nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, got.Document.Folders, 1)
+
+	placemarks := got.Document.Folders[0].Placemarks
+	assert.Len(t, placemarks, 2)
+	assert.Nil(t, placemarks[0].Point)
+	assert.Equal(t, "artifact-1", placemarks[1].Id)
+	assert.Equal(t, "37.61,55.75,120.5", placemarks[1].Point.Coordinates)
+}
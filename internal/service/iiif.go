@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type IIIFService struct {
+	artifactRepo   repo.ArtifactRepo
+	locationRepo   repo.LocationRepo
+	curatorRepo    repo.CuratorRepo
+	periodRepo     repo.PeriodRepo
+	attachmentRepo repo.AttachmentRepo
+	attachments    Attachment
+}
+
+func NewIIIFService(artifactRepo repo.ArtifactRepo, locationRepo repo.LocationRepo, curatorRepo repo.CuratorRepo,
+	periodRepo repo.PeriodRepo, attachmentRepo repo.AttachmentRepo, attachments Attachment) *IIIFService {
+	return &IIIFService{
+		artifactRepo:   artifactRepo,
+		locationRepo:   locationRepo,
+		curatorRepo:    curatorRepo,
+		periodRepo:     periodRepo,
+		attachmentRepo: attachmentRepo,
+		attachments:    attachments,
+	}
+}
+
+func (s *IIIFService) GetArtifactManifest(ctx context.Context, client any, links *entity.Links, artifactId int) (*entity.IIIFManifest, error) {
+	artifact, err := s.artifactRepo.GetArtifactById(ctx, client, artifactId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrArtifactNotFound
+		}
+		return nil, err
+	}
+
+	images, err := s.getArtifactImages(ctx, client, artifactId)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, ErrArtifactHasNoImages
+	}
+
+	location, err := s.locationRepo.GetLocationById(ctx, client, artifact.LocationId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, err
+	}
+
+	metadata, err := s.artifactMetadata(ctx, client, artifact, location)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestId := links.URL(fmt.Sprintf("/iiif/artifacts/%d/manifest", artifact.Id))
+	manifest := &entity.IIIFManifest{
+		Context:  entity.IIIFPresentationContext,
+		Id:       manifestId,
+		Type:     "Manifest",
+		Label:    entity.IIIFText(artifact.Name),
+		Metadata: metadata,
+		PartOf: []*entity.IIIFReference{{
+			Id:    links.URL(fmt.Sprintf("/iiif/locations/%d/collection", location.Id)),
+			Type:  "Collection",
+			Label: entity.IIIFText(location.Name),
+		}},
+		Items: make([]*entity.IIIFCanvas, 0, len(images)),
+	}
+	if artifact.FindContext != "" {
+		manifest.Summary = entity.IIIFText(artifact.FindContext)
+	}
+	if artifact.FoundOn != nil {
+		manifest.NavDate = artifact.FoundOn.UTC().Format("2006-01-02T15:04:05Z")
+	}
+
+	for _, image := range images {
+		manifest.Items = append(manifest.Items, iiifCanvas(links, artifact.Id, image))
+	}
+	manifest.Thumbnail = iiifThumbnail(links, images[0])
+
+	return manifest, nil
+}
+
+func (s *IIIFService) GetArtifactCanvas(ctx context.Context, client any, links *entity.Links, artifactId int, imageId int) (*entity.IIIFCanvas, error) {
+	image, err := s.getArtifactImage(ctx, client, artifactId, imageId)
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := iiifCanvas(links, artifactId, image)
+	canvas.Context = entity.IIIFPresentationContext
+
+	return canvas, nil
+}
+
+func (s *IIIFService) GetArtifactAnnotationPage(ctx context.Context, client any, links *entity.Links, artifactId int, imageId int) (*entity.IIIFAnnotationPage, error) {
+	image, err := s.getArtifactImage(ctx, client, artifactId, imageId)
+	if err != nil {
+		return nil, err
+	}
+
+	page := iiifCanvas(links, artifactId, image).Items[0]
+	page.Context = entity.IIIFPresentationContext
+
+	return page, nil
+}
+
+func (s *IIIFService) GetArtifactAnnotation(ctx context.Context, client any, links *entity.Links, artifactId int, imageId int) (*entity.IIIFAnnotation, error) {
+	image, err := s.getArtifactImage(ctx, client, artifactId, imageId)
+	if err != nil {
+		return nil, err
+	}
+
+	annotation := iiifCanvas(links, artifactId, image).Items[0].Items[0]
+	annotation.Context = entity.IIIFPresentationContext
+
+	return annotation, nil
+}
+
+func (s *IIIFService) GetImageContent(ctx context.Context, client any, imageId int) (*entity.AttachmentContent, error) {
+	content, err := s.attachments.GetAttachmentContent(ctx, client, imageId)
+	if err != nil {
+		return nil, err
+	}
+	if !isIIIFImage(content.Attachment) {
+		content.Content.Close()
+		return nil, ErrAttachmentNotFound
+	}
+
+	return content, nil
+}
+
+func (s *IIIFService) GetImageThumbnail(ctx context.Context, client any, imageId int) (*entity.ThumbnailContent, error) {
+	image, err := s.attachments.GetAttachment(ctx, client, imageId)
+	if err != nil {
+		return nil, err
+	}
+	if !isIIIFImage(image) {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return s.attachments.GetAttachmentThumbnail(ctx, client, imageId, entity.ThumbnailSizes[0].Name)
+}
+
+func (s *IIIFService) GetLocationCollection(ctx context.Context, client any, links *entity.Links, locationId int) (*entity.IIIFCollection, error) {
+	location, err := s.locationRepo.GetLocationById(ctx, client, locationId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, err
+	}
+
+	artifacts, err := s.artifactRepo.GetLocationArtifacts(ctx, client, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &entity.IIIFCollection{
+		Context:  entity.IIIFPresentationContext,
+		Id:       links.URL(fmt.Sprintf("/iiif/locations/%d/collection", location.Id)),
+		Type:     "Collection",
+		Label:    entity.IIIFText(location.Name),
+		Metadata: locationMetadata(location),
+		Items:    make([]*entity.IIIFReference, 0),
+	}
+	for _, artifact := range artifacts {
+		images, err := s.getArtifactImages(ctx, client, artifact.Id)
+		if err != nil {
+			return nil, err
+		}
+		if len(images) == 0 {
+			continue
+		}
+
+		collection.Items = append(collection.Items, &entity.IIIFReference{
+			Id:        links.URL(fmt.Sprintf("/iiif/artifacts/%d/manifest", artifact.Id)),
+			Type:      "Manifest",
+			Label:     entity.IIIFText(artifact.Name),
+			Thumbnail: iiifThumbnail(links, images[0]),
+		})
+	}
+
+	return collection, nil
+}
+
+func (s *IIIFService) getArtifactImages(ctx context.Context, client any, artifactId int) (entity.Attachments, error) {
+	attachments, err := s.attachmentRepo.GetParentAttachments(ctx, client, entity.AttachmentParentArtifact, artifactId)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(entity.Attachments, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment.Metadata != nil {
+			images = append(images, attachment)
+		}
+	}
+
+	return images, nil
+}
+
+func (s *IIIFService) getArtifactImage(ctx context.Context, client any, artifactId int, imageId int) (*entity.Attachment, error) {
+	_, err := s.artifactRepo.GetArtifactById(ctx, client, artifactId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrArtifactNotFound
+		}
+		return nil, err
+	}
+
+	images, err := s.getArtifactImages(ctx, client, artifactId)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		if image.Id == imageId {
+			return image, nil
+		}
+	}
+
+	return nil, ErrAttachmentNotFound
+}
+
+func (s *IIIFService) artifactMetadata(ctx context.Context, client any, artifact *entity.Artifact, location *entity.Location) ([]*entity.IIIFMetadataEntry, error) {
+	metadata := []*entity.IIIFMetadataEntry{iiifMetadata("Name", artifact.Name)}
+	if artifact.CatalogNumber != "" {
+		metadata = append(metadata, iiifMetadata("Catalog number", artifact.CatalogNumber))
+	}
+
+	dating := artifact.Dating.Normalized()
+	if dating.IsSet() {
+		metadata = append(metadata, iiifMetadata("Dating", formatDating(&dating)))
+	}
+	if dating.PeriodId != nil {
+		period, err := s.periodRepo.GetPeriodById(ctx, client, *dating.PeriodId)
+		if err != nil {
+			return nil, err
+		}
+		metadata = append(metadata, iiifMetadata("Period", period.Name))
+	}
+	if dating.Method != "" {
+		metadata = append(metadata, iiifMetadata("Dating method", dating.Method))
+	}
+
+	metadata = append(metadata, locationMetadata(location)...)
+	if artifact.FoundOn != nil {
+		metadata = append(metadata, iiifMetadata("Found on", artifact.FoundOn.Format("2006-01-02")))
+	}
+
+	if artifact.ResponsibleCuratorId != nil {
+		curator, err := s.curatorRepo.GetCuratorById(ctx, client, *artifact.ResponsibleCuratorId)
+		if err != nil {
+			return nil, err
+		}
+		metadata = append(metadata, iiifMetadata("Curator", curator.Name))
+	}
+
+	return metadata, nil
+}
+
+func isIIIFImage(attachment *entity.Attachment) bool {
+	return attachment.ParentType == entity.AttachmentParentArtifact && attachment.Metadata != nil
+}
+
+func locationMetadata(location *entity.Location) []*entity.IIIFMetadataEntry {
+	metadata := []*entity.IIIFMetadataEntry{iiifMetadata("Location", location.Name)}
+	if location.Country != "" {
+		country := entity.CountryName(location.Country, entity.LanguageEn)
+		if country == "" {
+			country = location.Country
+		}
+		metadata = append(metadata, iiifMetadata("Country", country))
+	}
+	if location.NearestTown != "" {
+		metadata = append(metadata, iiifMetadata("Nearest town", location.NearestTown))
+	}
+
+	return metadata
+}
+
+func iiifMetadata(label string, value string) *entity.IIIFMetadataEntry {
+	return &entity.IIIFMetadataEntry{Label: entity.IIIFText(label), Value: entity.IIIFText(value)}
+}
+
+func iiifCanvas(links *entity.Links, artifactId int, image *entity.Attachment) *entity.IIIFCanvas {
+	canvasId := links.URL(fmt.Sprintf("/iiif/artifacts/%d/canvas/%d", artifactId, image.Id))
+
+	return &entity.IIIFCanvas{
+		Id:        canvasId,
+		Type:      "Canvas",
+		Label:     entity.IIIFText(image.FileName),
+		Width:     image.Metadata.Width,
+		Height:    image.Metadata.Height,
+		Thumbnail: iiifThumbnail(links, image),
+		Items: []*entity.IIIFAnnotationPage{{
+			Id:   canvasId + "/page",
+			Type: "AnnotationPage",
+			Items: []*entity.IIIFAnnotation{{
+				Id:         canvasId + "/annotation",
+				Type:       "Annotation",
+				Motivation: "painting",
+				Body: &entity.IIIFResource{
+					Id:     links.URL(fmt.Sprintf("/iiif/images/%d", image.Id)),
+					Type:   "Image",
+					Format: image.ContentType,
+					Width:  image.Metadata.Width,
+					Height: image.Metadata.Height,
+				},
+				Target: canvasId,
+			}},
+		}},
+	}
+}
+
+func iiifThumbnail(links *entity.Links, image *entity.Attachment) []*entity.IIIFResource {
+	if image.ProcessingStatus != entity.AttachmentProcessingDone {
+		return nil
+	}
+
+	return []*entity.IIIFResource{{
+		Id:     links.URL(fmt.Sprintf("/iiif/images/%d/thumbnail", image.Id)),
+		Type:   "Image",
+		Format: "image/jpeg",
+	}}
+}
+
+func formatDating(dating *entity.Dating) string {
+	switch {
+	case dating.CentralYear != nil && dating.ErrorYears != nil && *dating.ErrorYears > 0:
+		return fmt.Sprintf("%s ± %d", formatYear(*dating.CentralYear), *dating.ErrorYears)
+	case dating.CentralYear != nil:
+		return formatYear(*dating.CentralYear)
+	case dating.EarliestYear != nil && dating.LatestYear != nil && *dating.EarliestYear == *dating.LatestYear:
+		return formatYear(*dating.EarliestYear)
+	case dating.EarliestYear != nil && dating.LatestYear != nil:
+		return formatYear(*dating.EarliestYear) + " – " + formatYear(*dating.LatestYear)
+	case dating.EarliestYear != nil:
+		return "after " + formatYear(*dating.EarliestYear)
+	default:
+		return "before " + formatYear(*dating.LatestYear)
+	}
+}
+
+func formatYear(year int) string {
+	if year < 0 {
+		return strconv.Itoa(-year) + " BCE"
+	}
+
+	return strconv.Itoa(year) + " CE"
+}
@@ -0,0 +1,466 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"db_cp_6/pkg/blobstore"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+type iiifMocks struct {
+	artifactRepo   *mocks.MockArtifactRepo
+	locationRepo   *mocks.MockLocationRepo
+	curatorRepo    *mocks.MockCuratorRepo
+	periodRepo     *mocks.MockPeriodRepo
+	attachmentRepo *mocks.MockAttachmentRepo
+}
+
+func TestIIIFService_GetArtifactManifest(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		artifactId int
+	}
+
+	type MockBehavior func(m *iiifMocks, args args)
+
+	links := &entity.Links{BaseURL: "https://finds.example/api/v1/"}
+	central, errorYears, periodId, curatorId := -350, 25, 3, 4
+	foundOn := time.Date(2023, 8, 2, 0, 0, 0, 0, time.UTC)
+	artifact := &entity.Artifact{
+		Id:                   1,
+		LocationId:           2,
+		FoundOn:              &foundOn,
+		FindContext:          "layer 4, north baulk",
+		Name:                 "Red-figure kylix",
+		CatalogNumber:        "2-2023-0001",
+		Dating:               entity.Dating{CentralYear: &central, ErrorYears: &errorYears, Method: entity.DatingTypological, PeriodId: &periodId},
+		ResponsibleCuratorId: &curatorId,
+	}
+	attachments := entity.Attachments{
+		{
+			Id:               10,
+			FileName:         "side.jpg",
+			ContentType:      "image/jpeg",
+			ProcessingStatus: entity.AttachmentProcessingDone,
+			Metadata:         &entity.PhotoMetadata{Width: 4000, Height: 3000},
+		},
+		{
+			Id:               11,
+			FileName:         "notes.pdf",
+			ContentType:      "application/pdf",
+			ProcessingStatus: entity.AttachmentProcessingNone,
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.IIIFManifest
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+			},
+			mockBehavior: func(m *iiifMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).Return(artifact, nil)
+				m.attachmentRepo.EXPECT().GetParentAttachments(args.ctx, args.client, entity.AttachmentParentArtifact, 1).
+					Return(attachments, nil)
+				m.locationRepo.EXPECT().GetLocationById(args.ctx, args.client, 2).
+					Return(&entity.Location{Id: 2, Name: "Olynthos", Country: "GR"}, nil)
+				m.periodRepo.EXPECT().GetPeriodById(args.ctx, args.client, 3).
+					Return(&entity.Period{Id: 3, Name: "Classical"}, nil)
+				m.curatorRepo.EXPECT().GetCuratorById(args.ctx, args.client, 4).
+					Return(&entity.Curator{Id: 4, Name: "Irene Papadaki"}, nil)
+			},
+			want: &entity.IIIFManifest{
+				Context: entity.IIIFPresentationContext,
+				Id:      "https://finds.example/api/v1/iiif/artifacts/1/manifest",
+				Type:    "Manifest",
+				Label:   entity.IIIFText("Red-figure kylix"),
+				Summary: entity.IIIFText("layer 4, north baulk"),
+				Metadata: []*entity.IIIFMetadataEntry{
+					{Label: entity.IIIFText("Name"), Value: entity.IIIFText("Red-figure kylix")},
+					{Label: entity.IIIFText("Catalog number"), Value: entity.IIIFText("2-2023-0001")},
+					{Label: entity.IIIFText("Dating"), Value: entity.IIIFText("350 BCE ± 25")},
+					{Label: entity.IIIFText("Period"), Value: entity.IIIFText("Classical")},
+					{Label: entity.IIIFText("Dating method"), Value: entity.IIIFText("typological")},
+					{Label: entity.IIIFText("Location"), Value: entity.IIIFText("Olynthos")},
+					{Label: entity.IIIFText("Country"), Value: entity.IIIFText("Greece")},
+					{Label: entity.IIIFText("Found on"), Value: entity.IIIFText("2023-08-02")},
+					{Label: entity.IIIFText("Curator"), Value: entity.IIIFText("Irene Papadaki")},
+				},
+				NavDate: "2023-08-02T00:00:00Z",
+				Thumbnail: []*entity.IIIFResource{{
+					Id:     "https://finds.example/api/v1/iiif/images/10/thumbnail",
+					Type:   "Image",
+					Format: "image/jpeg",
+				}},
+				PartOf: []*entity.IIIFReference{{
+					Id:    "https://finds.example/api/v1/iiif/locations/2/collection",
+					Type:  "Collection",
+					Label: entity.IIIFText("Olynthos"),
+				}},
+				Items: []*entity.IIIFCanvas{{
+					Id:     "https://finds.example/api/v1/iiif/artifacts/1/canvas/10",
+					Type:   "Canvas",
+					Label:  entity.IIIFText("side.jpg"),
+					Width:  4000,
+					Height: 3000,
+					Thumbnail: []*entity.IIIFResource{{
+						Id:     "https://finds.example/api/v1/iiif/images/10/thumbnail",
+						Type:   "Image",
+						Format: "image/jpeg",
+					}},
+					Items: []*entity.IIIFAnnotationPage{{
+						Id:   "https://finds.example/api/v1/iiif/artifacts/1/canvas/10/page",
+						Type: "AnnotationPage",
+						Items: []*entity.IIIFAnnotation{{
+							Id:         "https://finds.example/api/v1/iiif/artifacts/1/canvas/10/annotation",
+							Type:       "Annotation",
+							Motivation: "painting",
+							Body: &entity.IIIFResource{
+								Id:     "https://finds.example/api/v1/iiif/images/10",
+								Type:   "Image",
+								Format: "image/jpeg",
+								Width:  4000,
+								Height: 3000,
+							},
+							Target: "https://finds.example/api/v1/iiif/artifacts/1/canvas/10",
+						}},
+					}},
+				}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "no images error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+			},
+			mockBehavior: func(m *iiifMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).Return(artifact, nil)
+				m.attachmentRepo.EXPECT().GetParentAttachments(args.ctx, args.client, entity.AttachmentParentArtifact, 1).
+					Return(attachments[1:], nil)
+			},
+			want:    nil,
+			wantErr: ErrArtifactHasNoImages,
+		},
+		{
+			name: "artifact not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 100,
+			},
+			mockBehavior: func(m *iiifMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 100).Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrArtifactNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			m := &iiifMocks{
+				artifactRepo:   mocks.NewMockArtifactRepo(ctrl),
+				locationRepo:   mocks.NewMockLocationRepo(ctrl),
+				curatorRepo:    mocks.NewMockCuratorRepo(ctrl),
+				periodRepo:     mocks.NewMockPeriodRepo(ctrl),
+				attachmentRepo: mocks.NewMockAttachmentRepo(ctrl),
+			}
+			tc.mockBehavior(m, tc.args)
+
+			// init service
+			s := NewIIIFService(m.artifactRepo, m.locationRepo, m.curatorRepo, m.periodRepo, m.attachmentRepo, nil)
+
+			// run test
+			got, err := s.GetArtifactManifest(tc.args.ctx, tc.args.client, links, tc.args.artifactId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIIIFService_GetLocationCollection(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		locationId int
+	}
+
+	type MockBehavior func(m *iiifMocks, args args)
+
+	links := &entity.Links{BaseURL: "http://localhost:8080/api/v1"}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.IIIFCollection
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				locationId: 2,
+			},
+			mockBehavior: func(m *iiifMocks, args args) {
+				m.locationRepo.EXPECT().GetLocationById(args.ctx, args.client, 2).
+					Return(&entity.Location{Id: 2, Name: "Olynthos", NearestTown: "Nea Olynthos"}, nil)
+				m.artifactRepo.EXPECT().GetLocationArtifacts(args.ctx, args.client, 2).
+					Return(entity.Artifacts{{Id: 1, Name: "Kylix"}, {Id: 5, Name: "Loom weight"}}, nil)
+				m.attachmentRepo.EXPECT().GetParentAttachments(args.ctx, args.client, entity.AttachmentParentArtifact, 1).
+					Return(entity.Attachments{{Id: 10, ProcessingStatus: entity.AttachmentProcessingDone, Metadata: &entity.PhotoMetadata{Width: 10, Height: 10}}}, nil)
+				m.attachmentRepo.EXPECT().GetParentAttachments(args.ctx, args.client, entity.AttachmentParentArtifact, 5).
+					Return(entity.Attachments{}, nil)
+			},
+			want: &entity.IIIFCollection{
+				Context: entity.IIIFPresentationContext,
+				Id:      "http://localhost:8080/api/v1/iiif/locations/2/collection",
+				Type:    "Collection",
+				Label:   entity.IIIFText("Olynthos"),
+				Metadata: []*entity.IIIFMetadataEntry{
+					{Label: entity.IIIFText("Location"), Value: entity.IIIFText("Olynthos")},
+					{Label: entity.IIIFText("Nearest town"), Value: entity.IIIFText("Nea Olynthos")},
+				},
+				Items: []*entity.IIIFReference{{
+					Id:    "http://localhost:8080/api/v1/iiif/artifacts/1/manifest",
+					Type:  "Manifest",
+					Label: entity.IIIFText("Kylix"),
+					Thumbnail: []*entity.IIIFResource{{
+						Id:     "http://localhost:8080/api/v1/iiif/images/10/thumbnail",
+						Type:   "Image",
+						Format: "image/jpeg",
+					}},
+				}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "location not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				locationId: 100,
+			},
+			mockBehavior: func(m *iiifMocks, args args) {
+				m.locationRepo.EXPECT().GetLocationById(args.ctx, args.client, 100).Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrLocationNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			m := &iiifMocks{
+				artifactRepo:   mocks.NewMockArtifactRepo(ctrl),
+				locationRepo:   mocks.NewMockLocationRepo(ctrl),
+				curatorRepo:    mocks.NewMockCuratorRepo(ctrl),
+				periodRepo:     mocks.NewMockPeriodRepo(ctrl),
+				attachmentRepo: mocks.NewMockAttachmentRepo(ctrl),
+			}
+			tc.mockBehavior(m, tc.args)
+
+			// init service
+			s := NewIIIFService(m.artifactRepo, m.locationRepo, m.curatorRepo, m.periodRepo, m.attachmentRepo, nil)
+
+			// run test
+			got, err := s.GetLocationCollection(tc.args.ctx, tc.args.client, links, tc.args.locationId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIIIFService_GetArtifactCanvas(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		artifactId int
+		imageId    int
+	}
+
+	type MockBehavior func(m *iiifMocks, args args)
+
+	links := &entity.Links{BaseURL: "http://localhost:8080/api/v1"}
+	images := entity.Attachments{{Id: 10, FileName: "top.png", ContentType: "image/png", Metadata: &entity.PhotoMetadata{Width: 20, Height: 10}}}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.IIIFCanvas
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+				imageId:    10,
+			},
+			mockBehavior: func(m *iiifMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).Return(&entity.Artifact{Id: 1}, nil)
+				m.attachmentRepo.EXPECT().GetParentAttachments(args.ctx, args.client, entity.AttachmentParentArtifact, 1).
+					Return(images, nil)
+			},
+			want: &entity.IIIFCanvas{
+				Context: entity.IIIFPresentationContext,
+				Id:      "http://localhost:8080/api/v1/iiif/artifacts/1/canvas/10",
+				Type:    "Canvas",
+				Label:   entity.IIIFText("top.png"),
+				Width:   20,
+				Height:  10,
+				Items: []*entity.IIIFAnnotationPage{{
+					Id:   "http://localhost:8080/api/v1/iiif/artifacts/1/canvas/10/page",
+					Type: "AnnotationPage",
+					Items: []*entity.IIIFAnnotation{{
+						Id:         "http://localhost:8080/api/v1/iiif/artifacts/1/canvas/10/annotation",
+						Type:       "Annotation",
+						Motivation: "painting",
+						Body: &entity.IIIFResource{
+							Id:     "http://localhost:8080/api/v1/iiif/images/10",
+							Type:   "Image",
+							Format: "image/png",
+							Width:  20,
+							Height: 10,
+						},
+						Target: "http://localhost:8080/api/v1/iiif/artifacts/1/canvas/10",
+					}},
+				}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "image of other artifact error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+				imageId:    11,
+			},
+			mockBehavior: func(m *iiifMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).Return(&entity.Artifact{Id: 1}, nil)
+				m.attachmentRepo.EXPECT().GetParentAttachments(args.ctx, args.client, entity.AttachmentParentArtifact, 1).
+					Return(images, nil)
+			},
+			want:    nil,
+			wantErr: ErrAttachmentNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			m := &iiifMocks{
+				artifactRepo:   mocks.NewMockArtifactRepo(ctrl),
+				locationRepo:   mocks.NewMockLocationRepo(ctrl),
+				curatorRepo:    mocks.NewMockCuratorRepo(ctrl),
+				periodRepo:     mocks.NewMockPeriodRepo(ctrl),
+				attachmentRepo: mocks.NewMockAttachmentRepo(ctrl),
+			}
+			tc.mockBehavior(m, tc.args)
+
+			// init service
+			s := NewIIIFService(m.artifactRepo, m.locationRepo, m.curatorRepo, m.periodRepo, m.attachmentRepo, nil)
+
+			// run test
+			got, err := s.GetArtifactCanvas(tc.args.ctx, tc.args.client, links, tc.args.artifactId, tc.args.imageId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIIIFService_GetImageContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	attachmentRepo := mocks.NewMockAttachmentRepo(ctrl)
+	artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+	journalRepo := mocks.NewMockJournalRepo(ctrl)
+	store, err := blobstore.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	hash := putTestBlob(t, store, testPNG)
+
+	attachments := NewAttachmentService(attachmentRepo, artifactRepo, nil, nil, journalRepo, nil, AttachmentConfig{Store: store})
+	s := NewIIIFService(nil, nil, nil, nil, attachmentRepo, attachments)
+
+	attachmentRepo.EXPECT().GetAttachmentById(ctx, nil, 10).
+		Return(&entity.Attachment{Id: 10, ParentType: entity.AttachmentParentArtifact, ParentId: 1, Sha256: hash, Metadata: &entity.PhotoMetadata{Width: 1, Height: 1}}, nil)
+	artifactRepo.EXPECT().GetArtifactById(ctx, nil, 1).Return(&entity.Artifact{Id: 1}, nil)
+	content, err := s.GetImageContent(ctx, nil, 10)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(content.Content)
+	content.Content.Close()
+	assert.Equal(t, testPNG, data)
+
+	attachmentRepo.EXPECT().GetAttachmentById(ctx, nil, 11).
+		Return(&entity.Attachment{Id: 11, ParentType: entity.AttachmentParentJournalEntry, ParentId: 3, Sha256: hash, Metadata: &entity.PhotoMetadata{Width: 1, Height: 1}}, nil)
+	journalRepo.EXPECT().GetJournalEntryById(ctx, nil, 3).Return(&entity.JournalEntry{Id: 3}, nil)
+	_, err = s.GetImageContent(ctx, nil, 11)
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+
+	attachmentRepo.EXPECT().GetAttachmentById(ctx, nil, 12).
+		Return(&entity.Attachment{Id: 12, ParentType: entity.AttachmentParentArtifact, ParentId: 1, Sha256: hash}, nil)
+	artifactRepo.EXPECT().GetArtifactById(ctx, nil, 1).Return(&entity.Artifact{Id: 1}, nil)
+	_, err = s.GetImageContent(ctx, nil, 12)
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+
+	attachmentRepo.EXPECT().GetAttachmentById(ctx, nil, 13).
+		Return(&entity.Attachment{Id: 13, ParentType: entity.AttachmentParentArtifact, ParentId: 2, Sha256: hash, Metadata: &entity.PhotoMetadata{Width: 1, Height: 1}}, nil)
+	artifactRepo.EXPECT().GetArtifactById(ctx, nil, 2).Return(nil, repoerrs.ErrNotFound)
+	_, err = s.GetImageContent(ctx, nil, 13)
+	assert.ErrorIs(t, err, ErrAttachmentParentNotFound)
+}
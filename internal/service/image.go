@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/pkg/blobstore"
+	"db_cp_6/pkg/imaging"
+	"db_cp_6/pkg/logger"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultImageWorkers       = 2
+	DefaultImageQueueSize     = 64
+	DefaultImageSweepInterval = time.Minute
+)
+
+var processableImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type ImageConfig struct {
+	Workers       int
+	QueueSize     int
+	SweepInterval time.Duration
+	Log           *logger.Logger
+}
+
+type ImageProcessor struct {
+	attachmentRepo repo.AttachmentRepo
+	store          blobstore.Store
+	client         any
+	workers        int
+	sweepInterval  time.Duration
+	log            *logger.Logger
+	queue          chan int
+	mx             sync.Mutex
+	queued         map[int]bool
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+func NewImageProcessor(attachmentRepo repo.AttachmentRepo, store blobstore.Store, client any, cfg ImageConfig) *ImageProcessor {
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultImageWorkers
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultImageQueueSize
+	}
+	if cfg.SweepInterval <= 0 {
+		cfg.SweepInterval = DefaultImageSweepInterval
+	}
+	if cfg.Log == nil {
+		cfg.Log = logger.GetLogger()
+	}
+
+	return &ImageProcessor{
+		attachmentRepo: attachmentRepo,
+		store:          store,
+		client:         client,
+		workers:        cfg.Workers,
+		sweepInterval:  cfg.SweepInterval,
+		log:            cfg.Log,
+		queue:          make(chan int, cfg.QueueSize),
+		queued:         make(map[int]bool),
+	}
+}
+
+func (p *ImageProcessor) Enqueue(id int) bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.queued[id] {
+		return true
+	}
+
+	select {
+	case p.queue <- id:
+		p.queued[id] = true
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *ImageProcessor) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.work(ctx)
+	}
+
+	p.wg.Add(1)
+	go p.sweep(ctx)
+}
+
+func (p *ImageProcessor) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
+
+func (p *ImageProcessor) Process(ctx context.Context, id int) error {
+	err := p.attachmentRepo.StartAttachmentProcessing(ctx, p.client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrConflict) {
+			return nil
+		}
+		return err
+	}
+
+	err = p.process(ctx, id)
+	if err != nil {
+		if failErr := p.attachmentRepo.FailAttachmentProcessing(ctx, p.client, id, err.Error()); failErr != nil {
+			return failErr
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (p *ImageProcessor) process(ctx context.Context, id int) error {
+	attachment, err := p.attachmentRepo.GetAttachmentById(ctx, p.client, id)
+	if err != nil {
+		return err
+	}
+
+	content, err := p.store.Get(ctx, attachment.Sha256)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(content)
+	content.Close()
+	if err != nil {
+		return err
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return err
+	}
+
+	metadata := &entity.PhotoMetadata{
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}
+	orientation := 1
+	if exif, err := imaging.ReadExif(data); err == nil {
+		metadata.CapturedAt = exif.CapturedAt
+		metadata.CameraMake = exif.CameraMake
+		metadata.CameraModel = exif.CameraModel
+		metadata.Gps = entity.Coordinates{Latitude: exif.Latitude, Longitude: exif.Longitude, Elevation: exif.Altitude}
+		if !metadata.Gps.IsSet() {
+			metadata.Gps.Elevation = nil
+		}
+		orientation = exif.Orientation
+		if orientation >= 5 {
+			metadata.Width, metadata.Height = metadata.Height, metadata.Width
+		}
+	}
+
+	flat := imaging.Flatten(img)
+	thumbnails := make(entity.Thumbnails, 0, len(entity.ThumbnailSizes))
+	encodedThumbnails := make(map[string][]byte, len(entity.ThumbnailSizes))
+	for _, size := range entity.ThumbnailSizes {
+		thumb := imaging.Thumbnail(flat, size.MaxEdge, orientation)
+		encoded, err := imaging.EncodeJPEG(thumb, 85)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(encoded)
+		hash := hex.EncodeToString(sum[:])
+		encodedThumbnails[hash] = encoded
+
+		thumbnails = append(thumbnails, &entity.Thumbnail{
+			Size:   size.Name,
+			Width:  thumb.Bounds().Dx(),
+			Height: thumb.Bounds().Dy(),
+			Bytes:  int64(len(encoded)),
+			Sha256: hash,
+		})
+	}
+
+	return p.attachmentRepo.CompleteAttachmentProcessing(ctx, p.client, id, metadata, thumbnails, func(hash string) error {
+		return putBlob(ctx, p.store, hash, encodedThumbnails[hash], "image/jpeg")
+	})
+}
+
+func (p *ImageProcessor) work(ctx context.Context) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-p.queue:
+			if err := p.Process(ctx, id); err != nil {
+				p.log.Errorf("ImageProcessor work: Process attachment %d: %v", id, err)
+			}
+
+			p.mx.Lock()
+			delete(p.queued, id)
+			p.mx.Unlock()
+		}
+	}
+}
+
+func (p *ImageProcessor) sweep(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		p.enqueuePending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ImageProcessor) enqueuePending(ctx context.Context) {
+	statuses := []string{entity.AttachmentProcessingPending, entity.AttachmentProcessingRunning}
+	attachments, err := p.attachmentRepo.GetAttachmentsByProcessingStatus(ctx, p.client, statuses)
+	if err != nil {
+		p.log.Errorf("ImageProcessor enqueuePending: GetAttachmentsByProcessingStatus %v", err)
+		return
+	}
+
+	for _, attachment := range attachments {
+		if !p.Enqueue(attachment.Id) {
+			return
+		}
+	}
+}
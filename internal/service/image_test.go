@@ -0,0 +1,317 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"db_cp_6/pkg/blobstore"
+	"db_cp_6/pkg/imaging"
+	"encoding/hex"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func encodeTestImage(t *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func putTestBlob(t *testing.T, store blobstore.Store, data []byte) string {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if err := store.Put(context.Background(), hash, bytes.NewReader(data), int64(len(data)), "application/octet-stream"); err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestImageProcessor_Process(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		id      int
+		content []byte
+	}
+
+	type MockBehavior func(m *mocks.MockAttachmentRepo, store blobstore.Store, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:     context.Background(),
+				id:      1,
+				content: encodeTestImage(t, 640, 320),
+			},
+			mockBehavior: func(m *mocks.MockAttachmentRepo, store blobstore.Store, args args) {
+				hash := putTestBlob(t, store, args.content)
+				m.EXPECT().StartAttachmentProcessing(args.ctx, nil, args.id).Return(nil)
+				m.EXPECT().GetAttachmentById(args.ctx, nil, args.id).
+					Return(&entity.Attachment{Id: args.id, Sha256: hash, ContentType: "image/png"}, nil)
+				m.EXPECT().CompleteAttachmentProcessing(args.ctx, nil, args.id, gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ any, _ int, metadata *entity.PhotoMetadata, thumbnails entity.Thumbnails, storeBlob func(string) error) error {
+						assert.Equal(t, &entity.PhotoMetadata{Width: 640, Height: 320}, metadata)
+						assert.Len(t, thumbnails, 3)
+
+						sizes := [][2]int{{160, 80}, {480, 240}, {640, 320}}
+						for i, thumbnail := range thumbnails {
+							assert.Equal(t, entity.ThumbnailSizes[i].Name, thumbnail.Size)
+							assert.Equal(t, sizes[i], [2]int{thumbnail.Width, thumbnail.Height})
+
+							assert.NoError(t, storeBlob(thumbnail.Sha256))
+							exists, err := store.Exists(args.ctx, thumbnail.Sha256)
+							assert.NoError(t, err)
+							assert.True(t, exists)
+						}
+						return nil
+					})
+			},
+			wantErr: nil,
+		},
+		{
+			name: "already processed",
+			args: args{
+				ctx: context.Background(),
+				id:  1,
+			},
+			mockBehavior: func(m *mocks.MockAttachmentRepo, store blobstore.Store, args args) {
+				m.EXPECT().StartAttachmentProcessing(args.ctx, nil, args.id).Return(repoerrs.ErrConflict)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "corrupt image error",
+			args: args{
+				ctx:     context.Background(),
+				id:      2,
+				content: []byte("\x89PNG\r\n\x1a\nbroken"),
+			},
+			mockBehavior: func(m *mocks.MockAttachmentRepo, store blobstore.Store, args args) {
+				hash := putTestBlob(t, store, args.content)
+				m.EXPECT().StartAttachmentProcessing(args.ctx, nil, args.id).Return(nil)
+				m.EXPECT().GetAttachmentById(args.ctx, nil, args.id).
+					Return(&entity.Attachment{Id: args.id, Sha256: hash, ContentType: "image/png"}, nil)
+				m.EXPECT().FailAttachmentProcessing(args.ctx, nil, args.id, gomock.Any()).Return(nil)
+			},
+			wantErr: imaging.ErrUnsupportedFormat,
+		},
+		{
+			name: "missing blob error",
+			args: args{
+				ctx: context.Background(),
+				id:  3,
+			},
+			mockBehavior: func(m *mocks.MockAttachmentRepo, store blobstore.Store, args args) {
+				m.EXPECT().StartAttachmentProcessing(args.ctx, nil, args.id).Return(nil)
+				m.EXPECT().GetAttachmentById(args.ctx, nil, args.id).
+					Return(&entity.Attachment{Id: args.id, Sha256: hex.EncodeToString(make([]byte, 32))}, nil)
+				m.EXPECT().FailAttachmentProcessing(args.ctx, nil, args.id, gomock.Any()).Return(nil)
+			},
+			wantErr: blobstore.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			attachmentRepo := mocks.NewMockAttachmentRepo(ctrl)
+			store, err := blobstore.NewLocal(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.mockBehavior(attachmentRepo, store, tc.args)
+
+			// init service
+			p := NewImageProcessor(attachmentRepo, store, nil, ImageConfig{})
+
+			// run test
+			err = p.Process(tc.args.ctx, tc.args.id)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestImageProcessor_Enqueue(t *testing.T) {
+	p := NewImageProcessor(nil, nil, nil, ImageConfig{QueueSize: 2})
+
+	assert.True(t, p.Enqueue(1))
+	assert.True(t, p.Enqueue(1))
+	assert.True(t, p.Enqueue(2))
+	assert.False(t, p.Enqueue(3))
+	assert.Len(t, p.queue, 2)
+}
+
+func TestImageProcessor_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	attachmentRepo := mocks.NewMockAttachmentRepo(ctrl)
+	store, err := blobstore.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := encodeTestImage(t, 32, 32)
+	hash := putTestBlob(t, store, content)
+	done := make(chan struct{})
+
+	attachmentRepo.EXPECT().GetAttachmentsByProcessingStatus(gomock.Any(), nil,
+		[]string{entity.AttachmentProcessingPending, entity.AttachmentProcessingRunning}).
+		Return(entity.Attachments{{Id: 5}}, nil).MinTimes(1)
+	attachmentRepo.EXPECT().StartAttachmentProcessing(gomock.Any(), nil, 5).Return(nil)
+	attachmentRepo.EXPECT().GetAttachmentById(gomock.Any(), nil, 5).Return(&entity.Attachment{Id: 5, Sha256: hash}, nil)
+	attachmentRepo.EXPECT().CompleteAttachmentProcessing(gomock.Any(), nil, 5, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, any, int, *entity.PhotoMetadata, entity.Thumbnails, func(string) error) error {
+			close(done)
+			return nil
+		})
+	attachmentRepo.EXPECT().StartAttachmentProcessing(gomock.Any(), nil, 5).Return(repoerrs.ErrConflict).AnyTimes()
+
+	p := NewImageProcessor(attachmentRepo, store, nil, ImageConfig{Workers: 1, SweepInterval: time.Hour})
+	p.Start(context.Background())
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("attachment was not processed")
+	}
+	p.Stop()
+}
+
+func TestAttachmentService_GetArtifactFindSpotSuggestions(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		artifactId int
+	}
+
+	type MockBehavior func(m *attachmentMocks, args args)
+
+	lat, lon := 41.9, 12.5
+	capturedAt := time.Date(2024, 7, 14, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.FindSpotSuggestions
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 1,
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 1).
+					Return(&entity.Artifact{Id: 1}, nil)
+				m.attachmentRepo.EXPECT().GetParentAttachments(args.ctx, args.client, entity.AttachmentParentArtifact, 1).
+					Return(entity.Attachments{
+						{
+							Id:         10,
+							ParentType: entity.AttachmentParentArtifact,
+							ParentId:   1,
+							FileName:   "in situ.jpg",
+							Metadata: &entity.PhotoMetadata{
+								Width:      4000,
+								Height:     3000,
+								CapturedAt: &capturedAt,
+								Gps:        entity.Coordinates{Latitude: &lat, Longitude: &lon},
+							},
+						},
+						{
+							Id:         11,
+							ParentType: entity.AttachmentParentArtifact,
+							ParentId:   1,
+							FileName:   "scan.jpg",
+							Metadata:   &entity.PhotoMetadata{Width: 800, Height: 600},
+						},
+						{
+							Id:         12,
+							ParentType: entity.AttachmentParentArtifact,
+							ParentId:   1,
+							FileName:   "report.pdf",
+						},
+					}, nil)
+			},
+			want: entity.FindSpotSuggestions{
+				{
+					AttachmentId: 10,
+					FileName:     "in situ.jpg",
+					CapturedAt:   &capturedAt,
+					FindSpot:     (&entity.Coordinates{Latitude: &lat, Longitude: &lon}).WithDefaultDatum(),
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "artifact not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				artifactId: 100,
+			},
+			mockBehavior: func(m *attachmentMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactById(args.ctx, args.client, 100).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrArtifactNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init service
+			s, m, _ := newAttachmentTestService(t, ctrl, DefaultMaxAttachmentSize)
+			tc.mockBehavior(m, tc.args)
+
+			// run test
+			got, err := s.GetArtifactFindSpotSuggestions(tc.args.ctx, tc.args.client, tc.args.artifactId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
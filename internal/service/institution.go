@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+)
+
+type InstitutionService struct {
+	institutionRepo repo.InstitutionRepo
+}
+
+func NewInstitutionService(institutionRepo repo.InstitutionRepo) *InstitutionService {
+	return &InstitutionService{
+		institutionRepo: institutionRepo,
+	}
+}
+
+func (s *InstitutionService) GetInstitutionById(ctx context.Context, client any, id int) (*entity.Institution, error) {
+	institution, err := s.institutionRepo.GetInstitutionById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrInstitutionNotFound
+		}
+		return nil, err
+	}
+
+	return institution, nil
+}
+
+func (s *InstitutionService) GetAllInstitutions(ctx context.Context, client any) (entity.Institutions, error) {
+	return s.institutionRepo.GetAllInstitutions(ctx, client)
+}
+
+func (s *InstitutionService) CreateInstitution(ctx context.Context, client any, input *entity.CreateInstitutionInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	institution := &entity.Institution{
+		Name: input.Name,
+		City: input.City,
+	}
+	if input.Country != "" {
+		institution.Country, _ = entity.NormalizeCountry(input.Country)
+	}
+	id, err := s.institutionRepo.CreateInstitution(ctx, client, institution)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrInstitutionAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *InstitutionService) DeleteInstitution(ctx context.Context, client any, id int) error {
+	err := s.institutionRepo.DeleteInstitution(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrInstitutionNotFound
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInstitutionService_CreateInstitution(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		input  *entity.CreateInstitutionInput
+	}
+
+	type MockBehavior func(m *mocks.MockInstitutionRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateInstitutionInput{
+					Name:    "aaa",
+					City:    "bbb",
+					Country: "Россия",
+				},
+			},
+			mockBehavior: func(m *mocks.MockInstitutionRepo, args args) {
+				m.EXPECT().CreateInstitution(args.ctx, args.client, &entity.Institution{
+					Name:    "aaa",
+					City:    "bbb",
+					Country: "RU",
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "institution already exists error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateInstitutionInput{
+					Name: "aaa",
+				},
+			},
+			mockBehavior: func(m *mocks.MockInstitutionRepo, args args) {
+				m.EXPECT().CreateInstitution(args.ctx, args.client, &entity.Institution{
+					Name: "aaa",
+				}).
+					Return(0, repoerrs.ErrAlreadyExists)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "unknown country error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateInstitutionInput{
+					Name:    "aaa",
+					Country: "ccc",
+				},
+			},
+			mockBehavior: func(m *mocks.MockInstitutionRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+		{
+			name: "empty name error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input:  &entity.CreateInstitutionInput{},
+			},
+			mockBehavior: func(m *mocks.MockInstitutionRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			institutionRepo := mocks.NewMockInstitutionRepo(ctrl)
+			tc.mockBehavior(institutionRepo, tc.args)
+
+			// init service
+			s := NewInstitutionService(institutionRepo)
+
+			// run test
+			got, err := s.CreateInstitution(tc.args.ctx, tc.args.client, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestInstitutionService_DeleteInstitution(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		id     int
+	}
+
+	type MockBehavior func(m *mocks.MockInstitutionRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(m *mocks.MockInstitutionRepo, args args) {
+				m.EXPECT().DeleteInstitution(args.ctx, args.client, args.id).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "institution not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(m *mocks.MockInstitutionRepo, args args) {
+				m.EXPECT().DeleteInstitution(args.ctx, args.client, args.id).
+					Return(repoerrs.ErrNotFound)
+			},
+			wantErr: ErrInstitutionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			institutionRepo := mocks.NewMockInstitutionRepo(ctrl)
+			tc.mockBehavior(institutionRepo, tc.args)
+
+			// init service
+			s := NewInstitutionService(institutionRepo)
+
+			// run test
+			err := s.DeleteInstitution(tc.args.ctx, tc.args.client, tc.args.id)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
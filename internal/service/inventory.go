@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"time"
+)
+
+type InventoryService struct {
+	inventoryRepo repo.InventoryRepo
+}
+
+func NewInventoryService(inventoryRepo repo.InventoryRepo) *InventoryService {
+	return &InventoryService{
+		inventoryRepo: inventoryRepo,
+	}
+}
+
+func (s *InventoryService) GetInventoryItemById(ctx context.Context, client any, id int) (*entity.InventoryItem, error) {
+	item, err := s.inventoryRepo.GetInventoryItemById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrInventoryItemNotFound
+		}
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (s *InventoryService) GetAllInventoryItems(ctx context.Context, client any) (entity.InventoryItems, error) {
+	return s.inventoryRepo.GetAllInventoryItems(ctx, client)
+}
+
+func (s *InventoryService) CreateInventoryItem(ctx context.Context, client any, input *entity.CreateInventoryItemInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	item := &entity.InventoryItem{
+		Name:                input.Name,
+		ItemType:            input.ItemType,
+		TotalQuantity:       input.TotalQuantity,
+		ServiceIntervalDays: input.ServiceIntervalDays,
+		ServiceFieldDays:    input.ServiceFieldDays,
+		Serviceable:         true,
+	}
+	id, err := s.inventoryRepo.CreateInventoryItem(ctx, client, item)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrInventoryItemAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *InventoryService) DeleteInventoryItem(ctx context.Context, client any, id int) error {
+	err := s.inventoryRepo.DeleteInventoryItem(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrInventoryItemNotFound
+		}
+		if errors.Is(err, repoerrs.ErrInUse) {
+			return ErrInventoryItemReserved
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *InventoryService) GetItemAvailability(ctx context.Context, client any, itemId *int, from string, to string) (entity.ItemAvailabilities, error) {
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, ErrInvalidDateRange
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil || toDate.Before(fromDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	filter := &entity.AvailabilityFilter{
+		ItemId: itemId,
+		From:   fromDate,
+		To:     toDate,
+	}
+	return s.inventoryRepo.GetItemAvailability(ctx, client, filter)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInventoryService_CreateInventoryItem(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		input  *entity.CreateInventoryItemInput
+	}
+
+	type MockBehavior func(m *mocks.MockInventoryRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateInventoryItemInput{
+					Name:          "aaa",
+					ItemType:      "tent",
+					TotalQuantity: 10,
+				},
+			},
+			mockBehavior: func(m *mocks.MockInventoryRepo, args args) {
+				m.EXPECT().CreateInventoryItem(args.ctx, args.client, &entity.InventoryItem{
+					Name:          "aaa",
+					ItemType:      "tent",
+					TotalQuantity: 10,
+					Serviceable:   true,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "item already exists error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateInventoryItemInput{
+					Name:          "aaa",
+					ItemType:      "tent",
+					TotalQuantity: 10,
+				},
+			},
+			mockBehavior: func(m *mocks.MockInventoryRepo, args args) {
+				m.EXPECT().CreateInventoryItem(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrAlreadyExists)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "negative quantity error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateInventoryItemInput{
+					Name:          "aaa",
+					ItemType:      "tent",
+					TotalQuantity: -1,
+				},
+			},
+			mockBehavior: func(m *mocks.MockInventoryRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			tc.mockBehavior(inventoryRepo, tc.args)
+
+			// init service
+			s := NewInventoryService(inventoryRepo)
+
+			// run test
+			got, err := s.CreateInventoryItem(tc.args.ctx, tc.args.client, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestInventoryService_GetItemAvailability(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		itemId *int
+		from   string
+		to     string
+	}
+
+	type MockBehavior func(m *mocks.MockInventoryRepo, args args)
+
+	from, _ := time.Parse("2006-01-02", "2024-07-01")
+	to, _ := time.Parse("2006-01-02", "2024-07-31")
+	availability := entity.ItemAvailabilities{
+		{ItemId: 1, Name: "aaa", ItemType: "tent", TotalQuantity: 10, Reserved: 7, Available: 3},
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.ItemAvailabilities
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				from:   "2024-07-01",
+				to:     "2024-07-31",
+			},
+			mockBehavior: func(m *mocks.MockInventoryRepo, args args) {
+				m.EXPECT().GetItemAvailability(args.ctx, args.client, &entity.AvailabilityFilter{From: from, To: to}).
+					Return(availability, nil)
+			},
+			want:    availability,
+			wantErr: nil,
+		},
+		{
+			name: "reversed range error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				from:   "2024-07-31",
+				to:     "2024-07-01",
+			},
+			mockBehavior: func(m *mocks.MockInventoryRepo, args args) {},
+			want:         nil,
+			wantErr:      ErrInvalidDateRange,
+		},
+		{
+			name: "missing date error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				from:   "2024-07-01",
+			},
+			mockBehavior: func(m *mocks.MockInventoryRepo, args args) {},
+			want:         nil,
+			wantErr:      ErrInvalidDateRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			tc.mockBehavior(inventoryRepo, tc.args)
+
+			// init service
+			s := NewInventoryService(inventoryRepo)
+
+			// run test
+			got, err := s.GetItemAvailability(tc.args.ctx, tc.args.client, tc.args.itemId, tc.args.from, tc.args.to)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"sort"
+	"time"
+)
+
+type JournalService struct {
+	journalRepo    repo.JournalRepo
+	expeditionRepo repo.ExpeditionRepo
+	memberRepo     repo.MemberRepo
+	leaderRepo     repo.LeaderRepo
+	artifactRepo   repo.ArtifactRepo
+}
+
+func NewJournalService(journalRepo repo.JournalRepo, expeditionRepo repo.ExpeditionRepo, memberRepo repo.MemberRepo, leaderRepo repo.LeaderRepo, artifactRepo repo.ArtifactRepo) *JournalService {
+	return &JournalService{
+		journalRepo:    journalRepo,
+		expeditionRepo: expeditionRepo,
+		memberRepo:     memberRepo,
+		leaderRepo:     leaderRepo,
+		artifactRepo:   artifactRepo,
+	}
+}
+
+func (s *JournalService) GetExpeditionJournal(ctx context.Context, client any, expeditionId int) (entity.JournalEntries, error) {
+	return s.journalRepo.GetExpeditionJournalEntries(ctx, client, expeditionId)
+}
+
+func (s *JournalService) CreateJournalEntry(ctx context.Context, client any, user *entity.User, expeditionId int, input *entity.JournalEntryInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+	if user.Role != entity.RoleMember {
+		return 0, ErrJournalMemberNotListed
+	}
+
+	expedition, err := s.getOpenExpedition(ctx, client, expeditionId)
+	if err != nil {
+		return 0, err
+	}
+
+	members, err := s.memberRepo.GetExpeditionMembers(ctx, client, expeditionId)
+	if err != nil {
+		return 0, err
+	}
+	listed := false
+	for _, member := range members {
+		if member.Id == user.Id {
+			listed = true
+			break
+		}
+	}
+	if !listed {
+		return 0, ErrJournalMemberNotListed
+	}
+
+	entry := newJournalEntry(input)
+	entry.ExpeditionId = expeditionId
+	entry.MemberId = user.Id
+	if err = s.checkJournalEntry(ctx, client, expedition, entry); err != nil {
+		return 0, err
+	}
+
+	id, err := s.journalRepo.CreateJournalEntry(ctx, client, entry)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrConflict):
+			return 0, ErrJournalDayLocked
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return 0, ErrJournalArtifactMismatch
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *JournalService) UpdateJournalEntry(ctx context.Context, client any, user *entity.User, id int, input *entity.JournalEntryInput) error {
+	if err := input.IsValid(); err != nil {
+		return err
+	}
+
+	current, err := s.journalRepo.GetJournalEntryById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrJournalEntryNotFound
+		}
+		return err
+	}
+	if user.Role != entity.RoleMember || current.MemberId != user.Id {
+		return ErrJournalEntryForbidden
+	}
+
+	expedition, err := s.getOpenExpedition(ctx, client, current.ExpeditionId)
+	if err != nil {
+		return err
+	}
+
+	entry := newJournalEntry(input)
+	entry.Id = current.Id
+	entry.ExpeditionId = current.ExpeditionId
+	entry.MemberId = current.MemberId
+	if err = s.checkJournalEntry(ctx, client, expedition, entry); err != nil {
+		return err
+	}
+
+	err = s.journalRepo.UpdateJournalEntry(ctx, client, entry)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrConflict):
+			return ErrJournalDayLocked
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return ErrJournalEntryNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *JournalService) GetExpeditionJournalLocks(ctx context.Context, client any, expeditionId int) (entity.JournalDayLocks, error) {
+	return s.journalRepo.GetExpeditionJournalLocks(ctx, client, expeditionId)
+}
+
+func (s *JournalService) LockJournalDay(ctx context.Context, client any, user *entity.User, expeditionId int, input *entity.LockJournalDayInput) error {
+	if err := input.IsValid(); err != nil {
+		return err
+	}
+	if user.Role != entity.RoleLeader && !user.IsAdmin() {
+		return ErrJournalLockForbidden
+	}
+
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrExpeditionNotFound
+		}
+		return err
+	}
+
+	if !user.IsAdmin() {
+		leaders, err := s.leaderRepo.GetExpeditionLeaders(ctx, client, expeditionId)
+		if err != nil {
+			return err
+		}
+		allowed := false
+		for _, leader := range leaders {
+			if leader.Id == user.Id {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ErrJournalLockForbidden
+		}
+	}
+
+	day, _ := time.Parse("2006-01-02", input.Day)
+	if day.Before(expedition.StartDate) || day.After(expedition.EndDate) {
+		return ErrJournalDateOutOfRange
+	}
+
+	lock := &entity.JournalDayLock{
+		ExpeditionId: expeditionId,
+		Day:          day,
+	}
+	if !user.IsAdmin() {
+		lock.LockedById = &user.Id
+	}
+
+	err = s.journalRepo.LockJournalDay(ctx, client, lock)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return ErrJournalDayLocked
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return ErrExpeditionNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *JournalService) CompileExpeditionJournal(ctx context.Context, client any, expeditionId int) (*entity.JournalDocument, error) {
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrExpeditionNotFound
+		}
+		return nil, err
+	}
+
+	entries, err := s.journalRepo.GetExpeditionJournalEntries(ctx, client, expeditionId)
+	if err != nil {
+		return nil, err
+	}
+
+	locks, err := s.journalRepo.GetExpeditionJournalLocks(ctx, client, expeditionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newJournalDocument(expedition, entries, locks), nil
+}
+
+func (s *JournalService) getOpenExpedition(ctx context.Context, client any, expeditionId int) (*entity.Expedition, error) {
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrExpeditionNotFound
+		}
+		return nil, err
+	}
+	if expedition.ClosedOn != nil {
+		return nil, ErrExpeditionClosed
+	}
+
+	return expedition, nil
+}
+
+func (s *JournalService) checkJournalEntry(ctx context.Context, client any, expedition *entity.Expedition, entry *entity.JournalEntry) error {
+	if entry.EntryDate.Before(expedition.StartDate) || entry.EntryDate.After(expedition.EndDate) {
+		return ErrJournalDateOutOfRange
+	}
+	if len(entry.ArtifactIds) == 0 {
+		return nil
+	}
+
+	artifacts, err := s.artifactRepo.GetExpeditionArtifacts(ctx, client, expedition.Id)
+	if err != nil {
+		return err
+	}
+	registered := make(map[int]bool, len(artifacts))
+	for _, artifact := range artifacts {
+		if artifact.FoundOn != nil && artifact.FoundOn.Equal(entry.EntryDate) {
+			registered[artifact.Id] = true
+		}
+	}
+	for _, id := range entry.ArtifactIds {
+		if !registered[id] {
+			return ErrJournalArtifactMismatch
+		}
+	}
+
+	return nil
+}
+
+func newJournalEntry(input *entity.JournalEntryInput) *entity.JournalEntry {
+	entryDate, _ := time.Parse("2006-01-02", input.EntryDate)
+	artifactIds := input.ArtifactIds
+	if artifactIds == nil {
+		artifactIds = []int{}
+	}
+
+	return &entity.JournalEntry{
+		EntryDate:   entryDate,
+		Weather:     input.Weather,
+		Summary:     input.Summary,
+		HoursWorked: input.HoursWorked,
+		ArtifactIds: artifactIds,
+	}
+}
+
+func newJournalDocument(expedition *entity.Expedition, entries entity.JournalEntries, locks entity.JournalDayLocks) *entity.JournalDocument {
+	doc := &entity.JournalDocument{
+		ExpeditionId: expedition.Id,
+		LocationId:   expedition.LocationId,
+		StartDate:    expedition.StartDate,
+		EndDate:      expedition.EndDate,
+		Days:         make(entity.JournalDays, 0),
+	}
+
+	days := make(map[string]*entity.JournalDay)
+	dayOf := func(date time.Time) *entity.JournalDay {
+		key := date.Format("2006-01-02")
+		day, ok := days[key]
+		if !ok {
+			day = &entity.JournalDay{Date: date, Entries: make(entity.JournalEntries, 0)}
+			days[key] = day
+			doc.Days = append(doc.Days, day)
+		}
+		return day
+	}
+
+	for _, entry := range entries {
+		day := dayOf(entry.EntryDate)
+		day.Entries = append(day.Entries, entry)
+		day.HoursWorked += entry.HoursWorked
+		doc.HoursWorked += entry.HoursWorked
+	}
+	for _, lock := range locks {
+		day := dayOf(lock.Day)
+		day.Locked = true
+		day.Lock = lock
+	}
+
+	sort.SliceStable(doc.Days, func(i, j int) bool {
+		return doc.Days[i].Date.Before(doc.Days[j].Date)
+	})
+
+	return doc
+}
@@ -0,0 +1,510 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type journalMocks struct {
+	journalRepo    *mocks.MockJournalRepo
+	expeditionRepo *mocks.MockExpeditionRepo
+	memberRepo     *mocks.MockMemberRepo
+	leaderRepo     *mocks.MockLeaderRepo
+	artifactRepo   *mocks.MockArtifactRepo
+}
+
+func newJournalMocks(ctrl *gomock.Controller) *journalMocks {
+	return &journalMocks{
+		journalRepo:    mocks.NewMockJournalRepo(ctrl),
+		expeditionRepo: mocks.NewMockExpeditionRepo(ctrl),
+		memberRepo:     mocks.NewMockMemberRepo(ctrl),
+		leaderRepo:     mocks.NewMockLeaderRepo(ctrl),
+		artifactRepo:   mocks.NewMockArtifactRepo(ctrl),
+	}
+}
+
+func TestJournalService_CreateJournalEntry(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		user         *entity.User
+		expeditionId int
+		input        *entity.JournalEntryInput
+	}
+
+	type MockBehavior func(m *journalMocks, args args)
+
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	expedition := &entity.Expedition{Id: 1, LocationId: 2, StartDate: day("2024-07-01"), EndDate: day("2024-07-20")}
+	foundOn := day("2024-07-05")
+	otherDay := day("2024-07-06")
+	artifacts := entity.Artifacts{{Id: 10, FoundOn: &foundOn}, {Id: 11, FoundOn: &otherDay}}
+	member := &entity.User{Id: 3, Role: entity.RoleMember}
+	rostered := func(m *journalMocks, args args) {
+		m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+			Return(expedition, nil)
+		m.memberRepo.EXPECT().GetExpeditionMembers(args.ctx, args.client, 1).
+			Return(entity.Members{{Id: 3}}, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         member,
+				expeditionId: 1,
+				input:        &entity.JournalEntryInput{EntryDate: "2024-07-05", Weather: "sunny", Summary: "cleared trench A", HoursWorked: 7.5, ArtifactIds: []int{10}},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				rostered(m, args)
+				m.artifactRepo.EXPECT().GetExpeditionArtifacts(args.ctx, args.client, 1).
+					Return(artifacts, nil)
+				m.journalRepo.EXPECT().CreateJournalEntry(args.ctx, args.client, &entity.JournalEntry{
+					ExpeditionId: 1,
+					MemberId:     3,
+					EntryDate:    foundOn,
+					Weather:      "sunny",
+					Summary:      "cleared trench A",
+					HoursWorked:  7.5,
+					ArtifactIds:  []int{10},
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "member not listed error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 4, Role: entity.RoleMember},
+				expeditionId: 1,
+				input:        &entity.JournalEntryInput{EntryDate: "2024-07-05", Summary: "survey", HoursWorked: 4},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				rostered(m, args)
+			},
+			want:    0,
+			wantErr: ErrJournalMemberNotListed,
+		},
+		{
+			name: "leader not listed error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 3, Role: entity.RoleLeader},
+				expeditionId: 1,
+				input:        &entity.JournalEntryInput{EntryDate: "2024-07-05", Summary: "survey", HoursWorked: 4},
+			},
+			mockBehavior: func(m *journalMocks, args args) {},
+			want:         0,
+			wantErr:      ErrJournalMemberNotListed,
+		},
+		{
+			name: "date out of range error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         member,
+				expeditionId: 1,
+				input:        &entity.JournalEntryInput{EntryDate: "2024-07-21", Summary: "survey", HoursWorked: 4},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				rostered(m, args)
+			},
+			want:    0,
+			wantErr: ErrJournalDateOutOfRange,
+		},
+		{
+			name: "artifact from another day error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         member,
+				expeditionId: 1,
+				input:        &entity.JournalEntryInput{EntryDate: "2024-07-05", Summary: "survey", HoursWorked: 4, ArtifactIds: []int{10, 11}},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				rostered(m, args)
+				m.artifactRepo.EXPECT().GetExpeditionArtifacts(args.ctx, args.client, 1).
+					Return(artifacts, nil)
+			},
+			want:    0,
+			wantErr: ErrJournalArtifactMismatch,
+		},
+		{
+			name: "day locked error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         member,
+				expeditionId: 1,
+				input:        &entity.JournalEntryInput{EntryDate: "2024-07-05", Summary: "survey", HoursWorked: 4},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				rostered(m, args)
+				m.journalRepo.EXPECT().CreateJournalEntry(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrConflict)
+			},
+			want:    0,
+			wantErr: ErrJournalDayLocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			m := newJournalMocks(ctrl)
+			tc.mockBehavior(m, tc.args)
+
+			// init service
+			s := NewJournalService(m.journalRepo, m.expeditionRepo, m.memberRepo, m.leaderRepo, m.artifactRepo)
+
+			// run test
+			got, err := s.CreateJournalEntry(tc.args.ctx, tc.args.client, tc.args.user, tc.args.expeditionId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestJournalService_UpdateJournalEntry(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		user   *entity.User
+		id     int
+		input  *entity.JournalEntryInput
+	}
+
+	type MockBehavior func(m *journalMocks, args args)
+
+	start, _ := time.Parse("2006-01-02", "2024-07-01")
+	end, _ := time.Parse("2006-01-02", "2024-07-20")
+	entryDate, _ := time.Parse("2006-01-02", "2024-07-02")
+	expedition := &entity.Expedition{Id: 1, StartDate: start, EndDate: end}
+	current := &entity.JournalEntry{Id: 5, ExpeditionId: 1, MemberId: 3, EntryDate: entryDate}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 3, Role: entity.RoleMember},
+				id:     5,
+				input:  &entity.JournalEntryInput{EntryDate: "2024-07-02", Weather: "rain", Summary: "washed finds", HoursWorked: 3},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				m.journalRepo.EXPECT().GetJournalEntryById(args.ctx, args.client, 5).
+					Return(current, nil)
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				m.journalRepo.EXPECT().UpdateJournalEntry(args.ctx, args.client, &entity.JournalEntry{
+					Id:           5,
+					ExpeditionId: 1,
+					MemberId:     3,
+					EntryDate:    entryDate,
+					Weather:      "rain",
+					Summary:      "washed finds",
+					HoursWorked:  3,
+					ArtifactIds:  []int{},
+				}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "other author error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 4, Role: entity.RoleMember},
+				id:     5,
+				input:  &entity.JournalEntryInput{EntryDate: "2024-07-02", Summary: "washed finds", HoursWorked: 3},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				m.journalRepo.EXPECT().GetJournalEntryById(args.ctx, args.client, 5).
+					Return(current, nil)
+			},
+			wantErr: ErrJournalEntryForbidden,
+		},
+		{
+			name: "entry not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 3, Role: entity.RoleMember},
+				id:     100,
+				input:  &entity.JournalEntryInput{EntryDate: "2024-07-02", Summary: "washed finds", HoursWorked: 3},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				m.journalRepo.EXPECT().GetJournalEntryById(args.ctx, args.client, 100).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: ErrJournalEntryNotFound,
+		},
+		{
+			name: "day locked error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 3, Role: entity.RoleMember},
+				id:     5,
+				input:  &entity.JournalEntryInput{EntryDate: "2024-07-02", Summary: "washed finds", HoursWorked: 3},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				m.journalRepo.EXPECT().GetJournalEntryById(args.ctx, args.client, 5).
+					Return(current, nil)
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				m.journalRepo.EXPECT().UpdateJournalEntry(args.ctx, args.client, gomock.Any()).
+					Return(repoerrs.ErrConflict)
+			},
+			wantErr: ErrJournalDayLocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			m := newJournalMocks(ctrl)
+			tc.mockBehavior(m, tc.args)
+
+			// init service
+			s := NewJournalService(m.journalRepo, m.expeditionRepo, m.memberRepo, m.leaderRepo, m.artifactRepo)
+
+			// run test
+			err := s.UpdateJournalEntry(tc.args.ctx, tc.args.client, tc.args.user, tc.args.id, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJournalService_LockJournalDay(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		user         *entity.User
+		expeditionId int
+		input        *entity.LockJournalDayInput
+	}
+
+	type MockBehavior func(m *journalMocks, args args)
+
+	start, _ := time.Parse("2006-01-02", "2024-07-01")
+	end, _ := time.Parse("2006-01-02", "2024-07-20")
+	day, _ := time.Parse("2006-01-02", "2024-07-05")
+	expedition := &entity.Expedition{Id: 1, StartDate: start, EndDate: end}
+	leaderId := 7
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 7, Role: entity.RoleLeader},
+				expeditionId: 1,
+				input:        &entity.LockJournalDayInput{Day: "2024-07-05"},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				m.leaderRepo.EXPECT().GetExpeditionLeaders(args.ctx, args.client, 1).
+					Return(entity.Leaders{{Id: 7}}, nil)
+				m.journalRepo.EXPECT().LockJournalDay(args.ctx, args.client, &entity.JournalDayLock{
+					ExpeditionId: 1,
+					Day:          day,
+					LockedById:   &leaderId,
+				}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "other leader error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 8, Role: entity.RoleLeader},
+				expeditionId: 1,
+				input:        &entity.LockJournalDayInput{Day: "2024-07-05"},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				m.leaderRepo.EXPECT().GetExpeditionLeaders(args.ctx, args.client, 1).
+					Return(entity.Leaders{{Id: 7}}, nil)
+			},
+			wantErr: ErrJournalLockForbidden,
+		},
+		{
+			name: "member error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 3, Role: entity.RoleMember},
+				expeditionId: 1,
+				input:        &entity.LockJournalDayInput{Day: "2024-07-05"},
+			},
+			mockBehavior: func(m *journalMocks, args args) {},
+			wantErr:      ErrJournalLockForbidden,
+		},
+		{
+			name: "already locked error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 1, Role: entity.RoleAdmin},
+				expeditionId: 1,
+				input:        &entity.LockJournalDayInput{Day: "2024-07-05"},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				m.journalRepo.EXPECT().LockJournalDay(args.ctx, args.client, &entity.JournalDayLock{
+					ExpeditionId: 1,
+					Day:          day,
+				}).
+					Return(repoerrs.ErrAlreadyExists)
+			},
+			wantErr: ErrJournalDayLocked,
+		},
+		{
+			name: "day out of range error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				user:         &entity.User{Id: 1, Role: entity.RoleAdmin},
+				expeditionId: 1,
+				input:        &entity.LockJournalDayInput{Day: "2024-06-30"},
+			},
+			mockBehavior: func(m *journalMocks, args args) {
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+			},
+			wantErr: ErrJournalDateOutOfRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			m := newJournalMocks(ctrl)
+			tc.mockBehavior(m, tc.args)
+
+			// init service
+			s := NewJournalService(m.journalRepo, m.expeditionRepo, m.memberRepo, m.leaderRepo, m.artifactRepo)
+
+			// run test
+			err := s.LockJournalDay(tc.args.ctx, tc.args.client, tc.args.user, tc.args.expeditionId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJournalService_CompileExpeditionJournal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newJournalMocks(ctrl)
+	s := NewJournalService(m.journalRepo, m.expeditionRepo, m.memberRepo, m.leaderRepo, m.artifactRepo)
+
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	expedition := &entity.Expedition{Id: 1, LocationId: 2, StartDate: day("2024-07-01"), EndDate: day("2024-07-20")}
+	entries := entity.JournalEntries{
+		{Id: 1, MemberName: "Ivanov", EntryDate: day("2024-07-02"), Summary: "set up camp", HoursWorked: 6},
+		{Id: 2, MemberName: "Petrova", EntryDate: day("2024-07-02"), Weather: "wind", Summary: "survey", HoursWorked: 4.5},
+		{Id: 3, MemberName: "Ivanov", EntryDate: day("2024-07-04"), Summary: "trench A", HoursWorked: 8, ArtifactIds: []int{10}},
+	}
+	locks := entity.JournalDayLocks{
+		{ExpeditionId: 1, Day: day("2024-07-02")},
+		{ExpeditionId: 1, Day: day("2024-07-03")},
+	}
+
+	ctx := context.Background()
+	m.expeditionRepo.EXPECT().GetExpeditionById(ctx, nil, 1).Return(expedition, nil)
+	m.journalRepo.EXPECT().GetExpeditionJournalEntries(ctx, nil, 1).Return(entries, nil)
+	m.journalRepo.EXPECT().GetExpeditionJournalLocks(ctx, nil, 1).Return(locks, nil)
+
+	doc, err := s.CompileExpeditionJournal(ctx, nil, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 18.5, doc.HoursWorked)
+	assert.Len(t, doc.Days, 3)
+
+	assert.Equal(t, day("2024-07-02"), doc.Days[0].Date)
+	assert.True(t, doc.Days[0].Locked)
+	assert.Equal(t, 10.5, doc.Days[0].HoursWorked)
+	assert.Len(t, doc.Days[0].Entries, 2)
+
+	assert.Equal(t, day("2024-07-03"), doc.Days[1].Date)
+	assert.True(t, doc.Days[1].Locked)
+	assert.Empty(t, doc.Days[1].Entries)
+
+	assert.Equal(t, day("2024-07-04"), doc.Days[2].Date)
+	assert.False(t, doc.Days[2].Locked)
+
+	markdown := doc.Markdown()
+	assert.Contains(t, markdown, "## 2024-07-02 (locked)")
+	assert.Contains(t, markdown, "### Petrova, 4.5 h")
+	assert.Contains(t, markdown, "Artifacts: #10")
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"fmt"
+)
+
+type LabelService struct {
+	artifactRepo   repo.ArtifactRepo
+	locationRepo   repo.LocationRepo
+	expeditionRepo repo.ExpeditionRepo
+}
+
+func NewLabelService(artifactRepo repo.ArtifactRepo, locationRepo repo.LocationRepo, expeditionRepo repo.ExpeditionRepo) *LabelService {
+	return &LabelService{
+		artifactRepo:   artifactRepo,
+		locationRepo:   locationRepo,
+		expeditionRepo: expeditionRepo,
+	}
+}
+
+func (s *LabelService) GetLabelSheet(ctx context.Context, client any, links *entity.Links, query *entity.LabelQuery) (*entity.LabelSheet, error) {
+	if err := query.IsValid(); err != nil {
+		return nil, err
+	}
+
+	var artifacts entity.Artifacts
+	var err error
+	if query.ExpeditionId != nil {
+		artifacts, err = s.getExpeditionDayArtifacts(ctx, client, *query.ExpeditionId, query.Date)
+	} else {
+		artifacts, err = s.getArtifacts(ctx, client, query.ArtifactIds)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(artifacts) == 0 {
+		return nil, ErrNoArtifactsToLabel
+	}
+
+	locationNames := make(map[int]string)
+	sheet := &entity.LabelSheet{Labels: make([]*entity.Label, 0, len(artifacts))}
+	for _, artifact := range artifacts {
+		locationName, ok := locationNames[artifact.LocationId]
+		if !ok {
+			location, err := s.locationRepo.GetLocationById(ctx, client, artifact.LocationId)
+			if err != nil {
+				if errors.Is(err, repoerrs.ErrNotFound) {
+					return nil, ErrLocationNotFound
+				}
+				return nil, err
+			}
+			locationName = location.Name
+			locationNames[artifact.LocationId] = locationName
+		}
+
+		sheet.Labels = append(sheet.Labels, &entity.Label{
+			ArtifactId:    artifact.Id,
+			CatalogNumber: entity.WithCheckDigit(artifact.CatalogNumber),
+			Name:          artifact.Name,
+			LocationName:  locationName,
+			FoundOn:       artifact.FoundOn,
+			URL:           links.URL(fmt.Sprintf("/artifacts/%d", artifact.Id)),
+		})
+	}
+
+	return sheet, nil
+}
+
+func (s *LabelService) getArtifacts(ctx context.Context, client any, ids []int) (entity.Artifacts, error) {
+	unique := make([]int, 0, len(ids))
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	artifacts, err := s.artifactRepo.GetArtifactsByIds(ctx, client, unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(artifacts) != len(unique) {
+		return nil, ErrArtifactNotFound
+	}
+
+	return artifacts, nil
+}
+
+func (s *LabelService) getExpeditionDayArtifacts(ctx context.Context, client any, expeditionId int, date string) (entity.Artifacts, error) {
+	if _, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId); err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrExpeditionNotFound
+		}
+		return nil, err
+	}
+
+	artifacts, err := s.artifactRepo.GetExpeditionArtifacts(ctx, client, expeditionId)
+	if err != nil {
+		return nil, err
+	}
+
+	dayArtifacts := make(entity.Artifacts, 0)
+	for _, artifact := range artifacts {
+		if artifact.FoundOn != nil && artifact.FoundOn.Format("2006-01-02") == date {
+			dayArtifacts = append(dayArtifacts, artifact)
+		}
+	}
+
+	return dayArtifacts, nil
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type labelMocks struct {
+	artifactRepo   *mocks.MockArtifactRepo
+	locationRepo   *mocks.MockLocationRepo
+	expeditionRepo *mocks.MockExpeditionRepo
+}
+
+func TestLabelService_GetLabelSheet(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		query  *entity.LabelQuery
+	}
+
+	type MockBehavior func(m *labelMocks, args args)
+
+	links := &entity.Links{BaseURL: "https://finds.example/api/v1"}
+	expeditionId := 5
+	firstDay := time.Date(2023, 8, 2, 0, 0, 0, 0, time.UTC)
+	secondDay := time.Date(2023, 8, 3, 0, 0, 0, 0, time.UTC)
+	artifacts := entity.Artifacts{
+		{Id: 1, LocationId: 2, Name: "Red-figure kylix", CatalogNumber: "2-2023-0001", FoundOn: &firstDay},
+		{Id: 3, LocationId: 2, Name: "Loom weight", CatalogNumber: "2-2023-0002", FoundOn: &secondDay},
+	}
+	location := &entity.Location{Id: 2, Name: "Olynthos"}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.LabelSheet
+		wantErr      error
+	}{
+		{
+			name: "OK by artifact ids",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				query:  &entity.LabelQuery{ArtifactIds: []int{3, 1, 3}},
+			},
+			mockBehavior: func(m *labelMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactsByIds(args.ctx, args.client, []int{3, 1}).
+					Return(entity.Artifacts{artifacts[1], artifacts[0]}, nil)
+				m.locationRepo.EXPECT().GetLocationById(args.ctx, args.client, 2).Return(location, nil)
+			},
+			want: &entity.LabelSheet{Labels: []*entity.Label{
+				{
+					ArtifactId:    3,
+					CatalogNumber: "2-2023-0002-8",
+					Name:          "Loom weight",
+					LocationName:  "Olynthos",
+					FoundOn:       &secondDay,
+					URL:           "https://finds.example/api/v1/artifacts/3",
+				},
+				{
+					ArtifactId:    1,
+					CatalogNumber: "2-2023-0001-X",
+					Name:          "Red-figure kylix",
+					LocationName:  "Olynthos",
+					FoundOn:       &firstDay,
+					URL:           "https://finds.example/api/v1/artifacts/1",
+				},
+			}},
+			wantErr: nil,
+		},
+		{
+			name: "OK by expedition day",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				query:  &entity.LabelQuery{ExpeditionId: &expeditionId, Date: "2023-08-02"},
+			},
+			mockBehavior: func(m *labelMocks, args args) {
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 5).Return(&entity.Expedition{Id: 5}, nil)
+				m.artifactRepo.EXPECT().GetExpeditionArtifacts(args.ctx, args.client, 5).Return(artifacts, nil)
+				m.locationRepo.EXPECT().GetLocationById(args.ctx, args.client, 2).Return(location, nil)
+			},
+			want: &entity.LabelSheet{Labels: []*entity.Label{{
+				ArtifactId:    1,
+				CatalogNumber: "2-2023-0001-X",
+				Name:          "Red-figure kylix",
+				LocationName:  "Olynthos",
+				FoundOn:       &firstDay,
+				URL:           "https://finds.example/api/v1/artifacts/1",
+			}}},
+			wantErr: nil,
+		},
+		{
+			name: "nothing found on day error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				query:  &entity.LabelQuery{ExpeditionId: &expeditionId, Date: "2023-08-10"},
+			},
+			mockBehavior: func(m *labelMocks, args args) {
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 5).Return(&entity.Expedition{Id: 5}, nil)
+				m.artifactRepo.EXPECT().GetExpeditionArtifacts(args.ctx, args.client, 5).Return(artifacts, nil)
+			},
+			want:    nil,
+			wantErr: ErrNoArtifactsToLabel,
+		},
+		{
+			name: "expedition not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				query:  &entity.LabelQuery{ExpeditionId: &expeditionId, Date: "2023-08-02"},
+			},
+			mockBehavior: func(m *labelMocks, args args) {
+				m.expeditionRepo.EXPECT().GetExpeditionById(args.ctx, args.client, 5).Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrExpeditionNotFound,
+		},
+		{
+			name: "artifact not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				query:  &entity.LabelQuery{ArtifactIds: []int{1, 100}},
+			},
+			mockBehavior: func(m *labelMocks, args args) {
+				m.artifactRepo.EXPECT().GetArtifactsByIds(args.ctx, args.client, []int{1, 100}).
+					Return(artifacts[:1], nil)
+			},
+			want:    nil,
+			wantErr: ErrArtifactNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			m := &labelMocks{
+				artifactRepo:   mocks.NewMockArtifactRepo(ctrl),
+				locationRepo:   mocks.NewMockLocationRepo(ctrl),
+				expeditionRepo: mocks.NewMockExpeditionRepo(ctrl),
+			}
+			tc.mockBehavior(m, tc.args)
+
+			// init service
+			s := NewLabelService(m.artifactRepo, m.locationRepo, m.expeditionRepo)
+
+			// run test
+			got, err := s.GetLabelSheet(tc.args.ctx, tc.args.client, links, tc.args.query)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLabelQuery_IsValid(t *testing.T) {
+	expeditionId := 5
+	testCases := []struct {
+		name    string
+		query   entity.LabelQuery
+		wantErr bool
+	}{
+		{name: "artifact ids", query: entity.LabelQuery{ArtifactIds: []int{1}, Format: entity.LabelFormatSVG}},
+		{name: "expedition day", query: entity.LabelQuery{ExpeditionId: &expeditionId, Date: "2023-08-02"}},
+		{name: "empty", query: entity.LabelQuery{}, wantErr: true},
+		{name: "both selectors", query: entity.LabelQuery{ArtifactIds: []int{1}, ExpeditionId: &expeditionId, Date: "2023-08-02"}, wantErr: true},
+		{name: "missing date", query: entity.LabelQuery{ExpeditionId: &expeditionId}, wantErr: true},
+		{name: "too many ids", query: entity.LabelQuery{ArtifactIds: make([]int, entity.MaxLabelBatch+1)}, wantErr: true},
+		{name: "unknown format", query: entity.LabelQuery{ArtifactIds: []int{1}, Format: "png"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.query.IsValid()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLabelSheet_Render(t *testing.T) {
+	foundOn := time.Date(2023, 8, 2, 0, 0, 0, 0, time.UTC)
+	sheet := &entity.LabelSheet{Labels: make([]*entity.Label, 0)}
+	for i := 1; i <= 25; i++ {
+		sheet.Labels = append(sheet.Labels, &entity.Label{
+			ArtifactId:    i,
+			CatalogNumber: "2-2023-0001-X",
+			Name:          "Краснофигурный килик с изображением атлета",
+			LocationName:  "Олинф <север>",
+			FoundOn:       &foundOn,
+			URL:           "https://finds.example/api/v1/artifacts/1",
+		})
+	}
+
+	pdf, err := sheet.PDF()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/Count 2")
+	assert.Contains(t, string(pdf), "(2-2023-0001-X) Tj")
+	assert.Contains(t, string(pdf), "Krasnofigurnyy")
+
+	svg, err := sheet.SVG()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(svg, []byte("<svg ")))
+	assert.Equal(t, 25, bytes.Count(svg, []byte(`<g id="label-`)))
+	assert.Contains(t, string(svg), "Олинф &lt;север&gt;")
+}
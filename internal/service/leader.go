@@ -45,7 +45,7 @@ func (s *LeaderService) CreateLeader(ctx context.Context, client any, input *ent
 		return 0, err
 	}
 
-	bytes, err := bcrypt.GenerateFromPassword([]byte(input.Password), 14)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(input.Password), passwordCost)
 	if err != nil {
 		return 0, fmt.Errorf("LeaderService CreateLeader : %v", err)
 	}
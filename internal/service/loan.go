@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type LoanService struct {
+	loanRepo    repo.LoanRepo
+	custodyRepo repo.CustodyRepo
+}
+
+func NewLoanService(loanRepo repo.LoanRepo, custodyRepo repo.CustodyRepo) *LoanService {
+	return &LoanService{
+		loanRepo:    loanRepo,
+		custodyRepo: custodyRepo,
+	}
+}
+
+func (s *LoanService) GetLoanById(ctx context.Context, client any, id int) (*entity.Loan, error) {
+	loan, err := s.loanRepo.GetLoanById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrLoanNotFound
+		}
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (s *LoanService) GetAllLoans(ctx context.Context, client any) (entity.Loans, error) {
+	return s.loanRepo.GetAllLoans(ctx, client)
+}
+
+func (s *LoanService) GetArtifactLoans(ctx context.Context, client any, artifactId int) (entity.Loans, error) {
+	return s.loanRepo.GetArtifactLoans(ctx, client, artifactId)
+}
+
+func (s *LoanService) CreateLoan(ctx context.Context, client any, user *entity.User, input *entity.CreateLoanInput) (int, error) {
+	if user.Role != entity.RoleCurator && !user.IsAdmin() {
+		return 0, ErrLoanForbidden
+	}
+
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	outDate, _ := time.Parse("2006-01-02", input.OutDate)
+	dueDate, _ := time.Parse("2006-01-02", input.DueDate)
+	loan := &entity.Loan{
+		Borrower:       input.Borrower,
+		ArtifactIds:    uniqueIds(input.ArtifactIds),
+		OutDate:        outDate,
+		DueDate:        dueDate,
+		InsuranceValue: input.InsuranceValue,
+		Status:         entity.LoanRequested,
+		CreatedById:    user.Id,
+		CreatedByRole:  user.Role,
+	}
+
+	id, err := s.loanRepo.CreateLoan(ctx, client, loan)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrLoanOverlap
+		}
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrArtifactNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *LoanService) UpdateLoanStatus(ctx context.Context, client any, user *entity.User, id int, input *entity.UpdateLoanStatusInput) error {
+	if user.Role != entity.RoleCurator && !user.IsAdmin() {
+		return ErrLoanForbidden
+	}
+
+	loan, err := s.GetLoanById(ctx, client, id)
+	if err != nil {
+		return err
+	}
+
+	if !loan.CanMoveTo(input.Status) {
+		return ErrInvalidLoanTransition
+	}
+
+	var returnedOn *time.Time
+	if input.Status == entity.LoanReturned {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		returnedOn = &today
+	}
+
+	custody := make(entity.CustodyRecords, 0)
+	switch input.Status {
+	case entity.LoanShipped:
+		for _, artifactId := range loan.ArtifactIds {
+			record := &entity.CustodyRecord{
+				ArtifactId: artifactId,
+				HolderType: entity.HolderFacility,
+				HolderName: loan.Borrower,
+				Reason:     fmt.Sprintf("loan %d shipped", loan.Id),
+			}
+			if err = s.signCustody(ctx, client, user, record); err != nil {
+				return err
+			}
+			custody = append(custody, record)
+		}
+	case entity.LoanReturned:
+		for _, artifactId := range loan.ArtifactIds {
+			record, err := s.returnedCustody(ctx, client, user, artifactId)
+			if err != nil {
+				return err
+			}
+			record.Reason = fmt.Sprintf("loan %d returned", loan.Id)
+			if err = s.signCustody(ctx, client, user, record); err != nil {
+				return err
+			}
+			custody = append(custody, record)
+		}
+	}
+
+	err = s.loanRepo.UpdateLoanStatus(ctx, client, loan.Id, loan.Status, input.Status, returnedOn, custody)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return ErrLoanConflict
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return ErrCustodyConflict
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *LoanService) GetOverdueLoans(ctx context.Context, client any) (entity.OverdueLoans, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return s.loanRepo.GetOverdueLoans(ctx, client, today)
+}
+
+func (s *LoanService) returnedCustody(ctx context.Context, client any, user *entity.User, artifactId int) (*entity.CustodyRecord, error) {
+	record := &entity.CustodyRecord{
+		ArtifactId: artifactId,
+		HolderType: entity.HolderCurator,
+		HolderId:   &user.Id,
+	}
+	if user.IsAdmin() {
+		record.HolderType = entity.HolderFacility
+		record.HolderId = nil
+		record.HolderName = "museum"
+	}
+
+	records, err := s.custodyRepo.GetArtifactCustody(ctx, client, artifactId)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || records[len(records)-1].PreviousId == nil {
+		return record, nil
+	}
+
+	previousId := *records[len(records)-1].PreviousId
+	for _, previous := range records {
+		if previous.Id == previousId {
+			record.HolderType = previous.HolderType
+			record.HolderId = previous.HolderId
+			record.HolderName = previous.HolderName
+		}
+	}
+
+	return record, nil
+}
+
+func (s *LoanService) signCustody(ctx context.Context, client any, user *entity.User, record *entity.CustodyRecord) error {
+	current, err := s.custodyRepo.GetCurrentCustody(ctx, client, record.ArtifactId)
+	switch {
+	case errors.Is(err, repoerrs.ErrNotFound):
+	case err != nil:
+		return err
+	default:
+		record.PreviousId = &current.Id
+	}
+
+	record.SignedById = user.Id
+	record.SignedByRole = user.Role
+
+	return nil
+}
+
+func uniqueIds(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
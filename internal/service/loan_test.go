@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLoanService_CreateLoan(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		user   *entity.User
+		input  *entity.CreateLoanInput
+	}
+
+	type MockBehavior func(m *mocks.MockLoanRepo, args args)
+
+	curator := &entity.User{Id: 5, Role: entity.RoleCurator}
+	outDate, _ := time.Parse("2006-01-02", "2024-09-01")
+	dueDate, _ := time.Parse("2006-01-02", "2024-12-01")
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				input: &entity.CreateLoanInput{
+					Borrower:       "aaa",
+					ArtifactIds:    []int{1, 2, 1},
+					OutDate:        "2024-09-01",
+					DueDate:        "2024-12-01",
+					InsuranceValue: 1000,
+				},
+			},
+			mockBehavior: func(m *mocks.MockLoanRepo, args args) {
+				m.EXPECT().CreateLoan(args.ctx, args.client, &entity.Loan{
+					Borrower:       "aaa",
+					ArtifactIds:    []int{1, 2},
+					OutDate:        outDate,
+					DueDate:        dueDate,
+					InsuranceValue: 1000,
+					Status:         entity.LoanRequested,
+					CreatedById:    curator.Id,
+					CreatedByRole:  entity.RoleCurator,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "overlapping loan error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				input: &entity.CreateLoanInput{
+					Borrower:    "aaa",
+					ArtifactIds: []int{1},
+					OutDate:     "2024-09-01",
+					DueDate:     "2024-12-01",
+				},
+			},
+			mockBehavior: func(m *mocks.MockLoanRepo, args args) {
+				m.EXPECT().CreateLoan(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrAlreadyExists)
+			},
+			want:    0,
+			wantErr: ErrLoanOverlap,
+		},
+		{
+			name: "artifact not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				input: &entity.CreateLoanInput{
+					Borrower:    "aaa",
+					ArtifactIds: []int{100},
+					OutDate:     "2024-09-01",
+					DueDate:     "2024-12-01",
+				},
+			},
+			mockBehavior: func(m *mocks.MockLoanRepo, args args) {
+				m.EXPECT().CreateLoan(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrArtifactNotFound,
+		},
+		{
+			name: "member forbidden error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 2, Role: entity.RoleMember},
+				input: &entity.CreateLoanInput{
+					Borrower:    "aaa",
+					ArtifactIds: []int{1},
+					OutDate:     "2024-09-01",
+					DueDate:     "2024-12-01",
+				},
+			},
+			mockBehavior: func(m *mocks.MockLoanRepo, args args) {},
+			want:         0,
+			wantErr:      ErrLoanForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			loanRepo := mocks.NewMockLoanRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			tc.mockBehavior(loanRepo, tc.args)
+
+			// init service
+			s := NewLoanService(loanRepo, custodyRepo)
+
+			// run test
+			got, err := s.CreateLoan(tc.args.ctx, tc.args.client, tc.args.user, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLoanService_UpdateLoanStatus(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		user   *entity.User
+		id     int
+		input  *entity.UpdateLoanStatusInput
+	}
+
+	type MockBehavior func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args)
+
+	curator := &entity.User{Id: 5, Role: entity.RoleCurator}
+	memberId := 3
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK approve",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanApproved},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(&entity.Loan{Id: 1, Borrower: "aaa", ArtifactIds: []int{7}, Status: entity.LoanRequested}, nil)
+				lr.EXPECT().UpdateLoanStatus(args.ctx, args.client, 1, entity.LoanRequested, entity.LoanApproved, nil, entity.CustodyRecords{}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "OK ship transfers custody to borrower",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanShipped},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(&entity.Loan{Id: 1, Borrower: "aaa", ArtifactIds: []int{7}, Status: entity.LoanApproved}, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, 7).
+					Return(&entity.CustodyRecord{Id: 10, ArtifactId: 7}, nil)
+				previousId := 10
+				lr.EXPECT().UpdateLoanStatus(args.ctx, args.client, 1, entity.LoanApproved, entity.LoanShipped, nil, entity.CustodyRecords{
+					{
+						ArtifactId:   7,
+						PreviousId:   &previousId,
+						HolderType:   entity.HolderFacility,
+						HolderName:   "aaa",
+						Reason:       "loan 1 shipped",
+						SignedById:   curator.Id,
+						SignedByRole: entity.RoleCurator,
+					},
+				}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "OK return restores previous holder",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanReturned},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(&entity.Loan{Id: 1, Borrower: "aaa", ArtifactIds: []int{7}, Status: entity.LoanShipped}, nil)
+				previousId := 10
+				shipped := &entity.CustodyRecord{Id: 11, ArtifactId: 7, PreviousId: &previousId, HolderType: entity.HolderFacility, HolderName: "aaa"}
+				cr.EXPECT().GetArtifactCustody(args.ctx, args.client, 7).
+					Return(entity.CustodyRecords{
+						{Id: 10, ArtifactId: 7, HolderType: entity.HolderMember, HolderId: &memberId},
+						shipped,
+					}, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, 7).
+					Return(shipped, nil)
+				currentId := 11
+				lr.EXPECT().UpdateLoanStatus(args.ctx, args.client, 1, entity.LoanShipped, entity.LoanReturned, gomock.Any(), entity.CustodyRecords{
+					{
+						ArtifactId:   7,
+						PreviousId:   &currentId,
+						HolderType:   entity.HolderMember,
+						HolderId:     &memberId,
+						Reason:       "loan 1 returned",
+						SignedById:   curator.Id,
+						SignedByRole: entity.RoleCurator,
+					},
+				}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "OK cancel requested loan",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanCancelled},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(&entity.Loan{Id: 1, Borrower: "aaa", ArtifactIds: []int{7}, Status: entity.LoanRequested}, nil)
+				lr.EXPECT().UpdateLoanStatus(args.ctx, args.client, 1, entity.LoanRequested, entity.LoanCancelled, nil, entity.CustodyRecords{}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "cancel shipped loan error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanCancelled},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(&entity.Loan{Id: 1, Status: entity.LoanShipped}, nil)
+			},
+			wantErr: ErrInvalidLoanTransition,
+		},
+		{
+			name: "concurrent custody change error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanShipped},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(&entity.Loan{Id: 1, Borrower: "aaa", ArtifactIds: []int{7}, Status: entity.LoanApproved}, nil)
+				cr.EXPECT().GetCurrentCustody(args.ctx, args.client, 7).
+					Return(&entity.CustodyRecord{Id: 10, ArtifactId: 7}, nil)
+				lr.EXPECT().UpdateLoanStatus(args.ctx, args.client, 1, entity.LoanApproved, entity.LoanShipped, nil, gomock.Any()).
+					Return(repoerrs.ErrAlreadyExists)
+			},
+			wantErr: ErrCustodyConflict,
+		},
+		{
+			name: "skipping status error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanShipped},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(&entity.Loan{Id: 1, Status: entity.LoanRequested}, nil)
+			},
+			wantErr: ErrInvalidLoanTransition,
+		},
+		{
+			name: "concurrent update error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanApproved},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(&entity.Loan{Id: 1, Status: entity.LoanRequested}, nil)
+				lr.EXPECT().UpdateLoanStatus(args.ctx, args.client, 1, entity.LoanRequested, entity.LoanApproved, nil, entity.CustodyRecords{}).
+					Return(repoerrs.ErrNotFound)
+			},
+			wantErr: ErrLoanConflict,
+		},
+		{
+			name: "loan not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				id:     1,
+				input:  &entity.UpdateLoanStatusInput{Status: entity.LoanApproved},
+			},
+			mockBehavior: func(lr *mocks.MockLoanRepo, cr *mocks.MockCustodyRepo, args args) {
+				lr.EXPECT().GetLoanById(args.ctx, args.client, args.id).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: ErrLoanNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			loanRepo := mocks.NewMockLoanRepo(ctrl)
+			custodyRepo := mocks.NewMockCustodyRepo(ctrl)
+			tc.mockBehavior(loanRepo, custodyRepo, tc.args)
+
+			// init service
+			s := NewLoanService(loanRepo, custodyRepo)
+
+			// run test
+			err := s.UpdateLoanStatus(tc.args.ctx, tc.args.client, tc.args.user, tc.args.id, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"db_cp_6/internal/repo"
 	"db_cp_6/internal/repo/repoerrs"
 	"errors"
+	"fmt"
 )
 
 type LocationService struct {
@@ -30,8 +31,22 @@ func (s *LocationService) GetLocationById(ctx context.Context, client any, id in
 	return location, nil
 }
 
-func (s *LocationService) GetAllLocations(ctx context.Context, client any) (entity.Locations, error) {
-	return s.locationRepo.GetAllLocations(ctx, client)
+func (s *LocationService) GetAllLocations(ctx context.Context, client any, filter *entity.LocationFilter) (entity.Locations, error) {
+	if filter != nil && filter.BoundingBox != nil {
+		if err := filter.BoundingBox.IsValid(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBoundingBox, err)
+		}
+	}
+
+	return s.locationRepo.GetAllLocations(ctx, client, filter)
+}
+
+func (s *LocationService) GetNearbyLocations(ctx context.Context, client any, query *entity.NearbyQuery) (entity.NearbyLocations, error) {
+	if err := query.IsValid(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCoordinates, err)
+	}
+
+	return s.locationRepo.GetNearbyLocations(ctx, client, query)
 }
 
 func (s *LocationService) CreateLocation(ctx context.Context, client any, input *entity.CreateLocationInput) (int, error) {
@@ -39,10 +54,12 @@ func (s *LocationService) CreateLocation(ctx context.Context, client any, input
 		return 0, err
 	}
 
+	country, _ := entity.NormalizeCountry(input.Country)
 	exp := &entity.Location{
 		Name:        input.Name,
-		Country:     input.Country,
+		Country:     country,
 		NearestTown: input.NearestTown,
+		Coordinates: input.Coordinates.WithDefaultDatum(),
 	}
 	return s.locationRepo.CreateLocation(ctx, client, exp)
 }
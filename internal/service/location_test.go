@@ -95,6 +95,7 @@ func TestLocationService_GetAllLocations(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		client any
+		filter *entity.LocationFilter
 	}
 
 	type MockBehavior func(m *mocks.MockLocationRepo, args args)
@@ -113,7 +114,7 @@ func TestLocationService_GetAllLocations(t *testing.T) {
 				client: nil,
 			},
 			mockBehavior: func(m *mocks.MockLocationRepo, args args) {
-				m.EXPECT().GetAllLocations(args.ctx, args.client).
+				m.EXPECT().GetAllLocations(args.ctx, args.client, args.filter).
 					Return(entity.Locations{
 						&entity.Location{
 							Id:          1,
@@ -133,6 +134,17 @@ func TestLocationService_GetAllLocations(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid bounding box error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				filter: &entity.LocationFilter{BoundingBox: &entity.BoundingBox{MinLatitude: 10, MaxLatitude: -10}},
+			},
+			mockBehavior: func(m *mocks.MockLocationRepo, args args) {},
+			want:         nil,
+			wantErr:      true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -149,9 +161,9 @@ func TestLocationService_GetAllLocations(t *testing.T) {
 			s := NewLocationService(locationRepo)
 
 			// run test
-			got, err := s.GetAllLocations(tc.args.ctx, tc.args.client)
+			got, err := s.GetAllLocations(tc.args.ctx, tc.args.client, tc.args.filter)
 			if tc.wantErr {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidBoundingBox)
 				return
 			}
 
@@ -170,6 +182,8 @@ func TestLocationService_CreateLocation(t *testing.T) {
 
 	type MockBehavior func(m *mocks.MockLocationRepo, args args)
 
+	latitude, longitude, invalidLatitude := 42.45, 77.1, 91.0
+
 	testCases := []struct {
 		name         string
 		args         args
@@ -184,14 +198,14 @@ func TestLocationService_CreateLocation(t *testing.T) {
 				client: nil,
 				input: &entity.CreateLocationInput{
 					Name:        "aaa",
-					Country:     "bbb",
+					Country:     "Russian Federation",
 					NearestTown: "ccc",
 				},
 			},
 			mockBehavior: func(m *mocks.MockLocationRepo, args args) {
 				m.EXPECT().CreateLocation(args.ctx, args.client, &entity.Location{
 					Name:        args.input.Name,
-					Country:     args.input.Country,
+					Country:     "RU",
 					NearestTown: args.input.NearestTown,
 				}).
 					Return(1, nil)
@@ -199,6 +213,89 @@ func TestLocationService_CreateLocation(t *testing.T) {
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "OK with coordinates and default datum",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateLocationInput{
+					Name:        "aaa",
+					Country:     "KG",
+					NearestTown: "ccc",
+					Coordinates: entity.Coordinates{
+						Latitude:  &latitude,
+						Longitude: &longitude,
+					},
+				},
+			},
+			mockBehavior: func(m *mocks.MockLocationRepo, args args) {
+				m.EXPECT().CreateLocation(args.ctx, args.client, &entity.Location{
+					Name:        args.input.Name,
+					Country:     "KG",
+					NearestTown: args.input.NearestTown,
+					Coordinates: entity.Coordinates{
+						Latitude:  &latitude,
+						Longitude: &longitude,
+						Datum:     entity.DefaultDatum,
+					},
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "unknown country error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateLocationInput{
+					Name:        "aaa",
+					Country:     "bbb",
+					NearestTown: "ccc",
+				},
+			},
+			mockBehavior: func(m *mocks.MockLocationRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+		{
+			name: "invalid latitude error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateLocationInput{
+					Name:        "aaa",
+					Country:     "KG",
+					NearestTown: "ccc",
+					Coordinates: entity.Coordinates{
+						Latitude:  &invalidLatitude,
+						Longitude: &longitude,
+					},
+				},
+			},
+			mockBehavior: func(m *mocks.MockLocationRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+		{
+			name: "latitude without longitude error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateLocationInput{
+					Name:        "aaa",
+					Country:     "KG",
+					NearestTown: "ccc",
+					Coordinates: entity.Coordinates{
+						Latitude: &latitude,
+					},
+				},
+			},
+			mockBehavior: func(m *mocks.MockLocationRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -227,6 +324,122 @@ func TestLocationService_CreateLocation(t *testing.T) {
 	}
 }
 
+func TestLocationService_GetNearbyLocations(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		query  *entity.NearbyQuery
+	}
+
+	type MockBehavior func(m *mocks.MockLocationRepo, args args)
+
+	latitude, longitude := 42.45, 77.1
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.NearbyLocations
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				query: &entity.NearbyQuery{
+					Latitude:  42.5,
+					Longitude: 77,
+					RadiusKm:  50,
+				},
+			},
+			mockBehavior: func(m *mocks.MockLocationRepo, args args) {
+				m.EXPECT().GetNearbyLocations(args.ctx, args.client, args.query).
+					Return(entity.NearbyLocations{
+						&entity.NearbyLocation{
+							Location: entity.Location{
+								Id:          1,
+								Name:        "aaa",
+								Country:     "bbb",
+								NearestTown: "ccc",
+								Coordinates: entity.Coordinates{Latitude: &latitude, Longitude: &longitude},
+							},
+							DistanceKm: 9.7,
+						},
+					}, nil)
+			},
+			want: entity.NearbyLocations{
+				&entity.NearbyLocation{
+					Location: entity.Location{
+						Id:          1,
+						Name:        "aaa",
+						Country:     "bbb",
+						NearestTown: "ccc",
+						Coordinates: entity.Coordinates{Latitude: &latitude, Longitude: &longitude},
+					},
+					DistanceKm: 9.7,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid radius error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				query: &entity.NearbyQuery{
+					Latitude:  42.5,
+					Longitude: 77,
+					RadiusKm:  0,
+				},
+			},
+			mockBehavior: func(m *mocks.MockLocationRepo, args args) {},
+			want:         nil,
+			wantErr:      true,
+		},
+		{
+			name: "invalid longitude error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				query: &entity.NearbyQuery{
+					Latitude:  42.5,
+					Longitude: 181,
+					RadiusKm:  50,
+				},
+			},
+			mockBehavior: func(m *mocks.MockLocationRepo, args args) {},
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			locationRepo := mocks.NewMockLocationRepo(ctrl)
+			tc.mockBehavior(locationRepo, tc.args)
+
+			// init service
+			s := NewLocationService(locationRepo)
+
+			// run test
+			got, err := s.GetNearbyLocations(tc.args.ctx, tc.args.client, tc.args.query)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCoordinates)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestLocationService_DeleteLocation(t *testing.T) {
 	type args struct {
 		ctx    context.Context
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"time"
+)
+
+type MaintenanceService struct {
+	maintenanceRepo repo.MaintenanceRepo
+	inventoryRepo   repo.InventoryRepo
+	expeditionRepo  repo.ExpeditionRepo
+}
+
+func NewMaintenanceService(maintenanceRepo repo.MaintenanceRepo, inventoryRepo repo.InventoryRepo, expeditionRepo repo.ExpeditionRepo) *MaintenanceService {
+	return &MaintenanceService{
+		maintenanceRepo: maintenanceRepo,
+		inventoryRepo:   inventoryRepo,
+		expeditionRepo:  expeditionRepo,
+	}
+}
+
+func (s *MaintenanceService) GetItemMaintenanceLogs(ctx context.Context, client any, itemId int) (entity.MaintenanceLogs, error) {
+	_, err := s.inventoryRepo.GetInventoryItemById(ctx, client, itemId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrInventoryItemNotFound
+		}
+		return nil, err
+	}
+
+	return s.maintenanceRepo.GetItemMaintenanceLogs(ctx, client, itemId)
+}
+
+func (s *MaintenanceService) CreateMaintenanceLog(ctx context.Context, client any, itemId int, input *entity.CreateMaintenanceLogInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	performedOn := time.Now().UTC().Truncate(24 * time.Hour)
+	if input.PerformedOn != "" {
+		performedOn, _ = time.Parse("2006-01-02", input.PerformedOn)
+	}
+	if performedOn.After(time.Now()) {
+		return 0, ErrMaintenanceInFuture
+	}
+
+	log := &entity.MaintenanceLog{
+		ItemId:      itemId,
+		PerformedOn: performedOn,
+		Technician:  input.Technician,
+		Description: input.Description,
+		Serviceable: input.Serviceable,
+	}
+	id, err := s.maintenanceRepo.CreateMaintenanceLog(ctx, client, log)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrInventoryItemNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *MaintenanceService) UpdateMaintenanceSchedule(ctx context.Context, client any, itemId int, input *entity.UpdateMaintenanceScheduleInput) error {
+	if err := input.IsValid(); err != nil {
+		return err
+	}
+
+	err := s.maintenanceRepo.UpdateMaintenanceSchedule(ctx, client, itemId, input.ServiceIntervalDays, input.ServiceFieldDays)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrInventoryItemNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *MaintenanceService) GetMaintenanceDue(ctx context.Context, client any, expeditionId int) (entity.MaintenanceDues, error) {
+	_, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrExpeditionNotFound
+		}
+		return nil, err
+	}
+
+	return s.maintenanceRepo.GetMaintenanceDue(ctx, client, expeditionId)
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceService_CreateMaintenanceLog(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		itemId int
+		input  *entity.CreateMaintenanceLogInput
+	}
+
+	type MockBehavior func(m *mocks.MockMaintenanceRepo, args args)
+
+	performedOn, _ := time.Parse("2006-01-02", "2024-05-10")
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				itemId: 2,
+				input: &entity.CreateMaintenanceLogInput{
+					PerformedOn: "2024-05-10",
+					Technician:  "aaa",
+					Description: "calibration",
+					Serviceable: true,
+				},
+			},
+			mockBehavior: func(m *mocks.MockMaintenanceRepo, args args) {
+				m.EXPECT().CreateMaintenanceLog(args.ctx, args.client, &entity.MaintenanceLog{
+					ItemId:      2,
+					PerformedOn: performedOn,
+					Technician:  "aaa",
+					Description: "calibration",
+					Serviceable: true,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "date in future error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				itemId: 2,
+				input: &entity.CreateMaintenanceLogInput{
+					PerformedOn: time.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+					Technician:  "aaa",
+					Description: "calibration",
+				},
+			},
+			mockBehavior: func(m *mocks.MockMaintenanceRepo, args args) {},
+			want:         0,
+			wantErr:      ErrMaintenanceInFuture,
+		},
+		{
+			name: "item not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				itemId: 100,
+				input: &entity.CreateMaintenanceLogInput{
+					Technician:  "aaa",
+					Description: "broken display",
+				},
+			},
+			mockBehavior: func(m *mocks.MockMaintenanceRepo, args args) {
+				m.EXPECT().CreateMaintenanceLog(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrInventoryItemNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			maintenanceRepo := mocks.NewMockMaintenanceRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			tc.mockBehavior(maintenanceRepo, tc.args)
+
+			// init service
+			s := NewMaintenanceService(maintenanceRepo, inventoryRepo, expeditionRepo)
+
+			// run test
+			got, err := s.CreateMaintenanceLog(tc.args.ctx, tc.args.client, tc.args.itemId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMaintenanceService_UpdateMaintenanceSchedule(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		itemId int
+		input  *entity.UpdateMaintenanceScheduleInput
+	}
+
+	type MockBehavior func(m *mocks.MockMaintenanceRepo, args args)
+
+	intervalDays := 180
+	fieldDays := 30
+	invalidDays := 0
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				itemId: 2,
+				input:  &entity.UpdateMaintenanceScheduleInput{ServiceIntervalDays: &intervalDays, ServiceFieldDays: &fieldDays},
+			},
+			mockBehavior: func(m *mocks.MockMaintenanceRepo, args args) {
+				m.EXPECT().UpdateMaintenanceSchedule(args.ctx, args.client, 2, &intervalDays, &fieldDays).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid interval error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				itemId: 2,
+				input:  &entity.UpdateMaintenanceScheduleInput{ServiceIntervalDays: &invalidDays},
+			},
+			mockBehavior: func(m *mocks.MockMaintenanceRepo, args args) {},
+			wantErr:      true,
+		},
+		{
+			name: "item not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				itemId: 100,
+				input:  &entity.UpdateMaintenanceScheduleInput{ServiceFieldDays: &fieldDays},
+			},
+			mockBehavior: func(m *mocks.MockMaintenanceRepo, args args) {
+				m.EXPECT().UpdateMaintenanceSchedule(args.ctx, args.client, 100, nil, &fieldDays).
+					Return(repoerrs.ErrNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			maintenanceRepo := mocks.NewMockMaintenanceRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			tc.mockBehavior(maintenanceRepo, tc.args)
+
+			// init service
+			s := NewMaintenanceService(maintenanceRepo, inventoryRepo, expeditionRepo)
+
+			// run test
+			err := s.UpdateMaintenanceSchedule(tc.args.ctx, tc.args.client, tc.args.itemId, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMaintenanceService_GetMaintenanceDue(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		expeditionId int
+	}
+
+	type MockBehavior func(m *mocks.MockMaintenanceRepo, xr *mocks.MockExpeditionRepo, args args)
+
+	intervalDays := 180
+	lastServiced, _ := time.Parse("2006-01-02", "2024-01-10")
+	dueOn := lastServiced.AddDate(0, 0, intervalDays)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.MaintenanceDues
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+			},
+			mockBehavior: func(m *mocks.MockMaintenanceRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, args.expeditionId).
+					Return(&entity.Expedition{Id: 1}, nil)
+				m.EXPECT().GetMaintenanceDue(args.ctx, args.client, args.expeditionId).
+					Return(entity.MaintenanceDues{
+						{ItemId: 2, Name: "GPS", Serviceable: true, LastServicedOn: &lastServiced, ServiceIntervalDays: &intervalDays, DueOn: &dueOn},
+					}, nil)
+			},
+			want: entity.MaintenanceDues{
+				{ItemId: 2, Name: "GPS", Serviceable: true, LastServicedOn: &lastServiced, ServiceIntervalDays: &intervalDays, DueOn: &dueOn},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expedition not found error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 100,
+			},
+			mockBehavior: func(m *mocks.MockMaintenanceRepo, xr *mocks.MockExpeditionRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, args.expeditionId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrExpeditionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			maintenanceRepo := mocks.NewMockMaintenanceRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			tc.mockBehavior(maintenanceRepo, expeditionRepo, tc.args)
+
+			// init service
+			s := NewMaintenanceService(maintenanceRepo, inventoryRepo, expeditionRepo)
+
+			// run test
+			got, err := s.GetMaintenanceDue(tc.args.ctx, tc.args.client, tc.args.expeditionId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -46,7 +46,7 @@ func (s *MemberService) CreateMember(ctx context.Context, client any, input *ent
 		return 0, err
 	}
 
-	bytes, err := bcrypt.GenerateFromPassword([]byte(input.Password), 14)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(input.Password), passwordCost)
 	if err != nil {
 		return 0, fmt.Errorf("MemberService CreateMember : %v", err)
 	}
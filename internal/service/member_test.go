@@ -249,7 +249,7 @@ func TestMemberService_CreateMember(t *testing.T) {
 
 	type MockBehavior func(m *mocks.MockMemberRepo, args args)
 
-	bytes, _ := bcrypt.GenerateFromPassword([]byte("ddd"), 14)
+	bytes, _ := bcrypt.GenerateFromPassword([]byte("ddd"), passwordCost)
 
 	testCases := []struct {
 		name         string
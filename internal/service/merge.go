@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"fmt"
+)
+
+type MergeService struct {
+	mergeRepo repo.MergeRepo
+}
+
+func NewMergeService(mergeRepo repo.MergeRepo) *MergeService {
+	return &MergeService{mergeRepo: mergeRepo}
+}
+
+func (s *MergeService) GetDuplicateCandidates(ctx context.Context, client any, user *entity.User, query *entity.DuplicateQuery) (entity.DuplicateCandidates, error) {
+	if user.Role != entity.RoleLeader && !user.IsAdmin() {
+		return nil, ErrDuplicatesForbidden
+	}
+	if err := query.IsValid(); err != nil {
+		return nil, err
+	}
+
+	threshold, limit := query.Threshold, query.Limit
+	if threshold == 0 {
+		threshold = entity.DefaultDuplicateThreshold
+	}
+	if limit == 0 {
+		limit = entity.DefaultDuplicateLimit
+	}
+
+	return s.mergeRepo.GetDuplicateCandidates(ctx, client, query.Type, threshold, limit)
+}
+
+func (s *MergeService) Merge(ctx context.Context, client any, user *entity.User, entityType string, input *entity.MergeInput) (*entity.MergeRecord, error) {
+	if !user.IsAdmin() {
+		return nil, ErrMergeForbidden
+	}
+	query := entity.DuplicateQuery{Type: entityType}
+	if err := query.IsValid(); err != nil {
+		return nil, err
+	}
+	if err := input.IsValid(); err != nil {
+		return nil, err
+	}
+
+	record := &entity.MergeRecord{
+		EntityType:   entityType,
+		SurvivorId:   input.SurvivorId,
+		MergedId:     input.DuplicateId,
+		MergedById:   user.Id,
+		MergedByRole: user.Role,
+	}
+	if _, err := s.mergeRepo.Merge(ctx, client, record); err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return nil, ErrMergeTargetNotFound
+		case errors.Is(err, repoerrs.ErrConflict):
+			return nil, fmt.Errorf("%w: %v", ErrMergeConflict, err)
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *MergeService) GetMergeHistory(ctx context.Context, client any, user *entity.User, entityType string) (entity.MergeRecords, error) {
+	if user.Role != entity.RoleLeader && !user.IsAdmin() {
+		return nil, ErrDuplicatesForbidden
+	}
+	if entityType != "" {
+		query := entity.DuplicateQuery{Type: entityType}
+		if err := query.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.mergeRepo.GetMergeHistory(ctx, client, entityType)
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMergeService_GetDuplicateCandidates(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		user   *entity.User
+		query  *entity.DuplicateQuery
+	}
+
+	type MockBehavior func(m *mocks.MockMergeRepo, args args)
+
+	candidates := entity.DuplicateCandidates{{
+		Type:       entity.MergeLocation,
+		First:      &entity.DuplicateRecord{Id: 1, Name: "Issyk-Kul", Details: "Cholpon-Ata, Kyrgyzstan"},
+		Second:     &entity.DuplicateRecord{Id: 4, Name: "Иссык-Куль", Details: "Чолпон-Ата, Киргизия"},
+		Similarity: 1,
+	}}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.DuplicateCandidates
+		wantErr      error
+	}{
+		{
+			name: "OK defaults",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 2, Role: entity.RoleLeader},
+				query:  &entity.DuplicateQuery{Type: entity.MergeLocation},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {
+				m.EXPECT().GetDuplicateCandidates(args.ctx, args.client, entity.MergeLocation, entity.DefaultDuplicateThreshold, entity.DefaultDuplicateLimit).
+					Return(candidates, nil)
+			},
+			want:    candidates,
+			wantErr: nil,
+		},
+		{
+			name: "OK custom threshold",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 1, Role: entity.RoleAdmin},
+				query:  &entity.DuplicateQuery{Type: entity.MergeMember, Threshold: 0.45, Limit: 10},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {
+				m.EXPECT().GetDuplicateCandidates(args.ctx, args.client, entity.MergeMember, 0.45, 10).
+					Return(entity.DuplicateCandidates{}, nil)
+			},
+			want:    entity.DuplicateCandidates{},
+			wantErr: nil,
+		},
+		{
+			name: "forbidden error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 7, Role: entity.RoleMember},
+				query:  &entity.DuplicateQuery{Type: entity.MergeLocation},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {},
+			want:         nil,
+			wantErr:      ErrDuplicatesForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			mergeRepo := mocks.NewMockMergeRepo(ctrl)
+			tc.mockBehavior(mergeRepo, tc.args)
+
+			// init service
+			s := NewMergeService(mergeRepo)
+
+			// run test
+			got, err := s.GetDuplicateCandidates(tc.args.ctx, tc.args.client, tc.args.user, tc.args.query)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMergeService_Merge(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		user       *entity.User
+		entityType string
+		input      *entity.MergeInput
+	}
+
+	type MockBehavior func(m *mocks.MockMergeRepo, args args)
+
+	admin := &entity.User{Id: 1, Role: entity.RoleAdmin}
+	expected := &entity.MergeRecord{
+		EntityType:   entity.MergeMember,
+		SurvivorId:   3,
+		MergedId:     8,
+		MergedById:   1,
+		MergedByRole: entity.RoleAdmin,
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.MergeRecord
+		wantErr      error
+		wantAnyErr   bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       admin,
+				entityType: entity.MergeMember,
+				input:      &entity.MergeInput{SurvivorId: 3, DuplicateId: 8},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {
+				m.EXPECT().Merge(args.ctx, args.client, expected).DoAndReturn(
+					func(ctx context.Context, client any, record *entity.MergeRecord) (int, error) {
+						record.Id = 5
+						record.MergedName = "Ivanov Ivan"
+						record.Reassigned = map[string]int64{"journal_entries": 4}
+						return 5, nil
+					})
+			},
+			want: &entity.MergeRecord{
+				Id:           5,
+				EntityType:   entity.MergeMember,
+				SurvivorId:   3,
+				MergedId:     8,
+				MergedName:   "Ivanov Ivan",
+				Reassigned:   map[string]int64{"journal_entries": 4},
+				MergedById:   1,
+				MergedByRole: entity.RoleAdmin,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "record not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       admin,
+				entityType: entity.MergeMember,
+				input:      &entity.MergeInput{SurvivorId: 3, DuplicateId: 8},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {
+				m.EXPECT().Merge(args.ctx, args.client, gomock.Any()).Return(0, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrMergeTargetNotFound,
+		},
+		{
+			name: "conflict error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       admin,
+				entityType: entity.MergeLocation,
+				input:      &entity.MergeInput{SurvivorId: 1, DuplicateId: 4},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {
+				m.EXPECT().Merge(args.ctx, args.client, gomock.Any()).
+					Return(0, fmt.Errorf("%w: trenches: duplicate key", repoerrs.ErrConflict))
+			},
+			want:    nil,
+			wantErr: ErrMergeConflict,
+		},
+		{
+			name: "leader forbidden error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: 2, Role: entity.RoleLeader},
+				entityType: entity.MergeLocation,
+				input:      &entity.MergeInput{SurvivorId: 1, DuplicateId: 4},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {},
+			want:         nil,
+			wantErr:      ErrMergeForbidden,
+		},
+		{
+			name: "self merge error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       admin,
+				entityType: entity.MergeLocation,
+				input:      &entity.MergeInput{SurvivorId: 4, DuplicateId: 4},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {},
+			want:         nil,
+			wantAnyErr:   true,
+		},
+		{
+			name: "unknown type error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       admin,
+				entityType: "expedition",
+				input:      &entity.MergeInput{SurvivorId: 1, DuplicateId: 4},
+			},
+			mockBehavior: func(m *mocks.MockMergeRepo, args args) {},
+			want:         nil,
+			wantAnyErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			mergeRepo := mocks.NewMockMergeRepo(ctrl)
+			tc.mockBehavior(mergeRepo, tc.args)
+
+			// init service
+			s := NewMergeService(mergeRepo)
+
+			// run test
+			got, err := s.Merge(tc.args.ctx, tc.args.client, tc.args.user, tc.args.entityType, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			if tc.wantAnyErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -51,18 +51,33 @@ func (mr *MockArtifactRepoMockRecorder) CreateArtifact(arg0, arg1, arg2 interfac
 }
 
 // GetAllArtifacts mocks base method.
-func (m *MockArtifactRepo) GetAllArtifacts(arg0 context.Context, arg1 interface{}) (entity.Artifacts, error) {
+func (m *MockArtifactRepo) GetAllArtifacts(arg0 context.Context, arg1 interface{}, arg2 *entity.ArtifactFilter) (entity.Artifacts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllArtifacts", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAllArtifacts", arg0, arg1, arg2)
 	ret0, _ := ret[0].(entity.Artifacts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllArtifacts indicates an expected call of GetAllArtifacts.
-func (mr *MockArtifactRepoMockRecorder) GetAllArtifacts(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockArtifactRepoMockRecorder) GetAllArtifacts(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllArtifacts", reflect.TypeOf((*MockArtifactRepo)(nil).GetAllArtifacts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllArtifacts", reflect.TypeOf((*MockArtifactRepo)(nil).GetAllArtifacts), arg0, arg1, arg2)
+}
+
+// GetArtifactByCatalogNumber mocks base method.
+func (m *MockArtifactRepo) GetArtifactByCatalogNumber(arg0 context.Context, arg1 interface{}, arg2 *entity.CatalogNumber) (*entity.Artifact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtifactByCatalogNumber", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Artifact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactByCatalogNumber indicates an expected call of GetArtifactByCatalogNumber.
+func (mr *MockArtifactRepoMockRecorder) GetArtifactByCatalogNumber(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactByCatalogNumber", reflect.TypeOf((*MockArtifactRepo)(nil).GetArtifactByCatalogNumber), arg0, arg1, arg2)
 }
 
 // GetArtifactById mocks base method.
@@ -80,6 +95,66 @@ func (mr *MockArtifactRepoMockRecorder) GetArtifactById(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactById", reflect.TypeOf((*MockArtifactRepo)(nil).GetArtifactById), arg0, arg1, arg2)
 }
 
+// GetArtifactsByIds mocks base method.
+func (m *MockArtifactRepo) GetArtifactsByIds(arg0 context.Context, arg1 interface{}, arg2 []int) (entity.Artifacts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtifactsByIds", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Artifacts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactsByIds indicates an expected call of GetArtifactsByIds.
+func (mr *MockArtifactRepoMockRecorder) GetArtifactsByIds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactsByIds", reflect.TypeOf((*MockArtifactRepo)(nil).GetArtifactsByIds), arg0, arg1, arg2)
+}
+
+// GetCatalogCandidates mocks base method.
+func (m *MockArtifactRepo) GetCatalogCandidates(arg0 context.Context, arg1 interface{}, arg2 *entity.CatalogNumber, arg3 int) (entity.CatalogCandidates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogCandidates", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(entity.CatalogCandidates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogCandidates indicates an expected call of GetCatalogCandidates.
+func (mr *MockArtifactRepoMockRecorder) GetCatalogCandidates(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogCandidates", reflect.TypeOf((*MockArtifactRepo)(nil).GetCatalogCandidates), arg0, arg1, arg2, arg3)
+}
+
+// GetContextArtifacts mocks base method.
+func (m *MockArtifactRepo) GetContextArtifacts(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Artifacts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContextArtifacts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Artifacts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContextArtifacts indicates an expected call of GetContextArtifacts.
+func (mr *MockArtifactRepoMockRecorder) GetContextArtifacts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContextArtifacts", reflect.TypeOf((*MockArtifactRepo)(nil).GetContextArtifacts), arg0, arg1, arg2)
+}
+
+// GetExpeditionArtifacts mocks base method.
+func (m *MockArtifactRepo) GetExpeditionArtifacts(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Artifacts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpeditionArtifacts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Artifacts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpeditionArtifacts indicates an expected call of GetExpeditionArtifacts.
+func (mr *MockArtifactRepoMockRecorder) GetExpeditionArtifacts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionArtifacts", reflect.TypeOf((*MockArtifactRepo)(nil).GetExpeditionArtifacts), arg0, arg1, arg2)
+}
+
 // GetLocationArtifacts mocks base method.
 func (m *MockArtifactRepo) GetLocationArtifacts(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Artifacts, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +169,31 @@ func (mr *MockArtifactRepoMockRecorder) GetLocationArtifacts(arg0, arg1, arg2 in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationArtifacts", reflect.TypeOf((*MockArtifactRepo)(nil).GetLocationArtifacts), arg0, arg1, arg2)
 }
+
+// UpdateArtifactCurator mocks base method.
+func (m *MockArtifactRepo) UpdateArtifactCurator(arg0 context.Context, arg1 interface{}, arg2 int, arg3 *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArtifactCurator", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArtifactCurator indicates an expected call of UpdateArtifactCurator.
+func (mr *MockArtifactRepoMockRecorder) UpdateArtifactCurator(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArtifactCurator", reflect.TypeOf((*MockArtifactRepo)(nil).UpdateArtifactCurator), arg0, arg1, arg2, arg3)
+}
+
+// UpdateArtifactDating mocks base method.
+func (m *MockArtifactRepo) UpdateArtifactDating(arg0 context.Context, arg1 interface{}, arg2 int, arg3 *entity.Dating) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArtifactDating", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArtifactDating indicates an expected call of UpdateArtifactDating.
+func (mr *MockArtifactRepoMockRecorder) UpdateArtifactDating(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArtifactDating", reflect.TypeOf((*MockArtifactRepo)(nil).UpdateArtifactDating), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: AttachmentRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentRepo is a mock of AttachmentRepo interface.
+type MockAttachmentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepoMockRecorder
+}
+
+// MockAttachmentRepoMockRecorder is the mock recorder for MockAttachmentRepo.
+type MockAttachmentRepoMockRecorder struct {
+	mock *MockAttachmentRepo
+}
+
+// NewMockAttachmentRepo creates a new mock instance.
+func NewMockAttachmentRepo(ctrl *gomock.Controller) *MockAttachmentRepo {
+	mock := &MockAttachmentRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepo) EXPECT() *MockAttachmentRepoMockRecorder {
+	return m.recorder
+}
+
+// CompleteAttachmentProcessing mocks base method.
+func (m *MockAttachmentRepo) CompleteAttachmentProcessing(arg0 context.Context, arg1 interface{}, arg2 int, arg3 *entity.PhotoMetadata, arg4 entity.Thumbnails, arg5 func(string) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAttachmentProcessing", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteAttachmentProcessing indicates an expected call of CompleteAttachmentProcessing.
+func (mr *MockAttachmentRepoMockRecorder) CompleteAttachmentProcessing(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAttachmentProcessing", reflect.TypeOf((*MockAttachmentRepo)(nil).CompleteAttachmentProcessing), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachmentRepo) CreateAttachment(arg0 context.Context, arg1 interface{}, arg2 *entity.Attachment, arg3 func(string) error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentRepoMockRecorder) CreateAttachment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).CreateAttachment), arg0, arg1, arg2, arg3)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentRepo) DeleteAttachment(arg0 context.Context, arg1 interface{}, arg2 int, arg3 func(string) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentRepoMockRecorder) DeleteAttachment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).DeleteAttachment), arg0, arg1, arg2, arg3)
+}
+
+// FailAttachmentProcessing mocks base method.
+func (m *MockAttachmentRepo) FailAttachmentProcessing(arg0 context.Context, arg1 interface{}, arg2 int, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailAttachmentProcessing", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailAttachmentProcessing indicates an expected call of FailAttachmentProcessing.
+func (mr *MockAttachmentRepoMockRecorder) FailAttachmentProcessing(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailAttachmentProcessing", reflect.TypeOf((*MockAttachmentRepo)(nil).FailAttachmentProcessing), arg0, arg1, arg2, arg3)
+}
+
+// GetAttachmentById mocks base method.
+func (m *MockAttachmentRepo) GetAttachmentById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentById indicates an expected call of GetAttachmentById.
+func (mr *MockAttachmentRepoMockRecorder) GetAttachmentById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentById", reflect.TypeOf((*MockAttachmentRepo)(nil).GetAttachmentById), arg0, arg1, arg2)
+}
+
+// GetAttachmentThumbnails mocks base method.
+func (m *MockAttachmentRepo) GetAttachmentThumbnails(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Thumbnails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentThumbnails", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Thumbnails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentThumbnails indicates an expected call of GetAttachmentThumbnails.
+func (mr *MockAttachmentRepoMockRecorder) GetAttachmentThumbnails(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentThumbnails", reflect.TypeOf((*MockAttachmentRepo)(nil).GetAttachmentThumbnails), arg0, arg1, arg2)
+}
+
+// GetAttachmentsByProcessingStatus mocks base method.
+func (m *MockAttachmentRepo) GetAttachmentsByProcessingStatus(arg0 context.Context, arg1 interface{}, arg2 []string) (entity.Attachments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByProcessingStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Attachments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentsByProcessingStatus indicates an expected call of GetAttachmentsByProcessingStatus.
+func (mr *MockAttachmentRepoMockRecorder) GetAttachmentsByProcessingStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByProcessingStatus", reflect.TypeOf((*MockAttachmentRepo)(nil).GetAttachmentsByProcessingStatus), arg0, arg1, arg2)
+}
+
+// GetParentAttachments mocks base method.
+func (m *MockAttachmentRepo) GetParentAttachments(arg0 context.Context, arg1 interface{}, arg2 string, arg3 int) (entity.Attachments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParentAttachments", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(entity.Attachments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParentAttachments indicates an expected call of GetParentAttachments.
+func (mr *MockAttachmentRepoMockRecorder) GetParentAttachments(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentAttachments", reflect.TypeOf((*MockAttachmentRepo)(nil).GetParentAttachments), arg0, arg1, arg2, arg3)
+}
+
+// StartAttachmentProcessing mocks base method.
+func (m *MockAttachmentRepo) StartAttachmentProcessing(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAttachmentProcessing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartAttachmentProcessing indicates an expected call of StartAttachmentProcessing.
+func (mr *MockAttachmentRepoMockRecorder) StartAttachmentProcessing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAttachmentProcessing", reflect.TypeOf((*MockAttachmentRepo)(nil).StartAttachmentProcessing), arg0, arg1, arg2)
+}
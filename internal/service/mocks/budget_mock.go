@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: BudgetRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBudgetRepo is a mock of BudgetRepo interface.
+type MockBudgetRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetRepoMockRecorder
+}
+
+// MockBudgetRepoMockRecorder is the mock recorder for MockBudgetRepo.
+type MockBudgetRepoMockRecorder struct {
+	mock *MockBudgetRepo
+}
+
+// NewMockBudgetRepo creates a new mock instance.
+func NewMockBudgetRepo(ctrl *gomock.Controller) *MockBudgetRepo {
+	mock := &MockBudgetRepo{ctrl: ctrl}
+	mock.recorder = &MockBudgetRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetRepo) EXPECT() *MockBudgetRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateExchangeRate mocks base method.
+func (m *MockBudgetRepo) CreateExchangeRate(arg0 context.Context, arg1 interface{}, arg2 *entity.ExchangeRate) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExchangeRate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExchangeRate indicates an expected call of CreateExchangeRate.
+func (mr *MockBudgetRepoMockRecorder) CreateExchangeRate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeRate", reflect.TypeOf((*MockBudgetRepo)(nil).CreateExchangeRate), arg0, arg1, arg2)
+}
+
+// CreateExpense mocks base method.
+func (m *MockBudgetRepo) CreateExpense(arg0 context.Context, arg1 interface{}, arg2 *entity.Expense) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExpense", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExpense indicates an expected call of CreateExpense.
+func (mr *MockBudgetRepoMockRecorder) CreateExpense(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExpense", reflect.TypeOf((*MockBudgetRepo)(nil).CreateExpense), arg0, arg1, arg2)
+}
+
+// GetAllExchangeRates mocks base method.
+func (m *MockBudgetRepo) GetAllExchangeRates(arg0 context.Context, arg1 interface{}) (entity.ExchangeRates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllExchangeRates", arg0, arg1)
+	ret0, _ := ret[0].(entity.ExchangeRates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllExchangeRates indicates an expected call of GetAllExchangeRates.
+func (mr *MockBudgetRepoMockRecorder) GetAllExchangeRates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllExchangeRates", reflect.TypeOf((*MockBudgetRepo)(nil).GetAllExchangeRates), arg0, arg1)
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockBudgetRepo) GetExchangeRate(arg0 context.Context, arg1 interface{}, arg2, arg3 string, arg4 time.Time) (*entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockBudgetRepoMockRecorder) GetExchangeRate(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockBudgetRepo)(nil).GetExchangeRate), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetExpeditionBudget mocks base method.
+func (m *MockBudgetRepo) GetExpeditionBudget(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpeditionBudget", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpeditionBudget indicates an expected call of GetExpeditionBudget.
+func (mr *MockBudgetRepoMockRecorder) GetExpeditionBudget(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionBudget", reflect.TypeOf((*MockBudgetRepo)(nil).GetExpeditionBudget), arg0, arg1, arg2)
+}
+
+// GetExpeditionBudgetReport mocks base method.
+func (m *MockBudgetRepo) GetExpeditionBudgetReport(arg0 context.Context, arg1 interface{}, arg2 int) (entity.BudgetReportLines, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpeditionBudgetReport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.BudgetReportLines)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpeditionBudgetReport indicates an expected call of GetExpeditionBudgetReport.
+func (mr *MockBudgetRepoMockRecorder) GetExpeditionBudgetReport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionBudgetReport", reflect.TypeOf((*MockBudgetRepo)(nil).GetExpeditionBudgetReport), arg0, arg1, arg2)
+}
+
+// GetExpeditionExpenses mocks base method.
+func (m *MockBudgetRepo) GetExpeditionExpenses(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Expenses, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpeditionExpenses", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Expenses)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpeditionExpenses indicates an expected call of GetExpeditionExpenses.
+func (mr *MockBudgetRepoMockRecorder) GetExpeditionExpenses(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionExpenses", reflect.TypeOf((*MockBudgetRepo)(nil).GetExpeditionExpenses), arg0, arg1, arg2)
+}
+
+// GetLocationBudgetReport mocks base method.
+func (m *MockBudgetRepo) GetLocationBudgetReport(arg0 context.Context, arg1 interface{}, arg2 int) (entity.BudgetReportLines, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationBudgetReport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.BudgetReportLines)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationBudgetReport indicates an expected call of GetLocationBudgetReport.
+func (mr *MockBudgetRepoMockRecorder) GetLocationBudgetReport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationBudgetReport", reflect.TypeOf((*MockBudgetRepo)(nil).GetLocationBudgetReport), arg0, arg1, arg2)
+}
+
+// SetExpeditionBudget mocks base method.
+func (m *MockBudgetRepo) SetExpeditionBudget(arg0 context.Context, arg1 interface{}, arg2 *entity.Budget) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExpeditionBudget", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExpeditionBudget indicates an expected call of SetExpeditionBudget.
+func (mr *MockBudgetRepoMockRecorder) SetExpeditionBudget(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpeditionBudget", reflect.TypeOf((*MockBudgetRepo)(nil).SetExpeditionBudget), arg0, arg1, arg2)
+}
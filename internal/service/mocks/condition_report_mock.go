@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: ConditionReportRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConditionReportRepo is a mock of ConditionReportRepo interface.
+type MockConditionReportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockConditionReportRepoMockRecorder
+}
+
+// MockConditionReportRepoMockRecorder is the mock recorder for MockConditionReportRepo.
+type MockConditionReportRepoMockRecorder struct {
+	mock *MockConditionReportRepo
+}
+
+// NewMockConditionReportRepo creates a new mock instance.
+func NewMockConditionReportRepo(ctrl *gomock.Controller) *MockConditionReportRepo {
+	mock := &MockConditionReportRepo{ctrl: ctrl}
+	mock.recorder = &MockConditionReportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConditionReportRepo) EXPECT() *MockConditionReportRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateConditionReport mocks base method.
+func (m *MockConditionReportRepo) CreateConditionReport(arg0 context.Context, arg1 interface{}, arg2 *entity.ConditionReport) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConditionReport", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConditionReport indicates an expected call of CreateConditionReport.
+func (mr *MockConditionReportRepoMockRecorder) CreateConditionReport(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConditionReport", reflect.TypeOf((*MockConditionReportRepo)(nil).CreateConditionReport), arg0, arg1, arg2)
+}
+
+// GetArtifactConditionReports mocks base method.
+func (m *MockConditionReportRepo) GetArtifactConditionReports(arg0 context.Context, arg1 interface{}, arg2 int) (entity.ConditionReports, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtifactConditionReports", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.ConditionReports)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactConditionReports indicates an expected call of GetArtifactConditionReports.
+func (mr *MockConditionReportRepoMockRecorder) GetArtifactConditionReports(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactConditionReports", reflect.TypeOf((*MockConditionReportRepo)(nil).GetArtifactConditionReports), arg0, arg1, arg2)
+}
+
+// GetCurrentCondition mocks base method.
+func (m *MockConditionReportRepo) GetCurrentCondition(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.ConditionReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentCondition", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.ConditionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentCondition indicates an expected call of GetCurrentCondition.
+func (mr *MockConditionReportRepoMockRecorder) GetCurrentCondition(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentCondition", reflect.TypeOf((*MockConditionReportRepo)(nil).GetCurrentCondition), arg0, arg1, arg2)
+}
+
+// GetOverdueInspections mocks base method.
+func (m *MockConditionReportRepo) GetOverdueInspections(arg0 context.Context, arg1 interface{}, arg2 time.Time) (entity.OverdueInspections, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueInspections", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.OverdueInspections)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueInspections indicates an expected call of GetOverdueInspections.
+func (mr *MockConditionReportRepoMockRecorder) GetOverdueInspections(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueInspections", reflect.TypeOf((*MockConditionReportRepo)(nil).GetOverdueInspections), arg0, arg1, arg2)
+}
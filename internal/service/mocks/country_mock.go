@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: CountryRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCountryRepo is a mock of CountryRepo interface.
+type MockCountryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCountryRepoMockRecorder
+}
+
+// MockCountryRepoMockRecorder is the mock recorder for MockCountryRepo.
+type MockCountryRepoMockRecorder struct {
+	mock *MockCountryRepo
+}
+
+// NewMockCountryRepo creates a new mock instance.
+func NewMockCountryRepo(ctrl *gomock.Controller) *MockCountryRepo {
+	mock := &MockCountryRepo{ctrl: ctrl}
+	mock.recorder = &MockCountryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCountryRepo) EXPECT() *MockCountryRepoMockRecorder {
+	return m.recorder
+}
+
+// ApplyCountryMappings mocks base method.
+func (m *MockCountryRepo) ApplyCountryMappings(arg0 context.Context, arg1 interface{}, arg2 entity.CountryMappings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCountryMappings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyCountryMappings indicates an expected call of ApplyCountryMappings.
+func (mr *MockCountryRepoMockRecorder) ApplyCountryMappings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCountryMappings", reflect.TypeOf((*MockCountryRepo)(nil).ApplyCountryMappings), arg0, arg1, arg2)
+}
+
+// GetCountryUsages mocks base method.
+func (m *MockCountryRepo) GetCountryUsages(arg0 context.Context, arg1 interface{}) (entity.CountryUsages, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountryUsages", arg0, arg1)
+	ret0, _ := ret[0].(entity.CountryUsages)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountryUsages indicates an expected call of GetCountryUsages.
+func (mr *MockCountryRepoMockRecorder) GetCountryUsages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountryUsages", reflect.TypeOf((*MockCountryRepo)(nil).GetCountryUsages), arg0, arg1)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	entity "db_cp_6/internal/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -94,6 +95,36 @@ func (mr *MockCuratorRepoMockRecorder) GetCuratorById(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCuratorById", reflect.TypeOf((*MockCuratorRepo)(nil).GetCuratorById), arg0, arg1, arg2)
 }
 
+// GetCuratorByLogin mocks base method.
+func (m *MockCuratorRepo) GetCuratorByLogin(arg0 context.Context, arg1 interface{}, arg2 string) (*entity.Curator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCuratorByLogin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Curator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCuratorByLogin indicates an expected call of GetCuratorByLogin.
+func (mr *MockCuratorRepoMockRecorder) GetCuratorByLogin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCuratorByLogin", reflect.TypeOf((*MockCuratorRepo)(nil).GetCuratorByLogin), arg0, arg1, arg2)
+}
+
+// GetCuratorWorkloads mocks base method.
+func (m *MockCuratorRepo) GetCuratorWorkloads(arg0 context.Context, arg1 interface{}, arg2 time.Time) (entity.CuratorWorkloads, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCuratorWorkloads", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.CuratorWorkloads)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCuratorWorkloads indicates an expected call of GetCuratorWorkloads.
+func (mr *MockCuratorRepoMockRecorder) GetCuratorWorkloads(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCuratorWorkloads", reflect.TypeOf((*MockCuratorRepo)(nil).GetCuratorWorkloads), arg0, arg1, arg2)
+}
+
 // GetExpeditionCurators mocks base method.
 func (m *MockCuratorRepo) GetExpeditionCurators(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Curators, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +139,18 @@ func (mr *MockCuratorRepoMockRecorder) GetExpeditionCurators(arg0, arg1, arg2 in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionCurators", reflect.TypeOf((*MockCuratorRepo)(nil).GetExpeditionCurators), arg0, arg1, arg2)
 }
+
+// GetInstitutionCurators mocks base method.
+func (m *MockCuratorRepo) GetInstitutionCurators(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Curators, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstitutionCurators", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Curators)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstitutionCurators indicates an expected call of GetInstitutionCurators.
+func (mr *MockCuratorRepoMockRecorder) GetInstitutionCurators(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstitutionCurators", reflect.TypeOf((*MockCuratorRepo)(nil).GetInstitutionCurators), arg0, arg1, arg2)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: CustodyRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCustodyRepo is a mock of CustodyRepo interface.
+type MockCustodyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustodyRepoMockRecorder
+}
+
+// MockCustodyRepoMockRecorder is the mock recorder for MockCustodyRepo.
+type MockCustodyRepoMockRecorder struct {
+	mock *MockCustodyRepo
+}
+
+// NewMockCustodyRepo creates a new mock instance.
+func NewMockCustodyRepo(ctrl *gomock.Controller) *MockCustodyRepo {
+	mock := &MockCustodyRepo{ctrl: ctrl}
+	mock.recorder = &MockCustodyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustodyRepo) EXPECT() *MockCustodyRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateCustodyRecord mocks base method.
+func (m *MockCustodyRepo) CreateCustodyRecord(arg0 context.Context, arg1 interface{}, arg2 *entity.CustodyRecord) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustodyRecord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustodyRecord indicates an expected call of CreateCustodyRecord.
+func (mr *MockCustodyRepoMockRecorder) CreateCustodyRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustodyRecord", reflect.TypeOf((*MockCustodyRepo)(nil).CreateCustodyRecord), arg0, arg1, arg2)
+}
+
+// GetArtifactCustody mocks base method.
+func (m *MockCustodyRepo) GetArtifactCustody(arg0 context.Context, arg1 interface{}, arg2 int) (entity.CustodyRecords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtifactCustody", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.CustodyRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactCustody indicates an expected call of GetArtifactCustody.
+func (mr *MockCustodyRepoMockRecorder) GetArtifactCustody(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactCustody", reflect.TypeOf((*MockCustodyRepo)(nil).GetArtifactCustody), arg0, arg1, arg2)
+}
+
+// GetCurrentCustody mocks base method.
+func (m *MockCustodyRepo) GetCurrentCustody(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.CustodyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentCustody", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.CustodyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentCustody indicates an expected call of GetCurrentCustody.
+func (mr *MockCustodyRepoMockRecorder) GetCurrentCustody(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentCustody", reflect.TypeOf((*MockCustodyRepo)(nil).GetCurrentCustody), arg0, arg1, arg2)
+}
@@ -50,6 +50,21 @@ func (mr *MockEquipmentRepoMockRecorder) CreateEquipment(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEquipment", reflect.TypeOf((*MockEquipmentRepo)(nil).CreateEquipment), arg0, arg1, arg2)
 }
 
+// CreateEquipmentEvent mocks base method.
+func (m *MockEquipmentRepo) CreateEquipmentEvent(arg0 context.Context, arg1 interface{}, arg2 *entity.EquipmentEvent) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEquipmentEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEquipmentEvent indicates an expected call of CreateEquipmentEvent.
+func (mr *MockEquipmentRepoMockRecorder) CreateEquipmentEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEquipmentEvent", reflect.TypeOf((*MockEquipmentRepo)(nil).CreateEquipmentEvent), arg0, arg1, arg2)
+}
+
 // DeleteEquipment mocks base method.
 func (m *MockEquipmentRepo) DeleteEquipment(arg0 context.Context, arg1 interface{}, arg2 int) error {
 	m.ctrl.T.Helper()
@@ -94,6 +109,36 @@ func (mr *MockEquipmentRepoMockRecorder) GetEquipmentById(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEquipmentById", reflect.TypeOf((*MockEquipmentRepo)(nil).GetEquipmentById), arg0, arg1, arg2)
 }
 
+// GetEquipmentEvents mocks base method.
+func (m *MockEquipmentRepo) GetEquipmentEvents(arg0 context.Context, arg1 interface{}, arg2 int) (entity.EquipmentEvents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEquipmentEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.EquipmentEvents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEquipmentEvents indicates an expected call of GetEquipmentEvents.
+func (mr *MockEquipmentRepoMockRecorder) GetEquipmentEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEquipmentEvents", reflect.TypeOf((*MockEquipmentRepo)(nil).GetEquipmentEvents), arg0, arg1, arg2)
+}
+
+// GetExpeditionEquipmentBalances mocks base method.
+func (m *MockEquipmentRepo) GetExpeditionEquipmentBalances(arg0 context.Context, arg1 interface{}, arg2 int) (entity.EquipmentBalances, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpeditionEquipmentBalances", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.EquipmentBalances)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpeditionEquipmentBalances indicates an expected call of GetExpeditionEquipmentBalances.
+func (mr *MockEquipmentRepoMockRecorder) GetExpeditionEquipmentBalances(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionEquipmentBalances", reflect.TypeOf((*MockEquipmentRepo)(nil).GetExpeditionEquipmentBalances), arg0, arg1, arg2)
+}
+
 // GetExpeditionEquipments mocks base method.
 func (m *MockEquipmentRepo) GetExpeditionEquipments(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Equipments, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: ExcavationContextRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExcavationContextRepo is a mock of ExcavationContextRepo interface.
+type MockExcavationContextRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockExcavationContextRepoMockRecorder
+}
+
+// MockExcavationContextRepoMockRecorder is the mock recorder for MockExcavationContextRepo.
+type MockExcavationContextRepoMockRecorder struct {
+	mock *MockExcavationContextRepo
+}
+
+// NewMockExcavationContextRepo creates a new mock instance.
+func NewMockExcavationContextRepo(ctrl *gomock.Controller) *MockExcavationContextRepo {
+	mock := &MockExcavationContextRepo{ctrl: ctrl}
+	mock.recorder = &MockExcavationContextRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExcavationContextRepo) EXPECT() *MockExcavationContextRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateContext mocks base method.
+func (m *MockExcavationContextRepo) CreateContext(arg0 context.Context, arg1 interface{}, arg2 *entity.ExcavationContext) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContext indicates an expected call of CreateContext.
+func (mr *MockExcavationContextRepoMockRecorder) CreateContext(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContext", reflect.TypeOf((*MockExcavationContextRepo)(nil).CreateContext), arg0, arg1, arg2)
+}
+
+// CreateContextRelation mocks base method.
+func (m *MockExcavationContextRepo) CreateContextRelation(arg0 context.Context, arg1 interface{}, arg2 *entity.ContextRelation) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContextRelation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContextRelation indicates an expected call of CreateContextRelation.
+func (mr *MockExcavationContextRepoMockRecorder) CreateContextRelation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContextRelation", reflect.TypeOf((*MockExcavationContextRepo)(nil).CreateContextRelation), arg0, arg1, arg2)
+}
+
+// DeleteContext mocks base method.
+func (m *MockExcavationContextRepo) DeleteContext(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContext indicates an expected call of DeleteContext.
+func (mr *MockExcavationContextRepoMockRecorder) DeleteContext(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContext", reflect.TypeOf((*MockExcavationContextRepo)(nil).DeleteContext), arg0, arg1, arg2)
+}
+
+// DeleteContextRelation mocks base method.
+func (m *MockExcavationContextRepo) DeleteContextRelation(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContextRelation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContextRelation indicates an expected call of DeleteContextRelation.
+func (mr *MockExcavationContextRepoMockRecorder) DeleteContextRelation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContextRelation", reflect.TypeOf((*MockExcavationContextRepo)(nil).DeleteContextRelation), arg0, arg1, arg2)
+}
+
+// GetContextById mocks base method.
+func (m *MockExcavationContextRepo) GetContextById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.ExcavationContext, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContextById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.ExcavationContext)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContextById indicates an expected call of GetContextById.
+func (mr *MockExcavationContextRepoMockRecorder) GetContextById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContextById", reflect.TypeOf((*MockExcavationContextRepo)(nil).GetContextById), arg0, arg1, arg2)
+}
+
+// GetLocationContextRelations mocks base method.
+func (m *MockExcavationContextRepo) GetLocationContextRelations(arg0 context.Context, arg1 interface{}, arg2 int) (entity.ContextRelations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationContextRelations", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.ContextRelations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationContextRelations indicates an expected call of GetLocationContextRelations.
+func (mr *MockExcavationContextRepoMockRecorder) GetLocationContextRelations(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationContextRelations", reflect.TypeOf((*MockExcavationContextRepo)(nil).GetLocationContextRelations), arg0, arg1, arg2)
+}
+
+// GetLocationContexts mocks base method.
+func (m *MockExcavationContextRepo) GetLocationContexts(arg0 context.Context, arg1 interface{}, arg2 int) (entity.ExcavationContexts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationContexts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.ExcavationContexts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationContexts indicates an expected call of GetLocationContexts.
+func (mr *MockExcavationContextRepoMockRecorder) GetLocationContexts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationContexts", reflect.TypeOf((*MockExcavationContextRepo)(nil).GetLocationContexts), arg0, arg1, arg2)
+}
+
+// GetTrenchContexts mocks base method.
+func (m *MockExcavationContextRepo) GetTrenchContexts(arg0 context.Context, arg1 interface{}, arg2 int) (entity.ExcavationContexts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrenchContexts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.ExcavationContexts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrenchContexts indicates an expected call of GetTrenchContexts.
+func (mr *MockExcavationContextRepoMockRecorder) GetTrenchContexts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrenchContexts", reflect.TypeOf((*MockExcavationContextRepo)(nil).GetTrenchContexts), arg0, arg1, arg2)
+}
@@ -36,6 +36,20 @@ func (m *MockExpeditionRepo) EXPECT() *MockExpeditionRepoMockRecorder {
 	return m.recorder
 }
 
+// CloseExpedition mocks base method.
+func (m *MockExpeditionRepo) CloseExpedition(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseExpedition", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseExpedition indicates an expected call of CloseExpedition.
+func (mr *MockExpeditionRepoMockRecorder) CloseExpedition(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseExpedition", reflect.TypeOf((*MockExpeditionRepo)(nil).CloseExpedition), arg0, arg1, arg2)
+}
+
 // CreateExpedition mocks base method.
 func (m *MockExpeditionRepo) CreateExpedition(arg0 context.Context, arg1 interface{}, arg2 *entity.Expedition) (int, error) {
 	m.ctrl.T.Helper()
@@ -80,21 +94,6 @@ func (mr *MockExpeditionRepoMockRecorder) GetAllExpeditions(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllExpeditions", reflect.TypeOf((*MockExpeditionRepo)(nil).GetAllExpeditions), arg0, arg1)
 }
 
-// GetCuratorExpeditions mocks base method.
-func (m *MockExpeditionRepo) GetCuratorExpeditions(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Expeditions, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCuratorExpeditions", arg0, arg1, arg2)
-	ret0, _ := ret[0].(entity.Expeditions)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetCuratorExpeditions indicates an expected call of GetCuratorExpeditions.
-func (mr *MockExpeditionRepoMockRecorder) GetCuratorExpeditions(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCuratorExpeditions", reflect.TypeOf((*MockExpeditionRepo)(nil).GetCuratorExpeditions), arg0, arg1, arg2)
-}
-
 // GetExpeditionById mocks base method.
 func (m *MockExpeditionRepo) GetExpeditionById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.Expedition, error) {
 	m.ctrl.T.Helper()
@@ -110,36 +109,6 @@ func (mr *MockExpeditionRepoMockRecorder) GetExpeditionById(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionById", reflect.TypeOf((*MockExpeditionRepo)(nil).GetExpeditionById), arg0, arg1, arg2)
 }
 
-// GetLeaderExpeditions mocks base method.
-func (m *MockExpeditionRepo) GetLeaderExpeditions(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Expeditions, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLeaderExpeditions", arg0, arg1, arg2)
-	ret0, _ := ret[0].(entity.Expeditions)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetLeaderExpeditions indicates an expected call of GetLeaderExpeditions.
-func (mr *MockExpeditionRepoMockRecorder) GetLeaderExpeditions(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderExpeditions", reflect.TypeOf((*MockExpeditionRepo)(nil).GetLeaderExpeditions), arg0, arg1, arg2)
-}
-
-// GetMemberExpeditions mocks base method.
-func (m *MockExpeditionRepo) GetMemberExpeditions(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Expeditions, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMemberExpeditions", arg0, arg1, arg2)
-	ret0, _ := ret[0].(entity.Expeditions)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetMemberExpeditions indicates an expected call of GetMemberExpeditions.
-func (mr *MockExpeditionRepoMockRecorder) GetMemberExpeditions(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberExpeditions", reflect.TypeOf((*MockExpeditionRepo)(nil).GetMemberExpeditions), arg0, arg1, arg2)
-}
-
 // UpdateExpeditionDates mocks base method.
 func (m *MockExpeditionRepo) UpdateExpeditionDates(arg0 context.Context, arg1 interface{}, arg2 int, arg3, arg4 time.Time) error {
 	m.ctrl.T.Helper()
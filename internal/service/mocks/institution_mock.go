@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: InstitutionRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInstitutionRepo is a mock of InstitutionRepo interface.
+type MockInstitutionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstitutionRepoMockRecorder
+}
+
+// MockInstitutionRepoMockRecorder is the mock recorder for MockInstitutionRepo.
+type MockInstitutionRepoMockRecorder struct {
+	mock *MockInstitutionRepo
+}
+
+// NewMockInstitutionRepo creates a new mock instance.
+func NewMockInstitutionRepo(ctrl *gomock.Controller) *MockInstitutionRepo {
+	mock := &MockInstitutionRepo{ctrl: ctrl}
+	mock.recorder = &MockInstitutionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstitutionRepo) EXPECT() *MockInstitutionRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateInstitution mocks base method.
+func (m *MockInstitutionRepo) CreateInstitution(arg0 context.Context, arg1 interface{}, arg2 *entity.Institution) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInstitution", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInstitution indicates an expected call of CreateInstitution.
+func (mr *MockInstitutionRepoMockRecorder) CreateInstitution(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstitution", reflect.TypeOf((*MockInstitutionRepo)(nil).CreateInstitution), arg0, arg1, arg2)
+}
+
+// DeleteInstitution mocks base method.
+func (m *MockInstitutionRepo) DeleteInstitution(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInstitution", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInstitution indicates an expected call of DeleteInstitution.
+func (mr *MockInstitutionRepoMockRecorder) DeleteInstitution(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInstitution", reflect.TypeOf((*MockInstitutionRepo)(nil).DeleteInstitution), arg0, arg1, arg2)
+}
+
+// GetAllInstitutions mocks base method.
+func (m *MockInstitutionRepo) GetAllInstitutions(arg0 context.Context, arg1 interface{}) (entity.Institutions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllInstitutions", arg0, arg1)
+	ret0, _ := ret[0].(entity.Institutions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllInstitutions indicates an expected call of GetAllInstitutions.
+func (mr *MockInstitutionRepoMockRecorder) GetAllInstitutions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllInstitutions", reflect.TypeOf((*MockInstitutionRepo)(nil).GetAllInstitutions), arg0, arg1)
+}
+
+// GetInstitutionById mocks base method.
+func (m *MockInstitutionRepo) GetInstitutionById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.Institution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstitutionById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Institution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstitutionById indicates an expected call of GetInstitutionById.
+func (mr *MockInstitutionRepoMockRecorder) GetInstitutionById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstitutionById", reflect.TypeOf((*MockInstitutionRepo)(nil).GetInstitutionById), arg0, arg1, arg2)
+}
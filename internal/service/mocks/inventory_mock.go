@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: InventoryRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInventoryRepo is a mock of InventoryRepo interface.
+type MockInventoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepoMockRecorder
+}
+
+// MockInventoryRepoMockRecorder is the mock recorder for MockInventoryRepo.
+type MockInventoryRepoMockRecorder struct {
+	mock *MockInventoryRepo
+}
+
+// NewMockInventoryRepo creates a new mock instance.
+func NewMockInventoryRepo(ctrl *gomock.Controller) *MockInventoryRepo {
+	mock := &MockInventoryRepo{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepo) EXPECT() *MockInventoryRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateInventoryItem mocks base method.
+func (m *MockInventoryRepo) CreateInventoryItem(arg0 context.Context, arg1 interface{}, arg2 *entity.InventoryItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInventoryItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInventoryItem indicates an expected call of CreateInventoryItem.
+func (mr *MockInventoryRepoMockRecorder) CreateInventoryItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInventoryItem", reflect.TypeOf((*MockInventoryRepo)(nil).CreateInventoryItem), arg0, arg1, arg2)
+}
+
+// DeleteInventoryItem mocks base method.
+func (m *MockInventoryRepo) DeleteInventoryItem(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInventoryItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInventoryItem indicates an expected call of DeleteInventoryItem.
+func (mr *MockInventoryRepoMockRecorder) DeleteInventoryItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInventoryItem", reflect.TypeOf((*MockInventoryRepo)(nil).DeleteInventoryItem), arg0, arg1, arg2)
+}
+
+// GetAllInventoryItems mocks base method.
+func (m *MockInventoryRepo) GetAllInventoryItems(arg0 context.Context, arg1 interface{}) (entity.InventoryItems, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllInventoryItems", arg0, arg1)
+	ret0, _ := ret[0].(entity.InventoryItems)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllInventoryItems indicates an expected call of GetAllInventoryItems.
+func (mr *MockInventoryRepoMockRecorder) GetAllInventoryItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllInventoryItems", reflect.TypeOf((*MockInventoryRepo)(nil).GetAllInventoryItems), arg0, arg1)
+}
+
+// GetInventoryItemById mocks base method.
+func (m *MockInventoryRepo) GetInventoryItemById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.InventoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryItemById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.InventoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryItemById indicates an expected call of GetInventoryItemById.
+func (mr *MockInventoryRepoMockRecorder) GetInventoryItemById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryItemById", reflect.TypeOf((*MockInventoryRepo)(nil).GetInventoryItemById), arg0, arg1, arg2)
+}
+
+// GetItemAvailability mocks base method.
+func (m *MockInventoryRepo) GetItemAvailability(arg0 context.Context, arg1 interface{}, arg2 *entity.AvailabilityFilter) (entity.ItemAvailabilities, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemAvailability", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.ItemAvailabilities)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemAvailability indicates an expected call of GetItemAvailability.
+func (mr *MockInventoryRepoMockRecorder) GetItemAvailability(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemAvailability", reflect.TypeOf((*MockInventoryRepo)(nil).GetItemAvailability), arg0, arg1, arg2)
+}
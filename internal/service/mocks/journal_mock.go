@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: JournalRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockJournalRepo is a mock of JournalRepo interface.
+type MockJournalRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockJournalRepoMockRecorder
+}
+
+// MockJournalRepoMockRecorder is the mock recorder for MockJournalRepo.
+type MockJournalRepoMockRecorder struct {
+	mock *MockJournalRepo
+}
+
+// NewMockJournalRepo creates a new mock instance.
+func NewMockJournalRepo(ctrl *gomock.Controller) *MockJournalRepo {
+	mock := &MockJournalRepo{ctrl: ctrl}
+	mock.recorder = &MockJournalRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJournalRepo) EXPECT() *MockJournalRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateJournalEntry mocks base method.
+func (m *MockJournalRepo) CreateJournalEntry(arg0 context.Context, arg1 interface{}, arg2 *entity.JournalEntry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJournalEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJournalEntry indicates an expected call of CreateJournalEntry.
+func (mr *MockJournalRepoMockRecorder) CreateJournalEntry(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournalEntry", reflect.TypeOf((*MockJournalRepo)(nil).CreateJournalEntry), arg0, arg1, arg2)
+}
+
+// GetExpeditionJournalEntries mocks base method.
+func (m *MockJournalRepo) GetExpeditionJournalEntries(arg0 context.Context, arg1 interface{}, arg2 int) (entity.JournalEntries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpeditionJournalEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.JournalEntries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpeditionJournalEntries indicates an expected call of GetExpeditionJournalEntries.
+func (mr *MockJournalRepoMockRecorder) GetExpeditionJournalEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionJournalEntries", reflect.TypeOf((*MockJournalRepo)(nil).GetExpeditionJournalEntries), arg0, arg1, arg2)
+}
+
+// GetExpeditionJournalLocks mocks base method.
+func (m *MockJournalRepo) GetExpeditionJournalLocks(arg0 context.Context, arg1 interface{}, arg2 int) (entity.JournalDayLocks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpeditionJournalLocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.JournalDayLocks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpeditionJournalLocks indicates an expected call of GetExpeditionJournalLocks.
+func (mr *MockJournalRepoMockRecorder) GetExpeditionJournalLocks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpeditionJournalLocks", reflect.TypeOf((*MockJournalRepo)(nil).GetExpeditionJournalLocks), arg0, arg1, arg2)
+}
+
+// GetJournalEntryById mocks base method.
+func (m *MockJournalRepo) GetJournalEntryById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalEntryById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalEntryById indicates an expected call of GetJournalEntryById.
+func (mr *MockJournalRepoMockRecorder) GetJournalEntryById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalEntryById", reflect.TypeOf((*MockJournalRepo)(nil).GetJournalEntryById), arg0, arg1, arg2)
+}
+
+// LockJournalDay mocks base method.
+func (m *MockJournalRepo) LockJournalDay(arg0 context.Context, arg1 interface{}, arg2 *entity.JournalDayLock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockJournalDay", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockJournalDay indicates an expected call of LockJournalDay.
+func (mr *MockJournalRepoMockRecorder) LockJournalDay(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockJournalDay", reflect.TypeOf((*MockJournalRepo)(nil).LockJournalDay), arg0, arg1, arg2)
+}
+
+// UpdateJournalEntry mocks base method.
+func (m *MockJournalRepo) UpdateJournalEntry(arg0 context.Context, arg1 interface{}, arg2 *entity.JournalEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJournalEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJournalEntry indicates an expected call of UpdateJournalEntry.
+func (mr *MockJournalRepoMockRecorder) UpdateJournalEntry(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJournalEntry", reflect.TypeOf((*MockJournalRepo)(nil).UpdateJournalEntry), arg0, arg1, arg2)
+}
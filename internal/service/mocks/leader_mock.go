@@ -108,3 +108,18 @@ func (mr *MockLeaderRepoMockRecorder) GetLeaderById(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderById", reflect.TypeOf((*MockLeaderRepo)(nil).GetLeaderById), arg0, arg1, arg2)
 }
+
+// GetLeaderByLogin mocks base method.
+func (m *MockLeaderRepo) GetLeaderByLogin(arg0 context.Context, arg1 interface{}, arg2 string) (*entity.Leader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderByLogin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Leader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderByLogin indicates an expected call of GetLeaderByLogin.
+func (mr *MockLeaderRepoMockRecorder) GetLeaderByLogin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderByLogin", reflect.TypeOf((*MockLeaderRepo)(nil).GetLeaderByLogin), arg0, arg1, arg2)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: LoanRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoanRepo is a mock of LoanRepo interface.
+type MockLoanRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRepoMockRecorder
+}
+
+// MockLoanRepoMockRecorder is the mock recorder for MockLoanRepo.
+type MockLoanRepoMockRecorder struct {
+	mock *MockLoanRepo
+}
+
+// NewMockLoanRepo creates a new mock instance.
+func NewMockLoanRepo(ctrl *gomock.Controller) *MockLoanRepo {
+	mock := &MockLoanRepo{ctrl: ctrl}
+	mock.recorder = &MockLoanRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRepo) EXPECT() *MockLoanRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateLoan mocks base method.
+func (m *MockLoanRepo) CreateLoan(arg0 context.Context, arg1 interface{}, arg2 *entity.Loan) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoan", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoan indicates an expected call of CreateLoan.
+func (mr *MockLoanRepoMockRecorder) CreateLoan(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoan", reflect.TypeOf((*MockLoanRepo)(nil).CreateLoan), arg0, arg1, arg2)
+}
+
+// GetAllLoans mocks base method.
+func (m *MockLoanRepo) GetAllLoans(arg0 context.Context, arg1 interface{}) (entity.Loans, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllLoans", arg0, arg1)
+	ret0, _ := ret[0].(entity.Loans)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllLoans indicates an expected call of GetAllLoans.
+func (mr *MockLoanRepoMockRecorder) GetAllLoans(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLoans", reflect.TypeOf((*MockLoanRepo)(nil).GetAllLoans), arg0, arg1)
+}
+
+// GetArtifactLoans mocks base method.
+func (m *MockLoanRepo) GetArtifactLoans(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Loans, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtifactLoans", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Loans)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactLoans indicates an expected call of GetArtifactLoans.
+func (mr *MockLoanRepoMockRecorder) GetArtifactLoans(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactLoans", reflect.TypeOf((*MockLoanRepo)(nil).GetArtifactLoans), arg0, arg1, arg2)
+}
+
+// GetLoanById mocks base method.
+func (m *MockLoanRepo) GetLoanById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanById indicates an expected call of GetLoanById.
+func (mr *MockLoanRepoMockRecorder) GetLoanById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanById", reflect.TypeOf((*MockLoanRepo)(nil).GetLoanById), arg0, arg1, arg2)
+}
+
+// GetOverdueLoans mocks base method.
+func (m *MockLoanRepo) GetOverdueLoans(arg0 context.Context, arg1 interface{}, arg2 time.Time) (entity.OverdueLoans, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueLoans", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.OverdueLoans)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueLoans indicates an expected call of GetOverdueLoans.
+func (mr *MockLoanRepoMockRecorder) GetOverdueLoans(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueLoans", reflect.TypeOf((*MockLoanRepo)(nil).GetOverdueLoans), arg0, arg1, arg2)
+}
+
+// UpdateLoanStatus mocks base method.
+func (m *MockLoanRepo) UpdateLoanStatus(arg0 context.Context, arg1 interface{}, arg2 int, arg3, arg4 string, arg5 *time.Time, arg6 entity.CustodyRecords) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoanStatus", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLoanStatus indicates an expected call of UpdateLoanStatus.
+func (mr *MockLoanRepoMockRecorder) UpdateLoanStatus(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanStatus", reflect.TypeOf((*MockLoanRepo)(nil).UpdateLoanStatus), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
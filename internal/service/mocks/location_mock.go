@@ -65,18 +65,18 @@ func (mr *MockLocationRepoMockRecorder) DeleteLocation(arg0, arg1, arg2 interfac
 }
 
 // GetAllLocations mocks base method.
-func (m *MockLocationRepo) GetAllLocations(arg0 context.Context, arg1 interface{}) (entity.Locations, error) {
+func (m *MockLocationRepo) GetAllLocations(arg0 context.Context, arg1 interface{}, arg2 *entity.LocationFilter) (entity.Locations, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllLocations", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAllLocations", arg0, arg1, arg2)
 	ret0, _ := ret[0].(entity.Locations)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllLocations indicates an expected call of GetAllLocations.
-func (mr *MockLocationRepoMockRecorder) GetAllLocations(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockLocationRepoMockRecorder) GetAllLocations(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLocations", reflect.TypeOf((*MockLocationRepo)(nil).GetAllLocations), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLocations", reflect.TypeOf((*MockLocationRepo)(nil).GetAllLocations), arg0, arg1, arg2)
 }
 
 // GetLocationById mocks base method.
@@ -93,3 +93,33 @@ func (mr *MockLocationRepoMockRecorder) GetLocationById(arg0, arg1, arg2 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationById", reflect.TypeOf((*MockLocationRepo)(nil).GetLocationById), arg0, arg1, arg2)
 }
+
+// GetNearbyLocations mocks base method.
+func (m *MockLocationRepo) GetNearbyLocations(arg0 context.Context, arg1 interface{}, arg2 *entity.NearbyQuery) (entity.NearbyLocations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyLocations", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.NearbyLocations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyLocations indicates an expected call of GetNearbyLocations.
+func (mr *MockLocationRepoMockRecorder) GetNearbyLocations(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyLocations", reflect.TypeOf((*MockLocationRepo)(nil).GetNearbyLocations), arg0, arg1, arg2)
+}
+
+// GetSiteSummaries mocks base method.
+func (m *MockLocationRepo) GetSiteSummaries(arg0 context.Context, arg1 interface{}, arg2 *entity.ExportFilter) (entity.SiteSummaries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteSummaries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.SiteSummaries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteSummaries indicates an expected call of GetSiteSummaries.
+func (mr *MockLocationRepoMockRecorder) GetSiteSummaries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSummaries", reflect.TypeOf((*MockLocationRepo)(nil).GetSiteSummaries), arg0, arg1, arg2)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: MaintenanceRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMaintenanceRepo is a mock of MaintenanceRepo interface.
+type MockMaintenanceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceRepoMockRecorder
+}
+
+// MockMaintenanceRepoMockRecorder is the mock recorder for MockMaintenanceRepo.
+type MockMaintenanceRepoMockRecorder struct {
+	mock *MockMaintenanceRepo
+}
+
+// NewMockMaintenanceRepo creates a new mock instance.
+func NewMockMaintenanceRepo(ctrl *gomock.Controller) *MockMaintenanceRepo {
+	mock := &MockMaintenanceRepo{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceRepo) EXPECT() *MockMaintenanceRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateMaintenanceLog mocks base method.
+func (m *MockMaintenanceRepo) CreateMaintenanceLog(arg0 context.Context, arg1 interface{}, arg2 *entity.MaintenanceLog) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMaintenanceLog", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMaintenanceLog indicates an expected call of CreateMaintenanceLog.
+func (mr *MockMaintenanceRepoMockRecorder) CreateMaintenanceLog(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMaintenanceLog", reflect.TypeOf((*MockMaintenanceRepo)(nil).CreateMaintenanceLog), arg0, arg1, arg2)
+}
+
+// GetItemMaintenanceLogs mocks base method.
+func (m *MockMaintenanceRepo) GetItemMaintenanceLogs(arg0 context.Context, arg1 interface{}, arg2 int) (entity.MaintenanceLogs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemMaintenanceLogs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.MaintenanceLogs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemMaintenanceLogs indicates an expected call of GetItemMaintenanceLogs.
+func (mr *MockMaintenanceRepoMockRecorder) GetItemMaintenanceLogs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemMaintenanceLogs", reflect.TypeOf((*MockMaintenanceRepo)(nil).GetItemMaintenanceLogs), arg0, arg1, arg2)
+}
+
+// GetMaintenanceDue mocks base method.
+func (m *MockMaintenanceRepo) GetMaintenanceDue(arg0 context.Context, arg1 interface{}, arg2 int) (entity.MaintenanceDues, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaintenanceDue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.MaintenanceDues)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaintenanceDue indicates an expected call of GetMaintenanceDue.
+func (mr *MockMaintenanceRepoMockRecorder) GetMaintenanceDue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaintenanceDue", reflect.TypeOf((*MockMaintenanceRepo)(nil).GetMaintenanceDue), arg0, arg1, arg2)
+}
+
+// UpdateMaintenanceSchedule mocks base method.
+func (m *MockMaintenanceRepo) UpdateMaintenanceSchedule(arg0 context.Context, arg1 interface{}, arg2 int, arg3, arg4 *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMaintenanceSchedule", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMaintenanceSchedule indicates an expected call of UpdateMaintenanceSchedule.
+func (mr *MockMaintenanceRepoMockRecorder) UpdateMaintenanceSchedule(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMaintenanceSchedule", reflect.TypeOf((*MockMaintenanceRepo)(nil).UpdateMaintenanceSchedule), arg0, arg1, arg2, arg3, arg4)
+}
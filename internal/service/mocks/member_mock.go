@@ -108,3 +108,18 @@ func (mr *MockMemberRepoMockRecorder) GetMemberById(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberById", reflect.TypeOf((*MockMemberRepo)(nil).GetMemberById), arg0, arg1, arg2)
 }
+
+// GetMemberByLogin mocks base method.
+func (m *MockMemberRepo) GetMemberByLogin(arg0 context.Context, arg1 interface{}, arg2 string) (*entity.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberByLogin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberByLogin indicates an expected call of GetMemberByLogin.
+func (mr *MockMemberRepoMockRecorder) GetMemberByLogin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberByLogin", reflect.TypeOf((*MockMemberRepo)(nil).GetMemberByLogin), arg0, arg1, arg2)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: MergeRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMergeRepo is a mock of MergeRepo interface.
+type MockMergeRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMergeRepoMockRecorder
+}
+
+// MockMergeRepoMockRecorder is the mock recorder for MockMergeRepo.
+type MockMergeRepoMockRecorder struct {
+	mock *MockMergeRepo
+}
+
+// NewMockMergeRepo creates a new mock instance.
+func NewMockMergeRepo(ctrl *gomock.Controller) *MockMergeRepo {
+	mock := &MockMergeRepo{ctrl: ctrl}
+	mock.recorder = &MockMergeRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMergeRepo) EXPECT() *MockMergeRepoMockRecorder {
+	return m.recorder
+}
+
+// GetDuplicateCandidates mocks base method.
+func (m *MockMergeRepo) GetDuplicateCandidates(arg0 context.Context, arg1 interface{}, arg2 string, arg3 float64, arg4 int) (entity.DuplicateCandidates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicateCandidates", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(entity.DuplicateCandidates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicateCandidates indicates an expected call of GetDuplicateCandidates.
+func (mr *MockMergeRepoMockRecorder) GetDuplicateCandidates(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicateCandidates", reflect.TypeOf((*MockMergeRepo)(nil).GetDuplicateCandidates), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetMergeHistory mocks base method.
+func (m *MockMergeRepo) GetMergeHistory(arg0 context.Context, arg1 interface{}, arg2 string) (entity.MergeRecords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergeHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.MergeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergeHistory indicates an expected call of GetMergeHistory.
+func (mr *MockMergeRepoMockRecorder) GetMergeHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergeHistory", reflect.TypeOf((*MockMergeRepo)(nil).GetMergeHistory), arg0, arg1, arg2)
+}
+
+// Merge mocks base method.
+func (m *MockMergeRepo) Merge(arg0 context.Context, arg1 interface{}, arg2 *entity.MergeRecord) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockMergeRepoMockRecorder) Merge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockMergeRepo)(nil).Merge), arg0, arg1, arg2)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: PackingRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPackingRepo is a mock of PackingRepo interface.
+type MockPackingRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPackingRepoMockRecorder
+}
+
+// MockPackingRepoMockRecorder is the mock recorder for MockPackingRepo.
+type MockPackingRepoMockRecorder struct {
+	mock *MockPackingRepo
+}
+
+// NewMockPackingRepo creates a new mock instance.
+func NewMockPackingRepo(ctrl *gomock.Controller) *MockPackingRepo {
+	mock := &MockPackingRepo{ctrl: ctrl}
+	mock.recorder = &MockPackingRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPackingRepo) EXPECT() *MockPackingRepoMockRecorder {
+	return m.recorder
+}
+
+// ApplyPackingTemplate mocks base method.
+func (m *MockPackingRepo) ApplyPackingTemplate(arg0 context.Context, arg1 interface{}, arg2, arg3 int, arg4 entity.Equipments) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPackingTemplate", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyPackingTemplate indicates an expected call of ApplyPackingTemplate.
+func (mr *MockPackingRepoMockRecorder) ApplyPackingTemplate(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPackingTemplate", reflect.TypeOf((*MockPackingRepo)(nil).ApplyPackingTemplate), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreatePackingTemplate mocks base method.
+func (m *MockPackingRepo) CreatePackingTemplate(arg0 context.Context, arg1 interface{}, arg2 *entity.PackingTemplate) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePackingTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePackingTemplate indicates an expected call of CreatePackingTemplate.
+func (mr *MockPackingRepoMockRecorder) CreatePackingTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePackingTemplate", reflect.TypeOf((*MockPackingRepo)(nil).CreatePackingTemplate), arg0, arg1, arg2)
+}
+
+// DeletePackingTemplate mocks base method.
+func (m *MockPackingRepo) DeletePackingTemplate(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePackingTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePackingTemplate indicates an expected call of DeletePackingTemplate.
+func (mr *MockPackingRepoMockRecorder) DeletePackingTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePackingTemplate", reflect.TypeOf((*MockPackingRepo)(nil).DeletePackingTemplate), arg0, arg1, arg2)
+}
+
+// GetAllPackingTemplates mocks base method.
+func (m *MockPackingRepo) GetAllPackingTemplates(arg0 context.Context, arg1 interface{}) (entity.PackingTemplates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPackingTemplates", arg0, arg1)
+	ret0, _ := ret[0].(entity.PackingTemplates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPackingTemplates indicates an expected call of GetAllPackingTemplates.
+func (mr *MockPackingRepoMockRecorder) GetAllPackingTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPackingTemplates", reflect.TypeOf((*MockPackingRepo)(nil).GetAllPackingTemplates), arg0, arg1)
+}
+
+// GetPackingListReservations mocks base method.
+func (m *MockPackingRepo) GetPackingListReservations(arg0 context.Context, arg1 interface{}, arg2, arg3 int) (entity.PackingListLines, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPackingListReservations", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(entity.PackingListLines)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPackingListReservations indicates an expected call of GetPackingListReservations.
+func (mr *MockPackingRepoMockRecorder) GetPackingListReservations(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackingListReservations", reflect.TypeOf((*MockPackingRepo)(nil).GetPackingListReservations), arg0, arg1, arg2, arg3)
+}
+
+// GetPackingTemplateById mocks base method.
+func (m *MockPackingRepo) GetPackingTemplateById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.PackingTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPackingTemplateById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.PackingTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPackingTemplateById indicates an expected call of GetPackingTemplateById.
+func (mr *MockPackingRepoMockRecorder) GetPackingTemplateById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackingTemplateById", reflect.TypeOf((*MockPackingRepo)(nil).GetPackingTemplateById), arg0, arg1, arg2)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: PeriodRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPeriodRepo is a mock of PeriodRepo interface.
+type MockPeriodRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeriodRepoMockRecorder
+}
+
+// MockPeriodRepoMockRecorder is the mock recorder for MockPeriodRepo.
+type MockPeriodRepoMockRecorder struct {
+	mock *MockPeriodRepo
+}
+
+// NewMockPeriodRepo creates a new mock instance.
+func NewMockPeriodRepo(ctrl *gomock.Controller) *MockPeriodRepo {
+	mock := &MockPeriodRepo{ctrl: ctrl}
+	mock.recorder = &MockPeriodRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeriodRepo) EXPECT() *MockPeriodRepoMockRecorder {
+	return m.recorder
+}
+
+// CreatePeriod mocks base method.
+func (m *MockPeriodRepo) CreatePeriod(arg0 context.Context, arg1 interface{}, arg2 *entity.Period) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePeriod", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePeriod indicates an expected call of CreatePeriod.
+func (mr *MockPeriodRepoMockRecorder) CreatePeriod(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePeriod", reflect.TypeOf((*MockPeriodRepo)(nil).CreatePeriod), arg0, arg1, arg2)
+}
+
+// DeletePeriod mocks base method.
+func (m *MockPeriodRepo) DeletePeriod(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePeriod", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePeriod indicates an expected call of DeletePeriod.
+func (mr *MockPeriodRepoMockRecorder) DeletePeriod(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePeriod", reflect.TypeOf((*MockPeriodRepo)(nil).DeletePeriod), arg0, arg1, arg2)
+}
+
+// GetAllPeriods mocks base method.
+func (m *MockPeriodRepo) GetAllPeriods(arg0 context.Context, arg1 interface{}) (entity.Periods, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPeriods", arg0, arg1)
+	ret0, _ := ret[0].(entity.Periods)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPeriods indicates an expected call of GetAllPeriods.
+func (mr *MockPeriodRepoMockRecorder) GetAllPeriods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPeriods", reflect.TypeOf((*MockPeriodRepo)(nil).GetAllPeriods), arg0, arg1)
+}
+
+// GetPeriodById mocks base method.
+func (m *MockPeriodRepo) GetPeriodById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.Period, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeriodById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Period)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeriodById indicates an expected call of GetPeriodById.
+func (mr *MockPeriodRepoMockRecorder) GetPeriodById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeriodById", reflect.TypeOf((*MockPeriodRepo)(nil).GetPeriodById), arg0, arg1, arg2)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: SampleRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSampleRepo is a mock of SampleRepo interface.
+type MockSampleRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSampleRepoMockRecorder
+}
+
+// MockSampleRepoMockRecorder is the mock recorder for MockSampleRepo.
+type MockSampleRepoMockRecorder struct {
+	mock *MockSampleRepo
+}
+
+// NewMockSampleRepo creates a new mock instance.
+func NewMockSampleRepo(ctrl *gomock.Controller) *MockSampleRepo {
+	mock := &MockSampleRepo{ctrl: ctrl}
+	mock.recorder = &MockSampleRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSampleRepo) EXPECT() *MockSampleRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateSample mocks base method.
+func (m *MockSampleRepo) CreateSample(arg0 context.Context, arg1 interface{}, arg2 *entity.Sample) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSample", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSample indicates an expected call of CreateSample.
+func (mr *MockSampleRepoMockRecorder) CreateSample(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSample", reflect.TypeOf((*MockSampleRepo)(nil).CreateSample), arg0, arg1, arg2)
+}
+
+// DeleteSample mocks base method.
+func (m *MockSampleRepo) DeleteSample(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSample", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSample indicates an expected call of DeleteSample.
+func (mr *MockSampleRepoMockRecorder) DeleteSample(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSample", reflect.TypeOf((*MockSampleRepo)(nil).DeleteSample), arg0, arg1, arg2)
+}
+
+// GetAllSamples mocks base method.
+func (m *MockSampleRepo) GetAllSamples(arg0 context.Context, arg1 interface{}, arg2 *entity.SampleFilter) (entity.Samples, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSamples", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Samples)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSamples indicates an expected call of GetAllSamples.
+func (mr *MockSampleRepoMockRecorder) GetAllSamples(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSamples", reflect.TypeOf((*MockSampleRepo)(nil).GetAllSamples), arg0, arg1, arg2)
+}
+
+// GetSampleById mocks base method.
+func (m *MockSampleRepo) GetSampleById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.Sample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSampleById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Sample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSampleById indicates an expected call of GetSampleById.
+func (mr *MockSampleRepoMockRecorder) GetSampleById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSampleById", reflect.TypeOf((*MockSampleRepo)(nil).GetSampleById), arg0, arg1, arg2)
+}
+
+// UpdateSample mocks base method.
+func (m *MockSampleRepo) UpdateSample(arg0 context.Context, arg1 interface{}, arg2 *entity.Sample) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSample", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSample indicates an expected call of UpdateSample.
+func (mr *MockSampleRepoMockRecorder) UpdateSample(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSample", reflect.TypeOf((*MockSampleRepo)(nil).UpdateSample), arg0, arg1, arg2)
+}
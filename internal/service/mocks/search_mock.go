@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: SearchRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchRepo is a mock of SearchRepo interface.
+type MockSearchRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchRepoMockRecorder
+}
+
+// MockSearchRepoMockRecorder is the mock recorder for MockSearchRepo.
+type MockSearchRepoMockRecorder struct {
+	mock *MockSearchRepo
+}
+
+// NewMockSearchRepo creates a new mock instance.
+func NewMockSearchRepo(ctrl *gomock.Controller) *MockSearchRepo {
+	mock := &MockSearchRepo{ctrl: ctrl}
+	mock.recorder = &MockSearchRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchRepo) EXPECT() *MockSearchRepoMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchRepo) Search(arg0 context.Context, arg1 interface{}, arg2 *entity.SearchScope) (entity.SearchHits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.SearchHits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchRepoMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchRepo)(nil).Search), arg0, arg1, arg2)
+}
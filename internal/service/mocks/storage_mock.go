@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: StorageRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStorageRepo is a mock of StorageRepo interface.
+type MockStorageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageRepoMockRecorder
+}
+
+// MockStorageRepoMockRecorder is the mock recorder for MockStorageRepo.
+type MockStorageRepoMockRecorder struct {
+	mock *MockStorageRepo
+}
+
+// NewMockStorageRepo creates a new mock instance.
+func NewMockStorageRepo(ctrl *gomock.Controller) *MockStorageRepo {
+	mock := &MockStorageRepo{ctrl: ctrl}
+	mock.recorder = &MockStorageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageRepo) EXPECT() *MockStorageRepoMockRecorder {
+	return m.recorder
+}
+
+// CountStorageNodeArtifacts mocks base method.
+func (m *MockStorageRepo) CountStorageNodeArtifacts(arg0 context.Context, arg1 interface{}, arg2 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStorageNodeArtifacts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStorageNodeArtifacts indicates an expected call of CountStorageNodeArtifacts.
+func (mr *MockStorageRepoMockRecorder) CountStorageNodeArtifacts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStorageNodeArtifacts", reflect.TypeOf((*MockStorageRepo)(nil).CountStorageNodeArtifacts), arg0, arg1, arg2)
+}
+
+// CreateStorageNode mocks base method.
+func (m *MockStorageRepo) CreateStorageNode(arg0 context.Context, arg1 interface{}, arg2 *entity.StorageNode) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStorageNode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStorageNode indicates an expected call of CreateStorageNode.
+func (mr *MockStorageRepoMockRecorder) CreateStorageNode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStorageNode", reflect.TypeOf((*MockStorageRepo)(nil).CreateStorageNode), arg0, arg1, arg2)
+}
+
+// DeleteStorageNode mocks base method.
+func (m *MockStorageRepo) DeleteStorageNode(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStorageNode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStorageNode indicates an expected call of DeleteStorageNode.
+func (mr *MockStorageRepoMockRecorder) DeleteStorageNode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStorageNode", reflect.TypeOf((*MockStorageRepo)(nil).DeleteStorageNode), arg0, arg1, arg2)
+}
+
+// GetAllStorageNodes mocks base method.
+func (m *MockStorageRepo) GetAllStorageNodes(arg0 context.Context, arg1 interface{}) (entity.StorageNodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllStorageNodes", arg0, arg1)
+	ret0, _ := ret[0].(entity.StorageNodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllStorageNodes indicates an expected call of GetAllStorageNodes.
+func (mr *MockStorageRepoMockRecorder) GetAllStorageNodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStorageNodes", reflect.TypeOf((*MockStorageRepo)(nil).GetAllStorageNodes), arg0, arg1)
+}
+
+// GetArtifactStorageMoves mocks base method.
+func (m *MockStorageRepo) GetArtifactStorageMoves(arg0 context.Context, arg1 interface{}, arg2 int) (entity.StorageMoves, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtifactStorageMoves", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.StorageMoves)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactStorageMoves indicates an expected call of GetArtifactStorageMoves.
+func (mr *MockStorageRepoMockRecorder) GetArtifactStorageMoves(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactStorageMoves", reflect.TypeOf((*MockStorageRepo)(nil).GetArtifactStorageMoves), arg0, arg1, arg2)
+}
+
+// GetPickList mocks base method.
+func (m *MockStorageRepo) GetPickList(arg0 context.Context, arg1 interface{}, arg2 []int) (entity.StoredArtifacts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPickList", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.StoredArtifacts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPickList indicates an expected call of GetPickList.
+func (mr *MockStorageRepoMockRecorder) GetPickList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPickList", reflect.TypeOf((*MockStorageRepo)(nil).GetPickList), arg0, arg1, arg2)
+}
+
+// GetStorageContents mocks base method.
+func (m *MockStorageRepo) GetStorageContents(arg0 context.Context, arg1 interface{}, arg2 int) (entity.StoredArtifacts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageContents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.StoredArtifacts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageContents indicates an expected call of GetStorageContents.
+func (mr *MockStorageRepoMockRecorder) GetStorageContents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageContents", reflect.TypeOf((*MockStorageRepo)(nil).GetStorageContents), arg0, arg1, arg2)
+}
+
+// GetStorageNodeById mocks base method.
+func (m *MockStorageRepo) GetStorageNodeById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.StorageNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageNodeById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.StorageNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageNodeById indicates an expected call of GetStorageNodeById.
+func (mr *MockStorageRepoMockRecorder) GetStorageNodeById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageNodeById", reflect.TypeOf((*MockStorageRepo)(nil).GetStorageNodeById), arg0, arg1, arg2)
+}
+
+// MoveArtifact mocks base method.
+func (m *MockStorageRepo) MoveArtifact(arg0 context.Context, arg1 interface{}, arg2 *entity.StorageMove) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveArtifact", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveArtifact indicates an expected call of MoveArtifact.
+func (mr *MockStorageRepoMockRecorder) MoveArtifact(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveArtifact", reflect.TypeOf((*MockStorageRepo)(nil).MoveArtifact), arg0, arg1, arg2)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: db_cp_6/internal/repo (interfaces: TrenchRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	entity "db_cp_6/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTrenchRepo is a mock of TrenchRepo interface.
+type MockTrenchRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrenchRepoMockRecorder
+}
+
+// MockTrenchRepoMockRecorder is the mock recorder for MockTrenchRepo.
+type MockTrenchRepoMockRecorder struct {
+	mock *MockTrenchRepo
+}
+
+// NewMockTrenchRepo creates a new mock instance.
+func NewMockTrenchRepo(ctrl *gomock.Controller) *MockTrenchRepo {
+	mock := &MockTrenchRepo{ctrl: ctrl}
+	mock.recorder = &MockTrenchRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrenchRepo) EXPECT() *MockTrenchRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateTrench mocks base method.
+func (m *MockTrenchRepo) CreateTrench(arg0 context.Context, arg1 interface{}, arg2 *entity.Trench) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrench", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrench indicates an expected call of CreateTrench.
+func (mr *MockTrenchRepoMockRecorder) CreateTrench(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrench", reflect.TypeOf((*MockTrenchRepo)(nil).CreateTrench), arg0, arg1, arg2)
+}
+
+// DeleteTrench mocks base method.
+func (m *MockTrenchRepo) DeleteTrench(arg0 context.Context, arg1 interface{}, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrench", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrench indicates an expected call of DeleteTrench.
+func (mr *MockTrenchRepoMockRecorder) DeleteTrench(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrench", reflect.TypeOf((*MockTrenchRepo)(nil).DeleteTrench), arg0, arg1, arg2)
+}
+
+// GetLocationTrenches mocks base method.
+func (m *MockTrenchRepo) GetLocationTrenches(arg0 context.Context, arg1 interface{}, arg2 int) (entity.Trenches, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationTrenches", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.Trenches)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationTrenches indicates an expected call of GetLocationTrenches.
+func (mr *MockTrenchRepoMockRecorder) GetLocationTrenches(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationTrenches", reflect.TypeOf((*MockTrenchRepo)(nil).GetLocationTrenches), arg0, arg1, arg2)
+}
+
+// GetTrenchById mocks base method.
+func (m *MockTrenchRepo) GetTrenchById(arg0 context.Context, arg1 interface{}, arg2 int) (*entity.Trench, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrenchById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Trench)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrenchById indicates an expected call of GetTrenchById.
+func (mr *MockTrenchRepoMockRecorder) GetTrenchById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrenchById", reflect.TypeOf((*MockTrenchRepo)(nil).GetTrenchById), arg0, arg1, arg2)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+)
+
+type PackingService struct {
+	packingRepo    repo.PackingRepo
+	expeditionRepo repo.ExpeditionRepo
+	memberRepo     repo.MemberRepo
+	inventoryRepo  repo.InventoryRepo
+}
+
+func NewPackingService(packingRepo repo.PackingRepo, expeditionRepo repo.ExpeditionRepo, memberRepo repo.MemberRepo, inventoryRepo repo.InventoryRepo) *PackingService {
+	return &PackingService{
+		packingRepo:    packingRepo,
+		expeditionRepo: expeditionRepo,
+		memberRepo:     memberRepo,
+		inventoryRepo:  inventoryRepo,
+	}
+}
+
+func (s *PackingService) GetPackingTemplateById(ctx context.Context, client any, id int) (*entity.PackingTemplate, error) {
+	template, err := s.packingRepo.GetPackingTemplateById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrPackingTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *PackingService) GetAllPackingTemplates(ctx context.Context, client any) (entity.PackingTemplates, error) {
+	return s.packingRepo.GetAllPackingTemplates(ctx, client)
+}
+
+func (s *PackingService) CreatePackingTemplate(ctx context.Context, client any, input *entity.CreatePackingTemplateInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	template := &entity.PackingTemplate{
+		Name:  input.Name,
+		Items: input.Items,
+	}
+	id, err := s.packingRepo.CreatePackingTemplate(ctx, client, template)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return 0, ErrPackingTemplateAlreadyExists
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return 0, ErrInventoryItemNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *PackingService) DeletePackingTemplate(ctx context.Context, client any, id int) error {
+	err := s.packingRepo.DeletePackingTemplate(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrPackingTemplateNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *PackingService) ApplyPackingTemplate(ctx context.Context, client any, expeditionId int, input *entity.ApplyPackingTemplateInput) error {
+	expedition, template, members, err := s.getPackingContext(ctx, client, expeditionId, input.TemplateId)
+	if err != nil {
+		return err
+	}
+	if expedition.ClosedOn != nil {
+		return ErrExpeditionClosed
+	}
+
+	availability, err := s.inventoryRepo.GetItemAvailability(ctx, client, &entity.AvailabilityFilter{
+		From: expedition.StartDate,
+		To:   expedition.EndDate,
+	})
+	if err != nil {
+		return err
+	}
+	available := make(map[int]*entity.ItemAvailability, len(availability))
+	for _, a := range availability {
+		available[a.ItemId] = a
+	}
+
+	days := expeditionDays(expedition)
+	equipments := make(entity.Equipments, 0, len(template.Items))
+	for _, item := range template.Items {
+		amount := item.Quantity(members, days)
+		if amount == 0 {
+			continue
+		}
+
+		a, ok := available[item.ItemId]
+		switch {
+		case !ok:
+			return ErrInventoryItemNotFound
+		case !a.Serviceable:
+			return ErrInventoryItemUnserviceable
+		case a.Available < amount:
+			return ErrEquipmentOverbooked
+		}
+
+		equipments = append(equipments, &entity.Equipment{
+			ExpeditionId: expeditionId,
+			ItemId:       item.ItemId,
+			Amount:       amount,
+			ReservedFrom: expedition.StartDate,
+			ReservedTo:   expedition.EndDate,
+		})
+	}
+
+	err = s.packingRepo.ApplyPackingTemplate(ctx, client, expeditionId, template.Id, equipments)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return ErrPackingListAlreadyApplied
+		case errors.Is(err, repoerrs.ErrConflict):
+			return ErrEquipmentOverbooked
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return ErrPackingTemplateNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *PackingService) GetPackingListDiff(ctx context.Context, client any, expeditionId int, templateId int) (*entity.PackingListDiff, error) {
+	expedition, template, members, err := s.getPackingContext(ctx, client, expeditionId, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := s.packingRepo.GetPackingListReservations(ctx, client, expeditionId, templateId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrPackingListNotApplied
+		}
+		return nil, err
+	}
+
+	days := expeditionDays(expedition)
+	required := make(map[int]int, len(template.Items))
+	for _, item := range template.Items {
+		required[item.ItemId] = item.Quantity(members, days)
+	}
+	for _, line := range lines {
+		line.Required = required[line.ItemId]
+		line.Delta = line.Required - line.Reserved
+	}
+
+	return &entity.PackingListDiff{
+		ExpeditionId: expeditionId,
+		TemplateId:   templateId,
+		Members:      members,
+		Days:         days,
+		Lines:        lines,
+	}, nil
+}
+
+func (s *PackingService) getPackingContext(ctx context.Context, client any, expeditionId int, templateId int) (*entity.Expedition, *entity.PackingTemplate, int, error) {
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, expeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, nil, 0, ErrExpeditionNotFound
+		}
+		return nil, nil, 0, err
+	}
+
+	template, err := s.packingRepo.GetPackingTemplateById(ctx, client, templateId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, nil, 0, ErrPackingTemplateNotFound
+		}
+		return nil, nil, 0, err
+	}
+
+	members, err := s.memberRepo.GetExpeditionMembers(ctx, client, expeditionId)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return expedition, template, len(members), nil
+}
+
+func expeditionDays(expedition *entity.Expedition) int {
+	return int(expedition.EndDate.Sub(expedition.StartDate).Hours()/24) + 1
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPackingService_CreatePackingTemplate(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		input  *entity.CreatePackingTemplateInput
+	}
+
+	type MockBehavior func(m *mocks.MockPackingRepo, args args)
+
+	items := entity.PackingTemplateItems{
+		{ItemId: 1, PerPersonDay: 3},
+		{ItemId: 2, BaseQuantity: 1, PerPerson: 0.25},
+	}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input:  &entity.CreatePackingTemplateInput{Name: "survey", Items: items},
+			},
+			mockBehavior: func(m *mocks.MockPackingRepo, args args) {
+				m.EXPECT().CreatePackingTemplate(args.ctx, args.client, &entity.PackingTemplate{Name: "survey", Items: items}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "duplicate item error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreatePackingTemplateInput{Name: "survey", Items: entity.PackingTemplateItems{
+					{ItemId: 1, PerDay: 1},
+					{ItemId: 1, PerPerson: 1},
+				}},
+			},
+			mockBehavior: func(m *mocks.MockPackingRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+		{
+			name: "empty formula error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input:  &entity.CreatePackingTemplateInput{Name: "survey", Items: entity.PackingTemplateItems{{ItemId: 1}}},
+			},
+			mockBehavior: func(m *mocks.MockPackingRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+		{
+			name: "template already exists error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input:  &entity.CreatePackingTemplateInput{Name: "survey", Items: items},
+			},
+			mockBehavior: func(m *mocks.MockPackingRepo, args args) {
+				m.EXPECT().CreatePackingTemplate(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrAlreadyExists)
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			packingRepo := mocks.NewMockPackingRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			tc.mockBehavior(packingRepo, tc.args)
+
+			// init service
+			s := NewPackingService(packingRepo, expeditionRepo, memberRepo, inventoryRepo)
+
+			// run test
+			got, err := s.CreatePackingTemplate(tc.args.ctx, tc.args.client, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPackingService_ApplyPackingTemplate(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		expeditionId int
+		input        *entity.ApplyPackingTemplateInput
+	}
+
+	type MockBehavior func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, ir *mocks.MockInventoryRepo, args args)
+
+	start, _ := time.Parse("2006-01-02", "2024-07-01")
+	end, _ := time.Parse("2006-01-02", "2024-07-10")
+	expedition := &entity.Expedition{Id: 1, LocationId: 1, StartDate: start, EndDate: end}
+	closed := &entity.Expedition{Id: 1, LocationId: 1, StartDate: start, EndDate: end, ClosedOn: &end}
+	template := &entity.PackingTemplate{Id: 2, Name: "survey", Items: entity.PackingTemplateItems{
+		{ItemId: 1, Name: "water", PerPersonDay: 3},
+		{ItemId: 2, Name: "tent", PerPerson: 0.5},
+		{ItemId: 3, Name: "generator", BaseQuantity: 0, PerDay: 0},
+	}}
+	members := entity.Members{{Id: 1}, {Id: 2}, {Id: 3}}
+	availability := entity.ItemAvailabilities{
+		{ItemId: 1, TotalQuantity: 500, Available: 500, Serviceable: true},
+		{ItemId: 2, TotalQuantity: 5, Available: 2, Serviceable: true},
+		{ItemId: 3, TotalQuantity: 1, Available: 1, Serviceable: false},
+	}
+	filter := &entity.AvailabilityFilter{From: start, To: end}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+				input:        &entity.ApplyPackingTemplateInput{TemplateId: 2},
+			},
+			mockBehavior: func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, ir *mocks.MockInventoryRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				pr.EXPECT().GetPackingTemplateById(args.ctx, args.client, 2).
+					Return(template, nil)
+				mr.EXPECT().GetExpeditionMembers(args.ctx, args.client, 1).
+					Return(members, nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, filter).
+					Return(availability, nil)
+				pr.EXPECT().ApplyPackingTemplate(args.ctx, args.client, 1, 2, entity.Equipments{
+					{ExpeditionId: 1, ItemId: 1, Amount: 90, ReservedFrom: start, ReservedTo: end},
+					{ExpeditionId: 1, ItemId: 2, Amount: 2, ReservedFrom: start, ReservedTo: end},
+				}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "overbooked error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+				input:        &entity.ApplyPackingTemplateInput{TemplateId: 2},
+			},
+			mockBehavior: func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, ir *mocks.MockInventoryRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				pr.EXPECT().GetPackingTemplateById(args.ctx, args.client, 2).
+					Return(template, nil)
+				mr.EXPECT().GetExpeditionMembers(args.ctx, args.client, 1).
+					Return(append(members, &entity.Member{Id: 4}, &entity.Member{Id: 5}), nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, filter).
+					Return(availability, nil)
+			},
+			wantErr: ErrEquipmentOverbooked,
+		},
+		{
+			name: "already applied error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+				input:        &entity.ApplyPackingTemplateInput{TemplateId: 2},
+			},
+			mockBehavior: func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, ir *mocks.MockInventoryRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				pr.EXPECT().GetPackingTemplateById(args.ctx, args.client, 2).
+					Return(template, nil)
+				mr.EXPECT().GetExpeditionMembers(args.ctx, args.client, 1).
+					Return(members, nil)
+				ir.EXPECT().GetItemAvailability(args.ctx, args.client, filter).
+					Return(availability, nil)
+				pr.EXPECT().ApplyPackingTemplate(args.ctx, args.client, 1, 2, gomock.Any()).
+					Return(repoerrs.ErrAlreadyExists)
+			},
+			wantErr: ErrPackingListAlreadyApplied,
+		},
+		{
+			name: "expedition closed error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+				input:        &entity.ApplyPackingTemplateInput{TemplateId: 2},
+			},
+			mockBehavior: func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, ir *mocks.MockInventoryRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(closed, nil)
+				pr.EXPECT().GetPackingTemplateById(args.ctx, args.client, 2).
+					Return(template, nil)
+				mr.EXPECT().GetExpeditionMembers(args.ctx, args.client, 1).
+					Return(members, nil)
+			},
+			wantErr: ErrExpeditionClosed,
+		},
+		{
+			name: "template not found error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+				input:        &entity.ApplyPackingTemplateInput{TemplateId: 100},
+			},
+			mockBehavior: func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, ir *mocks.MockInventoryRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				pr.EXPECT().GetPackingTemplateById(args.ctx, args.client, 100).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: ErrPackingTemplateNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			packingRepo := mocks.NewMockPackingRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			tc.mockBehavior(packingRepo, expeditionRepo, memberRepo, inventoryRepo, tc.args)
+
+			// init service
+			s := NewPackingService(packingRepo, expeditionRepo, memberRepo, inventoryRepo)
+
+			// run test
+			err := s.ApplyPackingTemplate(tc.args.ctx, tc.args.client, tc.args.expeditionId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPackingService_GetPackingListDiff(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		expeditionId int
+		templateId   int
+	}
+
+	type MockBehavior func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args)
+
+	start, _ := time.Parse("2006-01-02", "2024-07-01")
+	end, _ := time.Parse("2006-01-02", "2024-07-10")
+	expedition := &entity.Expedition{Id: 1, LocationId: 1, StartDate: start, EndDate: end}
+	template := &entity.PackingTemplate{Id: 2, Name: "survey", Items: entity.PackingTemplateItems{
+		{ItemId: 1, Name: "water", PerPersonDay: 3},
+		{ItemId: 2, Name: "tent", PerPerson: 0.5},
+	}}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.PackingListDiff
+		wantErr      error
+	}{
+		{
+			name: "OK roster grew",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+				templateId:   2,
+			},
+			mockBehavior: func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				pr.EXPECT().GetPackingTemplateById(args.ctx, args.client, 2).
+					Return(template, nil)
+				mr.EXPECT().GetExpeditionMembers(args.ctx, args.client, 1).
+					Return(entity.Members{{Id: 1}, {Id: 2}, {Id: 3}, {Id: 4}}, nil)
+				pr.EXPECT().GetPackingListReservations(args.ctx, args.client, 1, 2).
+					Return(entity.PackingListLines{
+						{ItemId: 2, Name: "tent", Reserved: 2},
+						{ItemId: 1, Name: "water", Reserved: 90},
+					}, nil)
+			},
+			want: &entity.PackingListDiff{
+				ExpeditionId: 1,
+				TemplateId:   2,
+				Members:      4,
+				Days:         10,
+				Lines: entity.PackingListLines{
+					{ItemId: 2, Name: "tent", Reserved: 2, Required: 2, Delta: 0},
+					{ItemId: 1, Name: "water", Reserved: 90, Required: 120, Delta: 30},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not applied error",
+			args: args{
+				ctx:          context.Background(),
+				client:       nil,
+				expeditionId: 1,
+				templateId:   2,
+			},
+			mockBehavior: func(pr *mocks.MockPackingRepo, xr *mocks.MockExpeditionRepo, mr *mocks.MockMemberRepo, args args) {
+				xr.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				pr.EXPECT().GetPackingTemplateById(args.ctx, args.client, 2).
+					Return(template, nil)
+				mr.EXPECT().GetExpeditionMembers(args.ctx, args.client, 1).
+					Return(entity.Members{}, nil)
+				pr.EXPECT().GetPackingListReservations(args.ctx, args.client, 1, 2).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: ErrPackingListNotApplied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			packingRepo := mocks.NewMockPackingRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			memberRepo := mocks.NewMockMemberRepo(ctrl)
+			inventoryRepo := mocks.NewMockInventoryRepo(ctrl)
+			tc.mockBehavior(packingRepo, expeditionRepo, memberRepo, tc.args)
+
+			// init service
+			s := NewPackingService(packingRepo, expeditionRepo, memberRepo, inventoryRepo)
+
+			// run test
+			got, err := s.GetPackingListDiff(tc.args.ctx, tc.args.client, tc.args.expeditionId, tc.args.templateId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPackingTemplateItem_Quantity(t *testing.T) {
+	testCases := []struct {
+		name    string
+		item    *entity.PackingTemplateItem
+		members int
+		days    int
+		want    int
+	}{
+		{
+			name:    "exact product is not rounded up",
+			item:    &entity.PackingTemplateItem{ItemId: 1, PerPersonDay: 0.1},
+			members: 3,
+			days:    10,
+			want:    3,
+		},
+		{
+			name:    "fraction is rounded up",
+			item:    &entity.PackingTemplateItem{ItemId: 1, BaseQuantity: 1, PerPerson: 0.25},
+			members: 5,
+			days:    10,
+			want:    3,
+		},
+		{
+			name:    "mixed formula",
+			item:    &entity.PackingTemplateItem{ItemId: 1, BaseQuantity: 0.7, PerDay: 0.1, PerPersonDay: 0.3},
+			members: 2,
+			days:    7,
+			want:    6,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.item.Quantity(tc.members, tc.days))
+		})
+	}
+}
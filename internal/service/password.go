@@ -0,0 +1,3 @@
+package service
+
+const passwordCost = 14
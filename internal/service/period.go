@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+)
+
+type PeriodService struct {
+	periodRepo repo.PeriodRepo
+}
+
+func NewPeriodService(periodRepo repo.PeriodRepo) *PeriodService {
+	return &PeriodService{
+		periodRepo: periodRepo,
+	}
+}
+
+func (s *PeriodService) GetPeriodById(ctx context.Context, client any, id int) (*entity.Period, error) {
+	period, err := s.periodRepo.GetPeriodById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrPeriodNotFound
+		}
+		return nil, err
+	}
+
+	return period, nil
+}
+
+func (s *PeriodService) GetAllPeriods(ctx context.Context, client any) (entity.Periods, error) {
+	return s.periodRepo.GetAllPeriods(ctx, client)
+}
+
+func (s *PeriodService) CreatePeriod(ctx context.Context, client any, input *entity.CreatePeriodInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	if input.ParentId != nil {
+		parent, err := s.GetPeriodById(ctx, client, *input.ParentId)
+		if err != nil {
+			return 0, err
+		}
+
+		if input.StartYear < parent.StartYear || input.EndYear > parent.EndYear {
+			return 0, ErrPeriodOutsideParent
+		}
+	}
+
+	p := &entity.Period{
+		ParentId:  input.ParentId,
+		Name:      input.Name,
+		StartYear: input.StartYear,
+		EndYear:   input.EndYear,
+	}
+	id, err := s.periodRepo.CreatePeriod(ctx, client, p)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrPeriodAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *PeriodService) DeletePeriod(ctx context.Context, client any, id int) error {
+	err := s.periodRepo.DeletePeriod(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrPeriodNotFound
+		}
+		return err
+	}
+
+	return nil
+}
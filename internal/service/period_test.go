@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPeriodService_CreatePeriod(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		input  *entity.CreatePeriodInput
+	}
+
+	type MockBehavior func(m *mocks.MockPeriodRepo, args args)
+
+	parentId := 1
+	parent := &entity.Period{Id: parentId, Name: "Bronze Age", StartYear: -3300, EndYear: -1200}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreatePeriodInput{
+					ParentId:  &parentId,
+					Name:      "Late Bronze Age",
+					StartYear: -1600,
+					EndYear:   -1200,
+				},
+			},
+			mockBehavior: func(m *mocks.MockPeriodRepo, args args) {
+				m.EXPECT().GetPeriodById(args.ctx, args.client, parentId).
+					Return(parent, nil)
+				m.EXPECT().CreatePeriod(args.ctx, args.client, &entity.Period{
+					ParentId:  &parentId,
+					Name:      "Late Bronze Age",
+					StartYear: -1600,
+					EndYear:   -1200,
+				}).
+					Return(2, nil)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "outside parent error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreatePeriodInput{
+					ParentId:  &parentId,
+					Name:      "Iron Age",
+					StartYear: -1200,
+					EndYear:   -500,
+				},
+			},
+			mockBehavior: func(m *mocks.MockPeriodRepo, args args) {
+				m.EXPECT().GetPeriodById(args.ctx, args.client, parentId).
+					Return(parent, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "period already exists error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreatePeriodInput{
+					Name:      "Bronze Age",
+					StartYear: -3300,
+					EndYear:   -1200,
+				},
+			},
+			mockBehavior: func(m *mocks.MockPeriodRepo, args args) {
+				m.EXPECT().CreatePeriod(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrAlreadyExists)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "start after end error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreatePeriodInput{
+					Name:      "Bronze Age",
+					StartYear: -1200,
+					EndYear:   -3300,
+				},
+			},
+			mockBehavior: func(m *mocks.MockPeriodRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			periodRepo := mocks.NewMockPeriodRepo(ctrl)
+			tc.mockBehavior(periodRepo, tc.args)
+
+			// init service
+			s := NewPeriodService(periodRepo)
+
+			// run test
+			got, err := s.CreatePeriod(tc.args.ctx, tc.args.client, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+	"time"
+)
+
+type SampleService struct {
+	sampleRepo     repo.SampleRepo
+	expeditionRepo repo.ExpeditionRepo
+	artifactRepo   repo.ArtifactRepo
+	contextRepo    repo.ExcavationContextRepo
+}
+
+func NewSampleService(sampleRepo repo.SampleRepo, expeditionRepo repo.ExpeditionRepo, artifactRepo repo.ArtifactRepo,
+	contextRepo repo.ExcavationContextRepo) *SampleService {
+	return &SampleService{
+		sampleRepo:     sampleRepo,
+		expeditionRepo: expeditionRepo,
+		artifactRepo:   artifactRepo,
+		contextRepo:    contextRepo,
+	}
+}
+
+func (s *SampleService) GetSampleById(ctx context.Context, client any, id int) (*entity.Sample, error) {
+	sample, err := s.sampleRepo.GetSampleById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrSampleNotFound
+		}
+		return nil, err
+	}
+
+	return sample, nil
+}
+
+func (s *SampleService) GetAllSamples(ctx context.Context, client any, filter *entity.SampleFilter) (entity.Samples, error) {
+	if filter != nil && filter.Status != "" && !entity.IsValidSampleStatus(filter.Status) {
+		return nil, ErrInvalidSampleStatus
+	}
+
+	return s.sampleRepo.GetAllSamples(ctx, client, filter)
+}
+
+func (s *SampleService) CreateSample(ctx context.Context, client any, input *entity.CreateSampleInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	sample := &entity.Sample{
+		ExpeditionId: input.ExpeditionId,
+		ArtifactId:   input.ArtifactId,
+		ContextId:    input.ContextId,
+		Type:         input.Type,
+		Lab:          input.Lab,
+		SubmittedOn:  parseOptionalDate(input.SubmittedOn),
+		Status:       input.Status,
+		Results:      input.Results,
+	}
+	if sample.Status == "" {
+		sample.Status = entity.SampleCollected
+	}
+
+	if err := s.checkSampleOrigin(ctx, client, sample); err != nil {
+		return 0, err
+	}
+
+	return s.sampleRepo.CreateSample(ctx, client, sample)
+}
+
+func (s *SampleService) UpdateSample(ctx context.Context, client any, id int, input *entity.UpdateSampleInput) error {
+	if err := input.IsValid(); err != nil {
+		return err
+	}
+
+	sample, err := s.GetSampleById(ctx, client, id)
+	if err != nil {
+		return err
+	}
+
+	sample.Lab = input.Lab
+	sample.SubmittedOn = parseOptionalDate(input.SubmittedOn)
+	sample.Status = input.Status
+	sample.Results = input.Results
+
+	err = s.sampleRepo.UpdateSample(ctx, client, sample)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrSampleNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *SampleService) DeleteSample(ctx context.Context, client any, id int) error {
+	err := s.sampleRepo.DeleteSample(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrSampleNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *SampleService) checkSampleOrigin(ctx context.Context, client any, sample *entity.Sample) error {
+	expedition, err := s.expeditionRepo.GetExpeditionById(ctx, client, sample.ExpeditionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrExpeditionNotFound
+		}
+		return err
+	}
+
+	if sample.ArtifactId != nil {
+		artifact, err := s.artifactRepo.GetArtifactById(ctx, client, *sample.ArtifactId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrArtifactNotFound
+			}
+			return err
+		}
+
+		if artifact.LocationId != expedition.LocationId {
+			return ErrSampleLocationMismatch
+		}
+	}
+
+	if sample.ContextId != nil {
+		excavationContext, err := s.contextRepo.GetContextById(ctx, client, *sample.ContextId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrContextNotFound
+			}
+			return err
+		}
+
+		if excavationContext.LocationId != expedition.LocationId {
+			return ErrSampleLocationMismatch
+		}
+	}
+
+	return nil
+}
+
+func parseOptionalDate(date string) *time.Time {
+	if date == "" {
+		return nil
+	}
+
+	t, _ := time.Parse("2006-01-02", date)
+	return &t
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSampleService_CreateSample(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		input  *entity.CreateSampleInput
+	}
+
+	type MockBehavior func(sr *mocks.MockSampleRepo, er *mocks.MockExpeditionRepo, ar *mocks.MockArtifactRepo, args args)
+
+	artifactId := 1
+	expedition := &entity.Expedition{Id: 1, LocationId: 1}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateSampleInput{
+					ExpeditionId: 1,
+					ArtifactId:   &artifactId,
+					Type:         entity.SampleCharcoal,
+					Lab:          "Oxford",
+				},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, er *mocks.MockExpeditionRepo, ar *mocks.MockArtifactRepo, args args) {
+				er.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, artifactId).
+					Return(&entity.Artifact{Id: artifactId, LocationId: 1}, nil)
+				sr.EXPECT().CreateSample(args.ctx, args.client, &entity.Sample{
+					ExpeditionId: 1,
+					ArtifactId:   &artifactId,
+					Type:         entity.SampleCharcoal,
+					Lab:          "Oxford",
+					Status:       entity.SampleCollected,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "artifact from another location error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateSampleInput{
+					ExpeditionId: 1,
+					ArtifactId:   &artifactId,
+					Type:         entity.SampleBone,
+				},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, er *mocks.MockExpeditionRepo, ar *mocks.MockArtifactRepo, args args) {
+				er.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(expedition, nil)
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, artifactId).
+					Return(&entity.Artifact{Id: artifactId, LocationId: 2}, nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "expedition not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateSampleInput{
+					ExpeditionId: 1,
+					Type:         entity.SampleSoil,
+				},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, er *mocks.MockExpeditionRepo, ar *mocks.MockArtifactRepo, args args) {
+				er.EXPECT().GetExpeditionById(args.ctx, args.client, 1).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "invalid sample type error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateSampleInput{
+					ExpeditionId: 1,
+					Type:         "pottery",
+				},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, er *mocks.MockExpeditionRepo, ar *mocks.MockArtifactRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			sampleRepo := mocks.NewMockSampleRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			tc.mockBehavior(sampleRepo, expeditionRepo, artifactRepo, tc.args)
+
+			// init service
+			s := NewSampleService(sampleRepo, expeditionRepo, artifactRepo, contextRepo)
+
+			// run test
+			got, err := s.CreateSample(tc.args.ctx, tc.args.client, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSampleService_UpdateSample(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		id     int
+		input  *entity.UpdateSampleInput
+	}
+
+	type MockBehavior func(sr *mocks.MockSampleRepo, ar *mocks.MockArtifactRepo, args args)
+
+	artifactId := 1
+	radiocarbon := &entity.RadiocarbonResult{AgeBP: 3000, Sigma: 30, LabCode: "OxA-12345"}
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "OK accepted radiocarbon result",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+				input: &entity.UpdateSampleInput{
+					Lab:     "Oxford",
+					Status:  entity.SampleAccepted,
+					Results: entity.SampleResults{Radiocarbon: radiocarbon},
+				},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, ar *mocks.MockArtifactRepo, args args) {
+				sr.EXPECT().GetSampleById(args.ctx, args.client, args.id).
+					Return(&entity.Sample{
+						Id:           1,
+						ExpeditionId: 1,
+						ArtifactId:   &artifactId,
+						Type:         entity.SampleCharcoal,
+						Status:       entity.SampleInAnalysis,
+					}, nil)
+				sr.EXPECT().UpdateSample(args.ctx, args.client, &entity.Sample{
+					Id:           1,
+					ExpeditionId: 1,
+					ArtifactId:   &artifactId,
+					Type:         entity.SampleCharcoal,
+					Lab:          "Oxford",
+					Status:       entity.SampleAccepted,
+					Results:      entity.SampleResults{Radiocarbon: radiocarbon},
+				}).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK reported result",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+				input: &entity.UpdateSampleInput{
+					Status:  entity.SampleReported,
+					Results: entity.SampleResults{Radiocarbon: radiocarbon},
+				},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, ar *mocks.MockArtifactRepo, args args) {
+				sr.EXPECT().GetSampleById(args.ctx, args.client, args.id).
+					Return(&entity.Sample{Id: 1, ArtifactId: &artifactId, Status: entity.SampleInAnalysis}, nil)
+				sr.EXPECT().UpdateSample(args.ctx, args.client, gomock.Any()).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sample deleted concurrently error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+				input:  &entity.UpdateSampleInput{Status: entity.SampleRejected},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, ar *mocks.MockArtifactRepo, args args) {
+				sr.EXPECT().GetSampleById(args.ctx, args.client, args.id).
+					Return(&entity.Sample{Id: 1, ArtifactId: &artifactId, Status: entity.SampleAccepted}, nil)
+				sr.EXPECT().UpdateSample(args.ctx, args.client, gomock.Any()).
+					Return(repoerrs.ErrNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "sample not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+				input:  &entity.UpdateSampleInput{Status: entity.SampleSubmitted},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, ar *mocks.MockArtifactRepo, args args) {
+				sr.EXPECT().GetSampleById(args.ctx, args.client, args.id).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "radiocarbon result without lab code error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+				input: &entity.UpdateSampleInput{
+					Status:  entity.SampleAccepted,
+					Results: entity.SampleResults{Radiocarbon: &entity.RadiocarbonResult{AgeBP: 3000, Sigma: 30}},
+				},
+			},
+			mockBehavior: func(sr *mocks.MockSampleRepo, ar *mocks.MockArtifactRepo, args args) {},
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			sampleRepo := mocks.NewMockSampleRepo(ctrl)
+			expeditionRepo := mocks.NewMockExpeditionRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			contextRepo := mocks.NewMockExcavationContextRepo(ctrl)
+			tc.mockBehavior(sampleRepo, artifactRepo, tc.args)
+
+			// init service
+			s := NewSampleService(sampleRepo, expeditionRepo, artifactRepo, contextRepo)
+
+			// run test
+			err := s.UpdateSample(tc.args.ctx, tc.args.client, tc.args.id, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
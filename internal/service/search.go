@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"strings"
+)
+
+type SearchService struct {
+	searchRepo repo.SearchRepo
+}
+
+func NewSearchService(searchRepo repo.SearchRepo) *SearchService {
+	return &SearchService{searchRepo: searchRepo}
+}
+
+func (s *SearchService) Search(ctx context.Context, client any, user *entity.User, query *entity.SearchQuery) (entity.SearchHits, error) {
+	if err := query.IsValid(); err != nil {
+		return nil, err
+	}
+
+	scope := &entity.SearchScope{
+		Text:                  strings.TrimSpace(query.Text),
+		Types:                 query.Types,
+		UserRole:              user.Role,
+		UserId:                user.Id,
+		SharedExpeditionsOnly: user.Role == entity.RoleMember || user.Role == entity.RoleCurator,
+		Limit:                 query.Limit,
+		Offset:                query.Offset,
+	}
+	if len(scope.Types) == 0 {
+		scope.Types = entity.SearchTypes
+	}
+	if scope.Limit == 0 {
+		scope.Limit = entity.DefaultSearchLimit
+	}
+
+	hits, err := s.searchRepo.Search(ctx, client, scope)
+	if err != nil {
+		return nil, err
+	}
+	for _, hit := range hits {
+		hit.Highlight = entity.HighlightHTML(hit.Highlight)
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service/mocks"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		user   *entity.User
+		query  *entity.SearchQuery
+	}
+
+	type MockBehavior func(m *mocks.MockSearchRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         entity.SearchHits
+		wantErr      bool
+	}{
+		{
+			name: "OK member sees shared expeditions only",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 7, Role: entity.RoleMember},
+				query:  &entity.SearchQuery{Text: "  бронзовая фибула Иссык-Куль "},
+			},
+			mockBehavior: func(m *mocks.MockSearchRepo, args args) {
+				m.EXPECT().Search(args.ctx, args.client, &entity.SearchScope{
+					Text:                  "бронзовая фибула Иссык-Куль",
+					Types:                 entity.SearchTypes,
+					UserRole:              entity.RoleMember,
+					UserId:                7,
+					SharedExpeditionsOnly: true,
+					Limit:                 entity.DefaultSearchLimit,
+				}).Return(entity.SearchHits{{
+					Type:      entity.SearchTypeArtifact,
+					Id:        1,
+					Title:     "Бронзовая фибула",
+					Highlight: "\x02Бронзовая\x03 \x02фибула\x03 — <b>слой 4</b>",
+					Rank:      0.6,
+				}}, nil)
+			},
+			want: entity.SearchHits{{
+				Type:      entity.SearchTypeArtifact,
+				Id:        1,
+				Title:     "Бронзовая фибула",
+				Highlight: "<mark>Бронзовая</mark> <mark>фибула</mark> — &lt;b&gt;слой 4&lt;/b&gt;",
+				Rank:      0.6,
+			}},
+			wantErr: false,
+		},
+		{
+			name: "OK leader sees everyone",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 2, Role: entity.RoleLeader},
+				query:  &entity.SearchQuery{Text: "Ivanov", Types: []string{entity.SearchTypeMember}, Limit: 5, Offset: 10},
+			},
+			mockBehavior: func(m *mocks.MockSearchRepo, args args) {
+				m.EXPECT().Search(args.ctx, args.client, &entity.SearchScope{
+					Text:     "Ivanov",
+					Types:    []string{entity.SearchTypeMember},
+					UserRole: entity.RoleLeader,
+					UserId:   2,
+					Limit:    5,
+					Offset:   10,
+				}).Return(entity.SearchHits{}, nil)
+			},
+			want:    entity.SearchHits{},
+			wantErr: false,
+		},
+		{
+			name: "invalid type error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 1, Role: entity.RoleAdmin},
+				query:  &entity.SearchQuery{Text: "fibula", Types: []string{"sample"}},
+			},
+			mockBehavior: func(m *mocks.MockSearchRepo, args args) {},
+			wantErr:      true,
+		},
+		{
+			name: "empty query error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 1, Role: entity.RoleAdmin},
+				query:  &entity.SearchQuery{Text: "   "},
+			},
+			mockBehavior: func(m *mocks.MockSearchRepo, args args) {},
+			wantErr:      true,
+		},
+		{
+			name: "repo error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   &entity.User{Id: 1, Role: entity.RoleAdmin},
+				query:  &entity.SearchQuery{Text: "fibula"},
+			},
+			mockBehavior: func(m *mocks.MockSearchRepo, args args) {
+				m.EXPECT().Search(args.ctx, args.client, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			searchRepo := mocks.NewMockSearchRepo(ctrl)
+			tc.mockBehavior(searchRepo, tc.args)
+
+			// init service
+			s := NewSearchService(searchRepo)
+
+			// run test
+			got, err := s.Search(tc.args.ctx, tc.args.client, tc.args.user, tc.args.query)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
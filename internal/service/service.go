@@ -10,6 +10,8 @@ import (
 type Auth interface {
 	GetSession(token string) bool
 	GetClient(token string) (any, error)
+	GetUser(token string) (*entity.User, error)
+	SignIn(ctx context.Context, input *entity.SignInInput) (string, error)
 }
 
 type Leader interface {
@@ -31,14 +33,25 @@ type Member interface {
 type Curator interface {
 	GetCuratorById(ctx context.Context, client any, id int) (*entity.Curator, error)
 	GetExpeditionCurators(ctx context.Context, client any, expeditionId int) (entity.Curators, error)
+	GetInstitutionCurators(ctx context.Context, client any, institutionId int) (entity.Curators, error)
 	GetAllCurators(ctx context.Context, client any) (entity.Curators, error)
 	CreateCurator(ctx context.Context, client any, input *entity.CreateCuratorInput) (int, error)
 	DeleteCurator(ctx context.Context, client any, id int) error
+	AssignArtifactCurator(ctx context.Context, client any, artifactId int, input *entity.AssignCuratorInput) error
+	GetCuratorWorkloads(ctx context.Context, client any, intervalDays int) (entity.CuratorWorkloads, error)
+}
+
+type Institution interface {
+	GetInstitutionById(ctx context.Context, client any, id int) (*entity.Institution, error)
+	GetAllInstitutions(ctx context.Context, client any) (entity.Institutions, error)
+	CreateInstitution(ctx context.Context, client any, input *entity.CreateInstitutionInput) (int, error)
+	DeleteInstitution(ctx context.Context, client any, id int) error
 }
 
 type Location interface {
 	GetLocationById(ctx context.Context, client any, id int) (*entity.Location, error)
-	GetAllLocations(ctx context.Context, client any) (entity.Locations, error)
+	GetAllLocations(ctx context.Context, client any, filter *entity.LocationFilter) (entity.Locations, error)
+	GetNearbyLocations(ctx context.Context, client any, query *entity.NearbyQuery) (entity.NearbyLocations, error)
 	CreateLocation(ctx context.Context, client any, input *entity.CreateLocationInput) (int, error)
 	DeleteLocation(ctx context.Context, client any, id int) error
 }
@@ -49,43 +62,261 @@ type Expedition interface {
 	CreateExpedition(ctx context.Context, client any, input *entity.CreateExpeditionInput) (int, error)
 	UpdateExpeditionDates(ctx context.Context, client any, id int, startDate string, endDate string) error
 	DeleteExpedition(ctx context.Context, client any, id int) error
+	CloseExpedition(ctx context.Context, client any, id int) error
+}
+
+type Trench interface {
+	GetTrenchById(ctx context.Context, client any, id int) (*entity.Trench, error)
+	GetLocationTrenches(ctx context.Context, client any, locationId int) (entity.Trenches, error)
+	CreateTrench(ctx context.Context, client any, input *entity.CreateTrenchInput) (int, error)
+	DeleteTrench(ctx context.Context, client any, id int) error
+}
+
+type ExcavationContext interface {
+	GetContextById(ctx context.Context, client any, id int) (*entity.ExcavationContext, error)
+	GetTrenchContexts(ctx context.Context, client any, trenchId int) (entity.ExcavationContexts, error)
+	CreateContext(ctx context.Context, client any, input *entity.CreateExcavationContextInput) (int, error)
+	DeleteContext(ctx context.Context, client any, id int) error
+	CreateContextRelation(ctx context.Context, client any, input *entity.CreateContextRelationInput) (int, error)
+	DeleteContextRelation(ctx context.Context, client any, id int) error
+	GetHarrisMatrix(ctx context.Context, client any, locationId int) (*entity.HarrisMatrix, error)
 }
 
 type Artifact interface {
 	GetArtifactById(ctx context.Context, client any, id int) (*entity.Artifact, error)
 	GetLocationArtifacts(ctx context.Context, client any, locationId int) (entity.Artifacts, error)
-	GetAllArtifacts(ctx context.Context, client any) (entity.Artifacts, error)
+	GetExpeditionArtifacts(ctx context.Context, client any, expeditionId int) (entity.Artifacts, error)
+	GetContextArtifacts(ctx context.Context, client any, contextId int) (entity.Artifacts, error)
+	GetAllArtifacts(ctx context.Context, client any, filter *entity.ArtifactFilter) (entity.Artifacts, error)
 	CreateArtifact(ctx context.Context, client any, input *entity.CreateArtifactInput) (int, error)
 }
 
+type Period interface {
+	GetPeriodById(ctx context.Context, client any, id int) (*entity.Period, error)
+	GetAllPeriods(ctx context.Context, client any) (entity.Periods, error)
+	CreatePeriod(ctx context.Context, client any, input *entity.CreatePeriodInput) (int, error)
+	DeletePeriod(ctx context.Context, client any, id int) error
+}
+
+type Sample interface {
+	GetSampleById(ctx context.Context, client any, id int) (*entity.Sample, error)
+	GetAllSamples(ctx context.Context, client any, filter *entity.SampleFilter) (entity.Samples, error)
+	CreateSample(ctx context.Context, client any, input *entity.CreateSampleInput) (int, error)
+	UpdateSample(ctx context.Context, client any, id int, input *entity.UpdateSampleInput) error
+	DeleteSample(ctx context.Context, client any, id int) error
+}
+
+type Custody interface {
+	GetArtifactCustody(ctx context.Context, client any, artifactId int) (entity.CustodyRecords, error)
+	TransferArtifact(ctx context.Context, client any, user *entity.User, artifactId int, input *entity.TransferArtifactInput) (int, error)
+}
+
+type Export interface {
+	GetSiteSummaries(ctx context.Context, client any, filter *entity.ExportFilter) (entity.SiteSummaries, error)
+	ExportGeoJSON(ctx context.Context, client any, filter *entity.ExportFilter) (*entity.GeoJSONFeatureCollection, error)
+	ExportKML(ctx context.Context, client any, filter *entity.ExportFilter) (*entity.KML, error)
+}
+
+type ConditionReport interface {
+	GetArtifactConditionReports(ctx context.Context, client any, artifactId int) (entity.ConditionReports, error)
+	CreateConditionReport(ctx context.Context, client any, user *entity.User, artifactId int, input *entity.CreateConditionReportInput) (int, error)
+	GetOverdueInspections(ctx context.Context, client any, intervalDays int) (entity.OverdueInspections, error)
+}
+
+type Storage interface {
+	GetStorageNodeById(ctx context.Context, client any, id int) (*entity.StorageNode, error)
+	GetAllStorageNodes(ctx context.Context, client any) (entity.StorageNodes, error)
+	CreateStorageNode(ctx context.Context, client any, user *entity.User, input *entity.CreateStorageNodeInput) (int, error)
+	DeleteStorageNode(ctx context.Context, client any, user *entity.User, id int) error
+	MoveArtifact(ctx context.Context, client any, user *entity.User, artifactId int, input *entity.MoveArtifactInput) (int, error)
+	GetArtifactStorageMoves(ctx context.Context, client any, artifactId int) (entity.StorageMoves, error)
+	GetStorageContents(ctx context.Context, client any, nodeId int) (entity.StoredArtifacts, error)
+	GetPickList(ctx context.Context, client any, artifactIds []int) (entity.StoredArtifacts, error)
+}
+
+type Loan interface {
+	GetLoanById(ctx context.Context, client any, id int) (*entity.Loan, error)
+	GetAllLoans(ctx context.Context, client any) (entity.Loans, error)
+	GetArtifactLoans(ctx context.Context, client any, artifactId int) (entity.Loans, error)
+	CreateLoan(ctx context.Context, client any, user *entity.User, input *entity.CreateLoanInput) (int, error)
+	UpdateLoanStatus(ctx context.Context, client any, user *entity.User, id int, input *entity.UpdateLoanStatusInput) error
+	GetOverdueLoans(ctx context.Context, client any) (entity.OverdueLoans, error)
+}
+
+type Inventory interface {
+	GetInventoryItemById(ctx context.Context, client any, id int) (*entity.InventoryItem, error)
+	GetAllInventoryItems(ctx context.Context, client any) (entity.InventoryItems, error)
+	CreateInventoryItem(ctx context.Context, client any, input *entity.CreateInventoryItemInput) (int, error)
+	DeleteInventoryItem(ctx context.Context, client any, id int) error
+	GetItemAvailability(ctx context.Context, client any, itemId *int, from string, to string) (entity.ItemAvailabilities, error)
+}
+
+type Maintenance interface {
+	GetItemMaintenanceLogs(ctx context.Context, client any, itemId int) (entity.MaintenanceLogs, error)
+	CreateMaintenanceLog(ctx context.Context, client any, itemId int, input *entity.CreateMaintenanceLogInput) (int, error)
+	UpdateMaintenanceSchedule(ctx context.Context, client any, itemId int, input *entity.UpdateMaintenanceScheduleInput) error
+	GetMaintenanceDue(ctx context.Context, client any, expeditionId int) (entity.MaintenanceDues, error)
+}
+
 type Equipment interface {
 	GetEquipmentById(ctx context.Context, client any, id int) (*entity.Equipment, error)
 	GetExpeditionEquipments(ctx context.Context, client any, expeditionId int) (entity.Equipments, error)
 	GetAllEquipments(ctx context.Context, client any) (entity.Equipments, error)
 	CreateEquipment(ctx context.Context, client any, input *entity.CreateEquipmentInput) (int, error)
 	DeleteEquipment(ctx context.Context, client any, id int) error
+	GetEquipmentEvents(ctx context.Context, client any, equipmentId int) (entity.EquipmentEvents, error)
+	CheckOutEquipment(ctx context.Context, client any, equipmentId int, input *entity.CheckOutEquipmentInput) (int, error)
+	CheckInEquipment(ctx context.Context, client any, equipmentId int, input *entity.CheckInEquipmentInput) (int, error)
+	GetExpeditionReconciliation(ctx context.Context, client any, expeditionId int) (*entity.EquipmentReconciliation, error)
+}
+
+type Packing interface {
+	GetPackingTemplateById(ctx context.Context, client any, id int) (*entity.PackingTemplate, error)
+	GetAllPackingTemplates(ctx context.Context, client any) (entity.PackingTemplates, error)
+	CreatePackingTemplate(ctx context.Context, client any, input *entity.CreatePackingTemplateInput) (int, error)
+	DeletePackingTemplate(ctx context.Context, client any, id int) error
+	ApplyPackingTemplate(ctx context.Context, client any, expeditionId int, input *entity.ApplyPackingTemplateInput) error
+	GetPackingListDiff(ctx context.Context, client any, expeditionId int, templateId int) (*entity.PackingListDiff, error)
+}
+
+type Budget interface {
+	GetExpeditionBudget(ctx context.Context, client any, expeditionId int) (*entity.Budget, error)
+	SetExpeditionBudget(ctx context.Context, client any, user *entity.User, expeditionId int, input *entity.SetBudgetInput) error
+	GetExpeditionExpenses(ctx context.Context, client any, expeditionId int) (entity.Expenses, error)
+	CreateExpense(ctx context.Context, client any, user *entity.User, expeditionId int, input *entity.CreateExpenseInput) (int, error)
+	GetAllExchangeRates(ctx context.Context, client any) (entity.ExchangeRates, error)
+	CreateExchangeRate(ctx context.Context, client any, input *entity.CreateExchangeRateInput) (int, error)
+	GetExpeditionBudgetReport(ctx context.Context, client any, expeditionId int) (*entity.BudgetReport, error)
+	GetLocationBudgetReport(ctx context.Context, client any, locationId int) (*entity.BudgetReport, error)
+}
+
+type Journal interface {
+	GetExpeditionJournal(ctx context.Context, client any, expeditionId int) (entity.JournalEntries, error)
+	CreateJournalEntry(ctx context.Context, client any, user *entity.User, expeditionId int, input *entity.JournalEntryInput) (int, error)
+	UpdateJournalEntry(ctx context.Context, client any, user *entity.User, id int, input *entity.JournalEntryInput) error
+	GetExpeditionJournalLocks(ctx context.Context, client any, expeditionId int) (entity.JournalDayLocks, error)
+	LockJournalDay(ctx context.Context, client any, user *entity.User, expeditionId int, input *entity.LockJournalDayInput) error
+	CompileExpeditionJournal(ctx context.Context, client any, expeditionId int) (*entity.JournalDocument, error)
+}
+
+type Attachment interface {
+	MaxUploadSize() int64
+	GetAttachment(ctx context.Context, client any, id int) (*entity.Attachment, error)
+	GetParentAttachments(ctx context.Context, client any, parent *entity.AttachmentParent) (entity.Attachments, error)
+	GetAttachmentContent(ctx context.Context, client any, id int) (*entity.AttachmentContent, error)
+	UploadAttachment(ctx context.Context, client any, user *entity.User, input *entity.UploadAttachmentInput, file *entity.AttachmentFile) (int, error)
+	DeleteAttachment(ctx context.Context, client any, user *entity.User, id int) error
+	GetAttachmentThumbnail(ctx context.Context, client any, id int, size string) (*entity.ThumbnailContent, error)
+	GetArtifactFindSpotSuggestions(ctx context.Context, client any, artifactId int) (entity.FindSpotSuggestions, error)
+}
+
+type IIIF interface {
+	GetArtifactManifest(ctx context.Context, client any, links *entity.Links, artifactId int) (*entity.IIIFManifest, error)
+	GetArtifactCanvas(ctx context.Context, client any, links *entity.Links, artifactId int, imageId int) (*entity.IIIFCanvas, error)
+	GetArtifactAnnotationPage(ctx context.Context, client any, links *entity.Links, artifactId int, imageId int) (*entity.IIIFAnnotationPage, error)
+	GetArtifactAnnotation(ctx context.Context, client any, links *entity.Links, artifactId int, imageId int) (*entity.IIIFAnnotation, error)
+	GetImageContent(ctx context.Context, client any, imageId int) (*entity.AttachmentContent, error)
+	GetImageThumbnail(ctx context.Context, client any, imageId int) (*entity.ThumbnailContent, error)
+	GetLocationCollection(ctx context.Context, client any, links *entity.Links, locationId int) (*entity.IIIFCollection, error)
+}
+
+type Label interface {
+	GetLabelSheet(ctx context.Context, client any, links *entity.Links, query *entity.LabelQuery) (*entity.LabelSheet, error)
+}
+
+type Catalog interface {
+	LookupCatalogCode(ctx context.Context, client any, code string) (*entity.CatalogLookup, entity.CatalogCandidates, error)
+}
+
+type Search interface {
+	Search(ctx context.Context, client any, user *entity.User, query *entity.SearchQuery) (entity.SearchHits, error)
+}
+
+type Merge interface {
+	GetDuplicateCandidates(ctx context.Context, client any, user *entity.User, query *entity.DuplicateQuery) (entity.DuplicateCandidates, error)
+	Merge(ctx context.Context, client any, user *entity.User, entityType string, input *entity.MergeInput) (*entity.MergeRecord, error)
+	GetMergeHistory(ctx context.Context, client any, user *entity.User, entityType string) (entity.MergeRecords, error)
+}
+
+type Country interface {
+	GetCountries(lang string) entity.Countries
+	MigrateCountries(ctx context.Context, client any, user *entity.User, apply bool) (*entity.CountryMigrationReport, error)
+}
+
+type Images interface {
+	Start(ctx context.Context)
+	Stop()
 }
 
 type Services struct {
-	Auth       Auth
-	Leader     Leader
-	Member     Member
-	Curator    Curator
-	Location   Location
-	Expedition Expedition
-	Artifact   Artifact
-	Equipment  Equipment
+	Auth              Auth
+	Leader            Leader
+	Member            Member
+	Curator           Curator
+	Institution       Institution
+	Location          Location
+	Expedition        Expedition
+	Trench            Trench
+	ExcavationContext ExcavationContext
+	Artifact          Artifact
+	Period            Period
+	Sample            Sample
+	Custody           Custody
+	ConditionReport   ConditionReport
+	Storage           Storage
+	Loan              Loan
+	Inventory         Inventory
+	Maintenance       Maintenance
+	Equipment         Equipment
+	Packing           Packing
+	Budget            Budget
+	Journal           Journal
+	Attachment        Attachment
+	Images            Images
+	IIIF              IIIF
+	Label             Label
+	Catalog           Catalog
+	Search            Search
+	Merge             Merge
+	Country           Country
+	Export            Export
 }
 
-func NewServices(repos *repo.Repositories, admin any, leader any, member any) *Services {
+func NewServices(repos *repo.Repositories, attachments AttachmentConfig, admin any, leader any, member any, curator any) *Services {
+	images := NewImageProcessor(repos.AttachmentRepo, attachments.Store, admin, attachments.Images)
+	attachment := NewAttachmentService(repos.AttachmentRepo, repos.ArtifactRepo, repos.LocationRepo, repos.ExpeditionRepo, repos.JournalRepo, images, attachments)
+
 	return &Services{
-		Auth:       auth.NewAuthService(member, leader, admin),
-		Leader:     NewLeaderService(repos.LeaderRepo),
-		Member:     NewMemberService(repos.MemberRepo),
-		Curator:    NewCuratorService(repos.CuratorRepo),
-		Location:   NewLocationService(repos.LocationRepo),
-		Expedition: NewExpeditionService(repos.ExpeditionRepo),
-		Artifact:   NewArtifactService(repos.ArtifactRepo),
-		Equipment:  NewEquipmentService(repos.EquipmentRepo),
+		Auth:              auth.NewAuthService(repos.AdminRepo, repos.LeaderRepo, repos.MemberRepo, repos.CuratorRepo, member, leader, curator, admin),
+		Leader:            NewLeaderService(repos.LeaderRepo),
+		Member:            NewMemberService(repos.MemberRepo),
+		Curator:           NewCuratorService(repos.CuratorRepo, repos.ArtifactRepo),
+		Institution:       NewInstitutionService(repos.InstitutionRepo),
+		Location:          NewLocationService(repos.LocationRepo),
+		Expedition:        NewExpeditionService(repos.ExpeditionRepo),
+		Trench:            NewTrenchService(repos.TrenchRepo),
+		ExcavationContext: NewExcavationContextService(repos.ExcavationContextRepo),
+		Artifact:          NewArtifactService(repos.ArtifactRepo, repos.ExpeditionRepo, repos.CustodyRepo, repos.ExcavationContextRepo, repos.PeriodRepo, repos.ConditionReportRepo, repos.MemberRepo),
+		Period:            NewPeriodService(repos.PeriodRepo),
+		Sample:            NewSampleService(repos.SampleRepo, repos.ExpeditionRepo, repos.ArtifactRepo, repos.ExcavationContextRepo),
+		Custody:           NewCustodyService(repos.CustodyRepo, repos.ArtifactRepo, repos.MemberRepo, repos.CuratorRepo),
+		ConditionReport:   NewConditionReportService(repos.ConditionReportRepo, repos.ArtifactRepo),
+		Storage:           NewStorageService(repos.StorageRepo, repos.ArtifactRepo),
+		Loan:              NewLoanService(repos.LoanRepo, repos.CustodyRepo),
+		Inventory:         NewInventoryService(repos.InventoryRepo),
+		Maintenance:       NewMaintenanceService(repos.MaintenanceRepo, repos.InventoryRepo, repos.ExpeditionRepo),
+		Equipment:         NewEquipmentService(repos.EquipmentRepo, repos.InventoryRepo, repos.ExpeditionRepo),
+		Packing:           NewPackingService(repos.PackingRepo, repos.ExpeditionRepo, repos.MemberRepo, repos.InventoryRepo),
+		Budget:            NewBudgetService(repos.BudgetRepo, repos.ExpeditionRepo, repos.LeaderRepo, repos.LocationRepo),
+		Journal:           NewJournalService(repos.JournalRepo, repos.ExpeditionRepo, repos.MemberRepo, repos.LeaderRepo, repos.ArtifactRepo),
+		Attachment:        attachment,
+		Images:            images,
+		IIIF:              NewIIIFService(repos.ArtifactRepo, repos.LocationRepo, repos.CuratorRepo, repos.PeriodRepo, repos.AttachmentRepo, attachment),
+		Label:             NewLabelService(repos.ArtifactRepo, repos.LocationRepo, repos.ExpeditionRepo),
+		Catalog:           NewCatalogService(repos.ArtifactRepo, repos.CustodyRepo, repos.StorageRepo),
+		Search:            NewSearchService(repos.SearchRepo),
+		Merge:             NewMergeService(repos.MergeRepo),
+		Country:           NewCountryService(repos.CountryRepo),
+		Export:            NewExportService(repos.LocationRepo, repos.ArtifactRepo, repos.PeriodRepo),
 	}
 }
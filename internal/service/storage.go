@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+)
+
+type StorageService struct {
+	storageRepo  repo.StorageRepo
+	artifactRepo repo.ArtifactRepo
+}
+
+func NewStorageService(storageRepo repo.StorageRepo, artifactRepo repo.ArtifactRepo) *StorageService {
+	return &StorageService{
+		storageRepo:  storageRepo,
+		artifactRepo: artifactRepo,
+	}
+}
+
+func (s *StorageService) GetStorageNodeById(ctx context.Context, client any, id int) (*entity.StorageNode, error) {
+	node, err := s.storageRepo.GetStorageNodeById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrStorageNodeNotFound
+		}
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (s *StorageService) GetAllStorageNodes(ctx context.Context, client any) (entity.StorageNodes, error) {
+	return s.storageRepo.GetAllStorageNodes(ctx, client)
+}
+
+func (s *StorageService) CreateStorageNode(ctx context.Context, client any, user *entity.User, input *entity.CreateStorageNodeInput) (int, error) {
+	if user.Role != entity.RoleCurator && !user.IsAdmin() {
+		return 0, ErrStorageForbidden
+	}
+
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	if input.ParentId != nil {
+		parent, err := s.GetStorageNodeById(ctx, client, *input.ParentId)
+		if err != nil {
+			return 0, err
+		}
+		if !parent.CanContain(input.Kind) {
+			return 0, ErrStorageHierarchy
+		}
+	}
+
+	node := &entity.StorageNode{
+		ParentId: input.ParentId,
+		Kind:     input.Kind,
+		Name:     input.Name,
+		Capacity: input.Capacity,
+	}
+	id, err := s.storageRepo.CreateStorageNode(ctx, client, node)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return 0, ErrStorageNodeAlreadyExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *StorageService) DeleteStorageNode(ctx context.Context, client any, user *entity.User, id int) error {
+	if user.Role != entity.RoleCurator && !user.IsAdmin() {
+		return ErrStorageForbidden
+	}
+
+	err := s.storageRepo.DeleteStorageNode(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrStorageNodeNotFound
+		}
+		if errors.Is(err, repoerrs.ErrInUse) {
+			return ErrStorageNodeNotEmpty
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorageService) MoveArtifact(ctx context.Context, client any, user *entity.User, artifactId int, input *entity.MoveArtifactInput) (int, error) {
+	if user.Role != entity.RoleCurator && !user.IsAdmin() {
+		return 0, ErrStorageForbidden
+	}
+
+	artifact, err := s.artifactRepo.GetArtifactById(ctx, client, artifactId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return 0, ErrArtifactNotFound
+		}
+		return 0, err
+	}
+
+	node, err := s.GetStorageNodeById(ctx, client, input.NodeId)
+	if err != nil {
+		return 0, err
+	}
+
+	if node.Capacity != nil && (artifact.StorageNodeId == nil || *artifact.StorageNodeId != node.Id) {
+		count, err := s.storageRepo.CountStorageNodeArtifacts(ctx, client, node.Id)
+		if err != nil {
+			return 0, err
+		}
+		if count >= *node.Capacity {
+			return 0, ErrStorageNodeFull
+		}
+	}
+
+	move := &entity.StorageMove{
+		ArtifactId:  artifactId,
+		ToNodeId:    node.Id,
+		Reason:      input.Reason,
+		MovedById:   user.Id,
+		MovedByRole: user.Role,
+	}
+	id, err := s.storageRepo.MoveArtifact(ctx, client, move)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrConflict):
+			return 0, ErrStorageNodeFull
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return 0, ErrArtifactNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *StorageService) GetArtifactStorageMoves(ctx context.Context, client any, artifactId int) (entity.StorageMoves, error) {
+	return s.storageRepo.GetArtifactStorageMoves(ctx, client, artifactId)
+}
+
+func (s *StorageService) GetStorageContents(ctx context.Context, client any, nodeId int) (entity.StoredArtifacts, error) {
+	_, err := s.GetStorageNodeById(ctx, client, nodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storageRepo.GetStorageContents(ctx, client, nodeId)
+}
+
+func (s *StorageService) GetPickList(ctx context.Context, client any, artifactIds []int) (entity.StoredArtifacts, error) {
+	if len(artifactIds) == 0 {
+		return entity.StoredArtifacts{}, nil
+	}
+
+	return s.storageRepo.GetPickList(ctx, client, artifactIds)
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStorageService_CreateStorageNode(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		user   *entity.User
+		input  *entity.CreateStorageNodeInput
+	}
+
+	type MockBehavior func(m *mocks.MockStorageRepo, args args)
+
+	curator := &entity.User{Id: 5, Role: entity.RoleCurator}
+	member := &entity.User{Id: 2, Role: entity.RoleMember}
+	parentId := 1
+	capacity := 20
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK museum",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				input: &entity.CreateStorageNodeInput{
+					Kind: entity.StorageMuseum,
+					Name: "aaa",
+				},
+			},
+			mockBehavior: func(m *mocks.MockStorageRepo, args args) {
+				m.EXPECT().CreateStorageNode(args.ctx, args.client, &entity.StorageNode{
+					Kind: entity.StorageMuseum,
+					Name: "aaa",
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "OK box inside shelf",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				input: &entity.CreateStorageNodeInput{
+					ParentId: &parentId,
+					Kind:     entity.StorageBox,
+					Name:     "bbb",
+					Capacity: &capacity,
+				},
+			},
+			mockBehavior: func(m *mocks.MockStorageRepo, args args) {
+				m.EXPECT().GetStorageNodeById(args.ctx, args.client, parentId).
+					Return(&entity.StorageNode{Id: parentId, Kind: entity.StorageShelf}, nil)
+				m.EXPECT().CreateStorageNode(args.ctx, args.client, &entity.StorageNode{
+					ParentId: &parentId,
+					Kind:     entity.StorageBox,
+					Name:     "bbb",
+					Capacity: &capacity,
+				}).
+					Return(2, nil)
+			},
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name: "room inside box error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				input: &entity.CreateStorageNodeInput{
+					ParentId: &parentId,
+					Kind:     entity.StorageRoom,
+					Name:     "ccc",
+				},
+			},
+			mockBehavior: func(m *mocks.MockStorageRepo, args args) {
+				m.EXPECT().GetStorageNodeById(args.ctx, args.client, parentId).
+					Return(&entity.StorageNode{Id: parentId, Kind: entity.StorageBox}, nil)
+			},
+			want:    0,
+			wantErr: ErrStorageHierarchy,
+		},
+		{
+			name: "parent not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   curator,
+				input: &entity.CreateStorageNodeInput{
+					ParentId: &parentId,
+					Kind:     entity.StorageRoom,
+					Name:     "ccc",
+				},
+			},
+			mockBehavior: func(m *mocks.MockStorageRepo, args args) {
+				m.EXPECT().GetStorageNodeById(args.ctx, args.client, parentId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrStorageNodeNotFound,
+		},
+		{
+			name: "member forbidden error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				user:   member,
+				input: &entity.CreateStorageNodeInput{
+					Kind: entity.StorageMuseum,
+					Name: "aaa",
+				},
+			},
+			mockBehavior: func(m *mocks.MockStorageRepo, args args) {},
+			want:         0,
+			wantErr:      ErrStorageForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			storageRepo := mocks.NewMockStorageRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			tc.mockBehavior(storageRepo, tc.args)
+
+			// init service
+			s := NewStorageService(storageRepo, artifactRepo)
+
+			// run test
+			got, err := s.CreateStorageNode(tc.args.ctx, tc.args.client, tc.args.user, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStorageService_MoveArtifact(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     any
+		user       *entity.User
+		artifactId int
+		input      *entity.MoveArtifactInput
+	}
+
+	type MockBehavior func(sr *mocks.MockStorageRepo, ar *mocks.MockArtifactRepo, args args)
+
+	curator := &entity.User{Id: 5, Role: entity.RoleCurator}
+	capacity := 2
+	nodeId := 3
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      error
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       curator,
+				artifactId: 1,
+				input:      &entity.MoveArtifactInput{NodeId: nodeId, Reason: "rehousing"},
+			},
+			mockBehavior: func(sr *mocks.MockStorageRepo, ar *mocks.MockArtifactRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).
+					Return(&entity.Artifact{Id: 1}, nil)
+				sr.EXPECT().GetStorageNodeById(args.ctx, args.client, nodeId).
+					Return(&entity.StorageNode{Id: nodeId, Kind: entity.StorageBox, Capacity: &capacity}, nil)
+				sr.EXPECT().CountStorageNodeArtifacts(args.ctx, args.client, nodeId).
+					Return(1, nil)
+				sr.EXPECT().MoveArtifact(args.ctx, args.client, &entity.StorageMove{
+					ArtifactId:  1,
+					ToNodeId:    nodeId,
+					Reason:      "rehousing",
+					MovedById:   curator.Id,
+					MovedByRole: entity.RoleCurator,
+				}).
+					Return(7, nil)
+			},
+			want:    7,
+			wantErr: nil,
+		},
+		{
+			name: "node full error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       curator,
+				artifactId: 1,
+				input:      &entity.MoveArtifactInput{NodeId: nodeId},
+			},
+			mockBehavior: func(sr *mocks.MockStorageRepo, ar *mocks.MockArtifactRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).
+					Return(&entity.Artifact{Id: 1}, nil)
+				sr.EXPECT().GetStorageNodeById(args.ctx, args.client, nodeId).
+					Return(&entity.StorageNode{Id: nodeId, Kind: entity.StorageBox, Capacity: &capacity}, nil)
+				sr.EXPECT().CountStorageNodeArtifacts(args.ctx, args.client, nodeId).
+					Return(2, nil)
+			},
+			want:    0,
+			wantErr: ErrStorageNodeFull,
+		},
+		{
+			name: "node filled concurrently error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       curator,
+				artifactId: 1,
+				input:      &entity.MoveArtifactInput{NodeId: nodeId},
+			},
+			mockBehavior: func(sr *mocks.MockStorageRepo, ar *mocks.MockArtifactRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).
+					Return(&entity.Artifact{Id: 1}, nil)
+				sr.EXPECT().GetStorageNodeById(args.ctx, args.client, nodeId).
+					Return(&entity.StorageNode{Id: nodeId, Kind: entity.StorageBox, Capacity: &capacity}, nil)
+				sr.EXPECT().CountStorageNodeArtifacts(args.ctx, args.client, nodeId).
+					Return(1, nil)
+				sr.EXPECT().MoveArtifact(args.ctx, args.client, gomock.Any()).
+					Return(0, repoerrs.ErrConflict)
+			},
+			want:    0,
+			wantErr: ErrStorageNodeFull,
+		},
+		{
+			name: "artifact not found error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       curator,
+				artifactId: 1,
+				input:      &entity.MoveArtifactInput{NodeId: nodeId},
+			},
+			mockBehavior: func(sr *mocks.MockStorageRepo, ar *mocks.MockArtifactRepo, args args) {
+				ar.EXPECT().GetArtifactById(args.ctx, args.client, args.artifactId).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    0,
+			wantErr: ErrArtifactNotFound,
+		},
+		{
+			name: "leader forbidden error",
+			args: args{
+				ctx:        context.Background(),
+				client:     nil,
+				user:       &entity.User{Id: 1, Role: entity.RoleLeader},
+				artifactId: 1,
+				input:      &entity.MoveArtifactInput{NodeId: nodeId},
+			},
+			mockBehavior: func(sr *mocks.MockStorageRepo, ar *mocks.MockArtifactRepo, args args) {},
+			want:         0,
+			wantErr:      ErrStorageForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			storageRepo := mocks.NewMockStorageRepo(ctrl)
+			artifactRepo := mocks.NewMockArtifactRepo(ctrl)
+			tc.mockBehavior(storageRepo, artifactRepo, tc.args)
+
+			// init service
+			s := NewStorageService(storageRepo, artifactRepo)
+
+			// run test
+			got, err := s.MoveArtifact(tc.args.ctx, tc.args.client, tc.args.user, tc.args.artifactId, tc.args.input)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo"
+	"db_cp_6/internal/repo/repoerrs"
+	"errors"
+)
+
+type TrenchService struct {
+	trenchRepo repo.TrenchRepo
+}
+
+func NewTrenchService(trenchRepo repo.TrenchRepo) *TrenchService {
+	return &TrenchService{
+		trenchRepo: trenchRepo,
+	}
+}
+
+func (s *TrenchService) GetTrenchById(ctx context.Context, client any, id int) (*entity.Trench, error) {
+	trench, err := s.trenchRepo.GetTrenchById(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTrenchNotFound
+		}
+		return nil, err
+	}
+
+	return trench, nil
+}
+
+func (s *TrenchService) GetLocationTrenches(ctx context.Context, client any, locationId int) (entity.Trenches, error) {
+	return s.trenchRepo.GetLocationTrenches(ctx, client, locationId)
+}
+
+func (s *TrenchService) CreateTrench(ctx context.Context, client any, input *entity.CreateTrenchInput) (int, error) {
+	if err := input.IsValid(); err != nil {
+		return 0, err
+	}
+
+	t := &entity.Trench{
+		LocationId:  input.LocationId,
+		Name:        input.Name,
+		Description: input.Description,
+	}
+	return s.trenchRepo.CreateTrench(ctx, client, t)
+}
+
+func (s *TrenchService) DeleteTrench(ctx context.Context, client any, id int) error {
+	err := s.trenchRepo.DeleteTrench(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrTrenchNotFound
+		}
+		return err
+	}
+
+	return nil
+}
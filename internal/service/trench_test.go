@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/repo/repoerrs"
+	"db_cp_6/internal/service/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTrenchService_GetTrenchById(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		id     int
+	}
+
+	type MockBehavior func(m *mocks.MockTrenchRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         *entity.Trench
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(m *mocks.MockTrenchRepo, args args) {
+				m.EXPECT().GetTrenchById(args.ctx, args.client, args.id).
+					Return(&entity.Trench{
+						Id:         1,
+						LocationId: 1,
+						Name:       "A",
+					}, nil)
+			},
+			want: &entity.Trench{
+				Id:         1,
+				LocationId: 1,
+				Name:       "A",
+			},
+			wantErr: false,
+		},
+		{
+			name: "trench not found error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				id:     1,
+			},
+			mockBehavior: func(m *mocks.MockTrenchRepo, args args) {
+				m.EXPECT().GetTrenchById(args.ctx, args.client, args.id).
+					Return(nil, repoerrs.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			trenchRepo := mocks.NewMockTrenchRepo(ctrl)
+			tc.mockBehavior(trenchRepo, tc.args)
+
+			// init service
+			s := NewTrenchService(trenchRepo)
+
+			// run test
+			got, err := s.GetTrenchById(tc.args.ctx, tc.args.client, tc.args.id)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTrenchService_CreateTrench(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		input  *entity.CreateTrenchInput
+	}
+
+	type MockBehavior func(m *mocks.MockTrenchRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateTrenchInput{
+					LocationId: 1,
+					Name:       "A",
+				},
+			},
+			mockBehavior: func(m *mocks.MockTrenchRepo, args args) {
+				m.EXPECT().CreateTrench(args.ctx, args.client, &entity.Trench{
+					LocationId: args.input.LocationId,
+					Name:       args.input.Name,
+				}).
+					Return(1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "invalid name error",
+			args: args{
+				ctx:    context.Background(),
+				client: nil,
+				input: &entity.CreateTrenchInput{
+					LocationId: 1,
+				},
+			},
+			mockBehavior: func(m *mocks.MockTrenchRepo, args args) {},
+			want:         0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// init deps
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// init mocks
+			trenchRepo := mocks.NewMockTrenchRepo(ctrl)
+			tc.mockBehavior(trenchRepo, tc.args)
+
+			// init service
+			s := NewTrenchService(trenchRepo)
+
+			// run test
+			got, err := s.CreateTrench(tc.args.ctx, tc.args.client, tc.args.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
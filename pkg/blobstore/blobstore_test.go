@@ -0,0 +1,73 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+func TestStores(t *testing.T) {
+	local, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	stub := NewS3Stub("attachments", "eu-central-1", "access", "secret")
+	defer stub.Close()
+	s3, err := NewS3(stub.Config(), stub.Client())
+	require.NoError(t, err)
+
+	stores := map[string]Store{
+		"local": local,
+		"s3":    s3,
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			key := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+			data := []byte("test")
+
+			exists, err := store.Exists(ctx, key)
+			require.NoError(t, err)
+			assert.False(t, exists)
+
+			_, err = store.Get(ctx, key)
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			err = store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "text/plain")
+			require.NoError(t, err)
+
+			exists, err = store.Exists(ctx, key)
+			require.NoError(t, err)
+			assert.True(t, exists)
+
+			r, err := store.Get(ctx, key)
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			r.Close()
+			require.NoError(t, err)
+			assert.Equal(t, data, got)
+
+			require.NoError(t, store.Delete(ctx, key))
+			assert.ErrorIs(t, store.Delete(ctx, key), ErrNotFound)
+
+			err = store.Put(ctx, "../escape", bytes.NewReader(data), int64(len(data)), "")
+			assert.ErrorIs(t, err, ErrInvalidKey)
+		})
+	}
+}
+
+func TestS3_WrongCredentials(t *testing.T) {
+	stub := NewS3Stub("attachments", "eu-central-1", "access", "secret")
+	defer stub.Close()
+
+	cfg := stub.Config()
+	cfg.SecretKey = "wrong"
+	s3, err := NewS3(cfg, stub.Client())
+	require.NoError(t, err)
+
+	_, err = s3.Exists(context.Background(), "abcdef")
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package blobstore
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+type S3Stub struct {
+	*httptest.Server
+
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+
+	mu      sync.RWMutex
+	objects map[string]s3StubObject
+}
+
+type s3StubObject struct {
+	data        []byte
+	contentType string
+}
+
+func NewS3Stub(bucket string, region string, accessKey string, secretKey string) *S3Stub {
+	stub := &S3Stub{
+		bucket:    bucket,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		objects:   make(map[string]s3StubObject),
+	}
+	stub.Server = httptest.NewServer(http.HandlerFunc(stub.serve))
+
+	return stub
+}
+
+func (s *S3Stub) Config() S3Config {
+	return S3Config{
+		Endpoint:  s.URL,
+		Region:    s.region,
+		Bucket:    s.bucket,
+		AccessKey: s.accessKey,
+		SecretKey: s.secretKey,
+	}
+}
+
+func (s *S3Stub) serve(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !ok || bucket != s.bucket || key == "" {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.objects[key] = s3StubObject{data: data, contentType: r.Header.Get("Content-Type")}
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		s.mu.RLock()
+		object, found := s.objects[key]
+		s.mu.RUnlock()
+		if !found {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(object.data)
+		}
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, key)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *S3Stub) authorized(r *http.Request) bool {
+	signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	got := r.Header.Get("Authorization")
+	expected := r.Clone(r.Context())
+	signV4(expected, s.region, s.accessKey, s.secretKey, signedAt)
+
+	return got != "" && got == expected.Header.Get("Authorization")
+}
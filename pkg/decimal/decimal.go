@@ -0,0 +1,178 @@
+package decimal
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const maxScale = 18
+
+type Decimal struct {
+	value int64
+	scale int32
+}
+
+func New(value int64, scale int32) Decimal {
+	return Decimal{value: value, scale: scale}
+}
+
+func Parse(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+
+	intPart, fracPart, hasPoint := strings.Cut(str, ".")
+	if intPart == "" && fracPart == "" || hasPoint && fracPart == "" || len(fracPart) > maxScale || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if digits == "" {
+		return Decimal{scale: int32(len(fracPart))}, nil
+	}
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if negative {
+		value = -value
+	}
+
+	return Decimal{value: value, scale: int32(len(fracPart))}, nil
+}
+
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func (d Decimal) Sign() int {
+	switch {
+	case d.value > 0:
+		return 1
+	case d.value < 0:
+		return -1
+	}
+
+	return 0
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := align(d, other)
+	switch {
+	case a.value > b.value:
+		return 1
+	case a.value < b.value:
+		return -1
+	}
+
+	return 0
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := align(d, other)
+	return Decimal{value: a.value + b.value, scale: a.scale}
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b := align(d, other)
+	return Decimal{value: a.value - b.value, scale: a.scale}
+}
+
+func (d Decimal) FitsScale(scale int32) bool {
+	value, s := d.value, d.scale
+	for s > scale && value%10 == 0 {
+		value /= 10
+		s--
+	}
+
+	return s <= scale
+}
+
+func (d Decimal) String() string {
+	digits := strconv.FormatInt(d.value, 10)
+	sign := ""
+	if d.value < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if string(data) == "null" {
+		return nil
+	}
+
+	parsed, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+
+	return nil
+}
+
+func (d *Decimal) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case string:
+		parsed, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	case []byte:
+		return d.Scan(string(v))
+	case int64:
+		*d = Decimal{value: v}
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T into decimal", src)
+}
+
+func align(a Decimal, b Decimal) (Decimal, Decimal) {
+	for a.scale < b.scale {
+		a.value *= 10
+		a.scale++
+	}
+	for b.scale < a.scale {
+		b.value *= 10
+		b.scale++
+	}
+
+	return a, b
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
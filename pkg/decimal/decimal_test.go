@@ -0,0 +1,87 @@
+package decimal
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "12.34", want: "12.34"},
+		{input: "0.10", want: "0.10"},
+		{input: "-0.05", want: "-0.05"},
+		{input: "+7", want: "7"},
+		{input: ".5", want: "0.5"},
+		{input: "1500", want: "1500"},
+		{input: "1e3", wantErr: true},
+		{input: "1.", wantErr: true},
+		{input: "-", wantErr: true},
+		{input: "abc", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := Parse(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String())
+		})
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	total := Decimal{}
+	for i := 0; i < 10; i++ {
+		total = total.Add(MustParse("0.10"))
+	}
+	assert.Equal(t, "1.00", total.String())
+	assert.Equal(t, 0, total.Cmp(MustParse("1")))
+
+	assert.Equal(t, "-0.25", MustParse("1.5").Sub(MustParse("1.75")).String())
+	assert.Equal(t, -1, MustParse("1.5").Sub(MustParse("1.75")).Sign())
+	assert.Equal(t, 0, MustParse("0.00").Sign())
+}
+
+func TestDecimal_FitsScale(t *testing.T) {
+	assert.True(t, MustParse("12.30").FitsScale(2))
+	assert.True(t, MustParse("12.3000").FitsScale(2))
+	assert.False(t, MustParse("12.345").FitsScale(2))
+	assert.True(t, MustParse("1.23456789").FitsScale(8))
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var v struct {
+		Amount Decimal  `json:"amount"`
+		Rate   *Decimal `json:"rate"`
+	}
+
+	err := json.Unmarshal([]byte(`{"amount": 19.99, "rate": "0.01234567"}`), &v)
+	assert.NoError(t, err)
+	assert.Equal(t, "19.99", v.Amount.String())
+	assert.Equal(t, "0.01234567", v.Rate.String())
+
+	data, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":19.99,"rate":0.01234567}`, string(data))
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	var d Decimal
+
+	assert.NoError(t, d.Scan("1234.50"))
+	assert.Equal(t, "1234.50", d.String())
+
+	assert.NoError(t, d.Scan(int64(3)))
+	assert.Equal(t, "3", d.String())
+
+	assert.Error(t, d.Scan(1.5))
+}
@@ -0,0 +1,309 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+var ErrNoExif = errors.New("no exif data")
+
+const (
+	tagMake             = 0x010f
+	tagModel            = 0x0110
+	tagOrientation      = 0x0112
+	tagDateTime         = 0x0132
+	tagExifIFD          = 0x8769
+	tagGPSIFD           = 0x8825
+	tagDateTimeOriginal = 0x9003
+	tagOffsetOriginal   = 0x9011
+	tagGPSLatitudeRef   = 0x0001
+	tagGPSLatitude      = 0x0002
+	tagGPSLongitudeRef  = 0x0003
+	tagGPSLongitude     = 0x0004
+	tagGPSAltitudeRef   = 0x0005
+	tagGPSAltitude      = 0x0006
+)
+
+type Exif struct {
+	CapturedAt  *time.Time
+	CameraMake  string
+	CameraModel string
+	Orientation int
+	Latitude    *float64
+	Longitude   *float64
+	Altitude    *float64
+}
+
+func ReadExif(data []byte) (*Exif, error) {
+	payload, err := exifPayload(data)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := newTiffReader(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ifd0, err := t.ifd(t.order.Uint32(payload[4:8]))
+	if err != nil {
+		return nil, err
+	}
+
+	exif := &Exif{
+		CameraMake:  ifd0[tagMake].ascii(),
+		CameraModel: ifd0[tagModel].ascii(),
+		Orientation: 1,
+	}
+	if orientation, ok := t.uint(ifd0[tagOrientation]); ok && orientation >= 1 && orientation <= 8 {
+		exif.Orientation = int(orientation)
+	}
+
+	captured := ifd0[tagDateTime].ascii()
+	offset := ""
+	if pointer, ok := t.uint(ifd0[tagExifIFD]); ok {
+		if sub, err := t.ifd(pointer); err == nil {
+			if original := sub[tagDateTimeOriginal].ascii(); original != "" {
+				captured = original
+			}
+			offset = sub[tagOffsetOriginal].ascii()
+		}
+	}
+	exif.CapturedAt = parseExifTime(captured, offset)
+
+	if pointer, ok := t.uint(ifd0[tagGPSIFD]); ok {
+		if gps, err := t.ifd(pointer); err == nil {
+			exif.Latitude = t.coordinate(gps[tagGPSLatitude], gps[tagGPSLatitudeRef].ascii(), "S", 90)
+			exif.Longitude = t.coordinate(gps[tagGPSLongitude], gps[tagGPSLongitudeRef].ascii(), "W", 180)
+			if exif.Latitude == nil || exif.Longitude == nil {
+				exif.Latitude, exif.Longitude = nil, nil
+			}
+			if altitude := t.rationals(gps[tagGPSAltitude]); len(altitude) == 1 {
+				value := altitude[0]
+				if ref := gps[tagGPSAltitudeRef]; ref != nil && len(ref.value) > 0 && ref.value[0] == 1 {
+					value = -value
+				}
+				exif.Altitude = &value
+			}
+		}
+	}
+
+	return exif, nil
+}
+
+func exifPayload(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8}):
+		return jpegExif(data)
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return pngExif(data)
+	}
+
+	return nil, ErrNoExif
+}
+
+func jpegExif(data []byte) ([]byte, error) {
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xff {
+			return nil, ErrNoExif
+		}
+		marker := data[pos+1]
+		if marker == 0xff {
+			pos++
+			continue
+		}
+		if marker == 0xd9 || marker == 0xda {
+			break
+		}
+		if marker >= 0xd0 && marker <= 0xd7 || marker == 0x01 {
+			pos += 2
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if length < 2 || pos+2+length > len(data) {
+			return nil, ErrNoExif
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:], nil
+		}
+		pos += 2 + length
+	}
+
+	return nil, ErrNoExif
+}
+
+func pngExif(data []byte) ([]byte, error) {
+	pos := 8
+	for pos+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		kind := string(data[pos+4 : pos+8])
+		if length < 0 || pos+12+length > len(data) {
+			return nil, ErrNoExif
+		}
+		if kind == "eXIf" {
+			return data[pos+8 : pos+8+length], nil
+		}
+		if kind == "IDAT" || kind == "IEND" {
+			break
+		}
+		pos += 12 + length
+	}
+
+	return nil, ErrNoExif
+}
+
+type tiffEntry struct {
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+func (e *tiffEntry) ascii() string {
+	if e == nil || e.typ != 2 {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimRight(string(e.value), "\x00"))
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+var tiffTypeSizes = map[uint16]uint32{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 7: 1, 9: 4, 10: 8}
+
+func newTiffReader(data []byte) (*tiffReader, error) {
+	if len(data) < 8 {
+		return nil, ErrNoExif
+	}
+
+	t := &tiffReader{data: data}
+	switch string(data[:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return nil, ErrNoExif
+	}
+	if t.order.Uint16(data[2:4]) != 42 {
+		return nil, ErrNoExif
+	}
+
+	return t, nil
+}
+
+func (t *tiffReader) ifd(offset uint32) (map[uint16]*tiffEntry, error) {
+	if uint64(offset)+2 > uint64(len(t.data)) {
+		return nil, ErrNoExif
+	}
+
+	count := uint32(t.order.Uint16(t.data[offset : offset+2]))
+	if uint64(offset)+2+uint64(count)*12 > uint64(len(t.data)) {
+		return nil, ErrNoExif
+	}
+
+	entries := make(map[uint16]*tiffEntry, count)
+	for i := uint32(0); i < count; i++ {
+		raw := t.data[offset+2+i*12 : offset+2+(i+1)*12]
+		entry := &tiffEntry{
+			typ:   t.order.Uint16(raw[2:4]),
+			count: t.order.Uint32(raw[4:8]),
+		}
+
+		size, ok := tiffTypeSizes[entry.typ]
+		if !ok || uint64(size)*uint64(entry.count) > uint64(len(t.data)) {
+			continue
+		}
+		length := size * entry.count
+		if length <= 4 {
+			entry.value = raw[8 : 8+length]
+		} else {
+			start := t.order.Uint32(raw[8:12])
+			if uint64(start)+uint64(length) > uint64(len(t.data)) {
+				continue
+			}
+			entry.value = t.data[start : start+length]
+		}
+
+		entries[t.order.Uint16(raw[0:2])] = entry
+	}
+
+	return entries, nil
+}
+
+func (t *tiffReader) uint(e *tiffEntry) (uint32, bool) {
+	if e == nil || e.count == 0 {
+		return 0, false
+	}
+
+	switch e.typ {
+	case 3:
+		return uint32(t.order.Uint16(e.value)), true
+	case 4:
+		return t.order.Uint32(e.value), true
+	}
+
+	return 0, false
+}
+
+func (t *tiffReader) rationals(e *tiffEntry) []float64 {
+	if e == nil || e.typ != 5 {
+		return nil
+	}
+
+	values := make([]float64, 0, e.count)
+	for i := uint32(0); i < e.count; i++ {
+		numerator := t.order.Uint32(e.value[i*8 : i*8+4])
+		denominator := t.order.Uint32(e.value[i*8+4 : i*8+8])
+		if denominator == 0 {
+			return nil
+		}
+		values = append(values, float64(numerator)/float64(denominator))
+	}
+
+	return values
+}
+
+func (t *tiffReader) coordinate(e *tiffEntry, ref string, negative string, limit float64) *float64 {
+	parts := t.rationals(e)
+	if len(parts) != 3 {
+		return nil
+	}
+
+	value := parts[0] + parts[1]/60 + parts[2]/3600
+	if strings.EqualFold(ref, negative) {
+		value = -value
+	}
+	if math.IsNaN(value) || math.Abs(value) > limit {
+		return nil
+	}
+
+	return &value
+}
+
+func parseExifTime(value string, offset string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	location := time.UTC
+	if zone, err := time.Parse("-07:00", offset); err == nil {
+		location = zone.Location()
+	}
+
+	parsed, err := time.ParseInLocation("2006:01:02 15:04:05", value, location)
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
@@ -0,0 +1,164 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+	"time"
+)
+
+type testIFDEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	data  []byte
+}
+
+func testIFD(base uint32, entries []testIFDEntry) []byte {
+	le := binary.LittleEndian
+	head := make([]byte, 2+len(entries)*12+4)
+	le.PutUint16(head, uint16(len(entries)))
+
+	var extra []byte
+	extraStart := base + uint32(len(head))
+	for i, e := range entries {
+		raw := head[2+i*12 : 2+(i+1)*12]
+		le.PutUint16(raw[0:2], e.tag)
+		le.PutUint16(raw[2:4], e.typ)
+		le.PutUint32(raw[4:8], e.count)
+		if len(e.data) <= 4 {
+			copy(raw[8:12], e.data)
+			continue
+		}
+		le.PutUint32(raw[8:12], extraStart+uint32(len(extra)))
+		extra = append(extra, e.data...)
+	}
+
+	return append(head, extra...)
+}
+
+func testRationals(values ...[2]uint32) []byte {
+	data := make([]byte, 0, len(values)*8)
+	for _, v := range values {
+		data = binary.LittleEndian.AppendUint32(data, v[0])
+		data = binary.LittleEndian.AppendUint32(data, v[1])
+	}
+	return data
+}
+
+func testShort(v uint16) []byte {
+	return binary.LittleEndian.AppendUint16(nil, v)
+}
+
+func testLong(v uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, v)
+}
+
+func testExifJPEG(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			if x < 20 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+
+	ifd0Entries := func(exifAt, gpsAt uint32) []testIFDEntry {
+		return []testIFDEntry{
+			{tagMake, 2, 6, []byte("Nikon\x00")},
+			{tagModel, 2, 5, []byte("D750\x00")},
+			{tagOrientation, 3, 1, testShort(6)},
+			{tagExifIFD, 4, 1, testLong(exifAt)},
+			{tagGPSIFD, 4, 1, testLong(gpsAt)},
+		}
+	}
+	ifd0Len := uint32(len(testIFD(8, ifd0Entries(0, 0))))
+	exifAt := 8 + ifd0Len
+	exifIFD := testIFD(exifAt, []testIFDEntry{
+		{tagDateTimeOriginal, 2, 20, []byte("2024:07:05 14:30:00\x00")},
+		{tagOffsetOriginal, 2, 7, []byte("+03:00\x00")},
+	})
+	gpsAt := exifAt + uint32(len(exifIFD))
+	gpsIFD := testIFD(gpsAt, []testIFDEntry{
+		{tagGPSLatitudeRef, 2, 2, []byte("N\x00")},
+		{tagGPSLatitude, 5, 3, testRationals([2]uint32{43, 1}, [2]uint32{30, 1}, [2]uint32{36, 1})},
+		{tagGPSLongitudeRef, 2, 2, []byte("W\x00")},
+		{tagGPSLongitude, 5, 3, testRationals([2]uint32{1, 1}, [2]uint32{15, 1}, [2]uint32{0, 1})},
+		{tagGPSAltitudeRef, 1, 1, []byte{0}},
+		{tagGPSAltitude, 5, 1, testRationals([2]uint32{2505, 10})},
+	})
+
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = append(tiff, testIFD(8, ifd0Entries(exifAt, gpsAt))...)
+	tiff = append(tiff, exifIFD...)
+	tiff = append(tiff, gpsIFD...)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func TestReadExif(t *testing.T) {
+	exif, err := ReadExif(testExifJPEG(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Nikon", exif.CameraMake)
+	assert.Equal(t, "D750", exif.CameraModel)
+	assert.Equal(t, 6, exif.Orientation)
+	require.NotNil(t, exif.CapturedAt)
+	assert.True(t, exif.CapturedAt.Equal(time.Date(2024, 7, 5, 11, 30, 0, 0, time.UTC)))
+	require.NotNil(t, exif.Latitude)
+	assert.InDelta(t, 43.51, *exif.Latitude, 1e-9)
+	assert.InDelta(t, -1.25, *exif.Longitude, 1e-9)
+	assert.InDelta(t, 250.5, *exif.Altitude, 1e-9)
+
+	_, err = ReadExif([]byte("not an image"))
+	assert.ErrorIs(t, err, ErrNoExif)
+}
+
+func TestThumbnail(t *testing.T) {
+	data := testExifJPEG(t)
+	img, err := Decode(data)
+	require.NoError(t, err)
+	flat := Flatten(img)
+
+	thumb := Thumbnail(flat, 10, 1)
+	assert.Equal(t, image.Rect(0, 0, 10, 5), thumb.Bounds())
+	r, _, b, _ := thumb.At(1, 2).RGBA()
+	assert.Greater(t, r, b)
+
+	rotated := Thumbnail(flat, 10, 6)
+	assert.Equal(t, image.Rect(0, 0, 5, 10), rotated.Bounds())
+	r, _, b, _ = rotated.At(2, 1).RGBA()
+	assert.Greater(t, r, b)
+	r, _, b, _ = rotated.At(2, 8).RGBA()
+	assert.Greater(t, b, r)
+
+	unchanged := Thumbnail(flat, 100, 1)
+	assert.Equal(t, image.Rect(0, 0, 40, 20), unchanged.Bounds())
+
+	again := Thumbnail(flat, 10, 1)
+	assert.Equal(t, thumb.Pix, again.Pix)
+
+	encoded, err := EncodeJPEG(thumb, 85)
+	require.NoError(t, err)
+	_, err = Decode(encoded)
+	assert.NoError(t, err)
+
+	_, err = Decode([]byte("%PDF-1.4"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
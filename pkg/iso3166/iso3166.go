@@ -0,0 +1,145 @@
+package iso3166
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	English = "en"
+	Russian = "ru"
+)
+
+type Country struct {
+	Alpha2  string
+	Alpha3  string
+	NameEn  string
+	NameRu  string
+	Aliases []string
+}
+
+func (c *Country) Name(lang string) string {
+	if lang == Russian {
+		return c.NameRu
+	}
+
+	return c.NameEn
+}
+
+//go:embed countries.tsv
+var dataset string
+
+var (
+	countries []*Country
+	byAlpha2  map[string]*Country
+	byAlpha3  map[string]*Country
+	byKey     map[string]*Country
+)
+
+func init() {
+	var err error
+	countries, err = parse(dataset)
+	if err != nil {
+		panic(err)
+	}
+
+	byAlpha2 = make(map[string]*Country, len(countries))
+	byAlpha3 = make(map[string]*Country, len(countries))
+	byKey = make(map[string]*Country, 4*len(countries))
+	for _, c := range countries {
+		byAlpha2[c.Alpha2] = c
+		byAlpha3[c.Alpha3] = c
+		for _, name := range append([]string{c.NameEn, c.NameRu}, c.Aliases...) {
+			byKey[key(name)] = c
+		}
+	}
+}
+
+func parse(data string) ([]*Country, error) {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comma = '\t'
+	r.FieldsPerRecord = 5
+	r.LazyQuotes = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("iso3166: %v", err)
+	}
+
+	result := make([]*Country, 0, len(records))
+	seen := make(map[string]bool, 2*len(records))
+	for _, record := range records {
+		c := &Country{Alpha2: record[0], Alpha3: record[1], NameEn: record[2], NameRu: record[3]}
+		if len(c.Alpha2) != 2 || len(c.Alpha3) != 3 || c.NameEn == "" || c.NameRu == "" {
+			return nil, fmt.Errorf("iso3166: invalid record %q", record)
+		}
+		if seen[c.Alpha2] || seen[c.Alpha3] {
+			return nil, fmt.Errorf("iso3166: duplicate code %s", c.Alpha2)
+		}
+		seen[c.Alpha2], seen[c.Alpha3] = true, true
+
+		if record[4] != "" {
+			c.Aliases = strings.Split(record[4], "|")
+		}
+		result = append(result, c)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Alpha2 < result[j].Alpha2 })
+
+	return result, nil
+}
+
+func All() []*Country {
+	return countries
+}
+
+func ByAlpha2(code string) (*Country, bool) {
+	c, ok := byAlpha2[code]
+	return c, ok
+}
+
+func Find(value string) (*Country, bool) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if c, ok := byAlpha2[code]; ok {
+		return c, true
+	}
+	if c, ok := byAlpha3[code]; ok {
+		return c, true
+	}
+
+	c, ok := byKey[key(value)]
+	return c, ok
+}
+
+var folds = map[rune]rune{
+	'ё': 'е', 'å': 'a', 'á': 'a', 'à': 'a', 'ä': 'a', 'é': 'e', 'è': 'e', 'ç': 'c',
+	'í': 'i', 'ó': 'o', 'ô': 'o', 'ö': 'o', 'ü': 'u', 'ú': 'u', 'ñ': 'n',
+}
+
+func key(value string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(value) {
+		if folded, ok := folds[r]; ok {
+			r = folded
+		}
+		if r == '\'' || r == '’' {
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+
+	return strings.TrimPrefix(b.String(), "the ")
+}
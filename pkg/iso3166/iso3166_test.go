@@ -0,0 +1,66 @@
+package iso3166
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDataset(t *testing.T) {
+	assert.Len(t, All(), 249)
+
+	owners := make(map[string]string)
+	for _, c := range All() {
+		for _, name := range append([]string{c.NameEn, c.NameRu}, c.Aliases...) {
+			k := key(name)
+			if owner, ok := owners[k]; ok && owner != c.Alpha2 {
+				t.Errorf("name %q is shared by %s and %s", name, owner, c.Alpha2)
+			}
+			owners[k] = c.Alpha2
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	testCases := []struct {
+		input  string
+		want   string
+		wantOk bool
+	}{
+		{input: "RU", want: "RU", wantOk: true},
+		{input: " ru ", want: "RU", wantOk: true},
+		{input: "RUS", want: "RU", wantOk: true},
+		{input: "Russia", want: "RU", wantOk: true},
+		{input: "Russian Federation", want: "RU", wantOk: true},
+		{input: "российская федерация", want: "RU", wantOk: true},
+		{input: "РФ", want: "RU", wantOk: true},
+		{input: "Кыргызстан", want: "KG", wantOk: true},
+		{input: "The Netherlands", want: "NL", wantOk: true},
+		{input: "Cote d’Ivoire", want: "CI", wantOk: true},
+		{input: "Åland Islands", want: "AX", wantOk: true},
+		{input: "Объединенные Арабские Эмираты", want: "AE", wantOk: true},
+		{input: "UK", want: "GB", wantOk: true},
+		{input: "Атлантида", wantOk: false},
+		{input: "", wantOk: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, ok := Find(tc.input)
+			assert.Equal(t, tc.wantOk, ok)
+			if tc.wantOk {
+				assert.Equal(t, tc.want, got.Alpha2)
+			}
+		})
+	}
+}
+
+func TestCountry_Name(t *testing.T) {
+	c, ok := ByAlpha2("GR")
+	assert.True(t, ok)
+	assert.Equal(t, "Greece", c.Name(English))
+	assert.Equal(t, "Греция", c.Name(Russian))
+	assert.Equal(t, "Greece", c.Name("de"))
+
+	_, ok = ByAlpha2("gr")
+	assert.False(t, ok)
+}
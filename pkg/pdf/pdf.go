@@ -0,0 +1,173 @@
+package pdf
+
+import (
+	"bytes"
+	"db_cp_6/pkg/translit"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Font int
+
+const (
+	Helvetica Font = iota
+	CourierBold
+)
+
+var fontNames = [...]string{"Helvetica", "Courier-Bold"}
+
+const PointsPerMM = 72 / 25.4
+
+type Document struct {
+	width  float64
+	height float64
+	pages  []*Page
+}
+
+type Page struct {
+	content bytes.Buffer
+}
+
+func New(width float64, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+func (p *Page) FillRect(x float64, y float64, width float64, height float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(y), num(width), num(height))
+}
+
+func (p *Page) StrokeRect(x float64, y float64, width float64, height float64, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n", num(lineWidth), num(x), num(y), num(width), num(height))
+}
+
+func (p *Page) Text(x float64, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n", font+1, num(size), num(x), num(y), escape(Encode(text)))
+}
+
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	offsets := make([]int, 0)
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	firstPage := 3 + len(fontNames)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+	fonts := make([]string, len(fontNames))
+	for i := range fontNames {
+		fonts[i] = fmt.Sprintf("/F%d %d 0 R", i+1, 3+i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	for _, name := range fontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			num(d.width), num(d.height), strings.Join(fonts, " "), firstPage+i*2+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+func TextWidth(font Font, size float64, text string) float64 {
+	units := 0
+	for _, b := range Encode(text) {
+		if font == CourierBold {
+			units += 600
+		} else {
+			units += helveticaWidth(b)
+		}
+	}
+
+	return float64(units) * size / 1000
+}
+
+func Truncate(font Font, size float64, text string, width float64) string {
+	if TextWidth(font, size, text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && TextWidth(font, size, string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return strings.TrimSpace(string(runes)) + "..."
+}
+
+func Encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range translit.Latin(text) {
+		switch {
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		case r == '–':
+			encoded = append(encoded, 0x96)
+		case r == '—':
+			encoded = append(encoded, 0x97)
+		case r == '€':
+			encoded = append(encoded, 0x80)
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+
+	return encoded
+}
+
+func escape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+func num(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+func helveticaWidth(b byte) int {
+	if b >= 0x20 && b < 0x7F {
+		return helveticaWidths[b-0x20]
+	}
+
+	return 556
+}
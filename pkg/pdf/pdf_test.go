@@ -0,0 +1,55 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := New(210*PointsPerMM, 297*PointsPerMM)
+	page := doc.AddPage()
+	page.Text(10, 20, Helvetica, 9, "Амфора (fragment) \\ 2")
+	page.FillRect(1, 2, 3, 4)
+	doc.AddPage().StrokeRect(5, 5, 50, 20, 0.5)
+
+	data := doc.Bytes()
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Contains(t, string(data), `(Amfora \(fragment\) \\ 2) Tj`)
+	assert.Contains(t, string(data), "/MediaBox [0 0 595.28 841.89]")
+	assert.Contains(t, string(data), "/Count 2")
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	require.NotNil(t, startxref)
+	xref, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n0 9\n")))
+
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	require.Len(t, offsets, 8)
+	for i, match := range offsets {
+		offset, err := strconv.Atoi(string(match[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))))
+	}
+
+	lengths := regexp.MustCompile(`/Length (\d+) >>\nstream\n`).FindAllSubmatchIndex(data, -1)
+	require.Len(t, lengths, 2)
+	for _, match := range lengths {
+		length, err := strconv.Atoi(string(data[match[2]:match[3]]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data[match[1]+length:], []byte("endstream")))
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "Kylix", Truncate(Helvetica, 10, "Kylix", 100))
+	assert.Equal(t, "Red-figure...", Truncate(Helvetica, 10, "Red-figure kylix with palmettes", 60))
+	assert.Equal(t, 12.0, TextWidth(CourierBold, 10, "Ж"))
+	assert.Equal(t, 18.0, TextWidth(CourierBold, 10, "Жз"))
+}
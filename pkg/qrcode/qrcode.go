@@ -0,0 +1,478 @@
+package qrcode
+
+import "errors"
+
+type Level int
+
+const (
+	Low Level = iota
+	Medium
+	Quartile
+	High
+)
+
+var ErrTooLong = errors.New("data does not fit into a QR code")
+
+var formatLevelBits = [4]int{1, 0, 3, 2}
+
+type blockLayout struct {
+	ecPerBlock int
+	groups     [][2]int
+}
+
+var versionTotals = [...]int{0, 26, 44, 70, 100, 134, 172, 196, 242, 292, 346}
+
+var blockLayouts = [...][4]blockLayout{
+	{},
+	{{7, [][2]int{{1, 19}}}, {10, [][2]int{{1, 16}}}, {13, [][2]int{{1, 13}}}, {17, [][2]int{{1, 9}}}},
+	{{10, [][2]int{{1, 34}}}, {16, [][2]int{{1, 28}}}, {22, [][2]int{{1, 22}}}, {28, [][2]int{{1, 16}}}},
+	{{15, [][2]int{{1, 55}}}, {26, [][2]int{{1, 44}}}, {18, [][2]int{{2, 17}}}, {22, [][2]int{{2, 13}}}},
+	{{20, [][2]int{{1, 80}}}, {18, [][2]int{{2, 32}}}, {26, [][2]int{{2, 24}}}, {16, [][2]int{{4, 9}}}},
+	{{26, [][2]int{{1, 108}}}, {24, [][2]int{{2, 43}}}, {18, [][2]int{{2, 15}, {2, 16}}}, {22, [][2]int{{2, 11}, {2, 12}}}},
+	{{18, [][2]int{{2, 68}}}, {16, [][2]int{{4, 27}}}, {24, [][2]int{{4, 19}}}, {28, [][2]int{{4, 15}}}},
+	{{20, [][2]int{{2, 78}}}, {18, [][2]int{{4, 31}}}, {18, [][2]int{{2, 14}, {4, 15}}}, {26, [][2]int{{4, 13}, {1, 14}}}},
+	{{24, [][2]int{{2, 97}}}, {22, [][2]int{{2, 38}, {2, 39}}}, {22, [][2]int{{4, 18}, {2, 19}}}, {26, [][2]int{{4, 14}, {2, 15}}}},
+	{{30, [][2]int{{2, 116}}}, {22, [][2]int{{3, 36}, {2, 37}}}, {20, [][2]int{{4, 16}, {4, 17}}}, {24, [][2]int{{4, 12}, {4, 13}}}},
+	{{18, [][2]int{{2, 68}, {2, 69}}}, {26, [][2]int{{4, 43}, {1, 44}}}, {24, [][2]int{{6, 19}, {2, 20}}}, {28, [][2]int{{6, 15}, {2, 16}}}},
+}
+
+var alignmentCenters = [...][]int{
+	nil, nil,
+	{6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34},
+	{6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50},
+}
+
+const maxVersion = len(versionTotals) - 1
+
+type Code struct {
+	Size     int
+	version  int
+	modules  []bool
+	function []bool
+}
+
+func Encode(data []byte, level Level) (*Code, error) {
+	if level < Low || level > High {
+		level = Medium
+	}
+
+	version := 0
+	for v := 1; v <= maxVersion; v++ {
+		if 4+countBits(v)+len(data)*8 <= dataCapacity(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	c := &Code{Size: version*4 + 17, version: version}
+	c.modules = make([]bool, c.Size*c.Size)
+	c.function = make([]bool, c.Size*c.Size)
+
+	c.drawFunctionPatterns()
+	c.drawCodewords(c.codewords(data, level))
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(level, mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask)
+	}
+	c.applyMask(bestMask)
+	c.drawFormatBits(level, bestMask)
+
+	return c, nil
+}
+
+func (c *Code) Version() int {
+	return c.version
+}
+
+func (c *Code) Black(x int, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+
+	return c.modules[y*c.Size+x]
+}
+
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+
+	return 16
+}
+
+func dataCapacity(version int, level Level) int {
+	capacity := 0
+	for _, group := range blockLayouts[version][level].groups {
+		capacity += group[0] * group[1]
+	}
+
+	return capacity
+}
+
+func (c *Code) set(x int, y int, dark bool) {
+	c.modules[y*c.Size+x] = dark
+	c.function[y*c.Size+x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.Size; i++ {
+		c.set(6, i, i%2 == 0)
+		c.set(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	centers := alignmentCenters[c.version]
+	last := len(centers) - 1
+	for i, cx := range centers {
+		for j, cy := range centers {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(cx, cy)
+		}
+	}
+
+	c.drawFormatBits(Low, 0)
+	c.drawVersionBits()
+}
+
+func (c *Code) drawFinder(cx int, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+				continue
+			}
+			dist := maxInt(absInt(dx), absInt(dy))
+			c.set(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(cx int, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.set(cx+dx, cy+dy, maxInt(absInt(dx), absInt(dy)) != 1)
+		}
+	}
+}
+
+func (c *Code) drawFormatBits(level Level, mask int) {
+	data := formatLevelBits[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		c.set(8, i, bit(bits, i))
+	}
+	c.set(8, 7, bit(bits, 6))
+	c.set(8, 8, bit(bits, 7))
+	c.set(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.set(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.set(c.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.set(8, c.Size-15+i, bit(bits, i))
+	}
+	c.set(8, c.Size-8, true)
+}
+
+func (c *Code) drawVersionBits() {
+	if c.version < 7 {
+		return
+	}
+
+	rem := c.version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		a, b := c.Size-11+i%3, i/3
+		c.set(a, b, bit(bits, i))
+		c.set(b, a, bit(bits, i))
+	}
+}
+
+func (c *Code) codewords(data []byte, level Level) []byte {
+	capacity := dataCapacity(c.version, level)
+
+	var w bitWriter
+	w.write(0b0100, 4)
+	w.write(len(data), countBits(c.version))
+	for _, b := range data {
+		w.write(int(b), 8)
+	}
+	w.write(0, minInt(4, capacity*8-w.length))
+	w.write(0, (8-w.length%8)%8)
+	for pad := 0xEC; w.length < capacity*8; pad ^= 0xEC ^ 0x11 {
+		w.write(pad, 8)
+	}
+
+	layout := blockLayouts[c.version][level]
+	generator := rsGenerator(layout.ecPerBlock)
+	dataBlocks := make([][]byte, 0)
+	ecBlocks := make([][]byte, 0)
+	offset := 0
+	for _, group := range layout.groups {
+		for i := 0; i < group[0]; i++ {
+			block := w.bytes[offset : offset+group[1]]
+			offset += group[1]
+			dataBlocks = append(dataBlocks, block)
+			ecBlocks = append(ecBlocks, rsRemainder(block, generator))
+		}
+	}
+
+	result := make([]byte, 0, versionTotals[c.version])
+	for i := 0; ; i++ {
+		added := false
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	for i := 0; i < layout.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+
+	return result
+}
+
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < c.Size; vert++ {
+			y := vert
+			if upward {
+				y = c.Size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.function[y*c.Size+x] {
+					continue
+				}
+				if i < len(data)*8 {
+					c.modules[y*c.Size+x] = data[i>>3]>>(7-i&7)&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.function[y*c.Size+x] {
+				continue
+			}
+
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			default:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				c.modules[y*c.Size+x] = !c.modules[y*c.Size+x]
+			}
+		}
+	}
+}
+
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func (c *Code) penalty() int {
+	penalty := 0
+	line := make([]bool, c.Size)
+
+	for pass := 0; pass < 2; pass++ {
+		for i := 0; i < c.Size; i++ {
+			for j := 0; j < c.Size; j++ {
+				if pass == 0 {
+					line[j] = c.Black(j, i)
+				} else {
+					line[j] = c.Black(i, j)
+				}
+			}
+
+			run := 1
+			for j := 1; j <= c.Size; j++ {
+				if j < c.Size && line[j] == line[j-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+
+			for j := 0; j+len(finderLike[0]) <= c.Size; j++ {
+				for _, pattern := range finderLike {
+					matched := true
+					for k, dark := range pattern {
+						if line[j+k] != dark {
+							matched = false
+							break
+						}
+					}
+					if matched {
+						penalty += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Black(x, y) {
+				dark++
+			}
+			if x+1 < c.Size && y+1 < c.Size {
+				color := c.Black(x, y)
+				if color == c.Black(x+1, y) && color == c.Black(x, y+1) && color == c.Black(x+1, y+1) {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	total := c.Size * c.Size
+	penalty += absInt(dark*20-total*10) / total * 10
+
+	return penalty
+}
+
+type bitWriter struct {
+	bytes  []byte
+	length int
+}
+
+func (w *bitWriter) write(value int, count int) {
+	for i := count - 1; i >= 0; i-- {
+		if w.length%8 == 0 {
+			w.bytes = append(w.bytes, 0)
+		}
+		if value>>i&1 == 1 {
+			w.bytes[len(w.bytes)-1] |= 1 << (7 - w.length%8)
+		}
+		w.length++
+	}
+}
+
+func rsGenerator(degree int) []byte {
+	generator := make([]byte, degree)
+	generator[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			generator[j] = gfMultiply(generator[j], root)
+			if j+1 < degree {
+				generator[j] ^= generator[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return generator
+}
+
+func rsRemainder(data []byte, generator []byte) []byte {
+	remainder := make([]byte, len(generator))
+	for _, b := range data {
+		factor := b ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[len(remainder)-1] = 0
+		for i, coefficient := range generator {
+			remainder[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+
+	return remainder
+}
+
+func gfMultiply(x byte, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int(y>>i&1) * int(x)
+	}
+
+	return byte(z)
+}
+
+func bit(value int, i int) bool {
+	return value>>i&1 != 0
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,219 @@
+package qrcode
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func readFormat(c *Code) int {
+	bits := 0
+	for i := 0; i <= 5; i++ {
+		if c.Black(8, i) {
+			bits |= 1 << i
+		}
+	}
+	if c.Black(8, 7) {
+		bits |= 1 << 6
+	}
+	if c.Black(8, 8) {
+		bits |= 1 << 7
+	}
+	if c.Black(7, 8) {
+		bits |= 1 << 8
+	}
+	for i := 9; i < 15; i++ {
+		if c.Black(14-i, 8) {
+			bits |= 1 << i
+		}
+	}
+
+	return bits
+}
+
+func decode(t *testing.T, c *Code, level Level) []byte {
+	format := readFormat(c) ^ 0x5412
+	require.Equal(t, formatLevelBits[level], format>>13)
+	mask := format >> 10 & 7
+
+	rem := format >> 10
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	require.Equal(t, format&0x3FF, rem, "format BCH")
+
+	c.applyMask(mask)
+	defer c.applyMask(mask)
+
+	raw := make([]byte, versionTotals[c.version])
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			y := vert
+			if (right+1)&2 == 0 {
+				y = c.Size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.function[y*c.Size+x] || i >= len(raw)*8 {
+					continue
+				}
+				if c.Black(x, y) {
+					raw[i>>3] |= 1 << (7 - i&7)
+				}
+				i++
+			}
+		}
+	}
+	require.Equal(t, len(raw)*8, i)
+
+	layout := blockLayouts[c.version][level]
+	blocks := make([][]byte, 0)
+	for _, group := range layout.groups {
+		for k := 0; k < group[0]; k++ {
+			blocks = append(blocks, make([]byte, 0, group[1]+layout.ecPerBlock))
+		}
+	}
+	pos := 0
+	for col := 0; ; col++ {
+		added := false
+		for b := range blocks {
+			if col < dataLength(layout, b) {
+				blocks[b] = append(blocks[b], raw[pos])
+				pos++
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	for col := 0; col < layout.ecPerBlock; col++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], raw[pos])
+			pos++
+		}
+	}
+
+	data := make([]byte, 0)
+	for b, block := range blocks {
+		root := byte(1)
+		for k := 0; k < layout.ecPerBlock; k++ {
+			syndrome := byte(0)
+			for _, coefficient := range block {
+				syndrome = gfMultiply(syndrome, root) ^ coefficient
+			}
+			require.Zerof(t, syndrome, "block %d syndrome %d", b, k)
+			root = gfMultiply(root, 0x02)
+		}
+		data = append(data, block[:dataLength(layout, b)]...)
+	}
+
+	r := bitReader{data: data}
+	require.Equal(t, 0b0100, r.read(4))
+	length := r.read(countBits(c.version))
+	out := make([]byte, length)
+	for k := range out {
+		out[k] = byte(r.read(8))
+	}
+
+	return out
+}
+
+func dataLength(layout blockLayout, block int) int {
+	for _, group := range layout.groups {
+		if block < group[0] {
+			return group[1]
+		}
+		block -= group[0]
+	}
+	return 0
+}
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) read(count int) int {
+	value := 0
+	for i := 0; i < count; i++ {
+		value = value<<1 | int(r.data[r.pos>>3]>>(7-r.pos&7)&1)
+		r.pos++
+	}
+	return value
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		level       Level
+		wantVersion int
+	}{
+		{name: "short", data: "12-2024-0007", level: Medium, wantVersion: 1},
+		{name: "url", data: "https://finds.example.org/api/v1/artifacts/12345", level: Medium, wantVersion: 4},
+		{name: "two groups", data: strings.Repeat("x", 60), level: Quartile, wantVersion: 5},
+		{name: "version info", data: strings.Repeat("catalog ", 18), level: Low, wantVersion: 7},
+		{name: "largest", data: strings.Repeat("z", 119), level: High, wantVersion: 10},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Encode([]byte(tc.data), tc.level)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantVersion, c.Version())
+			assert.Equal(t, tc.wantVersion*4+17, c.Size)
+			assert.Equal(t, tc.data, string(decode(t, c, tc.level)))
+
+			for _, corner := range [][2]int{{0, 0}, {c.Size - 7, 0}, {0, c.Size - 7}} {
+				for d := 0; d < 7; d++ {
+					assert.True(t, c.Black(corner[0]+d, corner[1]))
+					assert.True(t, c.Black(corner[0], corner[1]+d))
+				}
+				assert.False(t, c.Black(corner[0]+1, corner[1]+1))
+				assert.True(t, c.Black(corner[0]+3, corner[1]+3))
+			}
+			assert.True(t, c.Black(8, c.Size-8))
+		})
+	}
+}
+
+func TestEncode_FormatBits(t *testing.T) {
+	want := map[Level]int{
+		Low:      0b111011111000100,
+		Medium:   0b101010000010010,
+		Quartile: 0b011010101011111,
+		High:     0b001011010001001,
+	}
+
+	for level, bits := range want {
+		c := &Code{Size: 21, version: 1, modules: make([]bool, 21*21), function: make([]bool, 21*21)}
+		c.drawFormatBits(level, 0)
+		assert.Equal(t, bits, readFormat(c))
+	}
+}
+
+func TestEncode_VersionBits(t *testing.T) {
+	c, err := Encode([]byte(strings.Repeat("catalog ", 18)), Low)
+	require.NoError(t, err)
+
+	bits := 0
+	for i := 0; i < 18; i++ {
+		if c.Black(c.Size-11+i%3, i/3) {
+			bits |= 1 << i
+		}
+		assert.Equal(t, c.Black(c.Size-11+i%3, i/3), c.Black(i/3, c.Size-11+i%3))
+	}
+	assert.Equal(t, 0x07C94, bits)
+}
+
+func TestEncode_TooLong(t *testing.T) {
+	_, err := Encode([]byte(strings.Repeat("a", 400)), Medium)
+	assert.ErrorIs(t, err, ErrTooLong)
+}
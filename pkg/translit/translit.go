@@ -0,0 +1,40 @@
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+func Latin(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		latin, ok := cyrillic[unicode.ToLower(r)]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		if !unicode.IsUpper(r) || latin == "" {
+			b.WriteString(latin)
+			continue
+		}
+
+		upperWord := len(latin) > 1 && (i+1 < len(runes) && unicode.IsUpper(runes[i+1]) ||
+			i > 0 && unicode.IsUpper(runes[i-1]) && (i+1 == len(runes) || !unicode.IsLetter(runes[i+1])))
+		if upperWord {
+			b.WriteString(strings.ToUpper(latin))
+		} else {
+			b.WriteString(strings.ToUpper(latin[:1]) + latin[1:])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,27 @@
+package translit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLatin(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{input: "Амфора", want: "Amfora"},
+		{input: "Чаша с ручкой", want: "Chasha s ruchkoy"},
+		{input: "ЩИТ", want: "SHCHIT"},
+		{input: "Юг, Щ", want: "Yug, Shch"},
+		{input: "Подъём", want: "Podyom"},
+		{input: "Kylix 3", want: "Kylix 3"},
+		{input: "Ольвия-2024", want: "Olviya-2024"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.want, Latin(tc.input))
+		})
+	}
+}
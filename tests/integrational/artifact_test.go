@@ -30,14 +30,12 @@ func TestPgArtifactService_GetArtifactById(t *testing.T) {
 				client: pgClient,
 				input: &entity.CreateArtifactInput{
 					Name: "aaa",
-					Age:  10000,
 				},
 			},
-			s:  service.NewArtifactService(pgRepo.ArtifactRepo),
+			s:  service.NewArtifactService(pgRepo.ArtifactRepo, pgRepo.ExpeditionRepo, pgRepo.CustodyRepo, pgRepo.ExcavationContextRepo, pgRepo.PeriodRepo, pgRepo.ConditionReportRepo, pgRepo.MemberRepo),
 			ls: service.NewLocationService(pgRepo.LocationRepo),
 			want: &entity.Artifact{
 				Name: "aaa",
-				Age:  10000,
 			},
 			wantErr: false,
 		},
@@ -52,7 +50,7 @@ func TestPgArtifactService_GetArtifactById(t *testing.T) {
 
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
@@ -94,7 +92,7 @@ func TestPgArtifactService_GetLocationArtifacts(t *testing.T) {
 				client:     pgClient,
 				locationId: 100,
 			},
-			s:       service.NewArtifactService(pgRepo.ArtifactRepo),
+			s:       service.NewArtifactService(pgRepo.ArtifactRepo, pgRepo.ExpeditionRepo, pgRepo.CustodyRepo, pgRepo.ExcavationContextRepo, pgRepo.PeriodRepo, pgRepo.ConditionReportRepo, pgRepo.MemberRepo),
 			want:    entity.Artifacts{},
 			wantErr: false,
 		},
@@ -113,6 +111,46 @@ func TestPgArtifactService_GetLocationArtifacts(t *testing.T) {
 	}
 }
 
+func TestPgArtifactService_GetExpeditionArtifacts(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		client       any
+		expeditionId int
+	}
+
+	testCases := []struct {
+		name    string
+		args    args
+		s       *service.ArtifactService
+		want    entity.Artifacts
+		wantErr bool
+	}{
+		{
+			name: "Simple positive test",
+			args: args{
+				ctx:          context.Background(),
+				client:       pgClient,
+				expeditionId: 100,
+			},
+			s:       service.NewArtifactService(pgRepo.ArtifactRepo, pgRepo.ExpeditionRepo, pgRepo.CustodyRepo, pgRepo.ExcavationContextRepo, pgRepo.PeriodRepo, pgRepo.ConditionReportRepo, pgRepo.MemberRepo),
+			want:    entity.Artifacts{},
+			wantErr: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.s.GetExpeditionArtifacts(tc.args.ctx, tc.args.client, tc.args.expeditionId)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestPgArtifactService_GetAllArtifacts(t *testing.T) {
 	type args struct {
 		ctx    context.Context
@@ -132,14 +170,14 @@ func TestPgArtifactService_GetAllArtifacts(t *testing.T) {
 				ctx:    context.Background(),
 				client: pgClient,
 			},
-			s:       service.NewArtifactService(pgRepo.ArtifactRepo),
+			s:       service.NewArtifactService(pgRepo.ArtifactRepo, pgRepo.ExpeditionRepo, pgRepo.CustodyRepo, pgRepo.ExcavationContextRepo, pgRepo.PeriodRepo, pgRepo.ConditionReportRepo, pgRepo.MemberRepo),
 			want:    entity.Artifacts{},
 			wantErr: false,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			got, err := tc.s.GetAllArtifacts(tc.args.ctx, tc.args.client)
+			got, err := tc.s.GetAllArtifacts(tc.args.ctx, tc.args.client, nil)
 			if tc.wantErr {
 				assert.Error(t, err)
 				return
@@ -172,10 +210,9 @@ func TestPgArtifactService_CreateArtifact(t *testing.T) {
 				client: pgClient,
 				input: &entity.CreateArtifactInput{
 					Name: "aaa",
-					Age:  10000,
 				},
 			},
-			s:       service.NewArtifactService(pgRepo.ArtifactRepo),
+			s:       service.NewArtifactService(pgRepo.ArtifactRepo, pgRepo.ExpeditionRepo, pgRepo.CustodyRepo, pgRepo.ExcavationContextRepo, pgRepo.PeriodRepo, pgRepo.ConditionReportRepo, pgRepo.MemberRepo),
 			ls:      service.NewLocationService(pgRepo.LocationRepo),
 			wantErr: false,
 		},
@@ -184,7 +221,7 @@ func TestPgArtifactService_CreateArtifact(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
@@ -31,7 +31,7 @@ func TestPgCuratorService_GetCuratorById(t *testing.T) {
 					Name: "aaa",
 				},
 			},
-			s: service.NewCuratorService(pgRepo.CuratorRepo),
+			s: service.NewCuratorService(pgRepo.CuratorRepo, pgRepo.ArtifactRepo),
 			want: &entity.Curator{
 				Name: "aaa",
 			},
@@ -81,7 +81,7 @@ func TestPgCuratorService_GetExpeditionCurators(t *testing.T) {
 				client:       pgClient,
 				expeditionId: 100,
 			},
-			s:       service.NewCuratorService(pgRepo.CuratorRepo),
+			s:       service.NewCuratorService(pgRepo.CuratorRepo, pgRepo.ArtifactRepo),
 			want:    entity.Curators{},
 			wantErr: false,
 		},
@@ -119,7 +119,7 @@ func TestPgCuratorService_GetAllCurators(t *testing.T) {
 				ctx:    context.Background(),
 				client: pgClient,
 			},
-			s:       service.NewCuratorService(pgRepo.CuratorRepo),
+			s:       service.NewCuratorService(pgRepo.CuratorRepo, pgRepo.ArtifactRepo),
 			want:    entity.Curators{},
 			wantErr: false,
 		},
@@ -160,7 +160,7 @@ func TestPgCuratorService_CreateCurator(t *testing.T) {
 					Name: "aaa",
 				},
 			},
-			s:       service.NewCuratorService(pgRepo.CuratorRepo),
+			s:       service.NewCuratorService(pgRepo.CuratorRepo, pgRepo.ArtifactRepo),
 			wantErr: false,
 		},
 	}
@@ -209,7 +209,7 @@ func TestPgCuratorService_DeleteCurator(t *testing.T) {
 					Name: "aaa",
 				},
 			},
-			s:       service.NewCuratorService(pgRepo.CuratorRepo),
+			s:       service.NewCuratorService(pgRepo.CuratorRepo, pgRepo.ArtifactRepo),
 			wantErr: false,
 		},
 	}
@@ -6,6 +6,7 @@ import (
 	"db_cp_6/internal/service"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestPgEquipmentService_GetEquipmentById(t *testing.T) {
@@ -21,6 +22,7 @@ func TestPgEquipmentService_GetEquipmentById(t *testing.T) {
 		s       *service.EquipmentService
 		es      *service.ExpeditionService
 		ls      *service.LocationService
+		is      *service.InventoryService
 		want    *entity.Equipment
 		wantErr bool
 	}{
@@ -30,16 +32,18 @@ func TestPgEquipmentService_GetEquipmentById(t *testing.T) {
 				ctx:    context.Background(),
 				client: pgClient,
 				input: &entity.CreateEquipmentInput{
-					Name:   "aaa",
 					Amount: 10000,
 				},
 			},
-			s:  service.NewEquipmentService(pgRepo.EquipmentRepo),
+			s:  service.NewEquipmentService(pgRepo.EquipmentRepo, pgRepo.InventoryRepo, pgRepo.ExpeditionRepo),
 			ls: service.NewLocationService(pgRepo.LocationRepo),
 			es: service.NewExpeditionService(pgRepo.ExpeditionRepo),
+			is: service.NewInventoryService(pgRepo.InventoryRepo),
 			want: &entity.Equipment{
-				Name:   "aaa",
-				Amount: 10000,
+				Name:         "aaa",
+				Amount:       10000,
+				ReservedFrom: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+				ReservedTo:   time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
 			},
 			wantErr: false,
 		},
@@ -54,7 +58,7 @@ func TestPgEquipmentService_GetEquipmentById(t *testing.T) {
 
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
@@ -68,6 +72,15 @@ func TestPgEquipmentService_GetEquipmentById(t *testing.T) {
 			tc.want.ExpeditionId = expeditionId
 			tc.args.input.ExpeditionId = expeditionId
 
+			itemId, err := tc.is.CreateInventoryItem(tc.args.ctx, tc.args.client, &entity.CreateInventoryItemInput{
+				Name:          "aaa",
+				ItemType:      "aaa",
+				TotalQuantity: 10000,
+			})
+			assert.NoError(t, err)
+			tc.args.input.ItemId = itemId
+			tc.want.ItemId = itemId
+
 			id, err := tc.s.CreateEquipment(tc.args.ctx, tc.args.client, tc.args.input)
 			assert.NoError(t, err)
 			tc.want.Id = id
@@ -78,6 +91,9 @@ func TestPgEquipmentService_GetEquipmentById(t *testing.T) {
 
 			err = tc.ls.DeleteLocation(tc.args.ctx, tc.args.client, locationId)
 			assert.NoError(t, err)
+
+			err = tc.is.DeleteInventoryItem(tc.args.ctx, tc.args.client, itemId)
+			assert.NoError(t, err)
 		})
 	}
 }
@@ -103,7 +119,7 @@ func TestPgEquipmentService_GetExpeditionEquipments(t *testing.T) {
 				client:       pgClient,
 				expeditionId: 100,
 			},
-			s:       service.NewEquipmentService(pgRepo.EquipmentRepo),
+			s:       service.NewEquipmentService(pgRepo.EquipmentRepo, pgRepo.InventoryRepo, pgRepo.ExpeditionRepo),
 			want:    entity.Equipments{},
 			wantErr: false,
 		},
@@ -141,7 +157,7 @@ func TestPgEquipmentService_GetAllEquipments(t *testing.T) {
 				ctx:    context.Background(),
 				client: pgClient,
 			},
-			s:       service.NewEquipmentService(pgRepo.EquipmentRepo),
+			s:       service.NewEquipmentService(pgRepo.EquipmentRepo, pgRepo.InventoryRepo, pgRepo.ExpeditionRepo),
 			want:    entity.Equipments{},
 			wantErr: false,
 		},
@@ -173,6 +189,7 @@ func TestPgEquipmentService_CreateEquipment(t *testing.T) {
 		s       *service.EquipmentService
 		ls      *service.LocationService
 		es      *service.ExpeditionService
+		is      *service.InventoryService
 		wantErr bool
 	}{
 		{
@@ -181,13 +198,13 @@ func TestPgEquipmentService_CreateEquipment(t *testing.T) {
 				ctx:    context.Background(),
 				client: pgClient,
 				input: &entity.CreateEquipmentInput{
-					Name:   "aaa",
 					Amount: 10000,
 				},
 			},
-			s:       service.NewEquipmentService(pgRepo.EquipmentRepo),
+			s:       service.NewEquipmentService(pgRepo.EquipmentRepo, pgRepo.InventoryRepo, pgRepo.ExpeditionRepo),
 			ls:      service.NewLocationService(pgRepo.LocationRepo),
 			es:      service.NewExpeditionService(pgRepo.ExpeditionRepo),
+			is:      service.NewInventoryService(pgRepo.InventoryRepo),
 			wantErr: false,
 		},
 	}
@@ -195,7 +212,7 @@ func TestPgEquipmentService_CreateEquipment(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
@@ -208,11 +225,22 @@ func TestPgEquipmentService_CreateEquipment(t *testing.T) {
 			assert.NoError(t, err)
 			tc.args.input.ExpeditionId = expeditionId
 
+			itemId, err := tc.is.CreateInventoryItem(tc.args.ctx, tc.args.client, &entity.CreateInventoryItemInput{
+				Name:          "aaa",
+				ItemType:      "aaa",
+				TotalQuantity: 10000,
+			})
+			assert.NoError(t, err)
+			tc.args.input.ItemId = itemId
+
 			_, err = tc.s.CreateEquipment(tc.args.ctx, tc.args.client, tc.args.input)
 			assert.NoError(t, err)
 
 			err = tc.ls.DeleteLocation(tc.args.ctx, tc.args.client, locationId)
 			assert.NoError(t, err)
+
+			err = tc.is.DeleteInventoryItem(tc.args.ctx, tc.args.client, itemId)
+			assert.NoError(t, err)
 		})
 	}
 }
@@ -230,6 +258,7 @@ func TestPgMemberService_DeleteEquipment(t *testing.T) {
 		s       *service.EquipmentService
 		ls      *service.LocationService
 		es      *service.ExpeditionService
+		is      *service.InventoryService
 		wantErr bool
 	}{
 		{
@@ -238,13 +267,13 @@ func TestPgMemberService_DeleteEquipment(t *testing.T) {
 				ctx:    context.Background(),
 				client: pgClient,
 				input: &entity.CreateEquipmentInput{
-					Name:   "aaa",
 					Amount: 10000,
 				},
 			},
-			s:       service.NewEquipmentService(pgRepo.EquipmentRepo),
+			s:       service.NewEquipmentService(pgRepo.EquipmentRepo, pgRepo.InventoryRepo, pgRepo.ExpeditionRepo),
 			ls:      service.NewLocationService(pgRepo.LocationRepo),
 			es:      service.NewExpeditionService(pgRepo.ExpeditionRepo),
+			is:      service.NewInventoryService(pgRepo.InventoryRepo),
 			wantErr: false,
 		},
 	}
@@ -258,7 +287,7 @@ func TestPgMemberService_DeleteEquipment(t *testing.T) {
 
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
@@ -271,6 +300,14 @@ func TestPgMemberService_DeleteEquipment(t *testing.T) {
 			assert.NoError(t, err)
 			tc.args.input.ExpeditionId = expeditionId
 
+			itemId, err := tc.is.CreateInventoryItem(tc.args.ctx, tc.args.client, &entity.CreateInventoryItemInput{
+				Name:          "aaa",
+				ItemType:      "aaa",
+				TotalQuantity: 10000,
+			})
+			assert.NoError(t, err)
+			tc.args.input.ItemId = itemId
+
 			id, err := tc.s.CreateEquipment(tc.args.ctx, tc.args.client, tc.args.input)
 			assert.NoError(t, err)
 
@@ -279,6 +316,9 @@ func TestPgMemberService_DeleteEquipment(t *testing.T) {
 
 			err = tc.ls.DeleteLocation(tc.args.ctx, tc.args.client, locationId)
 			assert.NoError(t, err)
+
+			err = tc.is.DeleteInventoryItem(tc.args.ctx, tc.args.client, itemId)
+			assert.NoError(t, err)
 		})
 	}
 }
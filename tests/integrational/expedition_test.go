@@ -57,7 +57,7 @@ func TestPgExpeditionService_GetExpeditionById(t *testing.T) {
 
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
@@ -149,7 +149,7 @@ func TestPgExpeditionService_CreateExpedition(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
@@ -203,7 +203,7 @@ func TestPgMemberService_UpdateExpeditionDates(t *testing.T) {
 
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
@@ -260,7 +260,7 @@ func TestPgMemberService_DeleteExpedition(t *testing.T) {
 
 			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			})
 			assert.NoError(t, err)
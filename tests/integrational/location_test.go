@@ -29,14 +29,14 @@ func TestPgLocationService_GetLocationById(t *testing.T) {
 				client: pgClient,
 				input: &entity.CreateLocationInput{
 					Name:        "aaa",
-					Country:     "aaa",
+					Country:     "RU",
 					NearestTown: "aaa",
 				},
 			},
 			s: service.NewLocationService(pgRepo.LocationRepo),
 			want: &entity.Location{
 				Name:        "aaa",
-				Country:     "aaa",
+				Country:     "RU",
 				NearestTown: "aaa",
 			},
 			wantErr: false,
@@ -90,7 +90,7 @@ func TestPgLocationService_GetAllLocations(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			got, err := tc.s.GetAllLocations(tc.args.ctx, tc.args.client)
+			got, err := tc.s.GetAllLocations(tc.args.ctx, tc.args.client, nil)
 			if tc.wantErr {
 				assert.Error(t, err)
 				return
@@ -122,7 +122,7 @@ func TestPgLocationService_CreateLocation(t *testing.T) {
 				client: pgClient,
 				input: &entity.CreateLocationInput{
 					Name:        "aaa",
-					Country:     "aaa",
+					Country:     "RU",
 					NearestTown: "aaa",
 				},
 			},
@@ -161,7 +161,7 @@ func TestPgLocationService_DeleteLocation(t *testing.T) {
 				client: pgClient,
 				input: &entity.CreateLocationInput{
 					Name:        "aaa",
-					Country:     "aaa",
+					Country:     "RU",
 					NearestTown: "aaa",
 				},
 			},
@@ -0,0 +1,125 @@
+package integrational
+
+import (
+	"context"
+	"db_cp_6/internal/entity"
+	"db_cp_6/internal/service"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPgSampleService_RadiocarbonDating(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		client any
+		dating entity.Dating
+		result *entity.RadiocarbonResult
+	}
+
+	earliest, latest := -1500, -1400
+
+	testCases := []struct {
+		name string
+		args args
+		s    *service.SampleService
+		as   *service.ArtifactService
+		es   *service.ExpeditionService
+		ps   *service.PeriodService
+		ls   *service.LocationService
+	}{
+		{
+			name: "Accepted sample dates artifact into period and rejection restores prior dating",
+			args: args{
+				ctx:    context.Background(),
+				client: pgClient,
+				dating: entity.Dating{
+					EarliestYear: &earliest,
+					LatestYear:   &latest,
+					Method:       entity.DatingTypological,
+					Confidence:   entity.ConfidenceLow,
+				},
+				result: &entity.RadiocarbonResult{AgeBP: 3000, Sigma: 30, LabCode: "OxA-12345"},
+			},
+			s:  service.NewSampleService(pgRepo.SampleRepo, pgRepo.ExpeditionRepo, pgRepo.ArtifactRepo, pgRepo.ExcavationContextRepo),
+			as: service.NewArtifactService(pgRepo.ArtifactRepo, pgRepo.ExpeditionRepo, pgRepo.CustodyRepo, pgRepo.ExcavationContextRepo, pgRepo.PeriodRepo, pgRepo.ConditionReportRepo, pgRepo.MemberRepo),
+			es: service.NewExpeditionService(pgRepo.ExpeditionRepo),
+			ps: service.NewPeriodService(pgRepo.PeriodRepo),
+			ls: service.NewLocationService(pgRepo.LocationRepo),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			locationId, err := tc.ls.CreateLocation(tc.args.ctx, tc.args.client, &entity.CreateLocationInput{
+				Name:        "aaa",
+				Country:     "RU",
+				NearestTown: "aaa",
+			})
+			assert.NoError(t, err)
+
+			periodId, err := tc.ps.CreatePeriod(tc.args.ctx, tc.args.client, &entity.CreatePeriodInput{
+				Name:      "aaa",
+				StartYear: -1200,
+				EndYear:   -1000,
+			})
+			assert.NoError(t, err)
+
+			expeditionId, err := tc.es.CreateExpedition(tc.args.ctx, tc.args.client, &entity.CreateExpeditionInput{
+				LocationId: locationId,
+				StartDate:  "2024-07-01",
+				EndDate:    "2024-08-01",
+			})
+			assert.NoError(t, err)
+
+			artifactId, err := tc.as.CreateArtifact(tc.args.ctx, tc.args.client, &entity.CreateArtifactInput{
+				LocationId: locationId,
+				Name:       "aaa",
+				Dating:     tc.args.dating,
+			})
+			assert.NoError(t, err)
+
+			sampleId, err := tc.s.CreateSample(tc.args.ctx, tc.args.client, &entity.CreateSampleInput{
+				ExpeditionId: expeditionId,
+				ArtifactId:   &artifactId,
+				Type:         entity.SampleCharcoal,
+				Lab:          "Oxford",
+				Status:       entity.SampleAccepted,
+				Results:      entity.SampleResults{Radiocarbon: tc.args.result},
+			})
+			assert.NoError(t, err)
+
+			got, err := tc.as.GetAllArtifacts(tc.args.ctx, tc.args.client, &entity.ArtifactFilter{PeriodId: &periodId})
+			assert.NoError(t, err)
+			assert.Contains(t, artifactIds(got), artifactId)
+
+			err = tc.s.UpdateSample(tc.args.ctx, tc.args.client, sampleId, &entity.UpdateSampleInput{
+				Lab:     "Oxford",
+				Status:  entity.SampleRejected,
+				Results: entity.SampleResults{Radiocarbon: tc.args.result},
+			})
+			assert.NoError(t, err)
+
+			artifact, err := tc.as.GetArtifactById(tc.args.ctx, tc.args.client, artifactId)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.args.dating, artifact.Dating)
+
+			got, err = tc.as.GetAllArtifacts(tc.args.ctx, tc.args.client, &entity.ArtifactFilter{PeriodId: &periodId})
+			assert.NoError(t, err)
+			assert.NotContains(t, artifactIds(got), artifactId)
+
+			err = tc.ls.DeleteLocation(tc.args.ctx, tc.args.client, locationId)
+			assert.NoError(t, err)
+
+			err = tc.ps.DeletePeriod(tc.args.ctx, tc.args.client, periodId)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func artifactIds(artifacts entity.Artifacts) []int {
+	ids := make([]int, 0, len(artifacts))
+	for _, a := range artifacts {
+		ids = append(ids, a.Id)
+	}
+
+	return ids
+}